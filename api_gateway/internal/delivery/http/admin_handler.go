@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+
+	userpb "github.com/Zhan028/Music_Service/userService/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImpersonateUser hands an admin a short-lived token for acting as the user
+// in :id; the JSON body must give a reason, e.g. {"reason": "TICKET-123"}
+func (h *Handler) ImpersonateUser(c *gin.Context) {
+	var req userpb.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.UserId = c.Param("id")
+
+	resp, err := h.clients.UserClient.ImpersonateUser(authContext(c), &req)
+	if err != nil {
+		adminErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+func adminErrorResponse(c *gin.Context, err error) {
+	st, _ := status.FromError(err)
+	switch st.Code() {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+	case codes.Unauthenticated:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": st.Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": st.Message()})
+	}
+}
@@ -0,0 +1,27 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	userpb "github.com/Zhan028/Music_Service/userService/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// ServeAvatar serves a user's avatar or one of its thumbnails below /media,
+// the default MEDIA_BASE_URL of the user service. Every upload gets new keys,
+// so the content behind a url never changes and may be cached forever.
+func (h *Handler) ServeAvatar(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	resp, err := h.clients.UserClient.GetAvatarImage(c.Request.Context(), &userpb.AvatarImageRequest{Key: key})
+	if err != nil {
+		streamErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Type", resp.GetMimeType())
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	http.ServeContent(c.Writer, c.Request, "", time.Time{}, bytes.NewReader(resp.GetData()))
+}
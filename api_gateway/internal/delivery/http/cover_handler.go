@@ -0,0 +1,29 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	trackspb "github.com/Zhan028/Music_Service/track-service/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// ServeCover serves a cover or thumbnail by the name in its url. Names are
+// derived from the image's SHA-256, so the content behind a url never changes
+// and may be cached forever.
+func (h *Handler) ServeCover(c *gin.Context) {
+	name := c.Param("name")
+	resp, err := h.clients.TracksClient.GetArtworkImage(c.Request.Context(), &trackspb.GetArtworkImageRequest{Name: name})
+	if err != nil {
+		streamErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Type", resp.GetMimeType())
+	c.Header("ETag", `"`+strings.TrimSuffix(name, path.Ext(name))+`"`)
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	http.ServeContent(c.Writer, c.Request, "", time.Time{}, bytes.NewReader(resp.GetData()))
+}
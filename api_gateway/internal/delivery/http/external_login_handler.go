@@ -0,0 +1,104 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"time"
+
+	userpb "github.com/Zhan028/Music_Service/userService/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ListIdentityProviders(c *gin.Context) {
+	resp, err := h.clients.UserClient.ListIdentityProviders(c.Request.Context(), &userpb.IdentityProviderRequest{})
+	if err != nil {
+		externalLoginErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// loginStateCookie ties a login to the browser that started it: the callback
+// is only accepted with the state the provider was sent to from here
+const (
+	loginStateCookie    = "oidc_state"
+	loginStateCookieTTL = 10 * time.Minute // Same as the login state in the user service
+)
+
+// BeginExternalLogin redirects to the provider; with ?link=true the provider
+// account is linked to the user of the bearer token
+func (h *Handler) BeginExternalLogin(c *gin.Context) {
+	req := userpb.ExternalLoginRequest{
+		Provider: c.Param("provider"),
+		Link:     c.Query("link") == "true",
+	}
+
+	resp, err := h.clients.UserClient.BeginExternalLogin(authContext(c), &req)
+	if err != nil {
+		externalLoginErrorResponse(c, err)
+		return
+	}
+	authURL, err := url.Parse(resp.AuthorizationUrl)
+	if err != nil || authURL.Query().Get("state") == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid authorization url"})
+		return
+	}
+
+	// Lax, not Strict: the callback is a cross-site redirect from the provider
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(loginStateCookie, authURL.Query().Get("state"), int(loginStateCookieTTL.Seconds()), "/auth", "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, resp.AuthorizationUrl)
+}
+
+// ExternalLoginCallback is the redirect URL registered at the providers
+func (h *Handler) ExternalLoginCallback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": providerErr, "error_description": c.Query("error_description")})
+		return
+	}
+
+	// A callback without the cookie was not started in this browser, e.g. a
+	// link crafted by an attacker to sign the victim into the attacker's account
+	state := c.Query("state")
+	cookie, err := c.Cookie(loginStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(loginStateCookie, "", -1, "/auth", "", c.Request.TLS != nil, true)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(state)) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "login request is invalid or expired"})
+		return
+	}
+
+	req := userpb.ExternalLoginCallback{
+		Provider: c.Param("provider"),
+		State:    state,
+		Code:     c.Query("code"),
+	}
+
+	resp, err := h.clients.UserClient.CompleteExternalLogin(c.Request.Context(), &req)
+	if err != nil {
+		externalLoginErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func externalLoginErrorResponse(c *gin.Context, err error) {
+	st, _ := status.FromError(err)
+	switch st.Code() {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+	case codes.Unauthenticated:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": st.Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": st.Message()})
+	}
+}
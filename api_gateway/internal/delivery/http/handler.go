@@ -41,7 +41,9 @@ func (h *Handler) CreatePlaylist(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.clients.PlaylistClient.CreatePlaylist(context.Background(), &req)
+	// The bearer token goes along, so the playlist service can check it and
+	// audit calls made while impersonating the user
+	resp, err := h.clients.PlaylistClient.CreatePlaylist(authContext(c), &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
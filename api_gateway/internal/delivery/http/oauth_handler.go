@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	userpb "github.com/Zhan028/Music_Service/userService/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authContext forwards the caller's bearer token to the backend services
+func authContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if auth := c.GetHeader("Authorization"); auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+	}
+	return ctx
+}
+
+func (h *Handler) RegisterOAuthClient(c *gin.Context) {
+	var req userpb.OAuthClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.clients.UserClient.RegisterOAuthClient(authContext(c), &req)
+	if err != nil {
+		oauthErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// Authorize handles both the initial request (GET, query parameters) and the
+// submitted consent (POST, form with approve=true). Once a code is issued the
+// user agent is redirected back to the client.
+func (h *Handler) Authorize(c *gin.Context) {
+	req := userpb.OAuthAuthorizeRequest{
+		ClientId:            c.Query("client_id"),
+		RedirectUri:         c.Query("redirect_uri"),
+		Scope:               c.Query("scope"),
+		State:               c.Query("state"),
+		CodeChallenge:       c.Query("code_challenge"),
+		CodeChallengeMethod: c.Query("code_challenge_method"),
+	}
+	if c.Request.Method == http.MethodPost {
+		req.ClientId = c.DefaultPostForm("client_id", req.ClientId)
+		req.RedirectUri = c.DefaultPostForm("redirect_uri", req.RedirectUri)
+		req.Scope = c.DefaultPostForm("scope", req.Scope)
+		req.State = c.DefaultPostForm("state", req.State)
+		req.CodeChallenge = c.DefaultPostForm("code_challenge", req.CodeChallenge)
+		req.CodeChallengeMethod = c.DefaultPostForm("code_challenge_method", req.CodeChallengeMethod)
+		req.Approve = c.PostForm("approve") == "true"
+	}
+	if resp := c.Query("response_type"); resp != "" && resp != "code" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported_response_type"})
+		return
+	}
+
+	resp, err := h.clients.UserClient.AuthorizeOAuth(authContext(c), &req)
+	if err != nil {
+		oauthErrorResponse(c, err)
+		return
+	}
+
+	if resp.ConsentRequired {
+		c.JSON(http.StatusOK, gin.H{
+			"consent_required": true,
+			"client_name":      resp.ClientName,
+			"scopes":           resp.Scopes,
+		})
+		return
+	}
+	c.Redirect(http.StatusFound, resp.RedirectTo)
+}
+
+// Token is the OAuth2 token endpoint. Clients send form parameters and may
+// authenticate with HTTP Basic instead of client_id/client_secret.
+func (h *Handler) Token(c *gin.Context) {
+	req := userpb.OAuthTokenRequest{
+		GrantType:    c.PostForm("grant_type"),
+		ClientId:     c.PostForm("client_id"),
+		ClientSecret: c.PostForm("client_secret"),
+		Code:         c.PostForm("code"),
+		RedirectUri:  c.PostForm("redirect_uri"),
+		CodeVerifier: c.PostForm("code_verifier"),
+		RefreshToken: c.PostForm("refresh_token"),
+		Scope:        c.PostForm("scope"),
+	}
+	if id, secret, ok := c.Request.BasicAuth(); ok {
+		req.ClientId, req.ClientSecret = id, secret
+	}
+
+	resp, err := h.clients.UserClient.ExchangeOAuthToken(c.Request.Context(), &req)
+	c.Header("Cache-Control", "no-store")
+	if err != nil {
+		oauthErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token":  resp.AccessToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+		"refresh_token": resp.RefreshToken,
+		"scope":         resp.Scope,
+	})
+}
+
+func (h *Handler) RevokeToken(c *gin.Context) {
+	req := userpb.OAuthRevokeRequest{
+		ClientId:     c.PostForm("client_id"),
+		ClientSecret: c.PostForm("client_secret"),
+		Token:        c.PostForm("token"),
+	}
+	if id, secret, ok := c.Request.BasicAuth(); ok {
+		req.ClientId, req.ClientSecret = id, secret
+	}
+
+	if _, err := h.clients.UserClient.RevokeOAuthToken(c.Request.Context(), &req); err != nil {
+		oauthErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) ListOAuthConsents(c *gin.Context) {
+	resp, err := h.clients.UserClient.ListOAuthConsents(authContext(c), &userpb.OAuthConsentRequest{})
+	if err != nil {
+		oauthErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) RevokeOAuthConsent(c *gin.Context) {
+	req := userpb.OAuthConsentRequest{ClientId: c.Param("client_id")}
+	resp, err := h.clients.UserClient.RevokeOAuthConsent(authContext(c), &req)
+	if err != nil {
+		oauthErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// oauthErrorResponse writes errors in the RFC 6749 format; the user service
+// puts the OAuth error code into the status message
+func oauthErrorResponse(c *gin.Context, err error) {
+	st, _ := status.FromError(err)
+	switch st.Code() {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+	case codes.Unauthenticated:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": st.Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"error": "access_denied", "error_description": st.Message()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+	}
+}
@@ -7,4 +7,36 @@ import (
 func RegisterRoutes(r *gin.Engine, h *Handler) {
 	r.POST("/register", h.RegisterUser)
 	r.POST("/playlists", h.CreatePlaylist)
+
+	// Audio for players, Range requests supported
+	r.GET("/tracks/:id/stream", h.StreamTrack)
+	r.HEAD("/tracks/:id/stream", h.StreamTrack)
+
+	// Track and album covers, public and immutable
+	r.GET("/covers/:name", h.ServeCover)
+	r.HEAD("/covers/:name", h.ServeCover)
+
+	// User avatars, public and immutable
+	r.GET("/media/*key", h.ServeAvatar)
+	r.HEAD("/media/*key", h.ServeAvatar)
+
+	// OAuth2 for third-party apps
+	oauth := r.Group("/oauth")
+	oauth.POST("/clients", h.RegisterOAuthClient)
+	oauth.GET("/authorize", h.Authorize)
+	oauth.POST("/authorize", h.Authorize)
+	oauth.POST("/token", h.Token)
+	oauth.POST("/revoke", h.RevokeToken)
+	oauth.GET("/consents", h.ListOAuthConsents)
+	oauth.DELETE("/consents/:client_id", h.RevokeOAuthConsent)
+
+	// Sign in with external OpenID Connect providers
+	auth := r.Group("/auth")
+	auth.GET("/providers", h.ListIdentityProviders)
+	auth.GET("/:provider/login", h.BeginExternalLogin)
+	auth.GET("/:provider/callback", h.ExternalLoginCallback)
+
+	// Support tooling, admin bearer token required
+	admin := r.Group("/admin")
+	admin.POST("/users/:id/impersonate", h.ImpersonateUser)
 }
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	trackspb "github.com/Zhan028/Music_Service/track-service/proto"
+	userpb "github.com/Zhan028/Music_Service/userService/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// audioQualityRank orders the max_audio_quality values of a plan
+var audioQualityRank = map[string]int{
+	"low":      1,
+	"normal":   2,
+	"high":     3,
+	"lossless": 4,
+}
+
+// StreamTrack serves the audio file of track :id. http.ServeContent takes
+// care of Range (206 Partial Content, 416), If-None-Match, If-Range and HEAD;
+// the bytes are pulled from the track service only for the requested range.
+func (h *Handler) StreamTrack(c *gin.Context) {
+	ctx := authContext(c)
+
+	entitlements, err := h.clients.UserClient.GetCurrentEntitlements(ctx, &userpb.CurrentUserRequest{})
+	if err != nil {
+		streamErrorResponse(c, err)
+		return
+	}
+
+	resp, err := h.clients.TracksClient.GetTrackByID(ctx, &trackspb.GetTrackByIDRequest{Id: c.Param("id")})
+	if err != nil {
+		streamErrorResponse(c, err)
+		return
+	}
+	file := resp.GetTrack().GetAudio()
+	if file == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "the track has no audio"})
+		return
+	}
+	if audioQualityRank[fileQuality(file)] > audioQualityRank[entitlements.GetMaxAudioQuality()] {
+		c.JSON(http.StatusForbidden, gin.H{"error": "your plan does not include " + fileQuality(file) + " audio"})
+		return
+	}
+
+	content := &trackAudioReader{
+		ctx:     ctx,
+		client:  h.clients.TracksClient,
+		trackID: resp.GetTrack().GetId(),
+		size:    file.GetSize(),
+	}
+	defer content.Close()
+
+	// The file behind a track changes only with a new upload, and the hash
+	// identifies its content
+	c.Header("ETag", `"`+file.GetSha256()+`"`)
+	c.Header("Content-Type", file.GetMimeType())
+	c.Header("Cache-Control", "private, max-age=86400")
+	http.ServeContent(c.Writer, c.Request, "", time.Unix(file.GetUploadedAt(), 0), content)
+}
+
+// fileQuality maps an audio file onto the quality levels of the plans
+func fileQuality(file *trackspb.AudioFile) string {
+	switch {
+	case file.GetCodec() == "flac" || file.GetCodec() == "pcm" || file.GetCodec() == "alac":
+		return "lossless"
+	case file.GetBitrate() >= 256000:
+		return "high"
+	case file.GetBitrate() >= 128000:
+		return "normal"
+	default:
+		return "low"
+	}
+}
+
+func streamErrorResponse(c *gin.Context, err error) {
+	st, _ := status.FromError(err)
+	switch st.Code() {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+	case codes.Unauthenticated:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": st.Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": st.Message()})
+	}
+}
+
+// trackAudioReader is an io.ReadSeeker over StreamTrackAudio. Seeking only
+// moves the offset; the stream is opened on the first Read after it, so
+// ServeContent's size probe and range seeks cost no requests.
+type trackAudioReader struct {
+	ctx     context.Context
+	client  trackspb.TrackServiceClient
+	trackID string
+	size    int64
+
+	offset int64
+	stream trackspb.TrackService_StreamTrackAudioClient
+	cancel context.CancelFunc
+	buf    []byte
+}
+
+func (r *trackAudioReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.stream == nil {
+		ctx, cancel := context.WithCancel(r.ctx)
+		stream, err := r.client.StreamTrackAudio(ctx, &trackspb.StreamTrackAudioRequest{
+			TrackId: r.trackID,
+			Offset:  r.offset,
+		})
+		if err != nil {
+			cancel()
+			return 0, err
+		}
+		r.stream, r.cancel = stream, cancel
+	}
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.GetData()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.offset += int64(n)
+	return n, nil
+}
+
+func (r *trackAudioReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	if offset != r.offset {
+		r.Close()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+// Close stops the stream in progress, if any
+func (r *trackAudioReader) Close() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.stream, r.cancel, r.buf = nil, nil, nil
+	return nil
+}
@@ -13,6 +13,8 @@ type Clients struct {
 	TracksClient   trackspb.TrackServiceClient
 }
 
+// NewClients dials the services on their default ports: user-service 50051,
+// playlist-service 50052, track-service 50053
 func NewClients() *Clients {
 	userConn, _ := grpc.Dial("localhost:50051", grpc.WithInsecure())
 	playlistConn, _ := grpc.Dial("localhost:50052", grpc.WithInsecure())
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"github.com/Zhan028/Music_Service/internal/client"
+	"github.com/Zhan028/Music_Service/internal/delivery/events"
 	grpc2 "github.com/Zhan028/Music_Service/internal/delivery/grpc"
 	mongodb2 "github.com/Zhan028/Music_Service/internal/repository/mongodb"
 	"github.com/Zhan028/Music_Service/internal/usecase"
@@ -13,6 +15,8 @@ import (
 	"syscall"
 
 	"github.com/joho/godotenv"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -29,7 +33,21 @@ func main() {
 	mongoUser := getEnv("MONGO_USER", "")
 	mongoPass := getEnv("MONGO_PASS", "")
 	mongoDBName := getEnv("MONGO_DB", "playlist_service")
-	grpcPort := getEnv("GRPC_PORT", "50051")
+	// Порты по умолчанию совпадают с адресами в api_gateway и user-service:
+	// user-service — 50051, playlist-service — 50052, track-service — 50053
+	grpcPort := getEnv("GRPC_PORT", "50052")
+	userServiceAddr := getEnv("USER_SERVICE_ADDR", "localhost:50051")
+	natsURL := getEnv("NATS_URL", nats.DefaultURL)
+	// Общий с user-service секрет для вызовов без токена пользователя
+	serviceToken := getEnv("SERVICE_TOKEN", "")
+	if serviceToken == "" {
+		log.Println("SERVICE_TOKEN is not set, calls from other services will be refused")
+	}
+
+	// Проверка лимитов через самого себя падала бы на каждом вызове с Unimplemented
+	if userServiceAddr == "localhost:"+grpcPort || userServiceAddr == ":"+grpcPort {
+		log.Fatalf("USER_SERVICE_ADDR %s points at this service's own GRPC_PORT", userServiceAddr)
+	}
 
 	// Создаем контекст с возможностью отмены
 	ctx, cancel := context.WithCancel(context.Background())
@@ -43,17 +61,44 @@ func main() {
 	}
 	log.Println("Connected to MongoDB successfully")
 
+	if err := mongodb2.EnsureIndexes(ctx, db); err != nil {
+		log.Fatalf("Failed to create MongoDB indexes: %v", err)
+	}
+
 	// Создаем репозиторий
 	playlistRepo := mongodb2.NewPlaylistRepository(db)
 
+	// Клиент user-service для проверки лимитов тарифа
+	userClient, err := client.NewUserClient(userServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to create user service client: %v", err)
+	}
+	defer userClient.Close()
+
 	// Создаем use case
-	playlistUseCase := usecase.NewPlaylistUseCase(playlistRepo)
+	playlistUseCase := usecase.NewPlaylistUseCase(playlistRepo, userClient)
+
+	// Подписываемся на события каталога, чтобы копии треков в плейлистах
+	// не расходились с track-service
+	nc, err := nats.Connect(natsURL, nats.Name("playlist-service"), nats.RetryOnFailedConnect(true), nats.MaxReconnects(-1))
+	if err != nil {
+		log.Fatalf("Failed to connect to NATS: %v", err)
+	}
+	defer nc.Close()
+	js, err := jetstream.New(nc)
+	if err != nil {
+		log.Fatalf("Failed to init JetStream: %v", err)
+	}
+	go events.NewTrackConsumer(js, playlistUseCase).Run(ctx)
 
 	// Создаем gRPC сервер
 	server := grpc2.NewPlaylistServer(playlistUseCase)
 
 	// Настраиваем gRPC сервер
-	grpcServer := grpc.NewServer()
+	// Токены, пересланные через api_gateway, проверяет user-service; там же
+	// записывается аудит вызовов с токеном имперсонации. Другие сервисы
+	// подтверждают вызов общим SERVICE_TOKEN
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(grpc2.Authenticate(userClient, serviceToken)))
 	pb.RegisterPlaylistServiceServer(grpcServer, server)
 
 	// Включаем reflection для удобства отладки (можно использовать grpcurl)
@@ -79,6 +124,7 @@ func main() {
 
 	log.Println("Shutting down server...")
 	grpcServer.GracefulStop()
+	cancel()
 	log.Println("Server stopped")
 }
 
@@ -7,6 +7,8 @@ go 1.23.0
 toolchain go1.23.3
 
 require (
+	github.com/joho/godotenv v1.5.1
+	github.com/nats-io/nats.go v1.42.0
 	go.mongodb.org/mongo-driver v1.17.3
 	google.golang.org/grpc v1.71.1
 	google.golang.org/protobuf v1.36.6
@@ -15,9 +17,10 @@ require (
 require (
 	github.com/golang-jwt/jwt/v4 v4.5.2 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
-	github.com/joho/godotenv v1.5.1 // indirect
-	github.com/klauspost/compress v1.16.7 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
 	github.com/montanaflynn/stats v0.7.1 // indirect
+	github.com/nats-io/nkeys v0.4.11 // indirect
+	github.com/nats-io/nuid v1.0.1 // indirect
 	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Zhan028/Music_Service/playlistService/internal/domain"
+	userpb "github.com/Zhan028/Music_Service/userService/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// UserClient получает данные о тарифах пользователей из user-service
+type UserClient struct {
+	conn   *grpc.ClientConn
+	client userpb.UserServiceClient
+}
+
+func NewUserClient(addr string) (*UserClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &UserClient{
+		conn:   conn,
+		client: userpb.NewUserServiceClient(conn),
+	}, nil
+}
+
+func (c *UserClient) Close() error {
+	return c.conn.Close()
+}
+
+// GetEntitlements реализует domain.EntitlementProvider
+func (c *UserClient) GetEntitlements(ctx context.Context, userID string) (*domain.Entitlements, error) {
+	resp, err := c.client.GetEntitlements(ctx, &userpb.UserID{Id: userID})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Entitlements{
+		Plan:                 resp.GetPlan(),
+		MaxPlaylists:         resp.GetMaxPlaylists(),
+		MaxTracksPerPlaylist: resp.GetMaxTracksPerPlaylist(),
+	}, nil
+}
+
+// AuthorizeCall реализует domain.CallAuthorizer: пересылает в user-service
+// токен из входящих метаданных вызова
+func (c *UserClient) AuthorizeCall(ctx context.Context, method string) (*domain.Caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+	}
+	resp, err := c.client.AuthorizeCall(ctx, &userpb.AuthorizeCallRequest{Method: method})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Caller{
+		UserID:    resp.GetUserId(),
+		ActorID:   resp.GetActorId(),
+		SessionID: resp.GetSessionId(),
+	}, nil
+}
@@ -0,0 +1,122 @@
+// Package events обрабатывает события каталога треков из NATS JetStream
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Zhan028/Music_Service/playlistService/internal/domain"
+	"github.com/Zhan028/Music_Service/playlistService/internal/usecase"
+	trackspb "github.com/Zhan028/Music_Service/track-service/proto"
+	"github.com/nats-io/nats.go/jetstream"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// trackStream — поток событий о треках; его создает track-service
+	trackStream = "TRACK_EVENTS"
+	// consumerName — durable-консьюмер, общий для всех экземпляров сервиса
+	consumerName = "playlist-service"
+
+	subjectTrackUpdated = "track.updated"
+	subjectTrackDeleted = "track.deleted"
+
+	// schemaVersion — версия TrackEvent, которую умеет разбирать сервис
+	schemaVersion = 1
+
+	// retryDelay — пауза перед повторной подпиской или обработкой события
+	retryDelay = 5 * time.Second
+)
+
+// TrackConsumer синхронизирует копии треков в плейлистах с каталогом.
+// События одного трека могут прийти повторно или не по порядку (повторная
+// доставка после ошибки, несколько экземпляров сервиса), поэтому копия
+// меняется, только если событие новее ее версии.
+type TrackConsumer struct {
+	js      jetstream.JetStream
+	useCase *usecase.PlaylistUseCase
+}
+
+func NewTrackConsumer(js jetstream.JetStream, useCase *usecase.PlaylistUseCase) *TrackConsumer {
+	return &TrackConsumer{
+		js:      js,
+		useCase: useCase,
+	}
+}
+
+// Run обрабатывает события, пока ctx не отменен. Если потока еще нет
+// (track-service не запускался) или NATS недоступен, подписка повторяется.
+func (c *TrackConsumer) Run(ctx context.Context) {
+	for {
+		consumer, err := c.js.CreateOrUpdateConsumer(ctx, trackStream, jetstream.ConsumerConfig{
+			Durable:        consumerName,
+			FilterSubjects: []string{subjectTrackUpdated, subjectTrackDeleted},
+			DeliverPolicy:  jetstream.DeliverAllPolicy,
+			AckPolicy:      jetstream.AckExplicitPolicy,
+			AckWait:        30 * time.Second,
+		})
+		if err == nil {
+			var sub jetstream.ConsumeContext
+			sub, err = consumer.Consume(func(msg jetstream.Msg) { c.handle(ctx, msg) })
+			if err == nil {
+				<-ctx.Done()
+				sub.Stop()
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Track events subscription failed, retrying: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (c *TrackConsumer) handle(ctx context.Context, msg jetstream.Msg) {
+	var event trackspb.TrackEvent
+	if err := proto.Unmarshal(msg.Data(), &event); err != nil {
+		log.Printf("Dropping malformed track event: %v", err)
+		_ = msg.Term()
+		return
+	}
+	if event.GetSchemaVersion() != schemaVersion {
+		log.Printf("Dropping track event %s: unsupported schema version %d", event.GetEventId(), event.GetSchemaVersion())
+		_ = msg.Term()
+		return
+	}
+
+	if err := c.apply(ctx, &event); err != nil {
+		log.Printf("Failed to apply track event %s: %v", event.GetEventId(), err)
+		_ = msg.NakWithDelay(retryDelay)
+		return
+	}
+	_ = msg.Ack()
+}
+
+func (c *TrackConsumer) apply(ctx context.Context, event *trackspb.TrackEvent) error {
+	var err error
+	switch event.GetType() {
+	case subjectTrackUpdated:
+		after := event.GetAfter()
+		if after == nil {
+			return nil
+		}
+		_, err = c.useCase.SyncTrack(ctx, domain.Track{
+			ID:       event.GetTrackId(),
+			Title:    after.GetTitle(),
+			Artist:   after.GetArtist(),
+			Duration: after.GetDurationSec(),
+			Album:    after.GetAlbum(),
+			Version:  event.GetVersion(),
+		})
+	case subjectTrackDeleted:
+		_, err = c.useCase.MarkTrackUnavailable(ctx, event.GetTrackId(), event.GetVersion())
+	}
+	return err
+}
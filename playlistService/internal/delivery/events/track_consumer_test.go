@@ -0,0 +1,215 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zhan028/Music_Service/playlistService/internal/domain"
+	"github.com/Zhan028/Music_Service/playlistService/internal/usecase"
+	trackspb "github.com/Zhan028/Music_Service/track-service/proto"
+	"github.com/nats-io/nats.go/jetstream"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeMsg запоминает, как обработчик завершил сообщение
+type fakeMsg struct {
+	jetstream.Msg
+	data   []byte
+	result string
+}
+
+func (m *fakeMsg) Data() []byte                       { return m.data }
+func (m *fakeMsg) Ack() error                         { m.result = "ack"; return nil }
+func (m *fakeMsg) NakWithDelay(_ time.Duration) error { m.result = "nak"; return nil }
+func (m *fakeMsg) Term() error                        { m.result = "term"; return nil }
+
+// copiesRepository хранит копии треков одного плейлиста и, как репозиторий
+// MongoDB, меняет копию, только если она старше версии события
+type copiesRepository struct {
+	domain.PlaylistRepository
+	copies map[string]domain.Track
+	err    error
+}
+
+func (r *copiesRepository) update(trackID string, version int64, apply func(*domain.Track)) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	track, ok := r.copies[trackID]
+	if !ok || track.Version >= version {
+		return 0, nil
+	}
+	apply(&track)
+	track.Version = version
+	r.copies[trackID] = track
+	return 1, nil
+}
+
+func (r *copiesRepository) SyncTrack(_ context.Context, track domain.Track) (int64, error) {
+	return r.update(track.ID, track.Version, func(stored *domain.Track) {
+		stored.Title, stored.Artist, stored.Album, stored.Duration = track.Title, track.Artist, track.Album, track.Duration
+	})
+}
+
+func (r *copiesRepository) MarkTrackUnavailable(_ context.Context, trackID string, version int64) (int64, error) {
+	return r.update(trackID, version, func(stored *domain.Track) { stored.Unavailable = true })
+}
+
+const trackID = "6650a1f0c2b3d4e5f6a7b8c9"
+
+func updated(version int64, title string) *trackspb.TrackEvent {
+	return &trackspb.TrackEvent{
+		SchemaVersion: schemaVersion,
+		EventId:       title,
+		Type:          subjectTrackUpdated,
+		TrackId:       trackID,
+		Version:       version,
+		After: &trackspb.Track{
+			Id:          trackID,
+			Title:       title,
+			Artist:      "Kino",
+			Album:       "Gruppa krovi",
+			DurationSec: 399,
+			Version:     version,
+		},
+	}
+}
+
+func deleted(version int64) *trackspb.TrackEvent {
+	return &trackspb.TrackEvent{
+		SchemaVersion: schemaVersion,
+		EventId:       "deleted",
+		Type:          subjectTrackDeleted,
+		TrackId:       trackID,
+		Version:       version,
+		Before:        &trackspb.Track{Id: trackID, Title: "Kukushka", Version: version - 1},
+	}
+}
+
+func copyAt(version int64, title string, unavailable bool) domain.Track {
+	return domain.Track{
+		ID:          trackID,
+		Title:       title,
+		Artist:      "Kino",
+		Album:       "Gruppa krovi",
+		Duration:    399,
+		Version:     version,
+		Unavailable: unavailable,
+	}
+}
+
+// deliver передает события обработчику по очереди и проверяет, что каждое
+// подтверждено
+func deliver(t *testing.T, c *TrackConsumer, events ...*trackspb.TrackEvent) {
+	t.Helper()
+	for _, event := range events {
+		data, err := proto.Marshal(event)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		msg := &fakeMsg{data: data}
+		c.handle(context.Background(), msg)
+		if msg.result != "ack" {
+			t.Errorf("event %s v%d: %s, want ack", event.GetType(), event.GetVersion(), msg.result)
+		}
+	}
+}
+
+func TestTrackConsumerOrdering(t *testing.T) {
+	cases := []struct {
+		name   string
+		stored domain.Track
+		events []*trackspb.TrackEvent
+		want   domain.Track
+	}{
+		{
+			name:   "update",
+			stored: copyAt(1, "Kukushka", false),
+			events: []*trackspb.TrackEvent{updated(2, "Kukushka (live)")},
+			want:   copyAt(2, "Kukushka (live)", false),
+		},
+		{
+			name:   "duplicate event",
+			stored: copyAt(1, "Kukushka", false),
+			events: []*trackspb.TrackEvent{updated(2, "Kukushka (live)"), updated(2, "Kukushka (live)")},
+			want:   copyAt(2, "Kukushka (live)", false),
+		},
+		{
+			name:   "older version after a newer one",
+			stored: copyAt(1, "Kukushka", false),
+			events: []*trackspb.TrackEvent{updated(3, "Kukushka (remastered)"), updated(2, "Kukushka (live)")},
+			want:   copyAt(3, "Kukushka (remastered)", false),
+		},
+		{
+			name:   "delete followed by a late update",
+			stored: copyAt(1, "Kukushka", false),
+			events: []*trackspb.TrackEvent{deleted(3), updated(2, "Kukushka (live)")},
+			want:   copyAt(3, "Kukushka", true),
+		},
+		{
+			name:   "copy without a version",
+			stored: copyAt(0, "Kukushka", false),
+			events: []*trackspb.TrackEvent{updated(1, "Kukushka (live)")},
+			want:   copyAt(1, "Kukushka (live)", false),
+		},
+		{
+			name:   "delete of a copy without a version",
+			stored: copyAt(0, "Kukushka", false),
+			events: []*trackspb.TrackEvent{deleted(2)},
+			want:   copyAt(2, "Kukushka", true),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &copiesRepository{copies: map[string]domain.Track{trackID: tc.stored}}
+			deliver(t, NewTrackConsumer(nil, usecase.NewPlaylistUseCase(repo, nil)), tc.events...)
+			if got := repo.copies[trackID]; got != tc.want {
+				t.Errorf("copy = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTrackConsumerRejects(t *testing.T) {
+	unsupported := updated(2, "Kukushka (live)")
+	unsupported.SchemaVersion = schemaVersion + 1
+	data, err := proto.Marshal(unsupported)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	valid, err := proto.Marshal(updated(2, "Kukushka (live)"))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		data    []byte
+		repoErr error
+		want    string
+	}{
+		// Испорченное событие или событие новой схемы не исправится
+		// при повторе, его нужно отбросить
+		{name: "malformed", data: []byte{0xff, 0xff}, want: "term"},
+		{name: "unsupported schema version", data: data, want: "term"},
+		// Ошибку базы данных стоит переждать
+		{name: "repository error", data: valid, repoErr: errors.New("connection refused"), want: "nak"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &copiesRepository{copies: map[string]domain.Track{trackID: copyAt(1, "Kukushka", false)}, err: tc.repoErr}
+			msg := &fakeMsg{data: tc.data}
+			NewTrackConsumer(nil, usecase.NewPlaylistUseCase(repo, nil)).handle(context.Background(), msg)
+			if msg.result != tc.want {
+				t.Errorf("result = %s, want %s", msg.result, tc.want)
+			}
+			if got := repo.copies[trackID]; got != copyAt(1, "Kukushka", false) {
+				t.Errorf("copy changed to %+v", got)
+			}
+		})
+	}
+}
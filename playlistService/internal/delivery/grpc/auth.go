@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+
+	"github.com/Zhan028/Music_Service/internal/domain"
+	"github.com/Zhan028/Music_Service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serviceTokenHeader — заголовок, которым другие сервисы подтверждают, что
+// вызов сделан ими, а не клиентом
+const serviceTokenHeader = "x-service-token"
+
+// serviceMethods — методы, которые другие сервисы вызывают без токена
+// пользователя: user-service выгружает плейлисты при экспорте данных и
+// удаляет их вместе с аккаунтом
+var serviceMethods = map[string]bool{
+	proto.PlaylistService_GetUserPlaylists_FullMethodName:    true,
+	proto.PlaylistService_DeleteUserPlaylists_FullMethodName: true,
+}
+
+type callerKey struct{}
+
+// callerFrom возвращает пользователя, от имени которого сделан вызов. Для
+// вызовов других сервисов ok == false.
+func callerFrom(ctx context.Context) (*domain.Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(*domain.Caller)
+	return caller, ok
+}
+
+// Authenticate — unary-перехватчик, проверяющий bearer-токен через
+// user-service. Вызов с токеном выполняется только для пользователя токена:
+// user_id в запросе должен с ним совпадать, а владельца плейлиста по ID
+// проверяют обработчики. Вызовы с токеном имперсонации попадают в журнал
+// аудита user-service, а ответ помечается заголовком "x-impersonated-by".
+// Вызов без токена пользователя принимается только от другого сервиса с
+// общим serviceToken и только для serviceMethods; пустой serviceToken
+// отключает такие вызовы.
+func Authenticate(authorizer domain.CallAuthorizer, serviceToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if len(md.Get("authorization")) == 0 {
+			if err := authenticateService(md, info.FullMethod, serviceToken); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+
+		caller, err := authorizer.AuthorizeCall(ctx, info.FullMethod)
+		if err != nil {
+			switch status.Code(err) {
+			case codes.Unauthenticated, codes.PermissionDenied, codes.InvalidArgument:
+				return nil, err
+			default:
+				log.Printf("Failed to authorize %s: %v", info.FullMethod, err)
+				return nil, status.Errorf(codes.Unavailable, "failed to authorize the call")
+			}
+		}
+
+		if r, ok := req.(interface{ GetUserId() string }); ok && r.GetUserId() != "" && r.GetUserId() != caller.UserID {
+			return nil, status.Errorf(codes.PermissionDenied, "not allowed for another user")
+		}
+		if caller.ActorID != "" {
+			if err := grpc.SetHeader(ctx, metadata.Pairs("x-impersonated-by", caller.ActorID)); err != nil {
+				log.Printf("Failed to mark impersonated response: %v", err)
+			}
+		}
+
+		return handler(context.WithValue(ctx, callerKey{}, caller), req)
+	}
+}
+
+func authenticateService(md metadata.MD, method, serviceToken string) error {
+	values := md.Get(serviceTokenHeader)
+	if serviceToken == "" || len(values) == 0 ||
+		subtle.ConstantTimeCompare([]byte(values[0]), []byte(serviceToken)) != 1 {
+		return status.Errorf(codes.Unauthenticated, "missing bearer token")
+	}
+	if !serviceMethods[method] {
+		return status.Errorf(codes.PermissionDenied, "not allowed for service calls")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zhan028/Music_Service/playlistService/internal/domain"
+	"github.com/Zhan028/Music_Service/playlistService/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// userAuthorizer принимает любой токен как токен пользователя userID
+type userAuthorizer struct{ userID string }
+
+func (a userAuthorizer) AuthorizeCall(_ context.Context, _ string) (*domain.Caller, error) {
+	return &domain.Caller{UserID: a.userID}, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	const secret = "s3cret"
+	cases := []struct {
+		name   string
+		md     metadata.MD
+		method string
+		req    interface{}
+		want   codes.Code
+		caller string // Пользователь, которого видит обработчик
+	}{
+		{name: "no credentials", method: proto.PlaylistService_DeleteUserPlaylists_FullMethodName, want: codes.Unauthenticated},
+		{name: "wrong service token", md: metadata.Pairs(serviceTokenHeader, "guess"),
+			method: proto.PlaylistService_DeleteUserPlaylists_FullMethodName, want: codes.Unauthenticated},
+		{name: "service call", md: metadata.Pairs(serviceTokenHeader, secret),
+			method: proto.PlaylistService_DeleteUserPlaylists_FullMethodName, want: codes.OK},
+		{name: "service call to a user method", md: metadata.Pairs(serviceTokenHeader, secret),
+			method: proto.PlaylistService_AddTrackToPlaylist_FullMethodName, want: codes.PermissionDenied},
+		{name: "user call", md: metadata.Pairs("authorization", "Bearer token"),
+			method: proto.PlaylistService_GetPlaylist_FullMethodName, req: &proto.GetPlaylistRequest{Id: "p1"}, want: codes.OK, caller: "alice"},
+		{name: "user call for another user", md: metadata.Pairs("authorization", "Bearer token"),
+			method: proto.PlaylistService_DeleteUserPlaylists_FullMethodName, req: &proto.DeleteUserPlaylistsRequest{UserId: "bob"},
+			want: codes.PermissionDenied},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+			var seen string
+			handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				if caller, ok := callerFrom(ctx); ok {
+					seen = caller.UserID
+				}
+				return nil, nil
+			}
+			_, err := Authenticate(userAuthorizer{userID: "alice"}, secret)(ctx, tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			if status.Code(err) != tc.want {
+				t.Fatalf("code = %v, want %v (%v)", status.Code(err), tc.want, err)
+			}
+			if seen != tc.caller {
+				t.Errorf("handler saw caller %q, want %q", seen, tc.caller)
+			}
+		})
+	}
+
+	t.Run("service calls disabled without a token", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(serviceTokenHeader, ""))
+		_, err := Authenticate(userAuthorizer{}, "")(ctx, nil,
+			&grpc.UnaryServerInfo{FullMethod: proto.PlaylistService_DeleteUserPlaylists_FullMethodName},
+			func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("code = %v, want Unauthenticated", status.Code(err))
+		}
+	})
+}
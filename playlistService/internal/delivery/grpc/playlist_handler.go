@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"github.com/Zhan028/Music_Service/internal/domain"
 	"github.com/Zhan028/Music_Service/internal/usecase"
 	"github.com/Zhan028/Music_Service/proto"
@@ -24,6 +25,9 @@ func NewPlaylistServer(useCase *usecase.PlaylistUseCase) *PlaylistServer {
 func (s *PlaylistServer) CreatePlaylist(ctx context.Context, req *proto.CreatePlaylistRequest) (*proto.Playlist, error) {
 	playlist, err := s.useCase.CreatePlaylist(ctx, req.Name, req.UserId, req.Description)
 	if err != nil {
+		if errors.Is(err, usecase.ErrPlaylistLimitReached) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create playlist: %v", err)
 	}
 
@@ -31,14 +35,26 @@ func (s *PlaylistServer) CreatePlaylist(ctx context.Context, req *proto.CreatePl
 }
 
 func (s *PlaylistServer) GetPlaylist(ctx context.Context, req *proto.GetPlaylistRequest) (*proto.Playlist, error) {
-	playlist, err := s.useCase.GetPlaylist(ctx, req.Id)
+	playlist, err := s.ownPlaylist(ctx, req.Id)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "playlist not found: %v", err)
+		return nil, err
 	}
 
 	return convertDomainToProto(playlist), nil
 }
 
+// ownPlaylist возвращает плейлист, если он принадлежит пользователю вызова
+func (s *PlaylistServer) ownPlaylist(ctx context.Context, id string) (*domain.Playlist, error) {
+	playlist, err := s.useCase.GetPlaylist(ctx, id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "playlist not found: %v", err)
+	}
+	if caller, ok := callerFrom(ctx); !ok || caller.UserID != playlist.UserID {
+		return nil, status.Errorf(codes.PermissionDenied, "not allowed for another user's playlist")
+	}
+	return playlist, nil
+}
+
 func (s *PlaylistServer) GetUserPlaylists(ctx context.Context, req *proto.GetUserPlaylistsRequest) (*proto.PlaylistList, error) {
 	playlists, err := s.useCase.GetUserPlaylists(ctx, req.UserId)
 	if err != nil {
@@ -57,6 +73,10 @@ func (s *PlaylistServer) GetUserPlaylists(ctx context.Context, req *proto.GetUse
 }
 
 func (s *PlaylistServer) AddTrackToPlaylist(ctx context.Context, req *proto.AddTrackRequest) (*proto.Playlist, error) {
+	if _, err := s.ownPlaylist(ctx, req.PlaylistId); err != nil {
+		return nil, err
+	}
+
 	track := domain.Track{
 		ID:       req.Track.Id,
 		Title:    req.Track.Title,
@@ -67,6 +87,9 @@ func (s *PlaylistServer) AddTrackToPlaylist(ctx context.Context, req *proto.AddT
 
 	playlist, err := s.useCase.AddTrackToPlaylist(ctx, req.PlaylistId, track)
 	if err != nil {
+		if errors.Is(err, usecase.ErrTrackLimitReached) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to add track to playlist: %v", err)
 	}
 
@@ -74,6 +97,10 @@ func (s *PlaylistServer) AddTrackToPlaylist(ctx context.Context, req *proto.AddT
 }
 
 func (s *PlaylistServer) RemoveTrackFromPlaylist(ctx context.Context, req *proto.RemoveTrackRequest) (*proto.Playlist, error) {
+	if _, err := s.ownPlaylist(ctx, req.PlaylistId); err != nil {
+		return nil, err
+	}
+
 	playlist, err := s.useCase.RemoveTrackFromPlaylist(ctx, req.PlaylistId, req.TrackId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to remove track from playlist: %v", err)
@@ -91,17 +118,27 @@ func (s *PlaylistServer) DeletePlaylist(ctx context.Context, req *proto.DeletePl
 	return &proto.DeletePlaylistResponse{Success: true}, nil
 }
 
+func (s *PlaylistServer) DeleteUserPlaylists(ctx context.Context, req *proto.DeleteUserPlaylistsRequest) (*proto.DeleteUserPlaylistsResponse, error) {
+	deleted, err := s.useCase.DeleteUserPlaylists(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete user playlists: %v", err)
+	}
+
+	return &proto.DeleteUserPlaylistsResponse{DeletedCount: deleted}, nil
+}
+
 // Вспомогательные функции для конвертации между моделями
 func convertDomainToProto(playlist *domain.Playlist) *proto.Playlist {
 	protoTracks := make([]*proto.Track, 0, len(playlist.Tracks))
 
 	for _, track := range playlist.Tracks {
 		protoTracks = append(protoTracks, &proto.Track{
-			Id:       track.ID,
-			Title:    track.Title,
-			Artist:   track.Artist,
-			Duration: track.Duration,
-			Album:    track.Album,
+			Id:          track.ID,
+			Title:       track.Title,
+			Artist:      track.Artist,
+			Duration:    track.Duration,
+			Album:       track.Album,
+			Unavailable: track.Unavailable,
 		})
 	}
 
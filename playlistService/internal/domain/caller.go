@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+// Caller — пользователь, от имени которого выполняется вызов
+type Caller struct {
+	UserID    string
+	ActorID   string // Администратор, если вызов сделан с токеном имперсонации
+	SessionID string // Сессия имперсонации
+}
+
+// CallAuthorizer проверяет bearer-токен вызова через user-service. Вызов с
+// токеном имперсонации записывается там в журнал аудита под именем method
+type CallAuthorizer interface {
+	AuthorizeCall(ctx context.Context, method string) (*Caller, error)
+}
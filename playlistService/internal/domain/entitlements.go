@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// Entitlements описывает лимиты тарифа пользователя. Нулевое значение означает отсутствие лимита
+type Entitlements struct {
+	Plan                 string
+	MaxPlaylists         int64
+	MaxTracksPerPlaylist int64
+}
+
+// EntitlementProvider получает лимиты тарифа пользователя из user-service
+type EntitlementProvider interface {
+	GetEntitlements(ctx context.Context, userID string) (*Entitlements, error)
+}
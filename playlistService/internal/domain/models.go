@@ -6,13 +6,17 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// Track представляет музыкальный трек
+// Track представляет копию трека из каталога внутри плейлиста
 type Track struct {
 	ID       string `json:"id" bson:"_id,omitempty"`
 	Title    string `json:"title" bson:"title"`
 	Artist   string `json:"artist" bson:"artist"`
 	Duration int32  `json:"duration" bson:"duration"` // в секундах
 	Album    string `json:"album" bson:"album"`
+	// Version — версия трека в каталоге, с которой синхронизирована копия
+	Version int64 `json:"version" bson:"version,omitempty"`
+	// Unavailable — трек удален из каталога
+	Unavailable bool `json:"unavailable" bson:"unavailable,omitempty"`
 }
 
 // Playlist представляет плейлист
@@ -13,6 +13,9 @@ type PlaylistRepository interface {
 	// GetByUserID получает все плейлисты пользователя
 	GetByUserID(ctx context.Context, userID string) ([]*Playlist, error)
 
+	// CountByUserID возвращает количество плейлистов пользователя
+	CountByUserID(ctx context.Context, userID string) (int64, error)
+
 	// Update обновляет существующий плейлист
 	Update(ctx context.Context, playlist *Playlist) (*Playlist, error)
 
@@ -24,4 +27,15 @@ type PlaylistRepository interface {
 
 	// Delete удаляет плейлист
 	Delete(ctx context.Context, id string, userID string) error
+
+	// DeleteByUserID удаляет все плейлисты пользователя и возвращает их количество
+	DeleteByUserID(ctx context.Context, userID string) (int64, error)
+
+	// SyncTrack обновляет копии трека во всех плейлистах, если они старше
+	// track.Version, и возвращает количество измененных плейлистов
+	SyncTrack(ctx context.Context, track Track) (int64, error)
+
+	// MarkTrackUnavailable помечает копии трека недоступными во всех
+	// плейлистах, если они старше version, и возвращает количество измененных плейлистов
+	MarkTrackUnavailable(ctx context.Context, trackID string, version int64) (int64, error)
 }
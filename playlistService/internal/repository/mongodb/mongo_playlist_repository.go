@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type mongoPlaylistRepository struct {
@@ -22,6 +23,16 @@ func NewPlaylistRepository(db *mongo.Database) domain2.PlaylistRepository {
 	}
 }
 
+// EnsureIndexes создает индекс по ID треков, нужный для синхронизации
+// копий треков с каталогом
+func EnsureIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("playlists").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "tracks._id", Value: 1}},
+		Options: options.Index().SetName("tracks_id"),
+	})
+	return err
+}
+
 func (r *mongoPlaylistRepository) Create(ctx context.Context, playlist *domain2.Playlist) (*domain2.Playlist, error) {
 	playlist, err := playlist.ToMongo()
 	if err != nil {
@@ -78,6 +89,10 @@ func (r *mongoPlaylistRepository) GetByUserID(ctx context.Context, userID string
 	return playlists, nil
 }
 
+func (r *mongoPlaylistRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
 func (r *mongoPlaylistRepository) Update(ctx context.Context, playlist *domain2.Playlist) (*domain2.Playlist, error) {
 	objID, err := primitive.ObjectIDFromHex(playlist.ID)
 	if err != nil {
@@ -164,3 +179,55 @@ func (r *mongoPlaylistRepository) Delete(ctx context.Context, id string, userID
 
 	return nil
 }
+
+func (r *mongoPlaylistRepository) DeleteByUserID(ctx context.Context, userID string) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// olderCopy отбирает копии трека, синхронизированные с версией меньше
+// version. Копии без версии добавлены до синхронизации и тоже считаются старыми.
+func olderCopy(prefix, trackID string, version int64) bson.M {
+	return bson.M{
+		prefix + "_id":     trackID,
+		prefix + "version": bson.M{"$not": bson.M{"$gte": version}},
+	}
+}
+
+// updateCopies применяет set ко всем копиям трека старше version. Повторное
+// или запоздавшее событие ничего не меняет, поэтому порядок доставки не важен.
+// updated_at не трогается: плейлист не менял владелец.
+func (r *mongoPlaylistRepository) updateCopies(ctx context.Context, trackID string, version int64, set bson.M) (int64, error) {
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"tracks": bson.M{"$elemMatch": olderCopy("", trackID, version)}},
+		bson.M{"$set": set},
+		options.Update().SetArrayFilters(options.ArrayFilters{
+			Filters: []interface{}{olderCopy("t.", trackID, version)},
+		}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *mongoPlaylistRepository) SyncTrack(ctx context.Context, track domain2.Track) (int64, error) {
+	return r.updateCopies(ctx, track.ID, track.Version, bson.M{
+		"tracks.$[t].title":    track.Title,
+		"tracks.$[t].artist":   track.Artist,
+		"tracks.$[t].album":    track.Album,
+		"tracks.$[t].duration": track.Duration,
+		"tracks.$[t].version":  track.Version,
+	})
+}
+
+func (r *mongoPlaylistRepository) MarkTrackUnavailable(ctx context.Context, trackID string, version int64) (int64, error) {
+	return r.updateCopies(ctx, trackID, version, bson.M{
+		"tracks.$[t].unavailable": true,
+		"tracks.$[t].version":     version,
+	})
+}
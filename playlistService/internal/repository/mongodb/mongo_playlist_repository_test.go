@@ -0,0 +1,212 @@
+package mongodb_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Zhan028/Music_Service/playlistService/internal/domain"
+	"github.com/Zhan028/Music_Service/playlistService/internal/repository/mongodb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newTestRepository подключается к MongoDB из MONGO_TEST_URI, например
+// MONGO_TEST_URI=mongodb://localhost:27017 go test ./internal/...
+// У каждого теста своя база, которая удаляется после него.
+func newTestRepository(t *testing.T) domain.PlaylistRepository {
+	t.Helper()
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Fatalf("ping: %v", err)
+	}
+
+	db := client.Database("playlist_service_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		if err := db.Drop(context.Background()); err != nil {
+			t.Logf("drop %s: %v", db.Name(), err)
+		}
+	})
+	if err := mongodb.EnsureIndexes(ctx, db); err != nil {
+		t.Fatalf("EnsureIndexes: %v", err)
+	}
+	return mongodb.NewPlaylistRepository(db)
+}
+
+func createPlaylist(t *testing.T, repo domain.PlaylistRepository, tracks ...domain.Track) string {
+	t.Helper()
+	playlist, err := repo.Create(context.Background(), &domain.Playlist{Name: "Mix", UserID: "user-1", Tracks: tracks})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return playlist.ID
+}
+
+// copyOf возвращает копию трека trackID из плейлиста
+func copyOf(t *testing.T, repo domain.PlaylistRepository, playlistID, trackID string) domain.Track {
+	t.Helper()
+	playlist, err := repo.GetByID(context.Background(), playlistID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	for _, track := range playlist.Tracks {
+		if track.ID == trackID {
+			return track
+		}
+	}
+	t.Fatalf("playlist %s has no track %s", playlistID, trackID)
+	return domain.Track{}
+}
+
+func wantModified(t *testing.T, name string, got int64, err error, want int64) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if got != want {
+		t.Errorf("%s modified %d playlists, want %d", name, got, want)
+	}
+}
+
+const trackID = "6650a1f0c2b3d4e5f6a7b8c9"
+
+func original() domain.Track {
+	return domain.Track{ID: trackID, Title: "Kukushka", Artist: "Kino", Album: "Gruppa krovi", Duration: 399, Version: 1}
+}
+
+func revision(version int64, title string) domain.Track {
+	track := original()
+	track.Title, track.Version = title, version
+	return track
+}
+
+func TestSyncTrack(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("updates every copy and leaves other tracks alone", func(t *testing.T) {
+		repo := newTestRepository(t)
+		other := domain.Track{ID: primitive.NewObjectID().Hex(), Title: "Zvezda", Version: 1}
+		first := createPlaylist(t, repo, original(), other)
+		second := createPlaylist(t, repo, original())
+		createPlaylist(t, repo, other)
+
+		n, err := repo.SyncTrack(ctx, revision(2, "Kukushka (live)"))
+		wantModified(t, "SyncTrack", n, err, 2)
+		for _, id := range []string{first, second} {
+			if got := copyOf(t, repo, id, trackID); got != revision(2, "Kukushka (live)") {
+				t.Errorf("copy in %s = %+v", id, got)
+			}
+		}
+		if got := copyOf(t, repo, first, other.ID); got != other {
+			t.Errorf("other track = %+v, want %+v", got, other)
+		}
+	})
+
+	t.Run("duplicate event", func(t *testing.T) {
+		repo := newTestRepository(t)
+		playlist := createPlaylist(t, repo, original())
+
+		n, err := repo.SyncTrack(ctx, revision(2, "Kukushka (live)"))
+		wantModified(t, "first delivery", n, err, 1)
+		n, err = repo.SyncTrack(ctx, revision(2, "Kukushka (live)"))
+		wantModified(t, "second delivery", n, err, 0)
+		if got := copyOf(t, repo, playlist, trackID); got != revision(2, "Kukushka (live)") {
+			t.Errorf("copy = %+v", got)
+		}
+	})
+
+	t.Run("older version after a newer one", func(t *testing.T) {
+		repo := newTestRepository(t)
+		playlist := createPlaylist(t, repo, original())
+
+		n, err := repo.SyncTrack(ctx, revision(3, "Kukushka (remastered)"))
+		wantModified(t, "version 3", n, err, 1)
+		n, err = repo.SyncTrack(ctx, revision(2, "Kukushka (live)"))
+		wantModified(t, "version 2", n, err, 0)
+		if got := copyOf(t, repo, playlist, trackID); got != revision(3, "Kukushka (remastered)") {
+			t.Errorf("copy = %+v, want version 3", got)
+		}
+	})
+
+	t.Run("copies without a version", func(t *testing.T) {
+		repo := newTestRepository(t)
+		// Копии, добавленные до синхронизации, хранятся без версии
+		legacy := original()
+		legacy.Version = 0
+		old := createPlaylist(t, repo, legacy)
+		current := createPlaylist(t, repo, revision(5, "Kukushka (2024)"))
+
+		n, err := repo.SyncTrack(ctx, revision(3, "Kukushka (live)"))
+		wantModified(t, "SyncTrack", n, err, 1)
+		if got := copyOf(t, repo, old, trackID); got != revision(3, "Kukushka (live)") {
+			t.Errorf("copy without a version = %+v, want version 3", got)
+		}
+		if got := copyOf(t, repo, current, trackID); got != revision(5, "Kukushka (2024)") {
+			t.Errorf("newer copy = %+v, want version 5", got)
+		}
+	})
+}
+
+func TestMarkTrackUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	deleted := func(version int64, title string) domain.Track {
+		track := revision(version, title)
+		track.Unavailable = true
+		return track
+	}
+
+	t.Run("delete followed by a late update", func(t *testing.T) {
+		repo := newTestRepository(t)
+		playlist := createPlaylist(t, repo, original())
+
+		n, err := repo.MarkTrackUnavailable(ctx, trackID, 3)
+		wantModified(t, "MarkTrackUnavailable", n, err, 1)
+		n, err = repo.SyncTrack(ctx, revision(2, "Kukushka (live)"))
+		wantModified(t, "late SyncTrack", n, err, 0)
+		if got := copyOf(t, repo, playlist, trackID); got != deleted(3, "Kukushka") {
+			t.Errorf("copy = %+v, want it unavailable at version 3", got)
+		}
+
+		// Повторное удаление тоже ничего не меняет
+		n, err = repo.MarkTrackUnavailable(ctx, trackID, 3)
+		wantModified(t, "duplicate MarkTrackUnavailable", n, err, 0)
+	})
+
+	t.Run("delete older than the copy", func(t *testing.T) {
+		repo := newTestRepository(t)
+		playlist := createPlaylist(t, repo, revision(4, "Kukushka (live)"))
+
+		n, err := repo.MarkTrackUnavailable(ctx, trackID, 4)
+		wantModified(t, "MarkTrackUnavailable", n, err, 0)
+		if got := copyOf(t, repo, playlist, trackID); got != revision(4, "Kukushka (live)") {
+			t.Errorf("copy = %+v, want it untouched", got)
+		}
+	})
+
+	t.Run("copies without a version", func(t *testing.T) {
+		repo := newTestRepository(t)
+		legacy := original()
+		legacy.Version = 0
+		playlist := createPlaylist(t, repo, legacy)
+
+		n, err := repo.MarkTrackUnavailable(ctx, trackID, 2)
+		wantModified(t, "MarkTrackUnavailable", n, err, 1)
+		if got := copyOf(t, repo, playlist, trackID); got != deleted(2, "Kukushka") {
+			t.Errorf("copy = %+v, want it unavailable at version 2", got)
+		}
+	})
+}
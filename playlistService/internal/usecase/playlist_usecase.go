@@ -3,16 +3,25 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	domain2 "github.com/Zhan028/Music_Service/playlistService/internal/domain"
 )
 
+var (
+	ErrPlaylistLimitReached = errors.New("playlist limit of the subscription plan reached")
+	ErrTrackLimitReached    = errors.New("track limit per playlist of the subscription plan reached")
+)
+
 type PlaylistUseCase struct {
-	repo domain2.PlaylistRepository
+	repo         domain2.PlaylistRepository
+	entitlements domain2.EntitlementProvider
 }
 
-func NewPlaylistUseCase(repo domain2.PlaylistRepository) *PlaylistUseCase {
+// NewPlaylistUseCase создает use case. Если entitlements равен nil, лимиты тарифов не проверяются
+func NewPlaylistUseCase(repo domain2.PlaylistRepository, entitlements domain2.EntitlementProvider) *PlaylistUseCase {
 	return &PlaylistUseCase{
-		repo: repo,
+		repo:         repo,
+		entitlements: entitlements,
 	}
 }
 
@@ -25,6 +34,20 @@ func (uc *PlaylistUseCase) CreatePlaylist(ctx context.Context, name, userID, des
 		return nil, errors.New("user ID cannot be empty")
 	}
 
+	limits, err := uc.limits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if limits.MaxPlaylists > 0 {
+		count, err := uc.repo.CountByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= limits.MaxPlaylists {
+			return nil, ErrPlaylistLimitReached
+		}
+	}
+
 	playlist := &domain2.Playlist{
 		Name:        name,
 		UserID:      userID,
@@ -73,6 +96,14 @@ func (uc *PlaylistUseCase) AddTrackToPlaylist(ctx context.Context, playlistID st
 		}
 	}
 
+	limits, err := uc.limits(ctx, playlist.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if limits.MaxTracksPerPlaylist > 0 && int64(len(playlist.Tracks)) >= limits.MaxTracksPerPlaylist {
+		return nil, ErrTrackLimitReached
+	}
+
 	return uc.repo.AddTrack(ctx, playlistID, track)
 }
 
@@ -99,3 +130,46 @@ func (uc *PlaylistUseCase) DeletePlaylist(ctx context.Context, id, userID string
 
 	return uc.repo.Delete(ctx, id, userID)
 }
+
+// DeleteUserPlaylists удаляет все плейлисты пользователя. Операция идемпотентна:
+// повторный вызов для уже очищенного пользователя просто вернет 0.
+func (uc *PlaylistUseCase) DeleteUserPlaylists(ctx context.Context, userID string) (int64, error) {
+	if userID == "" {
+		return 0, errors.New("user ID cannot be empty")
+	}
+
+	return uc.repo.DeleteByUserID(ctx, userID)
+}
+
+// SyncTrack обновляет копии измененного в каталоге трека во всех плейлистах.
+// Копии новее track.Version не меняются, поэтому повтор события безопасен.
+func (uc *PlaylistUseCase) SyncTrack(ctx context.Context, track domain2.Track) (int64, error) {
+	if track.ID == "" {
+		return 0, errors.New("track ID cannot be empty")
+	}
+
+	return uc.repo.SyncTrack(ctx, track)
+}
+
+// MarkTrackUnavailable помечает удаленный из каталога трек недоступным во
+// всех плейлистах. Трек остается в плейлистах, чтобы владелец видел, что пропало.
+func (uc *PlaylistUseCase) MarkTrackUnavailable(ctx context.Context, trackID string, version int64) (int64, error) {
+	if trackID == "" {
+		return 0, errors.New("track ID cannot be empty")
+	}
+
+	return uc.repo.MarkTrackUnavailable(ctx, trackID, version)
+}
+
+// limits возвращает лимиты тарифа владельца плейлистов
+func (uc *PlaylistUseCase) limits(ctx context.Context, userID string) (*domain2.Entitlements, error) {
+	if uc.entitlements == nil {
+		return &domain2.Entitlements{}, nil
+	}
+
+	entitlements, err := uc.entitlements.GetEntitlements(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entitlements: %w", err)
+	}
+	return entitlements, nil
+}
@@ -25,6 +25,7 @@ const (
 	PlaylistService_AddTrackToPlaylist_FullMethodName      = "/playlist.PlaylistService/AddTrackToPlaylist"
 	PlaylistService_RemoveTrackFromPlaylist_FullMethodName = "/playlist.PlaylistService/RemoveTrackFromPlaylist"
 	PlaylistService_DeletePlaylist_FullMethodName          = "/playlist.PlaylistService/DeletePlaylist"
+	PlaylistService_DeleteUserPlaylists_FullMethodName     = "/playlist.PlaylistService/DeleteUserPlaylists"
 )
 
 // PlaylistServiceClient is the client API for PlaylistService service.
@@ -43,6 +44,8 @@ type PlaylistServiceClient interface {
 	RemoveTrackFromPlaylist(ctx context.Context, in *RemoveTrackRequest, opts ...grpc.CallOption) (*Playlist, error)
 	// Удаление плейлиста
 	DeletePlaylist(ctx context.Context, in *DeletePlaylistRequest, opts ...grpc.CallOption) (*DeletePlaylistResponse, error)
+	// Удаление всех плейлистов пользователя (вызывается при удалении пользователя)
+	DeleteUserPlaylists(ctx context.Context, in *DeleteUserPlaylistsRequest, opts ...grpc.CallOption) (*DeleteUserPlaylistsResponse, error)
 }
 
 type playlistServiceClient struct {
@@ -107,6 +110,15 @@ func (c *playlistServiceClient) DeletePlaylist(ctx context.Context, in *DeletePl
 	return out, nil
 }
 
+func (c *playlistServiceClient) DeleteUserPlaylists(ctx context.Context, in *DeleteUserPlaylistsRequest, opts ...grpc.CallOption) (*DeleteUserPlaylistsResponse, error) {
+	out := new(DeleteUserPlaylistsResponse)
+	err := c.cc.Invoke(ctx, PlaylistService_DeleteUserPlaylists_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlaylistServiceServer is the server API for PlaylistService service.
 // All implementations must embed UnimplementedPlaylistServiceServer
 // for forward compatibility
@@ -123,6 +135,8 @@ type PlaylistServiceServer interface {
 	RemoveTrackFromPlaylist(context.Context, *RemoveTrackRequest) (*Playlist, error)
 	// Удаление плейлиста
 	DeletePlaylist(context.Context, *DeletePlaylistRequest) (*DeletePlaylistResponse, error)
+	// Удаление всех плейлистов пользователя (вызывается при удалении пользователя)
+	DeleteUserPlaylists(context.Context, *DeleteUserPlaylistsRequest) (*DeleteUserPlaylistsResponse, error)
 	mustEmbedUnimplementedPlaylistServiceServer()
 }
 
@@ -148,6 +162,9 @@ func (UnimplementedPlaylistServiceServer) RemoveTrackFromPlaylist(context.Contex
 func (UnimplementedPlaylistServiceServer) DeletePlaylist(context.Context, *DeletePlaylistRequest) (*DeletePlaylistResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeletePlaylist not implemented")
 }
+func (UnimplementedPlaylistServiceServer) DeleteUserPlaylists(context.Context, *DeleteUserPlaylistsRequest) (*DeleteUserPlaylistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserPlaylists not implemented")
+}
 func (UnimplementedPlaylistServiceServer) mustEmbedUnimplementedPlaylistServiceServer() {}
 
 // UnsafePlaylistServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -269,6 +286,24 @@ func _PlaylistService_DeletePlaylist_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaylistService_DeleteUserPlaylists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserPlaylistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).DeleteUserPlaylists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlaylistService_DeleteUserPlaylists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).DeleteUserPlaylists(ctx, req.(*DeleteUserPlaylistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PlaylistService_ServiceDesc is the grpc.ServiceDesc for PlaylistService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -300,6 +335,10 @@ var PlaylistService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeletePlaylist",
 			Handler:    _PlaylistService_DeletePlaylist_Handler,
 		},
+		{
+			MethodName: "DeleteUserPlaylists",
+			Handler:    _PlaylistService_DeleteUserPlaylists_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/playlist.proto",
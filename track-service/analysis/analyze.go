@@ -0,0 +1,61 @@
+// Package analysis измеряет декодированный звук: пики для превью формы
+// волны, интегральную громкость и истинный пик по ITU-R BS.1770-4
+package analysis
+
+import (
+	"io"
+	"math"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/audio"
+)
+
+// SilenceLevel — уровень тишины в дБ: громкость и пик ниже любых реальных.
+// Для полностью тихого файла логарифм не определен.
+const SilenceLevel = -120.0
+
+// Result — итог анализа файла
+type Result struct {
+	Waveform       []byte  // Пик каждой корзины, линейно: 255 — полная шкала
+	IntegratedLUFS float64 // Интегральная громкость, LUFS
+	TruePeakDBTP   float64 // Истинный пик, dBTP
+}
+
+// Analyze декодирует поток до конца за один проход и возвращает форму волны
+// из buckets корзин, громкость и истинный пик
+func Analyze(dec audio.Decoder, buckets int) (Result, error) {
+	channels, rate := dec.Channels(), dec.SampleRate()
+	wave := newWaveform(channels)
+	loudness := newLoudnessMeter(channels, rate)
+	peak := newTruePeakMeter(channels, rate)
+
+	buf := make([]float64, 4096*channels)
+	for {
+		n, err := dec.Read(buf)
+		if n > 0 {
+			samples := buf[:n]
+			wave.write(samples)
+			loudness.write(samples)
+			peak.write(samples)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	return Result{
+		Waveform:       wave.buckets(buckets),
+		IntegratedLUFS: loudness.integrated(),
+		TruePeakDBTP:   decibels(peak.peak),
+	}, nil
+}
+
+// decibels переводит амплитуду в дБ относительно полной шкалы
+func decibels(amplitude float64) float64 {
+	if amplitude <= 0 {
+		return SilenceLevel
+	}
+	return math.Max(20*math.Log10(amplitude), SilenceLevel)
+}
@@ -0,0 +1,168 @@
+package analysis
+
+import "math"
+
+// Параметры измерения громкости BS.1770-4: блоки 400 мс с перекрытием 75%,
+// абсолютный порог -70 LUFS и относительный на 10 LU ниже громкости блоков,
+// прошедших абсолютный порог
+const (
+	blockSubdivisions = 4 // Блок из четырех шагов по 100 мс
+	absoluteGate      = -70.0
+	relativeGate      = -10.0
+)
+
+// biquad — фильтр второго порядка в прямой форме II
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	z1, z2             float64
+}
+
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.z1
+	f.z1 = f.b1*x - f.a1*y + f.z2
+	f.z2 = f.b2*x - f.a2*y
+	return y
+}
+
+// kWeighting строит два каскада K-фильтра для частоты дискретизации rate.
+// Коэффициенты из BS.1770 заданы для 48 кГц; для других частот фильтры
+// пересчитываются по аналоговым прототипам, как в libebur128.
+func kWeighting(rate int) (shelf, highPass biquad) {
+	fs := float64(rate)
+
+	// Полка +4 дБ на высоких частотах: модель акустики головы
+	const f0, gain, q = 1681.974450955533, 3.999843853973347, 0.7071752369554196
+	k := math.Tan(math.Pi * f0 / fs)
+	vh := math.Pow(10, gain/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+	shelf = biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	// Фильтр высоких частот RLB
+	const hf0, hq = 38.13547087602444, 0.5003270373238773
+	k = math.Tan(math.Pi * hf0 / fs)
+	a0 = 1 + k/hq + k*k
+	highPass = biquad{
+		b0: 1, b1: -2, b2: 1,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/hq + k*k) / a0,
+	}
+	return shelf, highPass
+}
+
+// channelWeight — вес канала при суммировании: каналы окружения громче на
+// 1,5 дБ, LFE не учитывается. Порядок каналов — как в WAV и FLAC.
+func channelWeight(channel, channels int) float64 {
+	switch {
+	case channels == 6 && channel == 3:
+		return 0 // LFE в 5.1
+	case channels == 5 && channel >= 3, channels == 6 && channel >= 4:
+		return 1.41
+	default:
+		return 1
+	}
+}
+
+type loudnessMeter struct {
+	channels  int
+	weights   []float64
+	shelf     []biquad
+	highPass  []biquad
+	stepSize  int       // Кадров в шаге 100 мс
+	stepFrame int       // Кадров в текущем шаге
+	step      []float64 // Сумма квадратов по каналам в текущем шаге
+	steps     []float64 // Средние квадраты последних шагов, взвешенные и сложенные по каналам
+	blocks    []float64 // Взвешенная мощность каждого блока 400 мс
+}
+
+func newLoudnessMeter(channels, rate int) *loudnessMeter {
+	m := &loudnessMeter{
+		channels: channels,
+		weights:  make([]float64, channels),
+		shelf:    make([]biquad, channels),
+		highPass: make([]biquad, channels),
+		stepSize: rate / 10,
+		step:     make([]float64, channels),
+	}
+	for c := 0; c < channels; c++ {
+		m.weights[c] = channelWeight(c, channels)
+		m.shelf[c], m.highPass[c] = kWeighting(rate)
+	}
+	return m
+}
+
+func (m *loudnessMeter) write(samples []float64) {
+	for i := 0; i+m.channels <= len(samples); i += m.channels {
+		for c := 0; c < m.channels; c++ {
+			y := m.highPass[c].process(m.shelf[c].process(samples[i+c]))
+			m.step[c] += y * y
+		}
+		if m.stepFrame++; m.stepFrame == m.stepSize {
+			m.closeStep()
+		}
+	}
+}
+
+// closeStep завершает шаг 100 мс; каждые четыре последних шага образуют блок
+func (m *loudnessMeter) closeStep() {
+	var power float64
+	for c, sum := range m.step {
+		power += m.weights[c] * sum / float64(m.stepSize)
+		m.step[c] = 0
+	}
+	m.stepFrame = 0
+
+	m.steps = append(m.steps, power)
+	if len(m.steps) > blockSubdivisions {
+		m.steps = m.steps[1:]
+	}
+	if len(m.steps) == blockSubdivisions {
+		var block float64
+		for _, p := range m.steps {
+			block += p
+		}
+		m.blocks = append(m.blocks, block/blockSubdivisions)
+	}
+}
+
+// integrated возвращает интегральную громкость по блокам, прошедшим оба порога
+func (m *loudnessMeter) integrated() float64 {
+	mean := func(threshold float64) (float64, int) {
+		var sum float64
+		var n int
+		for _, b := range m.blocks {
+			if lufs(b) > threshold {
+				sum += b
+				n++
+			}
+		}
+		if n == 0 {
+			return 0, 0
+		}
+		return sum / float64(n), n
+	}
+
+	absolute, n := mean(absoluteGate)
+	if n == 0 {
+		return SilenceLevel
+	}
+	gated, n := mean(lufs(absolute) + relativeGate)
+	if n == 0 {
+		return SilenceLevel
+	}
+	return lufs(gated)
+}
+
+// lufs переводит взвешенную мощность в LUFS
+func lufs(power float64) float64 {
+	if power <= 0 {
+		return SilenceLevel
+	}
+	return -0.691 + 10*math.Log10(power)
+}
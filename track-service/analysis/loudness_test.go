@@ -0,0 +1,207 @@
+package analysis
+
+import (
+	"io"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// sliceDecoder отдает заранее сгенерированный сигнал
+type sliceDecoder struct {
+	rate, channels int
+	samples        []float64
+	err            error // Ошибка после конца сигнала вместо io.EOF
+}
+
+func (d *sliceDecoder) SampleRate() int { return d.rate }
+func (d *sliceDecoder) Channels() int   { return d.channels }
+
+func (d *sliceDecoder) Read(buf []float64) (int, error) {
+	n := len(buf) / d.channels * d.channels
+	if n > len(d.samples) {
+		n = len(d.samples)
+	}
+	copy(buf, d.samples[:n])
+	d.samples = d.samples[n:]
+	if n == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// amplitude переводит уровень в дБFS в линейную амплитуду
+func amplitude(dbfs float64) float64 {
+	return math.Pow(10, dbfs/20)
+}
+
+// sine — синус частоты freq и уровня dbfs (по пику) во всех каналах
+func sine(rate, channels int, freq, dbfs float64, duration float64) []float64 {
+	frames := int(duration * float64(rate))
+	a := amplitude(dbfs)
+	out := make([]float64, frames*channels)
+	for i := 0; i < frames; i++ {
+		v := a * math.Sin(2*math.Pi*freq*float64(i)/float64(rate))
+		for c := 0; c < channels; c++ {
+			out[i*channels+c] = v
+		}
+	}
+	return out
+}
+
+func measureLoudness(rate, channels int, samples []float64) float64 {
+	m := newLoudnessMeter(channels, rate)
+	m.write(samples)
+	return m.integrated()
+}
+
+// Тесты EBU Tech 3341: стереосинус 1 кГц уровня X дБFS имеет громкость X LUFS
+func TestIntegratedLoudnessSine(t *testing.T) {
+	for _, rate := range []int{44100, 48000, 96000} {
+		for _, level := range []float64{-23, -33, -3} {
+			got := measureLoudness(rate, 2, sine(rate, 2, 997, level, 20))
+			if math.Abs(got-level) > 0.1 {
+				t.Errorf("%d Hz, stereo sine at %v dBFS: %.2f LUFS, want %v", rate, level, got, level)
+			}
+		}
+	}
+
+	// Моно вдвое тише стерео с тем же сигналом в обоих каналах
+	if got := measureLoudness(48000, 1, sine(48000, 1, 997, -20, 20)); math.Abs(got-(-23.01)) > 0.1 {
+		t.Errorf("mono sine at -20 dBFS: %.2f LUFS, want -23.01", got)
+	}
+}
+
+// Тест 3 из EBU Tech 3341: тихие участки на 13 LU ниже отсекаются
+// относительным порогом
+func TestIntegratedLoudnessRelativeGate(t *testing.T) {
+	const rate = 48000
+	var samples []float64
+	samples = append(samples, sine(rate, 2, 997, -36, 10)...)
+	samples = append(samples, sine(rate, 2, 997, -23, 20)...)
+	samples = append(samples, sine(rate, 2, 997, -36, 10)...)
+
+	if got := measureLoudness(rate, 2, samples); math.Abs(got-(-23)) > 0.1 {
+		t.Errorf("integrated loudness = %.2f LUFS, want -23", got)
+	}
+}
+
+func TestIntegratedLoudnessAbsoluteGate(t *testing.T) {
+	const rate = 48000
+	// Тишина не входит в среднее: громкость та же, что у одного синуса
+	samples := append(make([]float64, rate*2*20), sine(rate, 2, 997, -23, 20)...)
+	if got := measureLoudness(rate, 2, samples); math.Abs(got-(-23)) > 0.1 {
+		t.Errorf("sine after silence = %.2f LUFS, want -23", got)
+	}
+
+	// Все ниже -70 LUFS считается тишиной
+	if got := measureLoudness(rate, 2, sine(rate, 2, 997, -75, 10)); got != SilenceLevel {
+		t.Errorf("sine at -75 dBFS = %.2f LUFS, want %v", got, SilenceLevel)
+	}
+	if got := measureLoudness(rate, 2, make([]float64, rate*2*10)); got != SilenceLevel {
+		t.Errorf("silence = %.2f LUFS, want %v", got, SilenceLevel)
+	}
+	// Короче одного блока в 400 мс
+	if got := measureLoudness(rate, 2, sine(rate, 2, 997, -23, 0.3)); got != SilenceLevel {
+		t.Errorf("300 ms = %.2f LUFS, want %v", got, SilenceLevel)
+	}
+}
+
+// Каналы окружения 5.1 весят на 1,5 дБ больше, LFE не учитывается
+func TestChannelWeights(t *testing.T) {
+	const rate = 48000
+	only := func(channel int) []float64 {
+		mono := sine(rate, 1, 997, -23, 10)
+		out := make([]float64, len(mono)*6)
+		for i, v := range mono {
+			out[i*6+channel] = v
+		}
+		return out
+	}
+
+	front := measureLoudness(rate, 6, only(0))
+	if math.Abs(front-(-26.01)) > 0.1 {
+		t.Errorf("front channel = %.2f LUFS, want -26.01", front)
+	}
+	if surround := measureLoudness(rate, 6, only(4)); math.Abs(surround-front-1.5) > 0.05 {
+		t.Errorf("surround channel is %.2f LU louder than front, want 1.5", surround-front)
+	}
+	if lfe := measureLoudness(rate, 6, only(3)); lfe != SilenceLevel {
+		t.Errorf("LFE channel = %.2f LUFS, want %v", lfe, SilenceLevel)
+	}
+}
+
+// Коэффициенты K-фильтра для 48 кГц из ITU-R BS.1770-4, таблицы 1 и 2
+func TestKWeightingCoefficients(t *testing.T) {
+	shelf, highPass := kWeighting(48000)
+
+	cases := []struct {
+		name      string
+		got, want [5]float64
+	}{
+		{
+			name: "shelf",
+			got:  [5]float64{shelf.b0, shelf.b1, shelf.b2, shelf.a1, shelf.a2},
+			want: [5]float64{1.53512485958697, -2.69169618940638, 1.19839281085285, -1.69065929318241, 0.73248077421585},
+		},
+		{
+			name: "high-pass",
+			got:  [5]float64{highPass.b0, highPass.b1, highPass.b2, highPass.a1, highPass.a2},
+			want: [5]float64{1, -2, 1, -1.99004745483398, 0.99007225036621},
+		},
+	}
+	for _, tc := range cases {
+		for i := range tc.want {
+			if math.Abs(tc.got[i]-tc.want[i]) > 1e-8 {
+				t.Errorf("%s coefficient %d = %.14f, want %.14f", tc.name, i, tc.got[i], tc.want[i])
+			}
+		}
+	}
+}
+
+// gain — усиление K-фильтра на частоте freq, дБ
+func gain(rate int, freq float64) float64 {
+	shelf, highPass := kWeighting(rate)
+	var in, out float64
+	samples := sine(rate, 1, freq, 0, 2)
+	for i, x := range samples {
+		y := highPass.process(shelf.process(x))
+		if i >= len(samples)/2 { // Без переходного процесса
+			in += x * x
+			out += y * y
+		}
+	}
+	return 10 * math.Log10(out/in)
+}
+
+// referenceGain — усиление эталонного K-фильтра BS.1770 для 48 кГц на
+// частоте freq, дБ, по передаточной функции
+func referenceGain(freq float64) float64 {
+	z := cmplx.Exp(complex(0, -2*math.Pi*freq/48000)) // z^-1
+	response := func(b0, b1, b2, a1, a2 float64) complex128 {
+		return (complex(b0, 0) + complex(b1, 0)*z + complex(b2, 0)*z*z) /
+			(1 + complex(a1, 0)*z + complex(a2, 0)*z*z)
+	}
+	h := response(1.53512485958697, -2.69169618940638, 1.19839281085285, -1.69065929318241, 0.73248077421585) *
+		response(1, -2, 1, -1.99004745483398, 0.99007225036621)
+	return 20 * math.Log10(cmplx.Abs(h))
+}
+
+// Пересчитанные для других частот фильтры дают ту же АЧХ, что и эталонный
+// для 48 кГц
+func TestKWeightingResponse(t *testing.T) {
+	if got := referenceGain(997); math.Abs(got-0.691) > 0.01 {
+		t.Fatalf("reference gain at 997 Hz = %.3f dB, want 0.691", got)
+	}
+	for _, freq := range []float64{25, 40, 100, 500, 997, 2000, 4000, 10000} {
+		want := referenceGain(freq)
+		for _, rate := range []int{44100, 48000, 96000} {
+			if got := gain(rate, freq); math.Abs(got-want) > 0.1 {
+				t.Errorf("%d Hz: gain at %v Hz = %.2f dB, want %.2f", rate, freq, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import "math"
+
+// Истинный пик по BS.1770-4, приложение 2: сигнал передискретизируется в 4
+// раза (в 2 раза от 96 кГц), и пик ищется среди восстановленных отсчетов —
+// между исходными сэмплами форма волны бывает выше любого из них
+const truePeakTaps = 12 // Отводов фильтра на фазу
+
+type truePeakMeter struct {
+	channels int
+	factor   int         // Во сколько раз повышается частота
+	phases   [][]float64 // Коэффициенты фильтра по фазам
+	history  [][]float64 // Последние сэмплы каждого канала, кольцевой буфер
+	pos      int
+	peak     float64
+}
+
+func newTruePeakMeter(channels, rate int) *truePeakMeter {
+	factor := 1
+	switch {
+	case rate < 96000:
+		factor = 4
+	case rate < 192000:
+		factor = 2
+	}
+	m := &truePeakMeter{
+		channels: channels,
+		factor:   factor,
+		history:  make([][]float64, channels),
+	}
+	for c := range m.history {
+		m.history[c] = make([]float64, truePeakTaps)
+	}
+	if factor > 1 {
+		m.phases = interpolationFilter(factor)
+	}
+	return m
+}
+
+// interpolationFilter строит полифазный ФНЧ с частотой среза на половине
+// исходной частоты: sinc с окном Ханна длиной factor·truePeakTaps
+func interpolationFilter(factor int) [][]float64 {
+	length := factor * truePeakTaps
+	center := float64(length-1) / 2
+	phases := make([][]float64, factor)
+	for p := range phases {
+		phases[p] = make([]float64, truePeakTaps)
+	}
+	for i := 0; i < length; i++ {
+		x := (float64(i) - center) / float64(factor)
+		sinc := 1.0
+		if x != 0 {
+			sinc = math.Sin(math.Pi*x) / (math.Pi * x)
+		}
+		window := 0.5 - 0.5*math.Cos(2*math.Pi*(float64(i)+0.5)/float64(length))
+		phases[i%factor][i/factor] = sinc * window
+	}
+	return phases
+}
+
+func (m *truePeakMeter) write(samples []float64) {
+	for i := 0; i+m.channels <= len(samples); i += m.channels {
+		for c := 0; c < m.channels; c++ {
+			s := samples[i+c]
+			m.peak = math.Max(m.peak, math.Abs(s))
+			if m.factor == 1 {
+				continue
+			}
+			h := m.history[c]
+			h[m.pos] = s
+			for _, phase := range m.phases {
+				var y float64
+				for k, coefficient := range phase {
+					y += coefficient * h[(m.pos-k+truePeakTaps)%truePeakTaps]
+				}
+				m.peak = math.Max(m.peak, math.Abs(y))
+			}
+		}
+		m.pos = (m.pos + 1) % truePeakTaps
+	}
+}
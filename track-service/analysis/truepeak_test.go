@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+)
+
+func measureTruePeak(rate, channels int, samples []float64) float64 {
+	m := newTruePeakMeter(channels, rate)
+	m.write(samples)
+	return decibels(m.peak)
+}
+
+// shiftedSine — синус частоты rate/4 со сдвигом фазы 45°: все отсчеты
+// попадают на ±0,707 амплитуды, а истинный пик лежит между ними
+func shiftedSine(rate, channels int, dbfs float64, duration float64) []float64 {
+	frames := int(duration * float64(rate))
+	a := amplitude(dbfs)
+	out := make([]float64, frames*channels)
+	for i := 0; i < frames; i++ {
+		v := a * math.Sin(math.Pi/2*float64(i)+math.Pi/4)
+		for c := 0; c < channels; c++ {
+			out[i*channels+c] = v
+		}
+	}
+	return out
+}
+
+// Тесты EBU Tech 3341 для истинного пика: допуск +0,2/−0,4 дБ
+func TestTruePeakBetweenSamples(t *testing.T) {
+	for _, rate := range []int{44100, 48000} {
+		samples := shiftedSine(rate, 2, -6, 1)
+		if sample := decibels(math.Abs(samples[0])); math.Abs(sample-(-9.01)) > 0.01 {
+			t.Fatalf("sample peak = %.2f dBFS, want -9.01", sample)
+		}
+		if got := measureTruePeak(rate, 2, samples); got < -6.4 || got > -5.8 {
+			t.Errorf("%d Hz: true peak = %.2f dBTP, want -6", rate, got)
+		}
+	}
+
+	// Синус 997 Гц почти не отличается от своих отсчетов
+	if got := measureTruePeak(48000, 2, sine(48000, 2, 997, -1, 1)); got < -1.4 || got > -0.8 {
+		t.Errorf("997 Hz sine at -1 dBFS: true peak = %.2f dBTP, want -1", got)
+	}
+}
+
+func TestTruePeakOversampling(t *testing.T) {
+	cases := []struct {
+		rate   int
+		factor int
+	}{
+		{rate: 44100, factor: 4},
+		{rate: 96000, factor: 2},
+		{rate: 192000, factor: 1},
+	}
+	for _, tc := range cases {
+		if m := newTruePeakMeter(1, tc.rate); m.factor != tc.factor {
+			t.Errorf("%d Hz: oversampling x%d, want x%d", tc.rate, m.factor, tc.factor)
+		}
+	}
+
+	// Без передискретизации истинный пик — пик отсчетов
+	samples := shiftedSine(192000, 1, -6, 0.1)
+	if got := measureTruePeak(192000, 1, samples); math.Abs(got-(-9.01)) > 0.01 {
+		t.Errorf("192 kHz true peak = %.2f dBTP, want -9.01", got)
+	}
+}
+
+// Коэффициенты каждой фазы фильтра в сумме дают единицу: постоянный
+// сигнал после передискретизации сохраняет уровень
+func TestInterpolationFilterGain(t *testing.T) {
+	for _, factor := range []int{2, 4} {
+		for p, phase := range interpolationFilter(factor) {
+			var sum float64
+			for _, c := range phase {
+				sum += c
+			}
+			if math.Abs(sum-1) > 0.01 {
+				t.Errorf("x%d phase %d: gain %.4f, want 1", factor, p, sum)
+			}
+		}
+	}
+}
+
+func TestTruePeakSilence(t *testing.T) {
+	if got := measureTruePeak(48000, 2, make([]float64, 48000)); got != SilenceLevel {
+		t.Errorf("silence: true peak = %v, want %v", got, SilenceLevel)
+	}
+	if got := decibels(1); got != 0 {
+		t.Errorf("decibels(1) = %v, want 0", got)
+	}
+	if got := decibels(1e-9); got != SilenceLevel {
+		t.Errorf("decibels(1e-9) = %v, want %v", got, SilenceLevel)
+	}
+}
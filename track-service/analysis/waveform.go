@@ -0,0 +1,54 @@
+package analysis
+
+import "math"
+
+// waveformBin — сколько кадров сводится в один пик до прореживания до
+// нужного числа корзин. Длина потока заранее не всегда известна, поэтому
+// пики сначала копятся с таким шагом.
+const waveformBin = 512
+
+type waveform struct {
+	channels int
+	peaks    []float64
+	current  float64
+	frames   int // Кадров в текущей корзине
+}
+
+func newWaveform(channels int) *waveform {
+	return &waveform{channels: channels}
+}
+
+func (w *waveform) write(samples []float64) {
+	for i := 0; i+w.channels <= len(samples); i += w.channels {
+		for _, s := range samples[i : i+w.channels] {
+			w.current = math.Max(w.current, math.Abs(s))
+		}
+		if w.frames++; w.frames == waveformBin {
+			w.peaks = append(w.peaks, w.current)
+			w.current, w.frames = 0, 0
+		}
+	}
+}
+
+// buckets прореживает накопленные пики до n корзин; у коротких файлов
+// корзин может получиться меньше
+func (w *waveform) buckets(n int) []byte {
+	peaks := w.peaks
+	if w.frames > 0 {
+		peaks = append(peaks, w.current)
+	}
+	if len(peaks) < n {
+		n = len(peaks)
+	}
+
+	out := make([]byte, n)
+	for i := range out {
+		from, to := i*len(peaks)/n, (i+1)*len(peaks)/n
+		var peak float64
+		for _, p := range peaks[from:to] {
+			peak = math.Max(peak, p)
+		}
+		out[i] = byte(math.Min(math.Ceil(peak*255), 255))
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestWaveformBuckets(t *testing.T) {
+	// Первая половина на уровне 0,5, вторая — на полной шкале
+	samples := make([]float64, 100*waveformBin)
+	for i := range samples {
+		v := 0.5
+		if i >= len(samples)/2 {
+			v = 1
+		}
+		if i%2 == 1 {
+			v = -v // Пик считается по модулю
+		}
+		samples[i] = v
+	}
+	w := newWaveform(1)
+	w.write(samples)
+
+	if got := w.buckets(2); !reflect.DeepEqual(got, []byte{128, 255}) {
+		t.Errorf("buckets(2) = %v, want [128 255]", got)
+	}
+	got := w.buckets(10)
+	if len(got) != 10 || got[0] != 128 || got[9] != 255 {
+		t.Errorf("buckets(10) = %v", got)
+	}
+}
+
+func TestWaveformChannels(t *testing.T) {
+	// Пик кадра — наибольший по всем каналам
+	w := newWaveform(2)
+	w.write([]float64{0.1, -0.75, 0.2, 0.3})
+	if got := w.buckets(10); !reflect.DeepEqual(got, []byte{192}) {
+		t.Errorf("buckets = %v, want [192]", got)
+	}
+}
+
+// У коротких файлов корзин меньше, чем запрошено; неполная последняя
+// корзина не теряется
+func TestWaveformShort(t *testing.T) {
+	w := newWaveform(1)
+	samples := make([]float64, waveformBin+10)
+	samples[len(samples)-1] = 1
+	w.write(samples)
+	if got := w.buckets(1000); !reflect.DeepEqual(got, []byte{0, 255}) {
+		t.Errorf("buckets = %v, want [0 255]", got)
+	}
+
+	if got := newWaveform(1).buckets(1000); len(got) != 0 {
+		t.Errorf("empty stream buckets = %v", got)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	const rate = 48000
+	dec := &sliceDecoder{rate: rate, channels: 2, samples: sine(rate, 2, 997, -23, 20)}
+
+	result, err := Analyze(dec, 100)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if math.Abs(result.IntegratedLUFS-(-23)) > 0.1 {
+		t.Errorf("IntegratedLUFS = %.2f, want -23", result.IntegratedLUFS)
+	}
+	if math.Abs(result.TruePeakDBTP-(-23)) > 0.2 {
+		t.Errorf("TruePeakDBTP = %.2f, want -23", result.TruePeakDBTP)
+	}
+	if len(result.Waveform) != 100 {
+		t.Fatalf("got %d waveform buckets, want 100", len(result.Waveform))
+	}
+	// -23 дБFS — 0,0708 полной шкалы
+	want := byte(math.Ceil(amplitude(-23) * 255))
+	for i, b := range result.Waveform {
+		if b < want-1 || b > want {
+			t.Fatalf("bucket %d = %d, want %d", i, b, want)
+		}
+	}
+
+	// Ошибка декодера посреди файла возвращается как есть
+	broken := errors.New("read failed")
+	dec = &sliceDecoder{rate: rate, channels: 2, samples: sine(rate, 2, 997, -23, 1), err: broken}
+	if _, err := Analyze(dec, 100); !errors.Is(err, broken) {
+		t.Errorf("Analyze with a failing decoder: err = %v, want %v", err, broken)
+	}
+
+	// Тихий файл
+	dec = &sliceDecoder{rate: rate, channels: 1, samples: make([]float64, rate)}
+	result, err = Analyze(dec, 10)
+	if err != nil {
+		t.Fatalf("Analyze silence: %v", err)
+	}
+	if result.IntegratedLUFS != SilenceLevel || result.TruePeakDBTP != SilenceLevel ||
+		!reflect.DeepEqual(result.Waveform, make([]byte, 10)) {
+		t.Errorf("Analyze silence = %+v", result)
+	}
+}
@@ -0,0 +1,144 @@
+package audio
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// Decoder отдает звук файла сэмплами в диапазоне [-1, 1], чередующимися по
+// каналам
+type Decoder interface {
+	SampleRate() int
+	Channels() int
+	// Read заполняет buf и возвращает число сэмплов — всегда кратное числу
+	// каналов. В конце потока возвращает 0 и io.EOF.
+	Read(buf []float64) (int, error)
+}
+
+// NewDecoder открывает файл для декодирования. Декодируются только WAV и
+// FLAC; для сжатых с потерями форматов возвращается ErrUnsupportedFormat.
+func NewDecoder(r io.ReaderAt, size int64) (Decoder, error) {
+	start, format, err := sniff(r, size)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "wav":
+		return newWAVDecoder(r, start, size)
+	case "flac":
+		return newFLACDecoder(r, start, size)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type wavDecoder struct {
+	r          *bufio.Reader
+	format     uint16
+	channels   int
+	sampleRate int
+	bits       int // Разрядность сэмпла в файле
+	remaining  int64
+	frame      []byte
+}
+
+// newWAVDecoder находит чанки fmt и data. Поддерживаются целые PCM 8–32 бит и
+// float 32/64 бит, в том числе в WAVE_FORMAT_EXTENSIBLE.
+func newWAVDecoder(r io.ReaderAt, start, size int64) (*wavDecoder, error) {
+	d := &wavDecoder{}
+	header := make([]byte, 8)
+	for offset := start + 12; offset+8 <= size; {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return nil, ErrMalformed
+		}
+		id, chunkSize := string(header[:4]), int64(binary.LittleEndian.Uint32(header[4:]))
+
+		switch id {
+		case "fmt ":
+			if chunkSize < 16 {
+				return nil, ErrMalformed
+			}
+			chunk := make([]byte, min(chunkSize, 40))
+			if _, err := r.ReadAt(chunk, offset+8); err != nil {
+				return nil, ErrMalformed
+			}
+			d.format = binary.LittleEndian.Uint16(chunk[0:])
+			d.channels = int(binary.LittleEndian.Uint16(chunk[2:]))
+			d.sampleRate = int(binary.LittleEndian.Uint32(chunk[4:]))
+			d.bits = int(binary.LittleEndian.Uint16(chunk[14:]))
+			// У EXTENSIBLE настоящий формат — первые два байта GUID подформата
+			if d.format == waveExtensible && len(chunk) >= 26 {
+				d.format = binary.LittleEndian.Uint16(chunk[24:])
+			}
+		case "data":
+			if d.format == 0 {
+				return nil, ErrMalformed
+			}
+			d.remaining = min(chunkSize, size-offset-8)
+			d.r = bufio.NewReaderSize(io.NewSectionReader(r, offset+8, d.remaining), 64<<10)
+		}
+		if d.r != nil {
+			break
+		}
+		offset += 8 + chunkSize + chunkSize%2
+	}
+
+	if d.r == nil || d.channels == 0 || d.sampleRate == 0 {
+		return nil, ErrMalformed
+	}
+	switch {
+	case d.format == wavePCM && d.bits >= 8 && d.bits <= 32:
+	case d.format == waveFloat && (d.bits == 32 || d.bits == 64):
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	d.frame = make([]byte, d.channels*((d.bits+7)/8))
+	return d, nil
+}
+
+func (d *wavDecoder) SampleRate() int { return d.sampleRate }
+func (d *wavDecoder) Channels() int   { return d.channels }
+
+func (d *wavDecoder) Read(buf []float64) (int, error) {
+	width := (d.bits + 7) / 8
+	n := 0
+	for n+d.channels <= len(buf) && d.remaining >= int64(len(d.frame)) {
+		if _, err := io.ReadFull(d.r, d.frame); err != nil {
+			// Обрезанный при записи файл: отдаем то, что успели прочитать
+			d.remaining = 0
+			break
+		}
+		d.remaining -= int64(len(d.frame))
+		for c := 0; c < d.channels; c++ {
+			buf[n] = d.sample(d.frame[c*width : (c+1)*width])
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// sample переводит сэмпл little-endian в [-1, 1]. 8-битный PCM беззнаковый,
+// остальные — со знаком; неполные байты выровнены по старшим битам.
+func (d *wavDecoder) sample(b []byte) float64 {
+	if d.format == waveFloat {
+		if len(b) == 4 {
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	if len(b) == 1 {
+		return (float64(b[0]) - 128) / 128
+	}
+	var v int64
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | int64(b[i])
+	}
+	bits := uint(len(b) * 8)
+	v = v << (64 - bits) >> (64 - bits) // Знак из старшего бита
+	return float64(v) / float64(int64(1)<<(bits-1))
+}
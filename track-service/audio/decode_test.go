@@ -0,0 +1,383 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// decodeAll читает поток до конца маленькими порциями, чтобы кадры
+// разрезались между вызовами Read
+func decodeAll(t *testing.T, file []byte) ([]float64, Decoder, error) {
+	t.Helper()
+	dec, err := NewDecoder(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		return nil, nil, err
+	}
+	var out []float64
+	buf := make([]float64, 7*dec.Channels())
+	for {
+		n, err := dec.Read(buf)
+		out = append(out, buf[:n]...)
+		if err == io.EOF {
+			return out, dec, nil
+		}
+		if err != nil {
+			return out, dec, err
+		}
+	}
+}
+
+func TestDecodeWAV(t *testing.T) {
+	float32LE := func(vs ...float32) []byte {
+		var b []byte
+		for _, v := range vs {
+			b = binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+		}
+		return b
+	}
+
+	cases := []struct {
+		name     string
+		format   uint16
+		bits     int
+		channels int
+		data     []byte
+		want     []float64
+	}{
+		{
+			name: "8-bit unsigned", format: wavePCM, bits: 8, channels: 1,
+			data: []byte{0x80, 0xFF, 0x00, 0xC0},
+			want: []float64{0, 127.0 / 128, -1, 0.5},
+		},
+		{
+			name: "16-bit stereo", format: wavePCM, bits: 16, channels: 2,
+			data: []byte{0x00, 0x40, 0x00, 0xC0, 0xFF, 0x7F, 0x00, 0x80},
+			want: []float64{0.5, -0.5, 32767.0 / 32768, -1},
+		},
+		{
+			name: "24-bit", format: wavePCM, bits: 24, channels: 1,
+			data: []byte{0x00, 0x00, 0x40, 0xFF, 0xFF, 0xFF},
+			want: []float64{0.5, -1.0 / (1 << 23)},
+		},
+		{
+			name: "32-bit float", format: waveFloat, bits: 32, channels: 2,
+			data: float32LE(0.25, -0.75, 1, 0),
+			want: []float64{0.25, -0.75, 1, 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, dec, err := decodeAll(t, wavFile(tc.format, 44100, tc.channels, tc.bits, tc.data))
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if dec.SampleRate() != 44100 || dec.Channels() != tc.channels {
+				t.Errorf("decoder reports %d Hz, %d channels", dec.SampleRate(), dec.Channels())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("samples = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// WAVE_FORMAT_EXTENSIBLE: настоящий формат записан в GUID подформата
+func TestDecodeWAVExtensible(t *testing.T) {
+	fmtChunk := make([]byte, 40)
+	binary.LittleEndian.PutUint16(fmtChunk[0:], waveExtensible)
+	binary.LittleEndian.PutUint16(fmtChunk[2:], 1)
+	binary.LittleEndian.PutUint32(fmtChunk[4:], 48000)
+	binary.LittleEndian.PutUint32(fmtChunk[8:], 48000*8)
+	binary.LittleEndian.PutUint16(fmtChunk[12:], 8)
+	binary.LittleEndian.PutUint16(fmtChunk[14:], 64)
+	binary.LittleEndian.PutUint16(fmtChunk[16:], 22)
+	binary.LittleEndian.PutUint16(fmtChunk[24:], waveFloat)
+
+	data := binary.LittleEndian.AppendUint64(nil, math.Float64bits(-0.125))
+	body := append([]byte("WAVE"), chunk("fmt ", fmtChunk)...)
+	body = append(body, chunk("data", data)...)
+	file := append(chunk("RIFF", body)[:8], body...)
+
+	got, _, err := decodeAll(t, file)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, []float64{-0.125}) {
+		t.Errorf("samples = %v, want [-0.125]", got)
+	}
+}
+
+func TestDecodeWAVTruncated(t *testing.T) {
+	data := []byte{0x00, 0x40, 0x00, 0xC0, 0x00, 0x20, 0x00, 0xE0}
+	file := wavFile(wavePCM, 44100, 2, 16, data)
+
+	// Неполный последний кадр отбрасывается
+	got, _, err := decodeAll(t, file[:len(file)-3])
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, []float64{0.5, -0.5}) {
+		t.Errorf("samples = %v, want [0.5 -0.5]", got)
+	}
+
+	cases := []struct {
+		name string
+		file []byte
+		want error
+	}{
+		{name: "data before fmt", file: append(append(chunk("RIFF", nil)[:8], "WAVE"...), chunk("data", data)...), want: ErrMalformed},
+		{name: "no data chunk", file: file[:12+8+16], want: ErrMalformed},
+		{name: "16-bit float", file: wavFile(waveFloat, 44100, 1, 16, data), want: ErrUnsupportedFormat},
+		{name: "compressed", file: wavFile(0x0002, 44100, 1, 4, data), want: ErrUnsupportedFormat},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := decodeAll(t, tc.file); !errors.Is(err, tc.want) {
+				t.Errorf("err = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecodeUnsupported(t *testing.T) {
+	if _, _, err := decodeAll(t, mp3Frames(3)); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("MP3: err = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+// bitWriter пишет поток старшим битом вперед — обратная операция к bitReader
+type bitWriter struct {
+	buf   []byte
+	cache uint64
+	n     uint
+}
+
+func (w *bitWriter) write(v uint64, bits uint) {
+	for i := int(bits) - 1; i >= 0; i-- {
+		w.cache = w.cache<<1 | (v>>uint(i))&1
+		if w.n++; w.n == 8 {
+			w.buf = append(w.buf, byte(w.cache))
+			w.cache, w.n = 0, 0
+		}
+	}
+}
+
+func (w *bitWriter) writeSigned(v int64, bits uint) {
+	w.write(uint64(v)&(1<<bits-1), bits)
+}
+
+func (w *bitWriter) unary(n int) {
+	for i := 0; i < n; i++ {
+		w.write(0, 1)
+	}
+	w.write(1, 1)
+}
+
+func (w *bitWriter) align() {
+	for w.n != 0 {
+		w.write(0, 1)
+	}
+}
+
+// flacSubframe записывает подкадр одного из видов, которые поддерживает
+// декодер
+type flacSubframe func(w *bitWriter, samples []int32, bits uint)
+
+func flacConstant(w *bitWriter, samples []int32, bits uint) {
+	w.write(0, 8)
+	w.writeSigned(int64(samples[0]), bits)
+}
+
+func flacVerbatim(w *bitWriter, samples []int32, bits uint) {
+	w.write(1<<1, 8)
+	for _, s := range samples {
+		w.writeSigned(int64(s), bits)
+	}
+}
+
+// flacWasted — CONSTANT с двумя отброшенными нулевыми младшими битами
+func flacWasted(w *bitWriter, samples []int32, bits uint) {
+	w.write(1, 8)
+	w.unary(1)
+	w.writeSigned(int64(samples[0]>>2), bits-2)
+}
+
+// writeRice кодирует остатки одним разделом с параметром Райса param
+func writeRice(w *bitWriter, residual []int64, param uint) {
+	w.write(0, 2) // Параметр в 4 битах
+	w.write(0, 4) // Один раздел
+	w.write(uint64(param), 4)
+	for _, r := range residual {
+		u := uint64(r<<1) ^ uint64(r>>63) // Зигзаг
+		w.unary(int(u >> param))
+		w.write(u&(1<<param-1), param)
+	}
+}
+
+// residual считает остатки предсказания с коэффициентами coefficients
+func residual(samples []int32, coefficients []int64) []int64 {
+	order := len(coefficients)
+	out := make([]int64, 0, len(samples)-order)
+	for i := order; i < len(samples); i++ {
+		var sum int64
+		for j, c := range coefficients {
+			sum += c * int64(samples[i-1-j])
+		}
+		out = append(out, int64(samples[i])-sum)
+	}
+	return out
+}
+
+// flacFixed2 — FIXED второго порядка
+func flacFixed2(w *bitWriter, samples []int32, bits uint) {
+	w.write((8+2)<<1, 8)
+	w.writeSigned(int64(samples[0]), bits)
+	w.writeSigned(int64(samples[1]), bits)
+	writeRice(w, residual(samples, []int64{2, -1}), 3)
+}
+
+// flacLPC1 — LPC первого порядка с коэффициентом 7/8
+func flacLPC1(w *bitWriter, samples []int32, bits uint) {
+	w.write(32<<1, 8)
+	w.writeSigned(int64(samples[0]), bits)
+	w.write(4-1, 4)     // Точность коэффициентов — 4 бита
+	w.writeSigned(3, 5) // Сдвиг
+	w.writeSigned(7, 4)
+	var res []int64
+	for i := 1; i < len(samples); i++ {
+		res = append(res, int64(samples[i])-(7*int64(samples[i-1])>>3))
+	}
+	writeRice(w, res, 4)
+}
+
+// flacFrame собирает кадр 16-битного звука. Контрольные суммы декодер не
+// проверяет, поэтому вместо них записываются нули.
+func flacFrame(w *bitWriter, number int, assignment int, channels [][]int32, kinds ...flacSubframe) {
+	w.write(0x3FFE, 14)
+	w.write(0, 2) // Резерв, кадры фиксированного размера
+	w.write(7, 4) // Размер блока — 16 бит после заголовка
+	w.write(0, 4) // Частота из STREAMINFO
+	w.write(uint64(assignment), 4)
+	w.write(4, 3) // 16 бит
+	w.write(0, 1)
+	w.write(uint64(number), 8)
+	w.write(uint64(len(channels[0])-1), 16)
+	w.write(0, 8) // CRC-8
+
+	for c, samples := range channels {
+		bits := uint(16)
+		if (assignment == flacLeftSide || assignment == flacMidSide) && c == 1 ||
+			assignment == flacRightSide && c == 0 {
+			bits++
+		}
+		kinds[c](w, samples, bits)
+	}
+	w.align()
+	w.write(0, 16) // CRC-16
+}
+
+func TestDecodeFLAC(t *testing.T) {
+	const n = 64
+	left, right := make([]int32, n), make([]int32, n)
+	for i := range left {
+		left[i] = int32(20000 * math.Sin(float64(i)/5))
+		right[i] = int32(-12000*math.Cos(float64(i)/7)) + int32(i%3)
+	}
+	side := make([]int32, n)
+	mid := make([]int32, n)
+	for i := range left {
+		side[i] = left[i] - right[i]
+		mid[i] = (left[i] + right[i]) >> 1
+	}
+	constant := make([]int32, n)
+	for i := range constant {
+		constant[i] = -4096
+	}
+	rightSide := make([]int32, n)
+	for i := range rightSide {
+		rightSide[i] = left[i] - constant[i]
+	}
+
+	w := &bitWriter{}
+	flacFrame(w, 0, 1, [][]int32{left, right}, flacVerbatim, flacFixed2)
+	flacFrame(w, 1, flacLeftSide, [][]int32{left, side}, flacLPC1, flacVerbatim)
+	flacFrame(w, 2, flacMidSide, [][]int32{mid, side}, flacFixed2, flacVerbatim)
+	flacFrame(w, 3, flacRightSide, [][]int32{rightSide, constant}, flacVerbatim, flacWasted)
+	flacFrame(w, 4, 1, [][]int32{constant, constant}, flacConstant, flacConstant)
+
+	var want []float64
+	for _, frame := range [][2][]int32{{left, right}, {left, right}, {left, right}, {left, constant}, {constant, constant}} {
+		for i := 0; i < n; i++ {
+			want = append(want, float64(frame[0][i])/32768, float64(frame[1][i])/32768)
+		}
+	}
+
+	file := flacFile(
+		flacBlock(0, false, flacStreamInfo(44100, 2, 16, 5*n)),
+		flacBlock(flacVorbisComment, true, vorbisComment("TITLE=Kino")),
+		w.buf,
+		id3v1Tag("Kino", "", "", "", 0, 0), // За последним кадром
+	)
+	got, dec, err := decodeAll(t, file)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if dec.SampleRate() != 44100 || dec.Channels() != 2 {
+		t.Errorf("decoder reports %d Hz, %d channels", dec.SampleRate(), dec.Channels())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d samples, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("frame %d, sample %d: got %v, want %v", i/(2*n), i%(2*n), got[i], want[i])
+		}
+	}
+}
+
+func TestDecodeFLACMalformed(t *testing.T) {
+	samples := make([]int32, 32)
+	for i := range samples {
+		samples[i] = int32(i * 100)
+	}
+	streamInfo := flacBlock(0, true, flacStreamInfo(48000, 1, 16, 64))
+	w := &bitWriter{}
+	flacFrame(w, 0, 0, [][]int32{samples}, flacVerbatim)
+	firstFrame := len(w.buf)
+	flacFrame(w, 1, 0, [][]int32{samples}, flacFixed2)
+	frames := w.buf
+
+	// Обрыв ровно между кадрами: первый кадр читается, ошибки нет
+	got, _, err := decodeAll(t, flacFile(streamInfo, frames[:firstFrame]))
+	if err != nil || len(got) != 32 {
+		t.Errorf("cut between frames: %d samples, err %v; want 32 samples", len(got), err)
+	}
+
+	cases := []struct {
+		name string
+		file []byte
+	}{
+		{name: "cut inside a frame", file: flacFile(streamInfo, frames[:len(frames)-10])},
+		{name: "no sync code", file: flacFile(streamInfo, []byte("not a frame at all"))},
+		// Кадр стерео в моно-потоке
+		{name: "channel count", file: func() []byte {
+			w := &bitWriter{}
+			flacFrame(w, 0, 1, [][]int32{samples, samples}, flacVerbatim, flacVerbatim)
+			return flacFile(streamInfo, w.buf)
+		}()},
+		{name: "metadata longer than the file", file: flacFile(flacBlock(0, false, flacStreamInfo(48000, 1, 16, 64)), []byte{0x84, 0xFF, 0xFF, 0xFF})},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := decodeAll(t, tc.file); !errors.Is(err, ErrMalformed) {
+				t.Errorf("err = %v, want ErrMalformed", err)
+			}
+		})
+	}
+}
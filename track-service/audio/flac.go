@@ -0,0 +1,85 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+func probeFLAC(r io.ReaderAt, start, size int64) (Info, error) {
+	// За сигнатурой первым всегда идет блок STREAMINFO длиной 34 байта
+	block := make([]byte, 4+4+34)
+	if _, err := r.ReadAt(block, start); err != nil {
+		return Info{}, ErrMalformed
+	}
+	if block[4]&0x7F != 0 || int(block[5])<<16|int(block[6])<<8|int(block[7]) < 34 {
+		return Info{}, ErrMalformed
+	}
+
+	// 20 бит частоты, 3 бита каналов, 5 бит разрядности, 36 бит числа сэмплов
+	bits := binary.BigEndian.Uint64(block[8+10:])
+	sampleRate := int(bits >> 44)
+	channels := int((bits>>41)&0x7) + 1
+	totalSamples := int64(bits & (1<<36 - 1))
+	if sampleRate == 0 {
+		return Info{}, ErrMalformed
+	}
+
+	return Info{
+		Format:     "flac",
+		Codec:      "flac",
+		MimeType:   "audio/flac",
+		SampleRate: sampleRate,
+		Channels:   channels,
+		Duration:   seconds(totalSamples, int64(sampleRate)),
+	}, nil
+}
+
+// Типы блоков метаданных FLAC
+const (
+	flacVorbisComment = 4
+	flacPicture       = 6
+)
+
+// readFLACTags читает блоки VORBIS_COMMENT и PICTURE, которые идут за
+// STREAMINFO до первого кадра
+func readFLACTags(r io.ReaderAt, start, size int64) (Tags, error) {
+	var tags Tags
+	var picture *Picture
+	header := make([]byte, 4)
+	for offset, last := start+4, false; !last && offset+4 <= size; {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return Tags{}, ErrMalformed
+		}
+		last = header[0]&0x80 != 0
+		kind := header[0] & 0x7F
+		length := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+		offset += 4
+		if offset+length > size {
+			return Tags{}, ErrMalformed
+		}
+
+		if kind == flacVorbisComment || kind == flacPicture {
+			if length > maxTagSize {
+				offset += length
+				continue
+			}
+			block := make([]byte, length)
+			if _, err := r.ReadAt(block, offset); err != nil {
+				return Tags{}, ErrMalformed
+			}
+			if kind == flacVorbisComment {
+				var err error
+				if tags, err = parseVorbisComment(block); err != nil {
+					return Tags{}, err
+				}
+			} else if pic, pictureKind, ok := parseFLACPicture(block); ok && (picture == nil || pictureKind == pictureFrontCover) {
+				picture = pic
+			}
+		}
+		offset += length
+	}
+	if picture != nil {
+		tags.Picture = picture
+	}
+	return tags, nil
+}
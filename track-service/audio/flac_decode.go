@@ -0,0 +1,478 @@
+package audio
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Размещение каналов в кадре FLAC: до 8 независимых каналов или стерео с
+// одним из способов декорреляции
+const (
+	flacLeftSide  = 8
+	flacRightSide = 9
+	flacMidSide   = 10
+)
+
+// flacDecoder декодирует кадры FLAC. Контрольные суммы кадров не
+// проверяются: испорченный кадр обнаруживается по нарушенной структуре.
+type flacDecoder struct {
+	br         bitReader
+	sampleRate int
+	channels   int
+	bits       int // Разрядность из STREAMINFO
+
+	block  [][]int32 // Сэмплы текущего кадра по каналам
+	pos    int       // Сколько сэмплов кадра уже отдано
+	length int
+	frames int // Прочитано кадров
+}
+
+// newFLACDecoder читает STREAMINFO и пропускает остальные блоки метаданных
+func newFLACDecoder(r io.ReaderAt, start, size int64) (*flacDecoder, error) {
+	info := make([]byte, 4+4+34)
+	if _, err := r.ReadAt(info, start); err != nil || info[4]&0x7F != 0 {
+		return nil, ErrMalformed
+	}
+	bits := binary.BigEndian.Uint64(info[8+10:])
+	d := &flacDecoder{
+		sampleRate: int(bits >> 44),
+		channels:   int((bits>>41)&0x7) + 1,
+		bits:       int((bits>>36)&0x1F) + 1,
+	}
+	if d.sampleRate == 0 {
+		return nil, ErrMalformed
+	}
+
+	header := make([]byte, 4)
+	offset := start + 4
+	for last := false; !last; {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return nil, ErrMalformed
+		}
+		last = header[0]&0x80 != 0
+		offset += 4 + (int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3]))
+	}
+	if offset > size {
+		return nil, ErrMalformed
+	}
+	d.br = bitReader{r: bufio.NewReaderSize(io.NewSectionReader(r, offset, size-offset), 64<<10)}
+	return d, nil
+}
+
+func (d *flacDecoder) SampleRate() int { return d.sampleRate }
+func (d *flacDecoder) Channels() int   { return d.channels }
+
+func (d *flacDecoder) Read(buf []float64) (int, error) {
+	n := 0
+	for n+d.channels <= len(buf) {
+		if d.pos == d.length {
+			err := d.readFrame()
+			if err == io.EOF && n > 0 {
+				break
+			}
+			if err != nil {
+				return n, err
+			}
+		}
+		scale := float64(int64(1) << (d.bits - 1))
+		for ; d.pos < d.length && n+d.channels <= len(buf); d.pos++ {
+			for c := 0; c < d.channels; c++ {
+				buf[n] = float64(d.block[c][d.pos]) / scale
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+var flacBlockSizes = [16]int{0, 192, 576, 1152, 2304, 4608, 0, 0, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768}
+var flacSampleSizes = [8]int{0, 8, 12, 0, 16, 20, 24, 32}
+
+// readFrame читает следующий кадр в d.block
+func (d *flacDecoder) readFrame() error {
+	br := &d.br
+	br.align()
+	sync, err := br.read(14)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return io.EOF
+		}
+		return err
+	}
+	if sync != 0x3FFE {
+		// За последним кадром бывают чужие теги, например ID3v1
+		if d.frames > 0 {
+			return io.EOF
+		}
+		return ErrMalformed
+	}
+	header, err := br.read(18)
+	if err != nil {
+		return ErrMalformed
+	}
+	blockCode := int(header>>12) & 0xF
+	rateCode := int(header>>8) & 0xF
+	assignment := int(header>>4) & 0xF
+	sizeCode := int(header>>1) & 0x7
+
+	// Номер кадра или сэмпла в UTF-8-подобной записи: нужен только его размер
+	first, err := br.read(8)
+	if err != nil {
+		return ErrMalformed
+	}
+	for mask := uint64(0x80); first&mask != 0 && mask > 1; mask >>= 1 {
+		if mask != 0x80 {
+			if _, err := br.read(8); err != nil {
+				return ErrMalformed
+			}
+		}
+	}
+
+	blockSize := flacBlockSizes[blockCode]
+	switch blockCode {
+	case 0:
+		return ErrMalformed
+	case 6, 7:
+		v, err := br.read(8 * uint(blockCode-5))
+		if err != nil {
+			return ErrMalformed
+		}
+		blockSize = int(v) + 1
+	}
+	switch rateCode {
+	case 12:
+		_, err = br.read(8)
+	case 13, 14:
+		_, err = br.read(16)
+	case 15:
+		return ErrMalformed
+	}
+	if err != nil {
+		return ErrMalformed
+	}
+	if _, err := br.read(8); err != nil { // CRC-8 заголовка
+		return ErrMalformed
+	}
+
+	bits := d.bits
+	if sizeCode != 0 {
+		if bits = flacSampleSizes[sizeCode]; bits == 0 {
+			return ErrMalformed
+		}
+	}
+	channels := assignment + 1
+	if assignment >= flacLeftSide {
+		if assignment > flacMidSide {
+			return ErrMalformed
+		}
+		channels = 2
+	}
+	if channels != d.channels {
+		return ErrMalformed
+	}
+
+	if len(d.block) != channels || cap(d.block[0]) < blockSize {
+		d.block = make([][]int32, channels)
+		for c := range d.block {
+			d.block[c] = make([]int32, blockSize)
+		}
+	}
+	for c := range d.block {
+		d.block[c] = d.block[c][:blockSize]
+		// Канал разности при декорреляции на бит шире
+		channelBits := bits
+		if (assignment == flacLeftSide || assignment == flacMidSide) && c == 1 ||
+			assignment == flacRightSide && c == 0 {
+			channelBits++
+		}
+		if err := d.readSubframe(d.block[c], channelBits); err != nil {
+			return err
+		}
+	}
+	decorrelate(d.block, assignment)
+
+	br.align()
+	if _, err := br.read(16); err != nil { // CRC-16 кадра
+		return ErrMalformed
+	}
+	d.pos, d.length = 0, blockSize
+	d.frames++
+	return nil
+}
+
+func (d *flacDecoder) readSubframe(out []int32, bits int) error {
+	br := &d.br
+	header, err := br.read(8)
+	if err != nil || header&0x80 != 0 {
+		return ErrMalformed
+	}
+	kind := int(header>>1) & 0x3F
+
+	// Сэмплы могли быть сдвинуты кодировщиком: младшие нулевые биты не хранятся
+	wasted := 0
+	if header&1 != 0 {
+		n, err := br.unary()
+		if err != nil {
+			return ErrMalformed
+		}
+		wasted = n + 1
+		bits -= wasted
+	}
+	if bits <= 0 || bits > 33 {
+		return ErrMalformed
+	}
+
+	switch {
+	case kind == 0: // CONSTANT
+		v, err := br.readSigned(uint(bits))
+		if err != nil {
+			return ErrMalformed
+		}
+		for i := range out {
+			out[i] = int32(v)
+		}
+	case kind == 1: // VERBATIM
+		for i := range out {
+			v, err := br.readSigned(uint(bits))
+			if err != nil {
+				return ErrMalformed
+			}
+			out[i] = int32(v)
+		}
+	case kind >= 8 && kind <= 12: // FIXED
+		if err := d.readFixed(out, kind-8, bits); err != nil {
+			return err
+		}
+	case kind >= 32: // LPC
+		if err := d.readLPC(out, kind-31, bits); err != nil {
+			return err
+		}
+	default:
+		return ErrMalformed
+	}
+
+	if wasted > 0 {
+		for i := range out {
+			out[i] <<= uint(wasted)
+		}
+	}
+	return nil
+}
+
+// Коэффициенты фиксированных предсказателей порядка 0–4
+var flacFixedCoefficients = [5][]int64{
+	{},
+	{1},
+	{2, -1},
+	{3, -3, 1},
+	{4, -6, 4, -1},
+}
+
+func (d *flacDecoder) readFixed(out []int32, order, bits int) error {
+	if order > len(out) {
+		return ErrMalformed
+	}
+	for i := 0; i < order; i++ {
+		v, err := d.br.readSigned(uint(bits))
+		if err != nil {
+			return ErrMalformed
+		}
+		out[i] = int32(v)
+	}
+	if err := d.readResidual(out, order); err != nil {
+		return err
+	}
+	predict(out, flacFixedCoefficients[order], order, 0)
+	return nil
+}
+
+func (d *flacDecoder) readLPC(out []int32, order, bits int) error {
+	br := &d.br
+	if order > len(out) {
+		return ErrMalformed
+	}
+	for i := 0; i < order; i++ {
+		v, err := br.readSigned(uint(bits))
+		if err != nil {
+			return ErrMalformed
+		}
+		out[i] = int32(v)
+	}
+	precision, err := br.read(4)
+	if err != nil || precision == 15 {
+		return ErrMalformed
+	}
+	shift, err := br.readSigned(5)
+	if err != nil || shift < 0 {
+		return ErrMalformed
+	}
+	coefficients := make([]int64, order)
+	for i := range coefficients {
+		c, err := br.readSigned(uint(precision) + 1)
+		if err != nil {
+			return ErrMalformed
+		}
+		coefficients[i] = c
+	}
+	if err := d.readResidual(out, order); err != nil {
+		return err
+	}
+	predict(out, coefficients, order, uint(shift))
+	return nil
+}
+
+// predict восстанавливает сэмплы: в out после первых order сэмплов лежат
+// остатки, к которым прибавляется предсказание по предыдущим сэмплам
+func predict(out []int32, coefficients []int64, order int, shift uint) {
+	for i := order; i < len(out); i++ {
+		var sum int64
+		for j, c := range coefficients {
+			sum += c * int64(out[i-1-j])
+		}
+		out[i] += int32(sum >> shift)
+	}
+}
+
+// readResidual читает остатки, закодированные кодом Райса по разделам
+func (d *flacDecoder) readResidual(out []int32, order int) error {
+	br := &d.br
+	method, err := br.read(2)
+	if err != nil || method > 1 {
+		return ErrMalformed
+	}
+	paramBits, escape := uint(4), uint64(0xF)
+	if method == 1 {
+		paramBits, escape = 5, 0x1F
+	}
+	partitionOrder, err := br.read(4)
+	if err != nil {
+		return ErrMalformed
+	}
+	partitions := 1 << partitionOrder
+	if len(out)%partitions != 0 || len(out)/partitions < order {
+		return ErrMalformed
+	}
+
+	i := order
+	for p := 0; p < partitions; p++ {
+		end := (p + 1) * (len(out) / partitions)
+		param, err := br.read(paramBits)
+		if err != nil {
+			return ErrMalformed
+		}
+		if param == escape {
+			rawBits, err := br.read(5)
+			if err != nil {
+				return ErrMalformed
+			}
+			for ; i < end; i++ {
+				v := int64(0)
+				if rawBits > 0 {
+					if v, err = br.readSigned(uint(rawBits)); err != nil {
+						return ErrMalformed
+					}
+				}
+				out[i] = int32(v)
+			}
+			continue
+		}
+		for ; i < end; i++ {
+			high, err := br.unary()
+			if err != nil {
+				return ErrMalformed
+			}
+			low, err := br.read(uint(param))
+			if err != nil {
+				return ErrMalformed
+			}
+			u := uint64(high)<<param | low
+			out[i] = int32(int64(u>>1) ^ -int64(u&1)) // Зигзаг-кодирование знака
+		}
+	}
+	return nil
+}
+
+// decorrelate восстанавливает левый и правый каналы стерео
+func decorrelate(block [][]int32, assignment int) {
+	switch assignment {
+	case flacLeftSide:
+		for i, side := range block[1] {
+			block[1][i] = block[0][i] - side
+		}
+	case flacRightSide:
+		for i, side := range block[0] {
+			block[0][i] = side + block[1][i]
+		}
+	case flacMidSide:
+		for i := range block[0] {
+			mid, side := int64(block[0][i]), int64(block[1][i])
+			mid = mid<<1 | side&1
+			block[0][i] = int32((mid + side) >> 1)
+			block[1][i] = int32((mid - side) >> 1)
+		}
+	}
+}
+
+// bitReader читает поток по битам, старшим битом вперед
+type bitReader struct {
+	r     io.ByteReader
+	cache uint64
+	n     uint // Сколько младших бит cache еще не прочитано
+}
+
+func (b *bitReader) read(bits uint) (uint64, error) {
+	for b.n < bits {
+		c, err := b.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		b.cache = b.cache<<8 | uint64(c)
+		b.n += 8
+	}
+	b.n -= bits
+	v := b.cache >> b.n
+	if bits < 64 {
+		v &= 1<<bits - 1
+	}
+	return v, nil
+}
+
+func (b *bitReader) readSigned(bits uint) (int64, error) {
+	v, err := b.read(bits)
+	if err != nil {
+		return 0, err
+	}
+	return int64(v<<(64-bits)) >> (64 - bits), nil
+}
+
+// unary считает нулевые биты до первой единицы
+func (b *bitReader) unary() (int, error) {
+	n := 0
+	for {
+		if b.n == 0 {
+			c, err := b.r.ReadByte()
+			if err != nil {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b.cache, b.n = uint64(c), 8
+		}
+		bit := (b.cache >> (b.n - 1)) & 1
+		b.n--
+		if bit == 1 {
+			return n, nil
+		}
+		if n++; n > 1<<20 {
+			return 0, ErrMalformed
+		}
+	}
+}
+
+// align отбрасывает биты до границы байта
+func (b *bitReader) align() {
+	b.n -= b.n % 8
+}
@@ -0,0 +1,431 @@
+package audio
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Кодировки текста в кадрах ID3v2
+const (
+	id3Latin1  = 0
+	id3UTF16   = 1 // С BOM
+	id3UTF16BE = 2
+	id3UTF8    = 3
+)
+
+// id3Frames сводит идентификаторы кадров ID3v2.2 к ID3v2.3/2.4
+var id3Frames = map[string]string{
+	"TT2": "TIT2", "TP1": "TPE1", "TP2": "TPE2", "TAL": "TALB", "TCO": "TCON",
+	"TRK": "TRCK", "TPA": "TPOS", "TYE": "TYER", "TDA": "TDAT", "TRC": "TSRC",
+	"TBP": "TBPM", "TKE": "TKEY", "TLA": "TLAN", "PIC": "APIC",
+}
+
+// readID3v2 читает тег ID3v2, который начинается с offset. Кадры читаются до
+// первого испорченного: уже прочитанные значения сохраняются.
+func readID3v2(r io.ReaderAt, offset int64) (Tags, error) {
+	header := make([]byte, 10)
+	if _, err := r.ReadAt(header, offset); err != nil || string(header[:3]) != "ID3" {
+		return Tags{}, nil
+	}
+	version, flags := header[3], header[5]
+	tagSize := syncsafe(header[6:10])
+	if version < 2 || version > 4 || tagSize > maxTagSize {
+		return Tags{}, nil
+	}
+
+	data := make([]byte, tagSize)
+	n, err := r.ReadAt(data, offset+10)
+	if err != nil && err != io.EOF {
+		return Tags{}, err
+	}
+	data = data[:n]
+
+	// В версиях 2.2 и 2.3 рассинхронизация снимается со всего тега сразу,
+	// в 2.4 — с каждого кадра отдельно
+	if flags&0x80 != 0 && version < 4 {
+		data = unsynchronise(data)
+	}
+	if flags&0x40 != 0 && version >= 3 && len(data) >= 4 {
+		// Расширенный заголовок: в 2.3 размер не включает само поле размера
+		extSize := int(binary.BigEndian.Uint32(data))
+		if version == 3 {
+			extSize += 4
+		} else {
+			extSize = syncsafe(data[:4])
+		}
+		if extSize > len(data) {
+			return Tags{}, nil
+		}
+		data = data[extSize:]
+	}
+
+	frames := id3FrameValues{}
+	idSize, headerSize := 4, 10
+	if version == 2 {
+		idSize, headerSize = 3, 6
+	}
+	for len(data) >= headerSize && data[0] != 0 {
+		id := string(data[:idSize])
+		var size int
+		switch version {
+		case 2:
+			size = int(data[3])<<16 | int(data[4])<<8 | int(data[5])
+		case 3:
+			size = int(binary.BigEndian.Uint32(data[4:]))
+		default:
+			size = syncsafe(data[4:8])
+		}
+		if size <= 0 || headerSize+size > len(data) {
+			break
+		}
+		body := data[headerSize : headerSize+size]
+		if version >= 3 {
+			var ok bool
+			if body, ok = id3FrameBody(version, data[9], body); !ok {
+				data = data[headerSize+size:]
+				continue
+			}
+		}
+		if version == 2 {
+			id = id3Frames[id]
+		}
+		frames.add(id, body, version)
+		data = data[headerSize+size:]
+	}
+	return frames.tags(), nil
+}
+
+// id3FrameBody снимает с содержимого кадра рассинхронизацию и сжатие.
+// Зашифрованные кадры пропускаются.
+func id3FrameBody(version, formatFlags byte, body []byte) ([]byte, bool) {
+	var grouped, compressed, encrypted, unsync, dataLength bool
+	if version == 3 {
+		compressed = formatFlags&0x80 != 0
+		encrypted = formatFlags&0x40 != 0
+		grouped = formatFlags&0x20 != 0
+		dataLength = compressed
+	} else {
+		grouped = formatFlags&0x40 != 0
+		compressed = formatFlags&0x08 != 0
+		encrypted = formatFlags&0x04 != 0
+		unsync = formatFlags&0x02 != 0
+		dataLength = formatFlags&0x01 != 0
+	}
+	if encrypted {
+		return nil, false
+	}
+	if grouped {
+		if len(body) < 1 {
+			return nil, false
+		}
+		body = body[1:]
+	}
+	if dataLength {
+		if len(body) < 4 {
+			return nil, false
+		}
+		body = body[4:]
+	}
+	if unsync {
+		body = unsynchronise(body)
+	}
+	if compressed {
+		zr, err := zlib.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, false
+		}
+		defer zr.Close()
+		body, err = io.ReadAll(io.LimitReader(zr, maxTagSize))
+		if err != nil {
+			return nil, false
+		}
+	}
+	return body, true
+}
+
+// unsynchronise убирает нулевые байты, вставленные после 0xFF
+func unsynchronise(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		out = append(out, b[i])
+		if b[i] == 0xFF && i+1 < len(b) && b[i+1] == 0 {
+			i++
+		}
+	}
+	return out
+}
+
+// id3FrameValues собирает значения нужных кадров: часть полей Tags
+// складывается из нескольких кадров
+type id3FrameValues struct {
+	text    map[string][]string
+	picture *Picture
+	// pictureType — тип уже найденной картинки; обложка заменяет остальные
+	pictureType int
+}
+
+func (f *id3FrameValues) add(id string, body []byte, version byte) {
+	if len(body) == 0 {
+		return
+	}
+	switch {
+	case id == "APIC":
+		f.addPicture(body, version)
+	case strings.HasPrefix(id, "T") && id != "TXXX":
+		if f.text == nil {
+			f.text = map[string][]string{}
+		}
+		if _, seen := f.text[id]; !seen {
+			f.text[id] = id3Text(body[0], body[1:])
+		}
+	}
+}
+
+// addPicture разбирает APIC (в 2.2 — PIC, где вместо MIME три буквы формата)
+func (f *id3FrameValues) addPicture(body []byte, version byte) {
+	encoding := body[0]
+	rest := body[1:]
+	var mime string
+	if version == 2 {
+		if len(rest) < 3 {
+			return
+		}
+		mime = "image/" + strings.ToLower(string(rest[:3]))
+		rest = rest[3:]
+	} else {
+		i := bytes.IndexByte(rest, 0)
+		if i < 0 {
+			return
+		}
+		mime, rest = string(rest[:i]), rest[i+1:]
+	}
+	if len(rest) < 1 {
+		return
+	}
+	kind := int(rest[0])
+	_, data := splitID3String(encoding, rest[1:])
+	if len(data) == 0 {
+		return
+	}
+	if f.picture != nil && (f.pictureType == pictureFrontCover || kind != pictureFrontCover) {
+		return
+	}
+	f.picture = &Picture{MimeType: imageMimeType(data, mime), Data: data}
+	f.pictureType = kind
+}
+
+func (f *id3FrameValues) tags() Tags {
+	first := func(ids ...string) string {
+		for _, id := range ids {
+			if v := f.text[id]; len(v) > 0 && v[0] != "" {
+				return v[0]
+			}
+		}
+		return ""
+	}
+
+	tags := Tags{
+		Title:       first("TIT2"),
+		Artist:      first("TPE1"),
+		Album:       first("TALB"),
+		AlbumArtist: first("TPE2"),
+		TrackNumber: position(first("TRCK")),
+		DiscNumber:  position(first("TPOS")),
+		ISRC:        first("TSRC"),
+		Language:    first("TLAN"),
+		BPM:         bpm(first("TBPM")),
+		Key:         first("TKEY"),
+		Picture:     f.picture,
+	}
+	for _, g := range f.text["TCON"] {
+		tags.Genres = append(tags.Genres, id3Genres(g)...)
+	}
+
+	// 2.4 хранит дату целиком; в 2.3 год и день с месяцем (DDMM) — в разных кадрах
+	tags.Date = first("TDRL", "TDRC")
+	if tags.Date == "" {
+		tags.Date = first("TYER")
+		if ddmm := first("TDAT"); len(tags.Date) == 4 && len(ddmm) == 4 {
+			tags.Date += "-" + ddmm[2:] + "-" + ddmm[:2]
+		}
+	}
+	return tags
+}
+
+// id3Text декодирует текстовый кадр; в 2.4 значений может быть несколько,
+// они разделены нулевым символом
+func id3Text(encoding byte, b []byte) []string {
+	var values []string
+	for len(b) > 0 {
+		var v string
+		v, b = splitID3String(encoding, b)
+		v = strings.TrimSpace(v)
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitID3String декодирует строку до нулевого символа и возвращает остаток
+func splitID3String(encoding byte, b []byte) (string, []byte) {
+	switch encoding {
+	case id3UTF16, id3UTF16BE:
+		end := len(b)
+		for i := 0; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				end = i
+				break
+			}
+		}
+		rest := b[end:]
+		if len(rest) >= 2 {
+			rest = rest[2:]
+		}
+		return decodeUTF16(b[:end], encoding == id3UTF16BE), rest
+	default:
+		end := bytes.IndexByte(b, 0)
+		rest := []byte(nil)
+		if end < 0 {
+			end = len(b)
+		} else {
+			rest = b[end+1:]
+		}
+		if encoding == id3Latin1 {
+			return latin1(b[:end]), rest
+		}
+		return string(b[:end]), rest
+	}
+}
+
+// decodeUTF16 декодирует UTF-16; BOM, если есть, задает порядок байтов
+func decodeUTF16(b []byte, bigEndian bool) string {
+	if len(b) >= 2 {
+		switch {
+		case b[0] == 0xFF && b[1] == 0xFE:
+			bigEndian, b = false, b[2:]
+		case b[0] == 0xFE && b[1] == 0xFF:
+			bigEndian, b = true, b[2:]
+		}
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = binary.BigEndian.Uint16(b[2*i:])
+		} else {
+			units[i] = binary.LittleEndian.Uint16(b[2*i:])
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// id3Genres разбирает жанр: кроме текста бывают номера жанров ID3v1 — "17",
+// "(17)" или "(17)Rock", а также "(RX)" и "(CR)"
+func id3Genres(s string) []string {
+	var genres []string
+	for strings.HasPrefix(s, "(") && !strings.HasPrefix(s, "((") {
+		end := strings.IndexByte(s, ')')
+		if end < 0 {
+			break
+		}
+		switch ref := s[1:end]; ref {
+		case "RX":
+			genres = append(genres, "Remix")
+		case "CR":
+			genres = append(genres, "Cover")
+		default:
+			if g := id3v1Genre(ref); g != "" {
+				genres = append(genres, g)
+			}
+		}
+		s = s[end+1:]
+	}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "(")
+	if g := id3v1Genre(s); g != "" {
+		return append(genres, g)
+	}
+	if s != "" && (len(genres) == 0 || !strings.EqualFold(s, genres[len(genres)-1])) {
+		genres = append(genres, s)
+	}
+	return genres
+}
+
+// id3v1Genre возвращает жанр по номеру ID3v1 или пустую строку
+func id3v1Genre(s string) string {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n >= len(id3v1Genres) {
+		return ""
+	}
+	return id3v1Genres[n]
+}
+
+// readID3v1 читает тег ID3v1 из последних 128 байт файла. Тег ID3v2 в начале
+// файла читает ReadTags и дополняет им найденное здесь.
+func readID3v1(r io.ReaderAt, start, size int64) (Tags, error) {
+	if size < 128 {
+		return Tags{}, nil
+	}
+	tag := make([]byte, 128)
+	if _, err := r.ReadAt(tag, size-128); err != nil {
+		return Tags{}, err
+	}
+	if string(tag[:3]) != "TAG" {
+		return Tags{}, nil
+	}
+
+	field := func(b []byte) string {
+		if i := bytes.IndexByte(b, 0); i >= 0 {
+			b = b[:i]
+		}
+		return strings.TrimSpace(latin1(b))
+	}
+	tags := Tags{
+		Title:  field(tag[3:33]),
+		Artist: field(tag[33:63]),
+		Album:  field(tag[63:93]),
+		Date:   field(tag[93:97]),
+	}
+	// ID3v1.1: номер трека в последнем байте комментария
+	if tag[125] == 0 && tag[126] != 0 {
+		tags.TrackNumber = int(tag[126])
+	}
+	if g := int(tag[127]); g < len(id3v1Genres) {
+		tags.Genres = []string{id3v1Genres[g]}
+	}
+	return tags, nil
+}
+
+// id3v1Genres — жанры ID3v1 с расширениями Winamp
+var id3v1Genres = []string{
+	"Blues", "Classic Rock", "Country", "Dance", "Disco", "Funk", "Grunge",
+	"Hip-Hop", "Jazz", "Metal", "New Age", "Oldies", "Other", "Pop", "R&B",
+	"Rap", "Reggae", "Rock", "Techno", "Industrial", "Alternative", "Ska",
+	"Death Metal", "Pranks", "Soundtrack", "Euro-Techno", "Ambient",
+	"Trip-Hop", "Vocal", "Jazz+Funk", "Fusion", "Trance", "Classical",
+	"Instrumental", "Acid", "House", "Game", "Sound Clip", "Gospel", "Noise",
+	"Alternative Rock", "Bass", "Soul", "Punk", "Space", "Meditative",
+	"Instrumental Pop", "Instrumental Rock", "Ethnic", "Gothic", "Darkwave",
+	"Techno-Industrial", "Electronic", "Pop-Folk", "Eurodance", "Dream",
+	"Southern Rock", "Comedy", "Cult", "Gangsta", "Top 40", "Christian Rap",
+	"Pop/Funk", "Jungle", "Native American", "Cabaret", "New Wave",
+	"Psychedelic", "Rave", "Showtunes", "Trailer", "Lo-Fi", "Tribal",
+	"Acid Punk", "Acid Jazz", "Polka", "Retro", "Musical", "Rock & Roll",
+	"Hard Rock", "Folk", "Folk-Rock", "National Folk", "Swing", "Fast Fusion",
+	"Bebop", "Latin", "Revival", "Celtic", "Bluegrass", "Avantgarde",
+	"Gothic Rock", "Progressive Rock", "Psychedelic Rock", "Symphonic Rock",
+	"Slow Rock", "Big Band", "Chorus", "Easy Listening", "Acoustic", "Humour",
+	"Speech", "Chanson", "Opera", "Chamber Music", "Sonata", "Symphony",
+	"Booty Bass", "Primus", "Porn Groove", "Satire", "Slow Jam", "Club",
+	"Tango", "Samba", "Folklore", "Ballad", "Power Ballad", "Rhythmic Soul",
+	"Freestyle", "Duet", "Punk Rock", "Drum Solo", "A Cappella", "Euro-House",
+	"Dance Hall", "Goa", "Drum & Bass", "Club-House", "Hardcore Techno",
+	"Terror", "Indie", "BritPop", "Negerpunk", "Polsk Punk", "Beat",
+	"Christian Gangsta Rap", "Heavy Metal", "Black Metal", "Crossover",
+	"Contemporary Christian", "Christian Rock", "Merengue", "Salsa",
+	"Thrash Metal", "Anime", "JPop", "Synthpop",
+}
@@ -0,0 +1,241 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// maxSyncSearch — сколько байт просматривается в поисках первого кадра
+const maxSyncSearch = 64 << 10
+
+// Битрейты MPEG в кбит/с: [версия 1 или 2][слой I, II, III][индекс]
+var mpegBitrates = [2][3][16]int{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	},
+}
+
+// Частоты дискретизации MPEG-1; у MPEG-2 они вдвое, у MPEG-2.5 вчетверо меньше
+var mpegSampleRates = [3]int{44100, 48000, 32000}
+
+type mpegFrame struct {
+	version    int // 1, 2 или 25 (MPEG-2.5)
+	layer      int
+	bitrate    int // бит/с
+	sampleRate int
+	channels   int
+	length     int // байт
+	samples    int // сэмплов на канал в кадре
+}
+
+func parseMPEGHeader(h []byte) (mpegFrame, bool) {
+	if len(h) < 4 || h[0] != 0xFF || h[1]&0xE0 != 0xE0 {
+		return mpegFrame{}, false
+	}
+	var f mpegFrame
+	switch (h[1] >> 3) & 3 {
+	case 0:
+		f.version = 25
+	case 2:
+		f.version = 2
+	case 3:
+		f.version = 1
+	default:
+		return f, false
+	}
+	f.layer = 4 - int((h[1]>>1)&3)
+	if f.layer == 4 {
+		return f, false
+	}
+	bitrateIndex, rateIndex := h[2]>>4, (h[2]>>2)&3
+	if bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+		return f, false
+	}
+
+	table := 0
+	if f.version != 1 {
+		table = 1
+	}
+	f.bitrate = mpegBitrates[table][f.layer-1][bitrateIndex] * 1000
+	f.sampleRate = mpegSampleRates[rateIndex]
+	switch f.version {
+	case 2:
+		f.sampleRate /= 2
+	case 25:
+		f.sampleRate /= 4
+	}
+	f.channels = 2
+	if h[3]>>6 == 3 {
+		f.channels = 1
+	}
+
+	padding := int((h[2] >> 1) & 1)
+	switch {
+	case f.layer == 1:
+		f.samples = 384
+		f.length = (12*f.bitrate/f.sampleRate + padding) * 4
+	case f.layer == 3 && f.version != 1:
+		f.samples = 576
+		f.length = 72*f.bitrate/f.sampleRate + padding
+	default:
+		f.samples = 1152
+		f.length = 144*f.bitrate/f.sampleRate + padding
+	}
+	return f, true
+}
+
+// findMPEGFrame ищет первый кадр, за которым сразу следует еще один кадр с
+// теми же параметрами: одиночное совпадение 0xFFE легко встретить в мусоре
+func findMPEGFrame(r io.ReaderAt, start, size int64) (int64, mpegFrame, error) {
+	buf := make([]byte, maxSyncSearch+4)
+	n, err := r.ReadAt(buf, start)
+	if err != nil && err != io.EOF {
+		return 0, mpegFrame{}, err
+	}
+	buf = buf[:n]
+
+	next := make([]byte, 4)
+	for i := 0; i+4 <= len(buf); i++ {
+		f, ok := parseMPEGHeader(buf[i:])
+		if !ok || f.length < 4 {
+			continue
+		}
+		offset := start + int64(i)
+		if offset+int64(f.length) == size {
+			return offset, f, nil
+		}
+		if _, err := r.ReadAt(next, offset+int64(f.length)); err != nil {
+			continue
+		}
+		if g, ok := parseMPEGHeader(next); ok && g.version == f.version && g.layer == f.layer && g.sampleRate == f.sampleRate {
+			return offset, f, nil
+		}
+	}
+	return 0, mpegFrame{}, ErrUnsupportedFormat
+}
+
+func probeMP3(r io.ReaderAt, start, size int64) (Info, error) {
+	offset, f, err := findMPEGFrame(r, start, size)
+	if err != nil {
+		return Info{}, err
+	}
+	if f.layer != 3 {
+		return Info{}, ErrUnsupportedFormat
+	}
+
+	info := Info{
+		Format:     "mp3",
+		Codec:      "mp3",
+		MimeType:   "audio/mpeg",
+		SampleRate: f.sampleRate,
+		Channels:   f.channels,
+	}
+
+	audioSize := size - offset
+	tag := make([]byte, 3)
+	if _, err := r.ReadAt(tag, size-128); err == nil && string(tag) == "TAG" {
+		audioSize -= 128 // ID3v1 в конце файла
+	}
+
+	if frames, delay, padding := vbrHeader(r, offset, f); frames > 0 {
+		samples := int64(frames)*int64(f.samples) - int64(delay+padding)
+		if samples <= 0 {
+			samples = int64(frames) * int64(f.samples)
+		}
+		info.Duration = seconds(samples, int64(f.sampleRate))
+		info.Bitrate = int(float64(audioSize) * 8 / info.Duration.Seconds())
+		return info, nil
+	}
+
+	// Без VBR-заголовка кадры пересчитываются по заголовкам: битрейт первого
+	// кадра говорит о длительности, только если поток CBR
+	if frames := countMPEGFrames(r, offset, size, f); frames > 0 {
+		info.Duration = seconds(frames*int64(f.samples), int64(f.sampleRate))
+		info.Bitrate = int(float64(audioSize) * 8 / info.Duration.Seconds())
+		return info, nil
+	}
+	info.Bitrate = f.bitrate
+	info.Duration = seconds(audioSize*8, int64(f.bitrate))
+	return info, nil
+}
+
+// vbrHeader читает число кадров из заголовка Xing/Info или VBRI в первом
+// кадре, а из тега LAME — задержку кодировщика и добивку в конце, которые
+// не входят в запись; frames 0 — заголовка нет
+func vbrHeader(r io.ReaderAt, offset int64, f mpegFrame) (frames, delay, padding int) {
+	frame := make([]byte, 256)
+	n, _ := r.ReadAt(frame, offset)
+	frame = frame[:n]
+
+	// Xing идет сразу после side information, длина которой зависит от версии и каналов
+	sideInfo := 32
+	switch {
+	case f.version == 1 && f.channels == 1:
+		sideInfo = 17
+	case f.version != 1 && f.channels == 2:
+		sideInfo = 17
+	case f.version != 1:
+		sideInfo = 9
+	}
+	if x := 4 + sideInfo; len(frame) >= x+12 {
+		tag := string(frame[x : x+4])
+		flags := binary.BigEndian.Uint32(frame[x+4:])
+		if (tag == "Xing" || tag == "Info") && flags&1 != 0 {
+			frames = int(binary.BigEndian.Uint32(frame[x+8:]))
+			delay, padding = lameGapless(frame, x, flags)
+			return frames, delay, padding
+		}
+	}
+	if len(frame) >= 36+18 && string(frame[36:40]) == "VBRI" {
+		return int(binary.BigEndian.Uint32(frame[36+14:])), 0, 0
+	}
+	return 0, 0, 0
+}
+
+// lameGapless читает из тега LAME, который следует за полями Xing,
+// 12 бит задержки и 12 бит добивки
+func lameGapless(frame []byte, x int, flags uint32) (delay, padding int) {
+	lame := x + 8
+	for bit, size := range []int{4, 4, 100, 4} {
+		if flags&(1<<bit) != 0 {
+			lame += size
+		}
+	}
+	if len(frame) < lame+24 {
+		return 0, 0
+	}
+	switch string(frame[lame : lame+4]) {
+	case "LAME", "Lavc", "Lavf":
+	default:
+		return 0, 0
+	}
+	b := frame[lame+21:]
+	return int(b[0])<<4 | int(b[1])>>4, int(b[1]&0x0F)<<8 | int(b[2])
+}
+
+// countMPEGFrames считает кадры с параметрами первого, переходя по длинам из
+// заголовков. Счет останавливается там, где кадра нет: в конце файла обычно
+// лежат теги APE или ID3v1.
+func countMPEGFrames(r io.ReaderAt, offset, end int64, first mpegFrame) int64 {
+	var frames int64
+	header := make([]byte, 4)
+	for offset+4 <= end {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			break
+		}
+		f, ok := parseMPEGHeader(header)
+		if !ok || f.length < 4 || f.layer != first.layer || f.sampleRate != first.sampleRate {
+			break
+		}
+		frames++
+		offset += int64(f.length)
+	}
+	return frames
+}
@@ -0,0 +1,278 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+type mp4Box struct {
+	kind   string
+	offset int64 // Начало содержимого
+	size   int64 // Размер содержимого
+}
+
+// mp4Boxes перечисляет атомы в диапазоне [start, end)
+func mp4Boxes(r io.ReaderAt, start, end int64) ([]mp4Box, error) {
+	var boxes []mp4Box
+	header := make([]byte, 16)
+	for offset := start; offset+8 <= end; {
+		if _, err := r.ReadAt(header[:8], offset); err != nil {
+			return nil, ErrMalformed
+		}
+		size := int64(binary.BigEndian.Uint32(header))
+		kind := string(header[4:8])
+		headerSize := int64(8)
+		switch size {
+		case 0: // До конца файла
+			size = end - offset
+		case 1: // 64-битный размер
+			if _, err := r.ReadAt(header[8:16], offset+8); err != nil {
+				return nil, ErrMalformed
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:]))
+			headerSize = 16
+		}
+		if size < headerSize || offset+size > end {
+			return nil, ErrMalformed
+		}
+		boxes = append(boxes, mp4Box{kind: kind, offset: offset + headerSize, size: size - headerSize})
+		offset += size
+	}
+	return boxes, nil
+}
+
+// findMP4Box спускается по пути из типов атомов, например "moov", "trak"
+func findMP4Box(r io.ReaderAt, parent mp4Box, path ...string) (mp4Box, bool) {
+	box := parent
+	for _, kind := range path {
+		children, err := mp4Boxes(r, box.offset, box.offset+box.size)
+		if err != nil {
+			return mp4Box{}, false
+		}
+		found := false
+		for _, c := range children {
+			if c.kind == kind {
+				box, found = c, true
+				break
+			}
+		}
+		if !found {
+			return mp4Box{}, false
+		}
+	}
+	return box, true
+}
+
+func probeMP4(r io.ReaderAt, start, size int64) (Info, error) {
+	root := mp4Box{offset: start, size: size - start}
+	moov, ok := findMP4Box(r, root, "moov")
+	if !ok {
+		return Info{}, ErrMalformed
+	}
+	traks, err := mp4Boxes(r, moov.offset, moov.offset+moov.size)
+	if err != nil {
+		return Info{}, err
+	}
+
+	// Берем первую звуковую дорожку; файлы с видео тоже подходят, если звук есть
+	for _, trak := range traks {
+		if trak.kind != "trak" {
+			continue
+		}
+		hdlr, ok := findMP4Box(r, trak, "mdia", "hdlr")
+		handler := make([]byte, 4)
+		if !ok || hdlr.size < 12 {
+			continue
+		}
+		if _, err := r.ReadAt(handler, hdlr.offset+8); err != nil || string(handler) != "soun" {
+			continue
+		}
+		return probeMP4Track(r, trak)
+	}
+	return Info{}, ErrUnsupportedFormat
+}
+
+func probeMP4Track(r io.ReaderAt, trak mp4Box) (Info, error) {
+	info := Info{Format: "m4a", MimeType: "audio/mp4"}
+
+	// mdhd: версия 0 — 32-битные время и длительность, версия 1 — 64-битные
+	if mdhd, ok := findMP4Box(r, trak, "mdia", "mdhd"); ok {
+		buf := make([]byte, 32)
+		n, _ := r.ReadAt(buf, mdhd.offset)
+		buf = buf[:n]
+		switch {
+		case len(buf) >= 20 && buf[0] == 0:
+			timescale := int64(binary.BigEndian.Uint32(buf[12:]))
+			info.Duration = seconds(int64(binary.BigEndian.Uint32(buf[16:])), timescale)
+		case len(buf) >= 32 && buf[0] == 1:
+			timescale := int64(binary.BigEndian.Uint32(buf[20:]))
+			info.Duration = seconds(int64(binary.BigEndian.Uint64(buf[24:])), timescale)
+		}
+	}
+
+	stsd, ok := findMP4Box(r, trak, "mdia", "minf", "stbl", "stsd")
+	if !ok {
+		return Info{}, ErrMalformed
+	}
+	// Полная версия и число записей, затем первая запись описания сэмплов
+	entries, err := mp4Boxes(r, stsd.offset+8, stsd.offset+stsd.size)
+	if err != nil || len(entries) == 0 {
+		return Info{}, ErrMalformed
+	}
+	entry := entries[0]
+	switch entry.kind {
+	case "mp4a":
+		info.Codec = "aac"
+	case "alac":
+		info.Codec = "alac"
+	default:
+		return Info{}, ErrUnsupportedFormat
+	}
+
+	// AudioSampleEntry: 8 байт SampleEntry, 8 зарезервировано, каналы,
+	// разрядность, 4 байта, частота в формате 16.16
+	buf := make([]byte, 28)
+	if _, err := r.ReadAt(buf, entry.offset); err != nil {
+		return Info{}, ErrMalformed
+	}
+	info.Channels = int(binary.BigEndian.Uint16(buf[16:]))
+	info.SampleRate = int(binary.BigEndian.Uint32(buf[24:]) >> 16)
+	if info.SampleRate == 0 {
+		return Info{}, ErrMalformed
+	}
+	return info, nil
+}
+
+// Типы значений в атоме data
+const (
+	mp4UTF8  = 1
+	mp4JPEG  = 13
+	mp4PNG   = 14
+	mp4BEInt = 21
+)
+
+// readMP4Tags читает теги iTunes из moov/udta/meta/ilst
+func readMP4Tags(r io.ReaderAt, start, size int64) (Tags, error) {
+	root := mp4Box{offset: start, size: size - start}
+	meta, ok := findMP4Box(r, root, "moov", "udta", "meta")
+	if !ok {
+		return Tags{}, nil
+	}
+	// В ISO meta — полный атом с 4 байтами версии и флагов, у QuickTime их нет
+	probe := make([]byte, 8)
+	if _, err := r.ReadAt(probe, meta.offset); err == nil && string(probe[4:8]) != "hdlr" {
+		meta.offset, meta.size = meta.offset+4, meta.size-4
+	}
+	ilst, ok := findMP4Box(r, meta, "ilst")
+	if !ok {
+		return Tags{}, nil
+	}
+	items, err := mp4Boxes(r, ilst.offset, ilst.offset+ilst.size)
+	if err != nil {
+		return Tags{}, err
+	}
+
+	var tags Tags
+	for _, item := range items {
+		if item.size > maxTagSize {
+			continue
+		}
+		body := make([]byte, item.size)
+		if _, err := r.ReadAt(body, item.offset); err != nil {
+			return Tags{}, ErrMalformed
+		}
+		name, kind, value := mp4ItemValue(item.kind, body)
+		if value == nil {
+			continue
+		}
+		text := strings.TrimSpace(string(value))
+
+		switch name {
+		case "\xa9nam":
+			tags.Title = text
+		case "\xa9ART":
+			tags.Artist = text
+		case "\xa9alb":
+			tags.Album = text
+		case "aART":
+			tags.AlbumArtist = text
+		case "\xa9gen":
+			tags.Genres = append(tags.Genres, text)
+		case "gnre":
+			// Номер жанра ID3v1, увеличенный на единицу
+			if len(value) >= 2 {
+				if g := int(binary.BigEndian.Uint16(value)) - 1; g >= 0 && g < len(id3v1Genres) {
+					tags.Genres = append(tags.Genres, id3v1Genres[g])
+				}
+			}
+		case "\xa9day":
+			tags.Date = text
+		case "trkn", "disk":
+			// 2 байта зарезервировано, номер, всего
+			if len(value) >= 4 {
+				n := int(binary.BigEndian.Uint16(value[2:]))
+				if name == "trkn" {
+					tags.TrackNumber = n
+				} else {
+					tags.DiscNumber = n
+				}
+			}
+		case "tmpo":
+			if kind == mp4BEInt && len(value) >= 2 {
+				tags.BPM = float64(binary.BigEndian.Uint16(value))
+			}
+		case "covr":
+			if tags.Picture == nil && len(value) > 0 {
+				mime := ""
+				switch kind {
+				case mp4JPEG:
+					mime = "image/jpeg"
+				case mp4PNG:
+					mime = "image/png"
+				}
+				tags.Picture = &Picture{MimeType: imageMimeType(value, mime), Data: value}
+			}
+		case "ISRC":
+			tags.ISRC = text
+		case "LANGUAGE":
+			tags.Language = text
+		case "INITIALKEY", "KEY":
+			tags.Key = text
+		case "BPM":
+			if tags.BPM == 0 {
+				tags.BPM = bpm(text)
+			}
+		}
+	}
+	return tags, nil
+}
+
+// mp4ItemValue возвращает имя элемента ilst, тип и содержимое первого атома
+// data. У элементов "----" имя берется из атома name и приводится к
+// верхнему регистру.
+func mp4ItemValue(name string, body []byte) (string, int, []byte) {
+	var kind int
+	var value []byte
+	for len(body) >= 8 {
+		size := int(binary.BigEndian.Uint32(body))
+		if size < 8 || size > len(body) {
+			break
+		}
+		child, content := string(body[4:8]), body[8:size]
+		body = body[size:]
+		switch child {
+		case "name":
+			if name == "----" && len(content) >= 4 {
+				name = strings.ToUpper(string(content[4:]))
+			}
+		case "data":
+			// 1 байт версии, 3 байта типа, 4 байта локали
+			if value == nil && len(content) >= 8 {
+				kind = int(binary.BigEndian.Uint32(content) & 0xFFFFFF)
+				value = content[8:]
+			}
+		}
+	}
+	return name, kind, value
+}
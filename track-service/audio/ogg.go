@@ -0,0 +1,151 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// oggPageHeader — размер заголовка страницы Ogg без таблицы сегментов
+const oggPageHeader = 27
+
+// opusRate — частота, в которой считается позиция в потоке Opus
+const opusRate = 48000
+
+func probeOgg(r io.ReaderAt, start, size int64) (Info, error) {
+	page := make([]byte, oggPageHeader+255)
+	n, _ := r.ReadAt(page, start)
+	if n < oggPageHeader {
+		return Info{}, ErrMalformed
+	}
+	segments := int(page[26])
+	packet := make([]byte, 64)
+	if _, err := r.ReadAt(packet, start+oggPageHeader+int64(segments)); err != nil {
+		return Info{}, ErrMalformed
+	}
+
+	var info Info
+	var preSkip int64
+	switch {
+	case bytes.HasPrefix(packet, []byte("\x01vorbis")):
+		info = Info{
+			Codec:      "vorbis",
+			Channels:   int(packet[11]),
+			SampleRate: int(binary.LittleEndian.Uint32(packet[12:])),
+		}
+	case bytes.HasPrefix(packet, []byte("OpusHead")):
+		info = Info{
+			Codec:    "opus",
+			Channels: int(packet[9]),
+			// Opus всегда декодируется в 48 кГц; поле заголовка — частота исходника
+			SampleRate: opusRate,
+		}
+		preSkip = int64(binary.LittleEndian.Uint16(packet[10:]))
+	default:
+		return Info{}, ErrUnsupportedFormat
+	}
+	if info.SampleRate == 0 {
+		return Info{}, ErrMalformed
+	}
+	info.Format = "ogg"
+	info.MimeType = "audio/ogg"
+
+	// Длительность — позиция последней страницы потока
+	if granule := lastGranule(r, size); granule > preSkip {
+		info.Duration = seconds(granule-preSkip, int64(info.SampleRate))
+	}
+	return info, nil
+}
+
+// lastGranule ищет последнюю страницу Ogg в конце файла и возвращает ее позицию
+func lastGranule(r io.ReaderAt, size int64) int64 {
+	tailSize := int64(64 << 10)
+	if tailSize > size {
+		tailSize = size
+	}
+	tail := make([]byte, tailSize)
+	if _, err := r.ReadAt(tail, size-tailSize); err != nil && err != io.EOF {
+		return 0
+	}
+	i := bytes.LastIndex(tail, []byte("OggS"))
+	if i < 0 || i+14 > len(tail) {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint64(tail[i+6:]))
+}
+
+// readOggTags читает второй пакет потока: комментарии Vorbis после
+// заголовка "\x03vorbis" или "OpusTags"
+func readOggTags(r io.ReaderAt, start, size int64) (Tags, error) {
+	packets, err := oggPackets(r, start, size, 2)
+	if err != nil {
+		return Tags{}, err
+	}
+	if len(packets) < 2 {
+		return Tags{}, nil
+	}
+	comment := packets[1]
+	switch {
+	case bytes.HasPrefix(comment, []byte("\x03vorbis")):
+		return parseVorbisComment(comment[7:])
+	case bytes.HasPrefix(comment, []byte("OpusTags")):
+		return parseVorbisComment(comment[8:])
+	default:
+		return Tags{}, nil
+	}
+}
+
+// oggPackets собирает первые count пакетов логического потока, которому
+// принадлежит первая страница. Пакет может занимать несколько страниц:
+// сегмент короче 255 байт завершает пакет.
+func oggPackets(r io.ReaderAt, start, size int64, count int) ([][]byte, error) {
+	var (
+		packets [][]byte
+		packet  []byte
+		serial  uint32
+		total   int
+	)
+	header := make([]byte, oggPageHeader)
+	for offset, first := start, true; len(packets) < count && offset+oggPageHeader <= size; first = false {
+		if _, err := r.ReadAt(header, offset); err != nil || string(header[:4]) != "OggS" {
+			return nil, ErrMalformed
+		}
+		pageSerial := binary.LittleEndian.Uint32(header[14:])
+		if first {
+			serial = pageSerial
+		}
+		table := make([]byte, header[26])
+		if _, err := r.ReadAt(table, offset+oggPageHeader); err != nil {
+			return nil, ErrMalformed
+		}
+		bodySize := 0
+		for _, s := range table {
+			bodySize += int(s)
+		}
+		bodyOffset := offset + oggPageHeader + int64(len(table))
+		offset = bodyOffset + int64(bodySize)
+		if pageSerial != serial {
+			continue
+		}
+
+		body := make([]byte, bodySize)
+		if _, err := r.ReadAt(body, bodyOffset); err != nil {
+			return nil, ErrMalformed
+		}
+		for _, s := range table {
+			packet = append(packet, body[:s]...)
+			body = body[s:]
+			if total += int(s); total > maxTagSize {
+				return nil, ErrMalformed
+			}
+			if s < 255 {
+				packets = append(packets, packet)
+				packet = nil
+				if len(packets) == count {
+					break
+				}
+			}
+		}
+	}
+	return packets, nil
+}
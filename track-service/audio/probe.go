@@ -0,0 +1,124 @@
+// Package audio распознает формат аудиофайлов и читает параметры потока из
+// заголовков, не декодируя сам звук
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported audio format")
+	ErrMalformed         = errors.New("malformed audio file")
+)
+
+// Info — параметры аудиофайла
+type Info struct {
+	Format     string // Контейнер: mp3, flac, wav, ogg или m4a
+	Codec      string // mp3, flac, pcm, vorbis, opus, aac или alac
+	MimeType   string
+	Bitrate    int // Средний битрейт, бит/с
+	SampleRate int // Гц
+	Channels   int
+	Duration   time.Duration
+}
+
+type prober func(r io.ReaderAt, start, size int64) (Info, error)
+
+// Probe определяет формат файла по сигнатуре и читает параметры потока.
+// Файлы, которые не удалось распознать, отклоняются с ErrUnsupportedFormat.
+func Probe(r io.ReaderAt, size int64) (Info, error) {
+	start, format, err := sniff(r, size)
+	if err != nil {
+		return Info{}, err
+	}
+
+	info, err := probers[format](r, start, size)
+	if err != nil {
+		return Info{}, err
+	}
+	if info.Bitrate == 0 && info.Duration > 0 {
+		info.Bitrate = int(float64(size-start) * 8 / info.Duration.Seconds())
+	}
+	return info, nil
+}
+
+var probers = map[string]prober{
+	"flac": probeFLAC,
+	"wav":  probeWAV,
+	"ogg":  probeOgg,
+	"m4a":  probeMP4,
+	"mp3":  probeMP3,
+}
+
+// sniff определяет контейнер по сигнатуре и возвращает его вместе со
+// смещением данных после ID3v2-тега
+func sniff(r io.ReaderAt, size int64) (int64, string, error) {
+	start, err := skipID3v2(r, size)
+	if err != nil {
+		return 0, "", err
+	}
+
+	head := make([]byte, 12)
+	n, _ := r.ReadAt(head, start)
+	head = head[:n]
+
+	switch {
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		return start, "flac", nil
+	case bytes.HasPrefix(head, []byte("RIFF")) && len(head) == 12 && string(head[8:12]) == "WAVE":
+		return start, "wav", nil
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return start, "ogg", nil
+	case len(head) >= 8 && string(head[4:8]) == "ftyp":
+		return start, "m4a", nil
+	case len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0:
+		return start, "mp3", nil
+	case start > 0:
+		// За ID3-тегом не всегда сразу идет кадр: ищем его дальше
+		return start, "mp3", nil
+	default:
+		return 0, "", ErrUnsupportedFormat
+	}
+}
+
+// skipID3v2 возвращает смещение данных после ID3v2-тега в начале файла
+func skipID3v2(r io.ReaderAt, size int64) (int64, error) {
+	header := make([]byte, 10)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		if size < 10 {
+			return 0, ErrUnsupportedFormat
+		}
+		return 0, err
+	}
+	if string(header[:3]) != "ID3" {
+		return 0, nil
+	}
+	tagSize := syncsafe(header[6:10])
+	offset := int64(10 + tagSize)
+	if header[5]&0x10 != 0 {
+		offset += 10 // Футер
+	}
+	if offset >= size {
+		return 0, ErrMalformed
+	}
+	return offset, nil
+}
+
+// syncsafe читает целое ID3, в каждом байте которого значимы 7 бит
+func syncsafe(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n<<7 | int(c&0x7F)
+	}
+	return n
+}
+
+func seconds(samples, rate int64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(samples) / float64(rate) * float64(time.Second))
+}
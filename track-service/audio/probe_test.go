@@ -0,0 +1,317 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Файлы для тестов собираются в коде: так видно, какие байты проверяются,
+// и в репозитории не лежат бинарные образцы
+
+// chunk собирает чанк RIFF с выравниванием по двум байтам
+func chunk(id string, body []byte) []byte {
+	b := make([]byte, 8, 8+len(body)+1)
+	copy(b, id)
+	binary.LittleEndian.PutUint32(b[4:], uint32(len(body)))
+	b = append(b, body...)
+	if len(body)%2 == 1 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// wavFile собирает WAV с PCM-данными и дополнительными чанками после них
+func wavFile(format uint16, rate, channels, bits int, data []byte, extra ...[]byte) []byte {
+	fmtChunk := make([]byte, 16)
+	binary.LittleEndian.PutUint16(fmtChunk[0:], format)
+	binary.LittleEndian.PutUint16(fmtChunk[2:], uint16(channels))
+	binary.LittleEndian.PutUint32(fmtChunk[4:], uint32(rate))
+	binary.LittleEndian.PutUint32(fmtChunk[8:], uint32(rate*channels*bits/8))
+	binary.LittleEndian.PutUint16(fmtChunk[12:], uint16(channels*bits/8))
+	binary.LittleEndian.PutUint16(fmtChunk[14:], uint16(bits))
+
+	body := []byte("WAVE")
+	body = append(body, chunk("fmt ", fmtChunk)...)
+	body = append(body, chunk("data", data)...)
+	for _, c := range extra {
+		body = append(body, c...)
+	}
+	return append(chunk("RIFF", body)[:8], body...)
+}
+
+// wavInfo собирает чанк LIST INFO из пар "поле", "значение"
+func wavInfo(fields ...string) []byte {
+	body := []byte("INFO")
+	for i := 0; i+1 < len(fields); i += 2 {
+		body = append(body, chunk(fields[i], append([]byte(fields[i+1]), 0))...)
+	}
+	return chunk("LIST", body)
+}
+
+// flacBlock собирает блок метаданных FLAC
+func flacBlock(kind byte, last bool, body []byte) []byte {
+	if last {
+		kind |= 0x80
+	}
+	n := len(body)
+	return append([]byte{kind, byte(n >> 16), byte(n >> 8), byte(n)}, body...)
+}
+
+func flacStreamInfo(rate, channels, bits int, samples int64) []byte {
+	b := make([]byte, 34)
+	binary.BigEndian.PutUint16(b[0:], 4096)
+	binary.BigEndian.PutUint16(b[2:], 4096)
+	packed := uint64(rate)<<44 | uint64(channels-1)<<41 | uint64(bits-1)<<36 | uint64(samples)
+	binary.BigEndian.PutUint64(b[10:], packed)
+	return b
+}
+
+func vorbisComment(entries ...string) []byte {
+	vendor := "reference libFLAC 1.4.3"
+	b := binary.LittleEndian.AppendUint32(nil, uint32(len(vendor)))
+	b = append(b, vendor...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(entries)))
+	for _, e := range entries {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(e)))
+		b = append(b, e...)
+	}
+	return b
+}
+
+func flacPictureBlock(kind int, mime string, data []byte) []byte {
+	field := func(b, v []byte) []byte {
+		return append(binary.BigEndian.AppendUint32(b, uint32(len(v))), v...)
+	}
+	b := binary.BigEndian.AppendUint32(nil, uint32(kind))
+	b = field(b, []byte(mime))
+	b = field(b, []byte("cover"))
+	b = append(b, make([]byte, 16)...)
+	return field(b, data)
+}
+
+func flacFile(blocks ...[]byte) []byte {
+	return bytes.Join(append([][]byte{[]byte("fLaC")}, blocks...), nil)
+}
+
+// Кадр MPEG-1 Layer III, 128 кбит/с, 44,1 кГц, стерео: 417 байт
+var mp3FrameHeader = []byte{0xFF, 0xFB, 0x90, 0x00}
+
+const mp3FrameLength = 417
+
+func mp3Frames(n int) []byte {
+	var b []byte
+	for i := 0; i < n; i++ {
+		frame := make([]byte, mp3FrameLength)
+		copy(frame, mp3FrameHeader)
+		b = append(b, frame...)
+	}
+	return b
+}
+
+// xingFrame — первый кадр VBR-файла с заголовком Xing и тегом LAME
+func xingFrame(frames, delay, padding int) []byte {
+	frame := make([]byte, mp3FrameLength)
+	copy(frame, mp3FrameHeader)
+	x := 4 + 32
+	copy(frame[x:], "Xing")
+	binary.BigEndian.PutUint32(frame[x+4:], 1) // Только число кадров
+	binary.BigEndian.PutUint32(frame[x+8:], uint32(frames))
+	lame := x + 12
+	copy(frame[lame:], "LAME3.100")
+	frame[lame+21] = byte(delay >> 4)
+	frame[lame+22] = byte(delay<<4) | byte(padding>>8)
+	frame[lame+23] = byte(padding)
+	return frame
+}
+
+func syncsafeBytes(n int) []byte {
+	return []byte{byte(n >> 21 & 0x7F), byte(n >> 14 & 0x7F), byte(n >> 7 & 0x7F), byte(n & 0x7F)}
+}
+
+// id3Frame собирает кадр ID3v2.3 или 2.4
+func id3Frame(version byte, id string, body []byte) []byte {
+	b := []byte(id)
+	if version == 4 {
+		b = append(b, syncsafeBytes(len(body))...)
+	} else {
+		b = binary.BigEndian.AppendUint32(b, uint32(len(body)))
+	}
+	return append(append(b, 0, 0), body...)
+}
+
+func id3Tag(version byte, frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	return append(append([]byte{'I', 'D', '3', version, 0, 0}, syncsafeBytes(len(body))...), body...)
+}
+
+func id3v1Tag(title, artist, album, year string, track, genre byte) []byte {
+	tag := make([]byte, 128)
+	copy(tag, "TAG")
+	copy(tag[3:33], title)
+	copy(tag[33:63], artist)
+	copy(tag[63:93], album)
+	copy(tag[93:97], year)
+	tag[126] = track
+	tag[127] = genre
+	return tag
+}
+
+func probe(t *testing.T, file []byte) (Info, error) {
+	t.Helper()
+	return Probe(bytes.NewReader(file), int64(len(file)))
+}
+
+// closeTo сравнивает длительности с точностью до миллисекунды
+func closeTo(got, want time.Duration) bool {
+	d := got - want
+	return d > -time.Millisecond && d < time.Millisecond
+}
+
+func TestProbeWAV(t *testing.T) {
+	// Секунда 16-битного моно с частотой 8 кГц
+	file := wavFile(wavePCM, 8000, 1, 16, make([]byte, 16000), wavInfo("INAM", "Kino"))
+
+	info, err := probe(t, file)
+	if err != nil {
+		t.Fatalf("Probe: %v", err)
+	}
+	want := Info{Format: "wav", Codec: "pcm", MimeType: "audio/wav", Bitrate: 128000, SampleRate: 8000, Channels: 1, Duration: time.Second}
+	if info != want {
+		t.Errorf("Probe = %+v, want %+v", info, want)
+	}
+
+	// Стерео с плавающей точкой
+	info, err = probe(t, wavFile(waveFloat, 48000, 2, 32, make([]byte, 48000*8/2)))
+	if err != nil {
+		t.Fatalf("Probe float: %v", err)
+	}
+	if info.Channels != 2 || info.SampleRate != 48000 || info.Duration != 500*time.Millisecond {
+		t.Errorf("Probe float = %+v", info)
+	}
+
+	// Сжатый звук внутри WAV (здесь MPEG) не принимается
+	if _, err := probe(t, wavFile(0x0055, 44100, 2, 16, make([]byte, 100))); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Probe MPEG in WAV: err = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestProbeFLAC(t *testing.T) {
+	file := flacFile(
+		flacBlock(0, false, flacStreamInfo(44100, 2, 16, 44100*3)),
+		flacBlock(flacVorbisComment, true, vorbisComment("TITLE=Kukushka")),
+		make([]byte, 1000),
+	)
+
+	info, err := probe(t, file)
+	if err != nil {
+		t.Fatalf("Probe: %v", err)
+	}
+	if info.Format != "flac" || info.Codec != "flac" || info.MimeType != "audio/flac" ||
+		info.SampleRate != 44100 || info.Channels != 2 || info.Duration != 3*time.Second {
+		t.Errorf("Probe = %+v", info)
+	}
+	// Битрейта в заголовках FLAC нет: он считается по размеру файла
+	if want := len(file) * 8 / 3; info.Bitrate != want {
+		t.Errorf("Bitrate = %d, want %d", info.Bitrate, want)
+	}
+
+	// Первым блоком обязан идти STREAMINFO
+	bad := flacFile(flacBlock(flacVorbisComment, true, flacStreamInfo(44100, 2, 16, 1)))
+	if _, err := probe(t, bad); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Probe without STREAMINFO: err = %v, want ErrMalformed", err)
+	}
+}
+
+func TestProbeMP3(t *testing.T) {
+	// CBR без VBR-заголовка: длительность по числу кадров
+	info, err := probe(t, mp3Frames(10))
+	if err != nil {
+		t.Fatalf("Probe CBR: %v", err)
+	}
+	want := seconds(10*1152, 44100)
+	if info.Format != "mp3" || info.MimeType != "audio/mpeg" || info.SampleRate != 44100 || info.Channels != 2 || !closeTo(info.Duration, want) {
+		t.Errorf("Probe CBR = %+v, want duration %v", info, want)
+	}
+	if info.Bitrate < 127000 || info.Bitrate > 129000 {
+		t.Errorf("Probe CBR bitrate = %d, want about 128000", info.Bitrate)
+	}
+
+	// Перед первым кадром ID3v2, в конце ID3v1: оба не считаются звуком
+	file := append(id3Tag(3, id3Frame(3, "TIT2", []byte("\x00Kino"))), mp3Frames(10)...)
+	file = append(file, id3v1Tag("Kino", "", "", "", 0, 0)...)
+	if info, err = probe(t, file); err != nil || !closeTo(info.Duration, want) {
+		t.Errorf("Probe with tags = %+v, %v; want duration %v", info, err, want)
+	}
+
+	// Заголовок Xing задает число кадров, тег LAME — задержку и добивку
+	vbr := append(xingFrame(100, 576, 1152), mp3Frames(2)...)
+	if info, err = probe(t, vbr); err != nil {
+		t.Fatalf("Probe VBR: %v", err)
+	}
+	if want := seconds(100*1152-576-1152, 44100); !closeTo(info.Duration, want) {
+		t.Errorf("Probe VBR duration = %v, want %v", info.Duration, want)
+	}
+
+	// Мусор перед первым кадром пропускается
+	junk := append(bytes.Repeat([]byte{0xFF, 0x00}, 100), mp3Frames(5)...)
+	if _, err := probe(t, append(id3Tag(3), junk...)); err != nil {
+		t.Errorf("Probe with junk before the first frame: %v", err)
+	}
+}
+
+func TestProbeRejects(t *testing.T) {
+	wav := wavFile(wavePCM, 8000, 1, 16, make([]byte, 16000))
+	flac := flacFile(flacBlock(0, true, flacStreamInfo(44100, 2, 16, 44100)))
+	tag := id3Tag(3, id3Frame(3, "TIT2", []byte("\x00Kino")))
+
+	cases := []struct {
+		name string
+		file []byte
+		want error
+	}{
+		{name: "empty", file: nil, want: ErrUnsupportedFormat},
+		{name: "shorter than a header", file: []byte("fLaC"), want: ErrUnsupportedFormat},
+		{name: "text", file: []byte("this is not an audio file at all"), want: ErrUnsupportedFormat},
+		{name: "mpeg sync without a second frame", file: append([]byte{0xFF, 0xFB, 0x90, 0x00}, make([]byte, 1000)...), want: ErrUnsupportedFormat},
+		{name: "layer II", file: bytes.Repeat(append([]byte{0xFF, 0xFD, 0x90, 0x00}, make([]byte, 413)...), 3), want: ErrUnsupportedFormat},
+		{name: "id3 tag only", file: tag, want: ErrMalformed},
+		{name: "id3 tag longer than the file", file: tag[:len(tag)-2], want: ErrMalformed},
+		{name: "wav cut inside fmt", file: wav[:12+8+10], want: ErrMalformed},
+		{name: "wav without data", file: wav[:12+8+16], want: ErrMalformed},
+		{name: "wav header only", file: wav[:12], want: ErrUnsupportedFormat},
+		{name: "flac cut inside streaminfo", file: flac[:20], want: ErrMalformed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if info, err := probe(t, tc.file); !errors.Is(err, tc.want) {
+				t.Errorf("Probe = %+v, %v; want %v", info, err, tc.want)
+			}
+		})
+	}
+}
+
+// Файл, оборванный посреди данных, принимается: длительность считается по
+// тому, что успело записаться
+func TestProbeTruncatedData(t *testing.T) {
+	wav := wavFile(wavePCM, 8000, 1, 16, make([]byte, 16000))
+	info, err := probe(t, wav[:len(wav)-8000])
+	if err != nil {
+		t.Fatalf("Probe truncated WAV: %v", err)
+	}
+	if info.Duration != 500*time.Millisecond {
+		t.Errorf("truncated WAV duration = %v, want 500ms", info.Duration)
+	}
+
+	mp3 := mp3Frames(10)
+	info, err = probe(t, mp3[:len(mp3)-200])
+	if err != nil {
+		t.Fatalf("Probe truncated MP3: %v", err)
+	}
+	if want := seconds(10*1152, 44100); !closeTo(info.Duration, want) {
+		t.Errorf("truncated MP3 duration = %v, want %v", info.Duration, want)
+	}
+}
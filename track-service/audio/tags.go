@@ -0,0 +1,154 @@
+package audio
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxTagSize ограничивает размер тегов, которые читаются в память целиком;
+// больше бывает только у файлов с несколькими огромными обложками
+const maxTagSize = 32 << 20
+
+// Tags — метаданные из тегов файла. Значения записаны так, как в файле:
+// проверка и нормализация — дело вызывающего.
+type Tags struct {
+	Title       string
+	Artist      string
+	Album       string
+	AlbumArtist string
+	Genres      []string
+	Date        string // Год, год и месяц или полная дата, например "2021-03-04"
+	TrackNumber int
+	DiscNumber  int
+	ISRC        string
+	Language    string
+	BPM         float64
+	Key         string
+	Picture     *Picture // Обложка; nil — в файле ее нет
+}
+
+// Picture — картинка, встроенная в теги
+type Picture struct {
+	MimeType string
+	Data     []byte
+}
+
+// Типы картинок ID3v2 и FLAC: обложка предпочтительнее остальных
+const (
+	pictureOther      = 0
+	pictureFrontCover = 3
+)
+
+type tagReader func(r io.ReaderAt, start, size int64) (Tags, error)
+
+var tagReaders = map[string]tagReader{
+	"flac": readFLACTags,
+	"wav":  readWAVTags,
+	"ogg":  readOggTags,
+	"m4a":  readMP4Tags,
+	"mp3":  readID3v1,
+}
+
+// ReadTags читает теги файла: ID3 у MP3, комментарии Vorbis у FLAC и Ogg,
+// атомы iTunes у MP4 и LIST INFO у WAV. Тег ID3v2 в начале файла читается
+// у любого формата и дополняет собственные теги контейнера.
+func ReadTags(r io.ReaderAt, size int64) (Tags, error) {
+	start, format, err := sniff(r, size)
+	if err != nil {
+		return Tags{}, err
+	}
+
+	tags, err := tagReaders[format](r, start, size)
+	if err != nil {
+		return Tags{}, err
+	}
+	if start > 0 {
+		id3, err := readID3v2(r, 0)
+		if err != nil {
+			return Tags{}, err
+		}
+		id3.merge(tags)
+		tags = id3
+	}
+	return tags, nil
+}
+
+// merge заполняет пустые поля t значениями из other
+func (t *Tags) merge(other Tags) {
+	setString := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	setString(&t.Title, other.Title)
+	setString(&t.Artist, other.Artist)
+	setString(&t.Album, other.Album)
+	setString(&t.AlbumArtist, other.AlbumArtist)
+	setString(&t.Date, other.Date)
+	setString(&t.ISRC, other.ISRC)
+	setString(&t.Language, other.Language)
+	setString(&t.Key, other.Key)
+	if len(t.Genres) == 0 {
+		t.Genres = other.Genres
+	}
+	if t.TrackNumber == 0 {
+		t.TrackNumber = other.TrackNumber
+	}
+	if t.DiscNumber == 0 {
+		t.DiscNumber = other.DiscNumber
+	}
+	if t.BPM == 0 {
+		t.BPM = other.BPM
+	}
+	if t.Picture == nil {
+		t.Picture = other.Picture
+	}
+}
+
+// position разбирает номер трека или диска вида "3" или "3/12"
+func position(s string) int {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		s = s[:i]
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// bpm разбирает темп; в тегах он бывает и целым, и дробным
+func bpm(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v < 0 {
+		return 0
+	}
+	return v
+}
+
+// latin1 декодирует ISO-8859-1: каждый байт — кодовая точка Unicode
+func latin1(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+// imageMimeType определяет тип картинки по сигнатуре: поле MIME в тегах
+// часто пустое или неверное
+func imageMimeType(data []byte, declared string) string {
+	switch {
+	case len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF:
+		return "image/jpeg"
+	case len(data) >= 8 && string(data[:8]) == "\x89PNG\r\n\x1a\n":
+		return "image/png"
+	case len(data) >= 6 && (string(data[:6]) == "GIF87a" || string(data[:6]) == "GIF89a"):
+		return "image/gif"
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return "image/webp"
+	}
+	return strings.ToLower(strings.TrimSpace(declared))
+}
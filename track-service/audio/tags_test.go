@@ -0,0 +1,196 @@
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var pngData = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+func readTags(t *testing.T, file []byte) (Tags, error) {
+	t.Helper()
+	return ReadTags(bytes.NewReader(file), int64(len(file)))
+}
+
+// utf16Text собирает тело текстового кадра ID3 в UTF-16 с BOM
+func utf16Text(s string) []byte {
+	b := []byte{id3UTF16, 0xFF, 0xFE}
+	for _, r := range s {
+		b = append(b, byte(r), byte(r>>8))
+	}
+	return b
+}
+
+func TestReadTagsID3v23(t *testing.T) {
+	apic := append([]byte{id3Latin1}, "image/jpeg\x00"...)
+	apic = append(append(apic, pictureFrontCover), "\x00"...)
+	apic = append(apic, pngData...)
+
+	tag := id3Tag(3,
+		id3Frame(3, "TIT2", utf16Text("Группа крови")),
+		id3Frame(3, "TPE1", []byte("\x00Kino")),
+		id3Frame(3, "TALB", []byte("\x03Группа крови")),
+		id3Frame(3, "TCON", []byte("\x00(17)Rock")),
+		id3Frame(3, "TRCK", []byte("\x003/12")),
+		id3Frame(3, "TYER", []byte("\x001988")),
+		id3Frame(3, "TDAT", []byte("\x000401")),
+		id3Frame(3, "TBPM", []byte("\x00118.5")),
+		id3Frame(3, "APIC", apic),
+	)
+	got, err := readTags(t, append(tag, mp3Frames(3)...))
+	if err != nil {
+		t.Fatalf("ReadTags: %v", err)
+	}
+	want := Tags{
+		Title:       "Группа крови",
+		Artist:      "Kino",
+		Album:       "Группа крови",
+		Genres:      []string{"Rock"},
+		Date:        "1988-01-04",
+		TrackNumber: 3,
+		BPM:         118.5,
+		// MIME берется по сигнатуре, а не из кадра
+		Picture: &Picture{MimeType: "image/png", Data: pngData},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTags = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadTagsID3v24(t *testing.T) {
+	tag := id3Tag(4,
+		id3Frame(4, "TIT2", []byte("\x03Кукушка")),
+		id3Frame(4, "TCON", []byte("\x03Rock\x00Post-Punk")),
+		id3Frame(4, "TDRC", []byte("\x032021-03-04")),
+		id3Frame(4, "TPOS", []byte("\x032/2")),
+		id3Frame(4, "TSRC", []byte("\x03RUA1D8800001")),
+	)
+	got, err := readTags(t, append(tag, mp3Frames(3)...))
+	if err != nil {
+		t.Fatalf("ReadTags: %v", err)
+	}
+	want := Tags{Title: "Кукушка", Genres: []string{"Rock", "Post-Punk"}, Date: "2021-03-04", DiscNumber: 2, ISRC: "RUA1D8800001"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTags = %+v, want %+v", got, want)
+	}
+}
+
+// ID3v1 в конце файла дополняет ID3v2, но не перекрывает его
+func TestReadTagsID3v1(t *testing.T) {
+	v1 := id3v1Tag("Old title", "Kino", "Nachalnik Kamchatki", "1984", 7, 17)
+
+	got, err := readTags(t, append(mp3Frames(3), v1...))
+	if err != nil {
+		t.Fatalf("ReadTags: %v", err)
+	}
+	want := Tags{Title: "Old title", Artist: "Kino", Album: "Nachalnik Kamchatki", Date: "1984", TrackNumber: 7, Genres: []string{"Rock"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTags v1 = %+v, want %+v", got, want)
+	}
+
+	file := append(id3Tag(3, id3Frame(3, "TIT2", []byte("\x00Kamchatka"))), mp3Frames(3)...)
+	got, err = readTags(t, append(file, v1...))
+	if err != nil {
+		t.Fatalf("ReadTags: %v", err)
+	}
+	if got.Title != "Kamchatka" || got.Artist != "Kino" || got.TrackNumber != 7 {
+		t.Errorf("ReadTags v2+v1 = %+v", got)
+	}
+}
+
+func TestReadTagsWAV(t *testing.T) {
+	file := wavFile(wavePCM, 8000, 1, 16, make([]byte, 100),
+		wavInfo("INAM", "Blood Type", "IART", "Kino", "IGNR", "Rock", "ICRD", "1988", "ITRK", "1"),
+		chunk("id3 ", id3Tag(3, id3Frame(3, "TIT2", []byte("\x00Gruppa krovi")))),
+	)
+	got, err := readTags(t, file)
+	if err != nil {
+		t.Fatalf("ReadTags: %v", err)
+	}
+	// Встроенный ID3v2 важнее LIST INFO
+	want := Tags{Title: "Gruppa krovi", Artist: "Kino", Genres: []string{"Rock"}, Date: "1988", TrackNumber: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTags = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadTagsFLAC(t *testing.T) {
+	other := []byte("GIF89a-other")
+	file := flacFile(
+		flacBlock(0, false, flacStreamInfo(44100, 2, 16, 44100)),
+		flacBlock(flacPicture, false, flacPictureBlock(pictureOther, "image/gif", other)),
+		flacBlock(flacVorbisComment, false, vorbisComment(
+			"TITLE=Звезда по имени Солнце",
+			"artist=Kino",
+			"ALBUMARTIST=Viktor Tsoi",
+			"GENRE=Rock",
+			"GENRE=New Wave",
+			"TRACKNUMBER=1",
+			"DATE=1989",
+			"INITIALKEY=Am",
+			"broken entry without a separator",
+		)),
+		flacBlock(flacPicture, true, flacPictureBlock(pictureFrontCover, "", pngData)),
+	)
+	got, err := readTags(t, file)
+	if err != nil {
+		t.Fatalf("ReadTags: %v", err)
+	}
+	want := Tags{
+		Title:       "Звезда по имени Солнце",
+		Artist:      "Kino",
+		AlbumArtist: "Viktor Tsoi",
+		Genres:      []string{"Rock", "New Wave"},
+		Date:        "1989",
+		TrackNumber: 1,
+		Key:         "Am",
+		// Обложка важнее картинки другого типа, даже если идет позже
+		Picture: &Picture{MimeType: "image/png", Data: pngData},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTags = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadTagsTruncated(t *testing.T) {
+	streamInfo := flacBlock(0, false, flacStreamInfo(44100, 2, 16, 44100))
+	comment := vorbisComment("TITLE=Kino", "ARTIST=Kino")
+	flac := flacFile(streamInfo, flacBlock(flacVorbisComment, true, comment))
+
+	// Блок обрывается раньше заявленной длины
+	if _, err := readTags(t, flac[:len(flac)-5]); !errors.Is(err, ErrMalformed) {
+		t.Errorf("FLAC cut inside VORBIS_COMMENT: err = %v, want ErrMalformed", err)
+	}
+
+	// Длина комментария внутри блока больше самого блока
+	broken := append([]byte(nil), comment...)
+	broken[len(broken)-len("ARTIST=Kino")-4] = 0xFF
+	if _, err := readTags(t, flacFile(streamInfo, flacBlock(flacVorbisComment, true, broken))); !errors.Is(err, ErrMalformed) {
+		t.Errorf("oversized Vorbis comment: err = %v, want ErrMalformed", err)
+	}
+
+	// Кадры ID3v2 читаются до первого испорченного
+	tag := id3Tag(3,
+		id3Frame(3, "TIT2", []byte("\x00Kino")),
+		[]byte("TPE1\x00\x00\x10\x00\x00\x00\x00Kino"),
+	)
+	got, err := readTags(t, append(tag, mp3Frames(3)...))
+	if err != nil {
+		t.Fatalf("ReadTags with a broken frame: %v", err)
+	}
+	if want := (Tags{Title: "Kino"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTags with a broken frame = %+v, want %+v", got, want)
+	}
+
+	// Чанк LIST длиннее файла пропускается, остальное читается
+	wav := wavFile(wavePCM, 8000, 1, 16, make([]byte, 100), wavInfo("INAM", "Kino"))
+	if got, err := readTags(t, wav[:len(wav)-3]); err != nil || got.Title != "" {
+		t.Errorf("WAV cut inside LIST = %+v, %v", got, err)
+	}
+
+	if _, err := readTags(t, []byte("ID3")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("3-byte file: err = %v, want ErrUnsupportedFormat", err)
+	}
+}
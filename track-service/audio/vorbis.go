@@ -0,0 +1,119 @@
+package audio
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+)
+
+// parseVorbisComment разбирает комментарии Vorbis — общий формат тегов FLAC,
+// Ogg Vorbis и Opus: строка кодировщика и пары "КЛЮЧ=значение" в UTF-8
+func parseVorbisComment(b []byte) (Tags, error) {
+	if len(b) < 4 {
+		return Tags{}, ErrMalformed
+	}
+	vendor := int(binary.LittleEndian.Uint32(b))
+	if 4+vendor+4 > len(b) {
+		return Tags{}, ErrMalformed
+	}
+	b = b[4+vendor:]
+	count := int(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+	fields := map[string][]string{}
+	for i := 0; i < count && len(b) >= 4; i++ {
+		n := int(binary.LittleEndian.Uint32(b))
+		if 4+n > len(b) {
+			return Tags{}, ErrMalformed
+		}
+		entry := string(b[4 : 4+n])
+		b = b[4+n:]
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToUpper(key)
+		if value = strings.TrimSpace(value); value != "" {
+			fields[key] = append(fields[key], value)
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if v := fields[k]; len(v) > 0 {
+				return v[0]
+			}
+		}
+		return ""
+	}
+	tags := Tags{
+		Title:       first("TITLE"),
+		Artist:      first("ARTIST"),
+		Album:       first("ALBUM"),
+		AlbumArtist: first("ALBUMARTIST", "ALBUM ARTIST"),
+		Genres:      fields["GENRE"],
+		Date:        first("DATE", "YEAR"),
+		TrackNumber: position(first("TRACKNUMBER")),
+		DiscNumber:  position(first("DISCNUMBER")),
+		ISRC:        first("ISRC"),
+		Language:    first("LANGUAGE"),
+		BPM:         bpm(first("BPM")),
+		Key:         first("KEY", "INITIALKEY"),
+	}
+
+	// Обложки в Ogg — блок PICTURE из FLAC в base64; COVERART — старый способ
+	for _, v := range fields["METADATA_BLOCK_PICTURE"] {
+		data, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			continue
+		}
+		if pic, kind, ok := parseFLACPicture(data); ok && (tags.Picture == nil || kind == pictureFrontCover) {
+			tags.Picture = pic
+		}
+	}
+	if v := first("COVERART"); tags.Picture == nil && v != "" {
+		if data, err := base64.StdEncoding.DecodeString(v); err == nil && len(data) > 0 {
+			tags.Picture = &Picture{MimeType: imageMimeType(data, first("COVERARTMIME")), Data: data}
+		}
+	}
+	return tags, nil
+}
+
+// parseFLACPicture разбирает блок PICTURE: тип картинки, MIME, описание,
+// размеры и сами данные; числа в big-endian в отличие от комментариев
+func parseFLACPicture(b []byte) (*Picture, int, bool) {
+	field := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		n := int(binary.BigEndian.Uint32(b))
+		if 4+n > len(b) {
+			return nil, false
+		}
+		v := b[4 : 4+n]
+		b = b[4+n:]
+		return v, true
+	}
+
+	if len(b) < 4 {
+		return nil, 0, false
+	}
+	kind := int(binary.BigEndian.Uint32(b))
+	b = b[4:]
+	mime, ok := field()
+	if !ok {
+		return nil, 0, false
+	}
+	if _, ok := field(); !ok { // Описание
+		return nil, 0, false
+	}
+	if len(b) < 16 { // Ширина, высота, глубина цвета, размер палитры
+		return nil, 0, false
+	}
+	b = b[16:]
+	data, ok := field()
+	if !ok || len(data) == 0 {
+		return nil, 0, false
+	}
+	return &Picture{MimeType: imageMimeType(data, string(mime)), Data: data}, kind, true
+}
@@ -0,0 +1,148 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+// Коды формата WAVE, которые мы принимаем
+const (
+	wavePCM        = 0x0001
+	waveFloat      = 0x0003
+	waveExtensible = 0xFFFE
+)
+
+func probeWAV(r io.ReaderAt, start, size int64) (Info, error) {
+	var (
+		format     uint16
+		channels   int
+		sampleRate int
+		byteRate   int
+		dataSize   int64 = -1
+	)
+
+	header := make([]byte, 8)
+	for offset := start + 12; offset+8 <= size; {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return Info{}, ErrMalformed
+		}
+		id, chunkSize := string(header[:4]), int64(binary.LittleEndian.Uint32(header[4:]))
+
+		switch id {
+		case "fmt ":
+			fmtChunk := make([]byte, 16)
+			if chunkSize < 16 {
+				return Info{}, ErrMalformed
+			}
+			if _, err := r.ReadAt(fmtChunk, offset+8); err != nil {
+				return Info{}, ErrMalformed
+			}
+			format = binary.LittleEndian.Uint16(fmtChunk[0:])
+			channels = int(binary.LittleEndian.Uint16(fmtChunk[2:]))
+			sampleRate = int(binary.LittleEndian.Uint32(fmtChunk[4:]))
+			byteRate = int(binary.LittleEndian.Uint32(fmtChunk[8:]))
+		case "data":
+			dataSize = chunkSize
+			if offset+8+dataSize > size {
+				dataSize = size - offset - 8 // Обрезанный при записи файл
+			}
+		}
+		if format != 0 && dataSize >= 0 {
+			break
+		}
+		// Чанки выравниваются по двум байтам
+		offset += 8 + chunkSize + chunkSize%2
+	}
+
+	if format != wavePCM && format != waveFloat && format != waveExtensible {
+		return Info{}, ErrUnsupportedFormat
+	}
+	if dataSize < 0 || byteRate == 0 || sampleRate == 0 {
+		return Info{}, ErrMalformed
+	}
+
+	return Info{
+		Format:     "wav",
+		Codec:      "pcm",
+		MimeType:   "audio/wav",
+		Bitrate:    byteRate * 8,
+		SampleRate: sampleRate,
+		Channels:   channels,
+		Duration:   seconds(dataSize, int64(byteRate)),
+	}, nil
+}
+
+// Поля LIST INFO, которые переносятся в теги
+var wavInfoFields = map[string]func(*Tags, string){
+	"INAM": func(t *Tags, v string) { t.Title = v },
+	"IART": func(t *Tags, v string) { t.Artist = v },
+	"IPRD": func(t *Tags, v string) { t.Album = v },
+	"IGNR": func(t *Tags, v string) { t.Genres = []string{v} },
+	"ICRD": func(t *Tags, v string) { t.Date = v },
+	"ITRK": func(t *Tags, v string) { t.TrackNumber = position(v) },
+	"IPRT": func(t *Tags, v string) { t.TrackNumber = position(v) },
+	"ISRC": func(t *Tags, v string) { t.ISRC = v },
+	"ILNG": func(t *Tags, v string) { t.Language = v },
+}
+
+// readWAVTags читает чанк LIST INFO и встроенный тег ID3v2 (чанк "id3 "),
+// которым WAV-файлы подписывают многие редакторы
+func readWAVTags(r io.ReaderAt, start, size int64) (Tags, error) {
+	var info, id3 Tags
+	header := make([]byte, 8)
+	for offset := start + 12; offset+8 <= size; {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return Tags{}, ErrMalformed
+		}
+		id, chunkSize := string(header[:4]), int64(binary.LittleEndian.Uint32(header[4:]))
+		if offset+8+chunkSize > size {
+			break
+		}
+
+		switch {
+		case id == "LIST" && chunkSize >= 4 && chunkSize <= maxTagSize:
+			chunk := make([]byte, chunkSize)
+			if _, err := r.ReadAt(chunk, offset+8); err != nil {
+				return Tags{}, ErrMalformed
+			}
+			if string(chunk[:4]) == "INFO" {
+				info = parseWAVInfo(chunk[4:])
+			}
+		case strings.EqualFold(id, "id3 "):
+			var err error
+			if id3, err = readID3v2(r, offset+8); err != nil {
+				return Tags{}, err
+			}
+		}
+		offset += 8 + chunkSize + chunkSize%2
+	}
+	id3.merge(info)
+	return id3, nil
+}
+
+func parseWAVInfo(b []byte) Tags {
+	var tags Tags
+	for len(b) >= 8 {
+		id, n := string(b[:4]), int(binary.LittleEndian.Uint32(b[4:]))
+		if 8+n > len(b) {
+			break
+		}
+		value := b[8 : 8+n]
+		if i := bytes.IndexByte(value, 0); i >= 0 {
+			value = value[:i]
+		}
+		if set, ok := wavInfoFields[id]; ok {
+			if v := strings.TrimSpace(string(value)); v != "" {
+				set(&tags, v)
+			}
+		}
+		next := 8 + n + n%2
+		if next > len(b) {
+			break
+		}
+		b = b[next:]
+	}
+	return tags
+}
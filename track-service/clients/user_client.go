@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"context"
+
+	userpb "github.com/Zhan028/Music_Service/userService/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UserClient читает настройки слушателей из user-service
+type UserClient struct {
+	conn   *grpc.ClientConn
+	client userpb.UserServiceClient
+}
+
+func NewUserClient(addr string) (*UserClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &UserClient{
+		conn:   conn,
+		client: userpb.NewUserServiceClient(conn),
+	}, nil
+}
+
+func (c *UserClient) Close() error {
+	return c.conn.Close()
+}
+
+// ExplicitContentFiltered сообщает, включен ли фильтр explicit-контента у
+// пользователя, чей токен пришел в метаданных authorization входящего
+// запроса. Токен передается в user-service как есть; запрос без токена
+// анонимный, и фильтр к нему не применяется.
+func (c *UserClient) ExplicitContentFiltered(ctx context.Context) (bool, error) {
+	auth := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(auth) == 0 {
+		return false, nil
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth[0])
+	prefs, err := c.client.GetCurrentPreferences(ctx, &userpb.CurrentUserRequest{})
+	if err != nil {
+		return false, err
+	}
+	return prefs.GetExplicitContentFilter(), nil
+}
+
+// MaxAudioQuality возвращает max_audio_quality тарифа пользователя, чей токен
+// пришел в метаданных authorization входящего запроса. Токен проверяет
+// user-service; без токена возвращается Unauthenticated.
+func (c *UserClient) MaxAudioQuality(ctx context.Context) (string, error) {
+	auth := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(auth) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth[0])
+	entitlements, err := c.client.GetCurrentEntitlements(ctx, &userpb.CurrentUserRequest{})
+	if err != nil {
+		return "", err
+	}
+	return entitlements.GetMaxAudioQuality(), nil
+}
@@ -0,0 +1,112 @@
+// Package events публикует события каталога из outbox в NATS JetStream
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SchemaVersion — версия формата TrackEvent. Меняется, только если старые
+// подписчики не смогут разобрать новые события.
+const SchemaVersion = 1
+
+// Subjects событий о треках
+const (
+	SubjectTrackCreated = "track.created"
+	SubjectTrackUpdated = "track.updated"
+	SubjectTrackDeleted = "track.deleted"
+)
+
+// StreamName — поток JetStream, в котором хранятся события о треках
+const StreamName = "TRACK_EVENTS"
+
+// StreamConfig — настройки потока. Окно дубликатов покрывает повторную
+// публикацию после падения между публикацией и отметкой в outbox.
+var StreamConfig = jetstream.StreamConfig{
+	Name:       StreamName,
+	Subjects:   []string{"track.>"},
+	Storage:    jetstream.FileStorage,
+	MaxAge:     7 * 24 * time.Hour,
+	Duplicates: 10 * time.Minute,
+}
+
+// publishBatch — сколько событий читается из outbox за раз
+const publishBatch = 100
+
+// Outbox — хранилище событий, ожидающих публикации
+type Outbox interface {
+	Pending(ctx context.Context, limit int64) ([]models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id primitive.ObjectID) error
+}
+
+// Relay переносит события из outbox в JetStream. Событие отмечается
+// опубликованным только после подтверждения от сервера, поэтому может уйти
+// дважды; JetStream отбрасывает повтор по заголовку Nats-Msg-Id.
+type Relay struct {
+	outbox      Outbox
+	js          jetstream.JetStream
+	streamReady bool
+}
+
+func NewRelay(outbox Outbox, js jetstream.JetStream) *Relay {
+	return &Relay{outbox: outbox, js: js}
+}
+
+// Run публикует события каждые interval, пока ctx не отменен. Недоступность
+// NATS только журналируется: события дождутся в outbox.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Publish(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("event relay: %v", err)
+			}
+		}
+	}
+}
+
+// Publish публикует ожидающие события в порядке записи и возвращает их
+// число. На первой ошибке останавливается, чтобы события одного трека не
+// обогнали друг друга.
+func (r *Relay) Publish(ctx context.Context) (int, error) {
+	if !r.streamReady {
+		if _, err := r.js.CreateOrUpdateStream(ctx, StreamConfig); err != nil {
+			return 0, err
+		}
+		r.streamReady = true
+	}
+
+	published := 0
+	for {
+		pending, err := r.outbox.Pending(ctx, publishBatch)
+		if err != nil {
+			return published, err
+		}
+		for _, e := range pending {
+			msg := nats.NewMsg(e.Subject)
+			msg.Data = e.Payload
+			msg.Header.Set(jetstream.MsgIDHeader, e.ID.Hex())
+			if _, err := r.js.PublishMsg(ctx, msg); err != nil {
+				return published, err
+			}
+			if err := r.outbox.MarkPublished(ctx, e.ID); err != nil {
+				return published, err
+			}
+			published++
+		}
+		if len(pending) < publishBatch {
+			return published, nil
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memOutbox — outbox в памяти. failMark заставляет MarkPublished вернуть
+// ошибку, как при падении между публикацией и отметкой.
+type memOutbox struct {
+	mu       sync.Mutex
+	events   []models.OutboxEvent
+	failMark bool
+}
+
+func (o *memOutbox) add(subject string, payload string) primitive.ObjectID {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	id := primitive.NewObjectID()
+	o.events = append(o.events, models.OutboxEvent{ID: id, Subject: subject, Payload: []byte(payload)})
+	return id
+}
+
+func (o *memOutbox) Pending(ctx context.Context, limit int64) ([]models.OutboxEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var pending []models.OutboxEvent
+	for _, e := range o.events {
+		if !e.Published && int64(len(pending)) < limit {
+			pending = append(pending, e)
+		}
+	}
+	return pending, nil
+}
+
+func (o *memOutbox) MarkPublished(ctx context.Context, id primitive.ObjectID) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.failMark {
+		return errors.New("outbox unavailable")
+	}
+	for i := range o.events {
+		if o.events[i].ID == id {
+			o.events[i].Published = true
+		}
+	}
+	return nil
+}
+
+func (o *memOutbox) pendingCount() int {
+	pending, _ := o.Pending(context.Background(), 1<<20)
+	return len(pending)
+}
+
+// runServer запускает встроенный NATS-сервер с JetStream
+func runServer(t *testing.T) *server.Server {
+	t.Helper()
+	s, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatalf("start nats: %v", err)
+	}
+	go s.Start()
+	if !s.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats is not ready")
+	}
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+func connect(t *testing.T, s *server.Server) jetstream.JetStream {
+	t.Helper()
+	nc, err := nats.Connect(s.ClientURL())
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	js, err := jetstream.New(nc)
+	if err != nil {
+		t.Fatalf("jetstream: %v", err)
+	}
+	return js
+}
+
+func TestRelayPublishesInOrder(t *testing.T) {
+	js := connect(t, runServer(t))
+	ctx := context.Background()
+
+	outbox := &memOutbox{}
+	first := outbox.add(SubjectTrackCreated, "created")
+	outbox.add(SubjectTrackUpdated, "updated")
+	outbox.add(SubjectTrackDeleted, "deleted")
+
+	n, err := NewRelay(outbox, js).Publish(ctx)
+	if err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if n != 3 || outbox.pendingCount() != 0 {
+		t.Fatalf("published %d, pending %d; want 3 and 0", n, outbox.pendingCount())
+	}
+
+	stream, err := js.Stream(ctx, StreamName)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	want := []struct{ subject, data string }{
+		{SubjectTrackCreated, "created"},
+		{SubjectTrackUpdated, "updated"},
+		{SubjectTrackDeleted, "deleted"},
+	}
+	for i, w := range want {
+		msg, err := stream.GetMsg(ctx, uint64(i+1))
+		if err != nil {
+			t.Fatalf("get message %d: %v", i+1, err)
+		}
+		if msg.Subject != w.subject || string(msg.Data) != w.data {
+			t.Errorf("message %d = %s %q, want %s %q", i+1, msg.Subject, msg.Data, w.subject, w.data)
+		}
+	}
+	msg, _ := stream.GetMsg(ctx, 1)
+	if got := msg.Header.Get(jetstream.MsgIDHeader); got != first.Hex() {
+		t.Errorf("Nats-Msg-Id = %q, want %q", got, first.Hex())
+	}
+}
+
+func TestRelayRepublishIsDeduplicated(t *testing.T) {
+	js := connect(t, runServer(t))
+	ctx := context.Background()
+
+	outbox := &memOutbox{failMark: true}
+	outbox.add(SubjectTrackCreated, "created")
+	relay := NewRelay(outbox, js)
+
+	// Событие ушло в NATS, но отметка в outbox не сохранилась
+	if _, err := relay.Publish(ctx); err == nil {
+		t.Fatal("publish succeeded with a failing outbox")
+	}
+	if outbox.pendingCount() != 1 {
+		t.Fatal("event is not pending after a failed mark")
+	}
+
+	outbox.failMark = false
+	if n, err := relay.Publish(ctx); err != nil || n != 1 {
+		t.Fatalf("republish: %d, %v", n, err)
+	}
+
+	stream, err := js.Stream(ctx, StreamName)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		t.Fatalf("stream info: %v", err)
+	}
+	if info.State.Msgs != 1 {
+		t.Errorf("stream has %d messages, want 1", info.State.Msgs)
+	}
+}
+
+func TestRelayKeepsEventsWhileNATSIsDown(t *testing.T) {
+	s := runServer(t)
+	js := connect(t, s)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	outbox := &memOutbox{}
+	relay := NewRelay(outbox, js)
+	if _, err := relay.Publish(ctx); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	s.Shutdown()
+	outbox.add(SubjectTrackUpdated, "updated")
+	downCtx, cancelDown := context.WithTimeout(ctx, time.Second)
+	defer cancelDown()
+	if _, err := relay.Publish(downCtx); err == nil {
+		t.Fatal("publish succeeded without NATS")
+	}
+	if outbox.pendingCount() != 1 {
+		t.Errorf("pending = %d, want 1", outbox.pendingCount())
+	}
+}
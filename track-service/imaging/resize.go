@@ -0,0 +1,104 @@
+// Package imaging уменьшает картинки обложек без внешних зависимостей
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// Fit уменьшает img так, чтобы большая сторона стала равна size, сохраняя
+// пропорции. Прозрачные области заливаются белым: превью кодируются в JPEG,
+// где альфа-канала нет.
+func Fit(img image.Image, size int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := FitSize(w, h, size)
+
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Over)
+
+	// Сначала по горизонтали во временный буфер, затем по вертикали
+	tmp := make([]float32, dw*h*3)
+	for y := 0; y < h; y++ {
+		row := src.Pix[y*src.Stride:]
+		for x, c := range contributions(w, dw) {
+			var r, g, bl float32
+			for i, weight := range c.weights {
+				p := row[(c.start+i)*4:]
+				r += weight * float32(p[0])
+				g += weight * float32(p[1])
+				bl += weight * float32(p[2])
+			}
+			t := tmp[(y*dw+x)*3:]
+			t[0], t[1], t[2] = r, g, bl
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y, c := range contributions(h, dh) {
+		for x := 0; x < dw; x++ {
+			var r, g, bl float32
+			for i, weight := range c.weights {
+				t := tmp[((c.start+i)*dw+x)*3:]
+				r += weight * t[0]
+				g += weight * t[1]
+				bl += weight * t[2]
+			}
+			p := dst.Pix[y*dst.Stride+x*4:]
+			p[0], p[1], p[2], p[3] = clamp(r), clamp(g), clamp(bl), 0xFF
+		}
+	}
+	return dst
+}
+
+// FitSize — размеры картинки w×h после Fit
+func FitSize(w, h, size int) (int, int) {
+	if w >= h {
+		return size, max(1, int(math.Round(float64(h)*float64(size)/float64(w))))
+	}
+	return max(1, int(math.Round(float64(w)*float64(size)/float64(h)))), size
+}
+
+// contribution — веса исходных пикселей start, start+1, ... для одного
+// пикселя результата
+type contribution struct {
+	start   int
+	weights []float32
+}
+
+// contributions считает веса треугольного фильтра для сжатия src пикселей
+// в dst. При уменьшении фильтр растягивается на scale исходных пикселей,
+// поэтому каждый исходный пиксель учитывается и мелкие детали не дают муара.
+func contributions(src, dst int) []contribution {
+	scale := float64(src) / float64(dst)
+	radius := math.Max(scale, 1)
+	list := make([]contribution, dst)
+	for i := range list {
+		center := (float64(i)+0.5)*scale - 0.5
+		start := max(int(math.Ceil(center-radius)), 0)
+		end := min(int(math.Floor(center+radius)), src-1)
+
+		weights := make([]float32, 0, end-start+1)
+		var sum float64
+		for j := start; j <= end; j++ {
+			w := math.Max(1-math.Abs(float64(j)-center)/radius, 0)
+			weights = append(weights, float32(w))
+			sum += w
+		}
+		if sum == 0 {
+			start, weights, sum = min(max(int(math.Round(center)), 0), src-1), []float32{1}, 1
+		}
+		for j := range weights {
+			weights[j] /= float32(sum)
+		}
+		list[i] = contribution{start: start, weights: weights}
+	}
+	return list
+}
+
+func clamp(v float32) uint8 {
+	return uint8(min(max(v+0.5, 0), 255))
+}
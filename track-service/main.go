@@ -9,13 +9,19 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/clients"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/events"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/migrations"
 	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
 	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
 	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/services"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/storage"
 	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/telemetry"
 )
 
@@ -28,8 +34,13 @@ func main() {
 		}
 	}()
 
-	// Подключение к MongoDB
-	mongoURI := "mongodb://localhost:27017"
+	// Подключение к MongoDB. События каталога пишутся в outbox в одной
+	// транзакции с изменениями, а транзакциям нужен набор реплик: на
+	// одиночном сервере изменения треков будут завершаться ошибкой.
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		mongoURI = "mongodb://localhost:27017/?replicaSet=rs0"
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,20 +55,98 @@ func main() {
 	}()
 
 	db := client.Database("trackdb")
+
+	// Миграции схемы (индексы, заполнение новых полей) до начала обслуживания
+	migrateCtx, cancelMigrate := context.WithTimeout(context.Background(), 10*time.Minute)
+	if err := migrations.Run(migrateCtx, db, migrations.All); err != nil {
+		log.Fatalf("failed to migrate trackdb: %v", err)
+	}
+	cancelMigrate()
+
 	trackRepo := repositories.NewTrackRepo(db)
-	trackService := services.NewTrackGRPCService(trackRepo)
+	artistRepo := repositories.NewArtistRepo(db)
+	albumRepo := repositories.NewAlbumRepo(db)
+	outboxRepo := repositories.NewOutboxRepo(db)
+
+	// Порты по умолчанию совпадают с адресами в api_gateway:
+	// user-service — 50051, playlist-service — 50052, track-service — 50053
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50053"
+	}
+
+	// Клиент user-service для настроек слушателей
+	userServiceAddr := os.Getenv("USER_SERVICE_ADDR")
+	if userServiceAddr == "" {
+		userServiceAddr = "localhost:50051"
+	}
+	if userServiceAddr == "localhost:"+grpcPort || userServiceAddr == ":"+grpcPort {
+		log.Fatalf("USER_SERVICE_ADDR %s points at this service's own GRPC_PORT", userServiceAddr)
+	}
+	userClient, err := clients.NewUserClient(userServiceAddr)
+	if err != nil {
+		log.Fatalf("failed to create user-service client: %v", err)
+	}
+	defer userClient.Close()
+
+	// Индекс подсказок строится в памяти по всему каталогу
+	loadCtx, cancelLoad := context.WithTimeout(context.Background(), time.Minute)
+	suggestIndex, err := services.LoadSuggestIndex(loadCtx, trackRepo)
+	cancelLoad()
+	if err != nil {
+		log.Fatalf("failed to build the suggest index: %v", err)
+	}
+
+	// Хранилище аудиофайлов
+	audioDir := os.Getenv("AUDIO_DIR")
+	if audioDir == "" {
+		audioDir = "audio"
+	}
+	blobStore, err := storage.NewLocalBlobStore(audioDir)
+	if err != nil {
+		log.Fatalf("failed to init the audio storage: %v", err)
+	}
+
+	trackService := services.NewTrackGRPCService(trackRepo, artistRepo, albumRepo, userClient, suggestIndex, blobStore, outboxRepo)
+	artistService := services.NewArtistGRPCService(artistRepo, albumRepo, trackRepo, suggestIndex, outboxRepo)
+	albumService := services.NewAlbumGRPCService(albumRepo, artistRepo, trackRepo, userClient, suggestIndex, blobStore, outboxRepo)
+
+	// Подключение к NATS для событий каталога. Пока NATS недоступен, события
+	// копятся в outbox и отправляются после переподключения.
+	natsURL := os.Getenv("NATS_URL")
+	if natsURL == "" {
+		natsURL = nats.DefaultURL
+	}
+	nc, err := nats.Connect(natsURL, nats.Name("track-service"), nats.RetryOnFailedConnect(true), nats.MaxReconnects(-1))
+	if err != nil {
+		log.Fatalf("failed to connect to NATS: %v", err)
+	}
+	defer nc.Close()
+	js, err := jetstream.New(nc)
+	if err != nil {
+		log.Fatalf("failed to init JetStream: %v", err)
+	}
+
+	// Фоновые задачи: анализ загруженного аудио (форма волны, громкость) и
+	// публикация событий из outbox
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go trackService.RunAnalysisWorker(backgroundCtx, time.Minute)
+	go events.NewRelay(outboxRepo, js).Run(backgroundCtx, time.Second)
 
 	// Запуск gRPC-сервера
 	grpcServer := grpc.NewServer()
 	pb.RegisterTrackServiceServer(grpcServer, trackService)
+	pb.RegisterArtistServiceServer(grpcServer, artistService)
+	pb.RegisterAlbumServiceServer(grpcServer, albumService)
 
-	listener, err := net.Listen("tcp", ":50052")
+	listener, err := net.Listen("tcp", ":"+grpcPort)
 	if err != nil {
-		log.Fatalf("failed to listen on port 50052: %v", err)
+		log.Fatalf("failed to listen on port %s: %v", grpcPort, err)
 	}
 
 	go func() {
-		log.Println("Track gRPC service is running on port 50052...")
+		log.Printf("Track gRPC service is running on port %s...", grpcPort)
 		if err := grpcServer.Serve(listener); err != nil {
 			log.Fatalf("failed to serve gRPC: %v", err)
 		}
@@ -70,4 +159,5 @@ func main() {
 	log.Println("Shutting down track gRPC service...")
 
 	grpcServer.GracefulStop()
+	stopBackground()
 }
@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Migration — одно изменение схемы или данных. Примененные миграции
+// записываются в коллекцию schema_migrations и больше не выполняются.
+// Up должна быть идемпотентной: если сервис упадет между Up и записью
+// о применении, миграция выполнится еще раз.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+type appliedMigration struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// All — миграции сервиса треков по порядку версий
+var All = []Migration{
+	{Version: 1, Description: "create the tracks text index", Up: createTrackTextIndex},
+	{Version: 2, Description: "backfill track search keys", Up: backfillTrackSearchKeys},
+	{Version: 3, Description: "backfill track popularity", Up: backfillTrackPopularity},
+	{Version: 4, Description: "create the tracks sort indexes", Up: createTrackSortIndexes},
+	{Version: 5, Description: "backfill track metadata defaults", Up: backfillTrackMetadata},
+	{Version: 6, Description: "create the tracks metadata indexes", Up: createTrackMetadataIndexes},
+	{Version: 7, Description: "create the artists and albums indexes", Up: createCatalogIndexes},
+	{Version: 8, Description: "link tracks to deduplicated artists and albums", Up: linkTracksToCatalog},
+	{Version: 9, Description: "create the tracks audio index", Up: createTrackAudioIndexes},
+	{Version: 10, Description: "create the tracks cover index", Up: createTrackCoverIndexes},
+	{Version: 11, Description: "create the tracks analysis index", Up: createTrackAnalysisIndexes},
+	{Version: 12, Description: "queue uploaded audio for analysis", Up: queueTrackAnalyses},
+	{Version: 13, Description: "create the albums cover index", Up: createAlbumCoverIndexes},
+	{Version: 14, Description: "create the outbox indexes", Up: createOutboxIndexes},
+}
+
+// Run применяет еще не примененные миграции по возрастанию версий
+func Run(ctx context.Context, db *mongo.Database, migrations []Migration) error {
+	coll := db.Collection("schema_migrations")
+
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	var applied []appliedMigration
+	if err := cursor.All(ctx, &applied); err != nil {
+		return err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, m := range applied {
+		done[m.Version] = true
+	}
+
+	pending := append([]Migration(nil), migrations...)
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+
+	for _, m := range pending {
+		if done[m.Version] {
+			continue
+		}
+		log.Printf("applying migration %d: %s", m.Version, m.Description)
+		if err := m.Up(ctx, db); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Description, err)
+		}
+		if _, err := coll.InsertOne(ctx, appliedMigration{
+			Version:     m.Version,
+			Description: m.Description,
+			AppliedAt:   time.Now(),
+		}); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("record migration %d: %w", m.Version, err)
+		}
+	}
+	return nil
+}
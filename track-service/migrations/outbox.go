@@ -0,0 +1,12 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func createOutboxIndexes(ctx context.Context, db *mongo.Database) error {
+	return repositories.NewOutboxRepo(db).EnsureIndexes(ctx)
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+	"log"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func createTrackAnalysisIndexes(ctx context.Context, db *mongo.Database) error {
+	return repositories.NewTrackRepo(db).EnsureAnalysisIndexes(ctx)
+}
+
+func queueTrackAnalyses(ctx context.Context, db *mongo.Database) error {
+	queued, err := repositories.NewTrackRepo(db).QueueTrackAnalyses(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("tracks queued for audio analysis: %v", queued)
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func createTrackCoverIndexes(ctx context.Context, db *mongo.Database) error {
+	return repositories.NewTrackRepo(db).EnsureCoverIndexes(ctx)
+}
+
+func createAlbumCoverIndexes(ctx context.Context, db *mongo.Database) error {
+	return repositories.NewAlbumRepo(db).EnsureCoverIndexes(ctx)
+}
@@ -0,0 +1,115 @@
+package migrations
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func createCatalogIndexes(ctx context.Context, db *mongo.Database) error {
+	if err := repositories.NewArtistRepo(db).EnsureIndexes(ctx); err != nil {
+		return err
+	}
+	if err := repositories.NewAlbumRepo(db).EnsureIndexes(ctx); err != nil {
+		return err
+	}
+	return repositories.NewTrackRepo(db).EnsureLinkIndexes(ctx)
+}
+
+// linkTracksToCatalog заводит исполнителей и альбомы по текстовым именам в
+// треках и связывает с ними треки. Имена, различающиеся только регистром и
+// пробелами, сводятся к одной записи; ее имя — самое частое написание.
+func linkTracksToCatalog(ctx context.Context, db *mongo.Database) error {
+	tracks := repositories.NewTrackRepo(db)
+	artists := repositories.NewArtistRepo(db)
+	albums := repositories.NewAlbumRepo(db)
+
+	unlinked, err := tracks.GetAllTracks(ctx, bson.M{"artists": bson.M{"$exists": false}}, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	artistNames := spellings{}
+	albumTitles := map[string]spellings{}
+	for _, t := range unlinked {
+		artistKey := models.NameKey(t.Artist)
+		artistNames.add(artistKey, t.Artist)
+		if albumTitles[artistKey] == nil {
+			albumTitles[artistKey] = spellings{}
+		}
+		albumTitles[artistKey].add(models.NameKey(t.Album), t.Album)
+	}
+
+	artistByKey := map[string]models.Artist{}
+	albumByKey := map[[2]string]models.Album{}
+	for artistKey, name := range artistNames.best() {
+		artist, err := artists.FindOrCreateArtist(ctx, name)
+		if err != nil {
+			return err
+		}
+		artistByKey[artistKey] = artist
+		for albumKey, title := range albumTitles[artistKey].best() {
+			album, err := albums.FindOrCreateAlbum(ctx, artist, title)
+			if err != nil {
+				return err
+			}
+			albumByKey[[2]string{artistKey, albumKey}] = album
+		}
+	}
+
+	for _, t := range unlinked {
+		artistKey, albumKey := models.NameKey(t.Artist), models.NameKey(t.Album)
+		update := bson.M{"artists": []models.ArtistCredit{}}
+		if artist, ok := artistByKey[artistKey]; ok {
+			update["artists"] = []models.ArtistCredit{{ID: artist.ID, Name: artist.Name}}
+			update["artist"] = artist.Name
+			if album, ok := albumByKey[[2]string{artistKey, albumKey}]; ok {
+				update["album_id"] = album.ID
+				update["album"] = album.Title
+			}
+		}
+		if err := tracks.UpdateTrack(ctx, t.ID, update); err != nil {
+			return err
+		}
+	}
+	log.Printf("%d tracks linked to %d artists and %d albums", len(unlinked), len(artistByKey), len(albumByKey))
+	return nil
+}
+
+// spellings считает написания имен с одинаковым ключом
+type spellings map[string]map[string]int
+
+func (s spellings) add(key, name string) {
+	if key == "" {
+		return
+	}
+	if s[key] == nil {
+		s[key] = map[string]int{}
+	}
+	s[key][models.CleanName(name)]++
+}
+
+// best возвращает самое частое написание для каждого ключа; при равенстве
+// выбирается первое по алфавиту, чтобы повторный запуск дал тот же результат
+func (s spellings) best() map[string]string {
+	best := make(map[string]string, len(s))
+	for key, counts := range s {
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if counts[names[i]] != counts[names[j]] {
+				return counts[names[i]] > counts[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		best[key] = names[0]
+	}
+	return best
+}
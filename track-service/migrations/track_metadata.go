@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+	"log"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func backfillTrackMetadata(ctx context.Context, db *mongo.Database) error {
+	updated, err := repositories.NewTrackRepo(db).BackfillMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("metadata defaults added to tracks: %v", updated)
+	return nil
+}
+
+func createTrackMetadataIndexes(ctx context.Context, db *mongo.Database) error {
+	return repositories.NewTrackRepo(db).EnsureMetadataIndexes(ctx)
+}
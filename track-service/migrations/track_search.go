@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+	"log"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func createTrackTextIndex(ctx context.Context, db *mongo.Database) error {
+	return repositories.NewTrackRepo(db).EnsureTextIndex(ctx)
+}
+
+func backfillTrackSearchKeys(ctx context.Context, db *mongo.Database) error {
+	updated, err := repositories.NewTrackRepo(db).BackfillSearchKeys(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("search keys added to %d tracks", updated)
+	return nil
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+	"log"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func backfillTrackPopularity(ctx context.Context, db *mongo.Database) error {
+	updated, err := repositories.NewTrackRepo(db).BackfillPopularity(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("popularity added to %d tracks", updated)
+	return nil
+}
+
+func createTrackSortIndexes(ctx context.Context, db *mongo.Database) error {
+	return repositories.NewTrackRepo(db).EnsureSortIndexes(ctx)
+}
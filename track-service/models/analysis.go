@@ -0,0 +1,28 @@
+package models
+
+// Состояния анализа аудиофайла трека
+const (
+	AnalysisPending     = "pending"     // Ждет фонового обработчика
+	AnalysisRunning     = "running"     // Обработчик взял файл в работу
+	AnalysisDone        = "done"        // Результат записан
+	AnalysisFailed      = "failed"      // Файл не удалось декодировать за все попытки
+	AnalysisUnsupported = "unsupported" // Формат не декодируется (сжатие с потерями)
+)
+
+// AnalysableFormats — форматы, которые умеет декодировать анализатор
+var AnalysableFormats = []string{"wav", "flac"}
+
+// TrackAnalysis — результат анализа аудиофайла: огибающая пиков для
+// отрисовки и громкость по EBU R128. AudioKey — ключ разобранного файла:
+// если за время анализа загрузили другой файл, результат не записывается.
+type TrackAnalysis struct {
+	Status         string  `bson:"status"`
+	AudioKey       string  `bson:"audio_key"`
+	Waveform       []byte  `bson:"waveform,omitempty"`
+	IntegratedLUFS float64 `bson:"integrated_lufs"`
+	TruePeakDBTP   float64 `bson:"true_peak_dbtp"`
+	Attempts       int     `bson:"attempts"`
+	Error          string  `bson:"error,omitempty"`
+	StartedAt      int64   `bson:"started_at,omitempty"`  // Unix-время взятия в работу
+	AnalyzedAt     int64   `bson:"analyzed_at,omitempty"` // Unix-время записи результата
+}
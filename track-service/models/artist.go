@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/text/unicode/norm"
+)
+
+type Artist struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Name      string             `bson:"name"`
+	NameKey   string             `bson:"name_key"` // См. NameKey
+	CreatedAt int64              `bson:"created_at"`
+}
+
+type Album struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Title       string             `bson:"title"`
+	TitleKey    string             `bson:"title_key"` // См. NameKey
+	ArtistID    primitive.ObjectID `bson:"artist_id"`
+	Artist      string             `bson:"artist"` // Копия имени исполнителя
+	ReleaseDate string             `bson:"release_date"`
+	CreatedAt   int64              `bson:"created_at"`
+	Cover       *Artwork           `bson:"cover,omitempty"` // nil — обложки нет
+}
+
+// ArtistCredit — исполнитель трека. Имя копируется из Artist, чтобы трек
+// отображался без дополнительных запросов.
+type ArtistCredit struct {
+	ID       primitive.ObjectID `bson:"id"`
+	Name     string             `bson:"name"`
+	Featured bool               `bson:"featured"`
+}
+
+// NameKey — ключ, по которому одинаковые имена исполнителей и названия
+// альбомов считаются одним и тем же: "Queen" и "queen " совпадают
+func NameKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(norm.NFC.String(name))), " ")
+}
+
+// CleanName убирает лишние пробелы в имени, сохраняя регистр
+func CleanName(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
@@ -0,0 +1,28 @@
+package models
+
+// Источники обложки
+const (
+	ArtworkEmbedded = "embedded" // Извлечена из тегов аудиофайла
+	ArtworkUploaded = "uploaded" // Загружена отдельно от аудио
+)
+
+// Artwork — картинка обложки. Как и аудиофайл, хранится под ключом из
+// SHA-256 содержимого.
+type Artwork struct {
+	Key        string      `bson:"key"`
+	SHA256     string      `bson:"sha256"`
+	MimeType   string      `bson:"mime_type"`
+	Width      int         `bson:"width"`
+	Height     int         `bson:"height"`
+	Size       int64       `bson:"size"`
+	Source     string      `bson:"source"`
+	Thumbnails []Thumbnail `bson:"thumbnails,omitempty"` // По возрастанию Size
+}
+
+// Thumbnail — уменьшенная копия обложки в JPEG. Size — большая сторона.
+type Thumbnail struct {
+	Size   int    `bson:"size"`
+	Key    string `bson:"key"`
+	Width  int    `bson:"width"`
+	Height int    `bson:"height"`
+}
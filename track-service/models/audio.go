@@ -0,0 +1,18 @@
+package models
+
+// AudioFile — загруженный аудиофайл трека. Файл хранится под ключом,
+// построенным из SHA-256 содержимого, поэтому одинаковые файлы хранятся один
+// раз, даже если загружены для разных треков.
+type AudioFile struct {
+	Key        string `bson:"key"`
+	SHA256     string `bson:"sha256"`
+	Size       int64  `bson:"size"`
+	Format     string `bson:"format"`
+	Codec      string `bson:"codec"`
+	MimeType   string `bson:"mime_type"`
+	Bitrate    int    `bson:"bitrate"`
+	SampleRate int    `bson:"sample_rate"`
+	Channels   int    `bson:"channels"`
+	DurationMs int64  `bson:"duration_ms"`
+	UploadedAt int64  `bson:"uploaded_at"`
+}
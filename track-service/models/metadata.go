@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// ReleaseDateLayout — формат даты релиза; в базе дата хранится строкой,
+// поэтому сравнение строк совпадает со сравнением дат
+const ReleaseDateLayout = "2006-01-02"
+
+const (
+	maxGenres      = 10
+	maxGenreLength = 40
+	maxTrackNumber = 999
+	maxBPM         = 400
+)
+
+var (
+	ErrInvalidISRC        = errors.New("isrc must look like CC-XXX-YY-NNNNN")
+	ErrInvalidReleaseDate = errors.New("release date must be YYYY-MM-DD")
+	ErrInvalidLanguage    = errors.New("language must be an ISO 639 code")
+	ErrInvalidKey         = errors.New("key must look like C, F#, Bb or Ebm")
+	ErrInvalidBPM         = errors.New("bpm must be between 0 and 400")
+	ErrInvalidTrackNumber = errors.New("disc and track numbers must be between 0 and 999")
+	ErrTooManyGenres      = errors.New("a track can have at most 10 genres of up to 40 characters")
+)
+
+// isrcPattern — код страны, код регистранта, год и номер записи
+var isrcPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$`)
+
+// NormalizeISRC проверяет ISRC и приводит его к записи без дефисов
+// в верхнем регистре: "us-rc1-76-07839" → "USRC17607839"
+func NormalizeISRC(s string) (string, error) {
+	isrc := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+	if !isrcPattern.MatchString(isrc) {
+		return "", ErrInvalidISRC
+	}
+	return isrc, nil
+}
+
+// NormalizeReleaseDate проверяет дату релиза в формате YYYY-MM-DD
+func NormalizeReleaseDate(s string) (string, error) {
+	date, err := time.Parse(ReleaseDateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return "", ErrInvalidReleaseDate
+	}
+	return date.Format(ReleaseDateLayout), nil
+}
+
+// NormalizeLanguage приводит код языка исполнения к ISO 639 ("RU" → "ru");
+// для инструментальных треков используется "zxx"
+func NormalizeLanguage(s string) (string, error) {
+	base, err := language.ParseBase(strings.TrimSpace(s))
+	if err != nil {
+		return "", ErrInvalidLanguage
+	}
+	return base.String(), nil
+}
+
+// NormalizeKey приводит тональность к записи вида "C", "F#", "Bb", "Ebm"
+func NormalizeKey(s string) (string, error) {
+	key := strings.TrimSpace(s)
+	if key == "" {
+		return "", ErrInvalidKey
+	}
+	note := strings.ToUpper(key[:1])
+	if !strings.Contains("ABCDEFG", note) {
+		return "", ErrInvalidKey
+	}
+	rest := key[1:]
+	accidental := ""
+	if strings.HasPrefix(rest, "#") || strings.HasPrefix(rest, "b") {
+		accidental, rest = rest[:1], rest[1:]
+	}
+	switch rest {
+	case "":
+		return note + accidental, nil
+	case "m":
+		return note + accidental + "m", nil
+	default:
+		return "", ErrInvalidKey
+	}
+}
+
+// NormalizeGenres приводит жанры к нижнему регистру и убирает пустые и повторы
+func NormalizeGenres(genres []string) ([]string, error) {
+	seen := make(map[string]bool, len(genres))
+	normalized := make([]string, 0, len(genres))
+	for _, g := range genres {
+		g = strings.ToLower(strings.Join(strings.Fields(g), " "))
+		if g == "" || seen[g] {
+			continue
+		}
+		if len([]rune(g)) > maxGenreLength {
+			return nil, ErrTooManyGenres
+		}
+		seen[g] = true
+		normalized = append(normalized, g)
+	}
+	if len(normalized) > maxGenres {
+		return nil, ErrTooManyGenres
+	}
+	return normalized, nil
+}
+
+// ValidateBPM проверяет темп трека; 0 — темп неизвестен
+func ValidateBPM(bpm float64) error {
+	if bpm < 0 || bpm > maxBPM {
+		return ErrInvalidBPM
+	}
+	return nil
+}
+
+// ValidateTrackNumber проверяет номер диска или трека; 0 — номер неизвестен
+func ValidateTrackNumber(n int32) error {
+	if n < 0 || n > maxTrackNumber {
+		return ErrInvalidTrackNumber
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxEvent — событие, записанное в одной транзакции с изменением, которое
+// его вызвало. Публикует его отдельный процесс, поэтому падение сервиса между
+// записью в базу и публикацией событие не теряет.
+type OutboxEvent struct {
+	ID        primitive.ObjectID `bson:"_id"` // Также идентификатор события для подписчиков
+	Subject   string             `bson:"subject"`
+	Payload   []byte             `bson:"payload"`
+	CreatedAt int64              `bson:"created_at"`
+	Published bool               `bson:"published"`
+	// Дата BSON, а не Unix-время: по ней TTL-индекс удаляет старые события
+	PublishedAt *time.Time `bson:"published_at,omitempty"`
+}
@@ -1,12 +1,54 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/search"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type Track struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	Title     string             `bson:"title"`
-	Artist    string             `bson:"artist"`
-	Album     string             `bson:"album"`
-	Duration  int32              `bson:"duration_sec"`
-	CreatedAt int64              `bson:"created_at"`
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Title      string             `bson:"title"`
+	Artist     string             `bson:"artist"`
+	Album      string             `bson:"album"`
+	Duration   int32              `bson:"duration_sec"`
+	Explicit   bool               `bson:"explicit"`
+	CreatedAt  int64              `bson:"created_at"`
+	Popularity int64              `bson:"popularity"` // Число прослушиваний
+	Version    int64              `bson:"version"`    // Растет с каждым изменением, о котором публикуется событие
+	Search     SearchKeys         `bson:"search"`
+
+	// Artist и Album — копии имени основного исполнителя и названия альбома
+	// для отображения и поиска; ссылки — в Artists и AlbumID
+	Artists []ArtistCredit     `bson:"artists"` // Основной исполнитель первым
+	AlbumID primitive.ObjectID `bson:"album_id,omitempty"`
+
+	Audio    *AudioFile     `bson:"audio,omitempty"`    // nil — аудио еще не загружено
+	Cover    *Artwork       `bson:"cover,omitempty"`    // nil — обложки нет
+	Analysis *TrackAnalysis `bson:"analysis,omitempty"` // Анализ текущего аудиофайла
+
+	Genres      []string `bson:"genres"`
+	ReleaseDate string   `bson:"release_date"` // YYYY-MM-DD, см. ReleaseDateLayout
+	ISRC        string   `bson:"isrc"`         // Без дефисов, см. NormalizeISRC
+	DiscNumber  int32    `bson:"disc_number"`
+	TrackNumber int32    `bson:"track_number"`
+	Language    string   `bson:"language"` // ISO 639, "zxx" — без слов
+	BPM         float64  `bson:"bpm"`
+	Key         string   `bson:"key"` // Тональность: "C", "F#", "Ebm"
+}
+
+// SearchKeys — латинская запись текстовых полей трека для полнотекстового
+// поиска: по ней кириллические треки находятся латиницей и наоборот
+type SearchKeys struct {
+	Title  string `bson:"title"`
+	Artist string `bson:"artist"`
+	Album  string `bson:"album"`
+}
+
+// NewSearchKeys строит ключи поиска по названию, исполнителю и альбому
+func NewSearchKeys(title, artist, album string) SearchKeys {
+	return SearchKeys{
+		Title:  search.Latin(title),
+		Artist: search.Latin(artist),
+		Album:  search.Latin(album),
+	}
 }
@@ -22,13 +22,29 @@ const (
 )
 
 type Track struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Artist        string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
-	Album         string                 `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
-	DurationSec   int32                  `protobuf:"varint,5,opt,name=duration_sec,json=durationSec,proto3" json:"duration_sec,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Artist      string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album       string                 `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
+	DurationSec int32                  `protobuf:"varint,5,opt,name=duration_sec,json=durationSec,proto3" json:"duration_sec,omitempty"`
+	CreatedAt   int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Explicit    bool                   `protobuf:"varint,7,opt,name=explicit,proto3" json:"explicit,omitempty"`
+	Popularity  int64                  `protobuf:"varint,8,opt,name=popularity,proto3" json:"popularity,omitempty"` // Число прослушиваний
+	Genres      []string               `protobuf:"bytes,9,rep,name=genres,proto3" json:"genres,omitempty"`
+	ReleaseDate string                 `protobuf:"bytes,10,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"` // YYYY-MM-DD
+	Isrc        string                 `protobuf:"bytes,11,opt,name=isrc,proto3" json:"isrc,omitempty"`                                  // Например US-RC1-76-07839; хранится без дефисов
+	DiscNumber  int32                  `protobuf:"varint,12,opt,name=disc_number,json=discNumber,proto3" json:"disc_number,omitempty"`
+	TrackNumber int32                  `protobuf:"varint,13,opt,name=track_number,json=trackNumber,proto3" json:"track_number,omitempty"`
+	Language    string                 `protobuf:"bytes,14,opt,name=language,proto3" json:"language,omitempty"` // ISO 639, "zxx" — без слов
+	Bpm         float64                `protobuf:"fixed64,15,opt,name=bpm,proto3" json:"bpm,omitempty"`
+	Key         string                 `protobuf:"bytes,16,opt,name=key,proto3" json:"key,omitempty"` // Тональность: C, F#, Bb, Ebm
+	// Исполнители трека, основной первым; artist — имя основного исполнителя
+	Artists       []*ArtistCredit `protobuf:"bytes,17,rep,name=artists,proto3" json:"artists,omitempty"`
+	AlbumId       string          `protobuf:"bytes,18,opt,name=album_id,json=albumId,proto3" json:"album_id,omitempty"`
+	Audio         *AudioFile      `protobuf:"bytes,19,opt,name=audio,proto3" json:"audio,omitempty"`      // Не задано, пока аудио не загружено
+	Cover         *Artwork        `protobuf:"bytes,20,opt,name=cover,proto3" json:"cover,omitempty"`      // Не задано, пока обложки нет
+	Version       int64           `protobuf:"varint,21,opt,name=version,proto3" json:"version,omitempty"` // Растет с каждым изменением трека; см. TrackEvent
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,55 +97,3396 @@ func (x *Track) GetArtist() string {
 	if x != nil {
 		return x.Artist
 	}
-	return ""
+	return ""
+}
+
+func (x *Track) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *Track) GetDurationSec() int32 {
+	if x != nil {
+		return x.DurationSec
+	}
+	return 0
+}
+
+func (x *Track) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Track) GetExplicit() bool {
+	if x != nil {
+		return x.Explicit
+	}
+	return false
+}
+
+func (x *Track) GetPopularity() int64 {
+	if x != nil {
+		return x.Popularity
+	}
+	return 0
+}
+
+func (x *Track) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *Track) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Track) GetIsrc() string {
+	if x != nil {
+		return x.Isrc
+	}
+	return ""
+}
+
+func (x *Track) GetDiscNumber() int32 {
+	if x != nil {
+		return x.DiscNumber
+	}
+	return 0
+}
+
+func (x *Track) GetTrackNumber() int32 {
+	if x != nil {
+		return x.TrackNumber
+	}
+	return 0
+}
+
+func (x *Track) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Track) GetBpm() float64 {
+	if x != nil {
+		return x.Bpm
+	}
+	return 0
+}
+
+func (x *Track) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Track) GetArtists() []*ArtistCredit {
+	if x != nil {
+		return x.Artists
+	}
+	return nil
+}
+
+func (x *Track) GetAlbumId() string {
+	if x != nil {
+		return x.AlbumId
+	}
+	return ""
+}
+
+func (x *Track) GetAudio() *AudioFile {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *Track) GetCover() *Artwork {
+	if x != nil {
+		return x.Cover
+	}
+	return nil
+}
+
+func (x *Track) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Artwork struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MimeType string                 `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Width    int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`   // Пикселей
+	Height   int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"` // Пикселей
+	Size     int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`     // Байт
+	Sha256   string                 `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Source   string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"` // embedded — извлечена из аудиофайла, uploaded — загружена отдельно
+	Url      string                 `protobuf:"bytes,7,opt,name=url,proto3" json:"url,omitempty"`       // Путь на шлюзе: /covers/<sha256>.<ext>
+	// Уменьшенные копии в JPEG по возрастанию размера; больше оригинала не делаются
+	Thumbnails    []*ArtworkThumbnail `protobuf:"bytes,8,rep,name=thumbnails,proto3" json:"thumbnails,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artwork) Reset() {
+	*x = Artwork{}
+	mi := &file_proto_track_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artwork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artwork) ProtoMessage() {}
+
+func (x *Artwork) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artwork.ProtoReflect.Descriptor instead.
+func (*Artwork) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Artwork) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Artwork) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Artwork) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Artwork) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Artwork) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Artwork) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Artwork) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Artwork) GetThumbnails() []*ArtworkThumbnail {
+	if x != nil {
+		return x.Thumbnails
+	}
+	return nil
+}
+
+type ArtworkThumbnail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int32                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"` // Большая сторона: 64, 300 или 640
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArtworkThumbnail) Reset() {
+	*x = ArtworkThumbnail{}
+	mi := &file_proto_track_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArtworkThumbnail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtworkThumbnail) ProtoMessage() {}
+
+func (x *ArtworkThumbnail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtworkThumbnail.ProtoReflect.Descriptor instead.
+func (*ArtworkThumbnail) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ArtworkThumbnail) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ArtworkThumbnail) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ArtworkThumbnail) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ArtworkThumbnail) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type AudioFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // Контейнер: mp3, flac, wav, ogg или m4a
+	Codec         string                 `protobuf:"bytes,2,opt,name=codec,proto3" json:"codec,omitempty"`   // mp3, flac, pcm, vorbis, opus, aac или alac
+	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                               // Байт
+	Bitrate       int32                  `protobuf:"varint,5,opt,name=bitrate,proto3" json:"bitrate,omitempty"`                         // Средний битрейт, бит/с
+	SampleRate    int32                  `protobuf:"varint,6,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"` // Гц
+	Channels      int32                  `protobuf:"varint,7,opt,name=channels,proto3" json:"channels,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Sha256        string                 `protobuf:"bytes,9,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	UploadedAt    int64                  `protobuf:"varint,10,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AudioFile) Reset() {
+	*x = AudioFile{}
+	mi := &file_proto_track_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AudioFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioFile) ProtoMessage() {}
+
+func (x *AudioFile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioFile.ProtoReflect.Descriptor instead.
+func (*AudioFile) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AudioFile) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *AudioFile) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *AudioFile) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *AudioFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AudioFile) GetBitrate() int32 {
+	if x != nil {
+		return x.Bitrate
+	}
+	return 0
+}
+
+func (x *AudioFile) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *AudioFile) GetChannels() int32 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *AudioFile) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *AudioFile) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *AudioFile) GetUploadedAt() int64 {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return 0
+}
+
+type ArtistCredit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArtistId      string                 `protobuf:"bytes,1,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Featured      bool                   `protobuf:"varint,3,opt,name=featured,proto3" json:"featured,omitempty"` // Приглашенный исполнитель (feat.)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArtistCredit) Reset() {
+	*x = ArtistCredit{}
+	mi := &file_proto_track_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArtistCredit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtistCredit) ProtoMessage() {}
+
+func (x *ArtistCredit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtistCredit.ProtoReflect.Descriptor instead.
+func (*ArtistCredit) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ArtistCredit) GetArtistId() string {
+	if x != nil {
+		return x.ArtistId
+	}
+	return ""
+}
+
+func (x *ArtistCredit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ArtistCredit) GetFeatured() bool {
+	if x != nil {
+		return x.Featured
+	}
+	return false
+}
+
+type CreateTrackRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Artist      string                 `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album       string                 `protobuf:"bytes,3,opt,name=album,proto3" json:"album,omitempty"`
+	DurationSec int32                  `protobuf:"varint,4,opt,name=duration_sec,json=durationSec,proto3" json:"duration_sec,omitempty"`
+	Explicit    bool                   `protobuf:"varint,5,opt,name=explicit,proto3" json:"explicit,omitempty"`
+	Genres      []string               `protobuf:"bytes,6,rep,name=genres,proto3" json:"genres,omitempty"`
+	ReleaseDate string                 `protobuf:"bytes,7,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"` // YYYY-MM-DD
+	Isrc        string                 `protobuf:"bytes,8,opt,name=isrc,proto3" json:"isrc,omitempty"`                                  // Например US-RC1-76-07839; хранится без дефисов
+	DiscNumber  int32                  `protobuf:"varint,9,opt,name=disc_number,json=discNumber,proto3" json:"disc_number,omitempty"`
+	TrackNumber int32                  `protobuf:"varint,10,opt,name=track_number,json=trackNumber,proto3" json:"track_number,omitempty"`
+	Language    string                 `protobuf:"bytes,11,opt,name=language,proto3" json:"language,omitempty"` // ISO 639, "zxx" — без слов
+	Bpm         float64                `protobuf:"fixed64,12,opt,name=bpm,proto3" json:"bpm,omitempty"`
+	Key         string                 `protobuf:"bytes,13,opt,name=key,proto3" json:"key,omitempty"` // Тональность: C, F#, Bb, Ebm
+	// Основной исполнитель и альбом задаются по ID или, как раньше, по имени:
+	// по имени находится существующая запись или создается новая
+	ArtistId          string   `protobuf:"bytes,14,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	FeaturedArtistIds []string `protobuf:"bytes,15,rep,name=featured_artist_ids,json=featuredArtistIds,proto3" json:"featured_artist_ids,omitempty"`
+	AlbumId           string   `protobuf:"bytes,16,opt,name=album_id,json=albumId,proto3" json:"album_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateTrackRequest) Reset() {
+	*x = CreateTrackRequest{}
+	mi := &file_proto_track_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTrackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTrackRequest) ProtoMessage() {}
+
+func (x *CreateTrackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTrackRequest.ProtoReflect.Descriptor instead.
+func (*CreateTrackRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateTrackRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTrackRequest) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *CreateTrackRequest) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *CreateTrackRequest) GetDurationSec() int32 {
+	if x != nil {
+		return x.DurationSec
+	}
+	return 0
+}
+
+func (x *CreateTrackRequest) GetExplicit() bool {
+	if x != nil {
+		return x.Explicit
+	}
+	return false
+}
+
+func (x *CreateTrackRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *CreateTrackRequest) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *CreateTrackRequest) GetIsrc() string {
+	if x != nil {
+		return x.Isrc
+	}
+	return ""
+}
+
+func (x *CreateTrackRequest) GetDiscNumber() int32 {
+	if x != nil {
+		return x.DiscNumber
+	}
+	return 0
+}
+
+func (x *CreateTrackRequest) GetTrackNumber() int32 {
+	if x != nil {
+		return x.TrackNumber
+	}
+	return 0
+}
+
+func (x *CreateTrackRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CreateTrackRequest) GetBpm() float64 {
+	if x != nil {
+		return x.Bpm
+	}
+	return 0
+}
+
+func (x *CreateTrackRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CreateTrackRequest) GetArtistId() string {
+	if x != nil {
+		return x.ArtistId
+	}
+	return ""
+}
+
+func (x *CreateTrackRequest) GetFeaturedArtistIds() []string {
+	if x != nil {
+		return x.FeaturedArtistIds
+	}
+	return nil
+}
+
+func (x *CreateTrackRequest) GetAlbumId() string {
+	if x != nil {
+		return x.AlbumId
+	}
+	return ""
+}
+
+type CreateTrackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Track         *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTrackResponse) Reset() {
+	*x = CreateTrackResponse{}
+	mi := &file_proto_track_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTrackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTrackResponse) ProtoMessage() {}
+
+func (x *CreateTrackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTrackResponse.ProtoReflect.Descriptor instead.
+func (*CreateTrackResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateTrackResponse) GetTrack() *Track {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+type GetTrackByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrackByIDRequest) Reset() {
+	*x = GetTrackByIDRequest{}
+	mi := &file_proto_track_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrackByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrackByIDRequest) ProtoMessage() {}
+
+func (x *GetTrackByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrackByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetTrackByIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTrackByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTrackByIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Track         *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrackByIDResponse) Reset() {
+	*x = GetTrackByIDResponse{}
+	mi := &file_proto_track_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrackByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrackByIDResponse) ProtoMessage() {}
+
+func (x *GetTrackByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrackByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetTrackByIDResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTrackByIDResponse) GetTrack() *Track {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+type GetAllTracksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Title  string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Artist string                 `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`
+	// Устарело: номер страницы через skip; при вставках страницы сдвигаются.
+	// Используется, только если cursor не задан.
+	Page  int64 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int64 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Устарело и не учитывается: настройки (фильтр explicit-контента) берутся
+	// у пользователя, чей токен передан в метаданных authorization
+	UserId string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// next_cursor из предыдущего ответа; пустой — первая страница
+	Cursor string `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// title, artist, created_at (по умолчанию), duration или popularity
+	SortBy string `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// asc или desc; по умолчанию created_at и popularity — desc, остальные — asc
+	Order string `protobuf:"bytes,8,opt,name=order,proto3" json:"order,omitempty"`
+	// Посчитать total_count; требует отдельного запроса к базе
+	IncludeTotal bool `protobuf:"varint,9,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
+	// Фильтры по метаданным; пустые значения не фильтруют
+	Genres          []string `protobuf:"bytes,10,rep,name=genres,proto3" json:"genres,omitempty"`                                            // Любой из жанров
+	ReleaseDateFrom string   `protobuf:"bytes,11,opt,name=release_date_from,json=releaseDateFrom,proto3" json:"release_date_from,omitempty"` // YYYY-MM-DD, включительно
+	ReleaseDateTo   string   `protobuf:"bytes,12,opt,name=release_date_to,json=releaseDateTo,proto3" json:"release_date_to,omitempty"`       // YYYY-MM-DD, включительно
+	Isrc            string   `protobuf:"bytes,13,opt,name=isrc,proto3" json:"isrc,omitempty"`
+	Language        string   `protobuf:"bytes,14,opt,name=language,proto3" json:"language,omitempty"`
+	Explicit        *bool    `protobuf:"varint,15,opt,name=explicit,proto3,oneof" json:"explicit,omitempty"`
+	BpmMin          float64  `protobuf:"fixed64,16,opt,name=bpm_min,json=bpmMin,proto3" json:"bpm_min,omitempty"`
+	BpmMax          float64  `protobuf:"fixed64,17,opt,name=bpm_max,json=bpmMax,proto3" json:"bpm_max,omitempty"`
+	Key             string   `protobuf:"bytes,18,opt,name=key,proto3" json:"key,omitempty"`
+	ArtistId        string   `protobuf:"bytes,19,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"` // Треки, в которых участвует исполнитель, в том числе как приглашенный
+	AlbumId         string   `protobuf:"bytes,20,opt,name=album_id,json=albumId,proto3" json:"album_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetAllTracksRequest) Reset() {
+	*x = GetAllTracksRequest{}
+	mi := &file_proto_track_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllTracksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllTracksRequest) ProtoMessage() {}
+
+func (x *GetAllTracksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllTracksRequest.ProtoReflect.Descriptor instead.
+func (*GetAllTracksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAllTracksRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetAllTracksRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAllTracksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+func (x *GetAllTracksRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *GetAllTracksRequest) GetReleaseDateFrom() string {
+	if x != nil {
+		return x.ReleaseDateFrom
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetReleaseDateTo() string {
+	if x != nil {
+		return x.ReleaseDateTo
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetIsrc() string {
+	if x != nil {
+		return x.Isrc
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetExplicit() bool {
+	if x != nil && x.Explicit != nil {
+		return *x.Explicit
+	}
+	return false
+}
+
+func (x *GetAllTracksRequest) GetBpmMin() float64 {
+	if x != nil {
+		return x.BpmMin
+	}
+	return 0
+}
+
+func (x *GetAllTracksRequest) GetBpmMax() float64 {
+	if x != nil {
+		return x.BpmMax
+	}
+	return 0
+}
+
+func (x *GetAllTracksRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetArtistId() string {
+	if x != nil {
+		return x.ArtistId
+	}
+	return ""
+}
+
+func (x *GetAllTracksRequest) GetAlbumId() string {
+	if x != nil {
+		return x.AlbumId
+	}
+	return ""
+}
+
+type GetAllTracksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tracks        []*Track               `protobuf:"bytes,1,rep,name=tracks,proto3" json:"tracks,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`  // Пустой, если страница последняя
+	TotalCount    int64                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Только при include_total
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllTracksResponse) Reset() {
+	*x = GetAllTracksResponse{}
+	mi := &file_proto_track_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllTracksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllTracksResponse) ProtoMessage() {}
+
+func (x *GetAllTracksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllTracksResponse.ProtoReflect.Descriptor instead.
+func (*GetAllTracksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAllTracksResponse) GetTracks() []*Track {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+func (x *GetAllTracksResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetAllTracksResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type SearchTracksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Слова ищутся в названии, исполнителе и альбоме, кириллицей и латиницей;
+	// "фраза в кавычках" должна встретиться целиком
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page  int64  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int64  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Устарело и не учитывается: настройки (фильтр explicit-контента) берутся
+	// у пользователя, чей токен передан в метаданных authorization
+	UserId        string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTracksRequest) Reset() {
+	*x = SearchTracksRequest{}
+	mi := &file_proto_track_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTracksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTracksRequest) ProtoMessage() {}
+
+func (x *SearchTracksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTracksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTracksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchTracksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTracksRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchTracksRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchTracksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ScoredTrack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Track         *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // Релевантность: чем больше, тем лучше совпадение
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoredTrack) Reset() {
+	*x = ScoredTrack{}
+	mi := &file_proto_track_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoredTrack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoredTrack) ProtoMessage() {}
+
+func (x *ScoredTrack) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoredTrack.ProtoReflect.Descriptor instead.
+func (*ScoredTrack) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ScoredTrack) GetTrack() *Track {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+func (x *ScoredTrack) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SearchTracksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ScoredTrack         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // По убыванию релевантности
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTracksResponse) Reset() {
+	*x = SearchTracksResponse{}
+	mi := &file_proto_track_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTracksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTracksResponse) ProtoMessage() {}
+
+func (x *SearchTracksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTracksResponse.ProtoReflect.Descriptor instead.
+func (*SearchTracksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchTracksResponse) GetResults() []*ScoredTrack {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SuggestRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"` // Начало ввода; с трех символов допускается одна опечатка
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // По умолчанию и максимум 20
+	// Устарело и не учитывается: настройки (фильтр explicit-контента) берутся
+	// у пользователя, чей токен передан в метаданных authorization
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRequest) Reset() {
+	*x = SuggestRequest{}
+	mi := &file_proto_track_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRequest) ProtoMessage() {}
+
+func (x *SuggestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SuggestRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SuggestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`                                // track, artist или album
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                                // Название трека, имя исполнителя или название альбома
+	Subtitle      string                 `protobuf:"bytes,3,opt,name=subtitle,proto3" json:"subtitle,omitempty"`                        // Исполнитель трека или альбома
+	TrackId       string                 `protobuf:"bytes,4,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`           // Только для треков
+	TrackCount    int32                  `protobuf:"varint,5,opt,name=track_count,json=trackCount,proto3" json:"track_count,omitempty"` // Сколько треков у исполнителя или в альбоме
+	Fuzzy         bool                   `protobuf:"varint,6,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"`                             // Найдено с исправлением опечатки
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_proto_track_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Suggestion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetSubtitle() string {
+	if x != nil {
+		return x.Subtitle
+	}
+	return ""
+}
+
+func (x *Suggestion) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+func (x *Suggestion) GetTrackCount() int32 {
+	if x != nil {
+		return x.TrackCount
+	}
+	return 0
+}
+
+func (x *Suggestion) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+type SuggestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestResponse) Reset() {
+	*x = SuggestResponse{}
+	mi := &file_proto_track_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestResponse) ProtoMessage() {}
+
+func (x *SuggestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestResponse.ProtoReflect.Descriptor instead.
+func (*SuggestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SuggestResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+// Событие каталога в NATS JetStream (поток TRACK_EVENTS) на subject
+// track.created, track.updated или track.deleted. Доставка — хотя бы один раз:
+// повтор узнается по event_id, устаревшее событие — по version.
+type TrackEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion int32                  `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"` // Версия формата события, сейчас 1
+	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                    // Совпадает с заголовком Nats-Msg-Id
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                                         // Совпадает с subject
+	TrackId       string                 `protobuf:"bytes,4,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`                         // Версия трека после изменения; у удаления — следующая за последней
+	OccurredAt    int64                  `protobuf:"varint,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // Unix-время в миллисекундах
+	Before        *Track                 `protobuf:"bytes,7,opt,name=before,proto3" json:"before,omitempty"`                            // Нет у track.created
+	After         *Track                 `protobuf:"bytes,8,opt,name=after,proto3" json:"after,omitempty"`                              // Нет у track.deleted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackEvent) Reset() {
+	*x = TrackEvent{}
+	mi := &file_proto_track_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackEvent) ProtoMessage() {}
+
+func (x *TrackEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackEvent.ProtoReflect.Descriptor instead.
+func (*TrackEvent) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TrackEvent) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *TrackEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *TrackEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TrackEvent) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+func (x *TrackEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TrackEvent) GetOccurredAt() int64 {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return 0
+}
+
+func (x *TrackEvent) GetBefore() *Track {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *TrackEvent) GetAfter() *Track {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+type UploadTrackAudioRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Первое сообщение — info, за ним файл частями (рекомендуется до 1 МБ)
+	//
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadTrackAudioRequest_Info
+	//	*UploadTrackAudioRequest_Chunk
+	Payload       isUploadTrackAudioRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadTrackAudioRequest) Reset() {
+	*x = UploadTrackAudioRequest{}
+	mi := &file_proto_track_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadTrackAudioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadTrackAudioRequest) ProtoMessage() {}
+
+func (x *UploadTrackAudioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadTrackAudioRequest.ProtoReflect.Descriptor instead.
+func (*UploadTrackAudioRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UploadTrackAudioRequest) GetPayload() isUploadTrackAudioRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadTrackAudioRequest) GetInfo() *AudioUploadInfo {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadTrackAudioRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadTrackAudioRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadTrackAudioRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadTrackAudioRequest_Payload interface {
+	isUploadTrackAudioRequest_Payload()
+}
+
+type UploadTrackAudioRequest_Info struct {
+	Info *AudioUploadInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadTrackAudioRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadTrackAudioRequest_Info) isUploadTrackAudioRequest_Payload() {}
+
+func (*UploadTrackAudioRequest_Chunk) isUploadTrackAudioRequest_Payload() {}
+
+type AudioUploadInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrackId       string                 `protobuf:"bytes,1,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"` // Пустой — трек создается по тегам файла
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`              // Только для журнала: формат определяется по содержимому
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AudioUploadInfo) Reset() {
+	*x = AudioUploadInfo{}
+	mi := &file_proto_track_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AudioUploadInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioUploadInfo) ProtoMessage() {}
+
+func (x *AudioUploadInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioUploadInfo.ProtoReflect.Descriptor instead.
+func (*AudioUploadInfo) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AudioUploadInfo) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+func (x *AudioUploadInfo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type UploadTrackAudioResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Track        *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	Deduplicated bool                   `protobuf:"varint,2,opt,name=deduplicated,proto3" json:"deduplicated,omitempty"` // Такой файл уже хранился и повторно не записывался
+	// Поля трека, заполненные из тегов файла: title, artist, album, genres,
+	// release_date, isrc, disc_number, track_number, language, bpm, key, cover
+	Prefilled     []string `protobuf:"bytes,3,rep,name=prefilled,proto3" json:"prefilled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadTrackAudioResponse) Reset() {
+	*x = UploadTrackAudioResponse{}
+	mi := &file_proto_track_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadTrackAudioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadTrackAudioResponse) ProtoMessage() {}
+
+func (x *UploadTrackAudioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadTrackAudioResponse.ProtoReflect.Descriptor instead.
+func (*UploadTrackAudioResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UploadTrackAudioResponse) GetTrack() *Track {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+func (x *UploadTrackAudioResponse) GetDeduplicated() bool {
+	if x != nil {
+		return x.Deduplicated
+	}
+	return false
+}
+
+func (x *UploadTrackAudioResponse) GetPrefilled() []string {
+	if x != nil {
+		return x.Prefilled
+	}
+	return nil
+}
+
+type StreamTrackAudioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrackId       string                 `protobuf:"bytes,1,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Первый байт файла
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"` // Сколько байт отдать; 0 — до конца файла
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTrackAudioRequest) Reset() {
+	*x = StreamTrackAudioRequest{}
+	mi := &file_proto_track_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTrackAudioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTrackAudioRequest) ProtoMessage() {}
+
+func (x *StreamTrackAudioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTrackAudioRequest.ProtoReflect.Descriptor instead.
+func (*StreamTrackAudioRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamTrackAudioRequest) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+func (x *StreamTrackAudioRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *StreamTrackAudioRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type AudioChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AudioChunk) Reset() {
+	*x = AudioChunk{}
+	mi := &file_proto_track_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AudioChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioChunk) ProtoMessage() {}
+
+func (x *AudioChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioChunk.ProtoReflect.Descriptor instead.
+func (*AudioChunk) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AudioChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Обложка — JPEG, PNG или GIF до 3 МБ, каждая сторона от 200 до 4000 пикселей
+type UploadTrackCoverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrackId       string                 `protobuf:"bytes,1,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`
+	Image         []byte                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadTrackCoverRequest) Reset() {
+	*x = UploadTrackCoverRequest{}
+	mi := &file_proto_track_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadTrackCoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadTrackCoverRequest) ProtoMessage() {}
+
+func (x *UploadTrackCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadTrackCoverRequest.ProtoReflect.Descriptor instead.
+func (*UploadTrackCoverRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UploadTrackCoverRequest) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+func (x *UploadTrackCoverRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type UploadTrackCoverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Track         *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadTrackCoverResponse) Reset() {
+	*x = UploadTrackCoverResponse{}
+	mi := &file_proto_track_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadTrackCoverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadTrackCoverResponse) ProtoMessage() {}
+
+func (x *UploadTrackCoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadTrackCoverResponse.ProtoReflect.Descriptor instead.
+func (*UploadTrackCoverResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UploadTrackCoverResponse) GetTrack() *Track {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+type DeleteTrackCoverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrackId       string                 `protobuf:"bytes,1,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTrackCoverRequest) Reset() {
+	*x = DeleteTrackCoverRequest{}
+	mi := &file_proto_track_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTrackCoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTrackCoverRequest) ProtoMessage() {}
+
+func (x *DeleteTrackCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTrackCoverRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTrackCoverRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteTrackCoverRequest) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+type DeleteTrackCoverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Track         *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTrackCoverResponse) Reset() {
+	*x = DeleteTrackCoverResponse{}
+	mi := &file_proto_track_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTrackCoverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTrackCoverResponse) ProtoMessage() {}
+
+func (x *DeleteTrackCoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTrackCoverResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTrackCoverResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteTrackCoverResponse) GetTrack() *Track {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+type GetArtworkImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Последняя часть url обложки или превью
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArtworkImageRequest) Reset() {
+	*x = GetArtworkImageRequest{}
+	mi := &file_proto_track_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArtworkImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArtworkImageRequest) ProtoMessage() {}
+
+func (x *GetArtworkImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArtworkImageRequest.ProtoReflect.Descriptor instead.
+func (*GetArtworkImageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetArtworkImageRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetArtworkImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MimeType      string                 `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArtworkImageResponse) Reset() {
+	*x = GetArtworkImageResponse{}
+	mi := &file_proto_track_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArtworkImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArtworkImageResponse) ProtoMessage() {}
+
+func (x *GetArtworkImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArtworkImageResponse.ProtoReflect.Descriptor instead.
+func (*GetArtworkImageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetArtworkImageResponse) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *GetArtworkImageResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetTrackAnalysisRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrackId       string                 `protobuf:"bytes,1,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrackAnalysisRequest) Reset() {
+	*x = GetTrackAnalysisRequest{}
+	mi := &file_proto_track_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrackAnalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrackAnalysisRequest) ProtoMessage() {}
+
+func (x *GetTrackAnalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrackAnalysisRequest.ProtoReflect.Descriptor instead.
+func (*GetTrackAnalysisRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetTrackAnalysisRequest) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+// Анализ выполняется в фоне после загрузки WAV или FLAC; значения заданы только при status done
+type GetTrackAnalysisResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                         // pending, running, done, failed или unsupported (сжатие с потерями)
+	Waveform       []byte                 `protobuf:"bytes,2,opt,name=waveform,proto3" json:"waveform,omitempty"`                                     // Огибающая пиков: по байту 0–255 на каждый из равных отрезков трека
+	IntegratedLufs float64                `protobuf:"fixed64,3,opt,name=integrated_lufs,json=integratedLufs,proto3" json:"integrated_lufs,omitempty"` // Интегральная громкость по EBU R128
+	TruePeakDbtp   float64                `protobuf:"fixed64,4,opt,name=true_peak_dbtp,json=truePeakDbtp,proto3" json:"true_peak_dbtp,omitempty"`     // Истинный пик
+	// Усиление до -14 LUFS, ограниченное так, чтобы пик не превысил -1 dBTP
+	GainDb        float64 `protobuf:"fixed64,5,opt,name=gain_db,json=gainDb,proto3" json:"gain_db,omitempty"`
+	AnalyzedAt    int64   `protobuf:"varint,6,opt,name=analyzed_at,json=analyzedAt,proto3" json:"analyzed_at,omitempty"`
+	Error         string  `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // Причина при status failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrackAnalysisResponse) Reset() {
+	*x = GetTrackAnalysisResponse{}
+	mi := &file_proto_track_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrackAnalysisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrackAnalysisResponse) ProtoMessage() {}
+
+func (x *GetTrackAnalysisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrackAnalysisResponse.ProtoReflect.Descriptor instead.
+func (*GetTrackAnalysisResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetTrackAnalysisResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetTrackAnalysisResponse) GetWaveform() []byte {
+	if x != nil {
+		return x.Waveform
+	}
+	return nil
+}
+
+func (x *GetTrackAnalysisResponse) GetIntegratedLufs() float64 {
+	if x != nil {
+		return x.IntegratedLufs
+	}
+	return 0
+}
+
+func (x *GetTrackAnalysisResponse) GetTruePeakDbtp() float64 {
+	if x != nil {
+		return x.TruePeakDbtp
+	}
+	return 0
+}
+
+func (x *GetTrackAnalysisResponse) GetGainDb() float64 {
+	if x != nil {
+		return x.GainDb
+	}
+	return 0
+}
+
+func (x *GetTrackAnalysisResponse) GetAnalyzedAt() int64 {
+	if x != nil {
+		return x.AnalyzedAt
+	}
+	return 0
+}
+
+func (x *GetTrackAnalysisResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UpdateTrackRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Artist      string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album       string                 `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
+	DurationSec int32                  `protobuf:"varint,5,opt,name=duration_sec,json=durationSec,proto3" json:"duration_sec,omitempty"`
+	Explicit    *bool                  `protobuf:"varint,6,opt,name=explicit,proto3,oneof" json:"explicit,omitempty"`
+	// Пустые значения не меняют поле
+	Genres            []string `protobuf:"bytes,7,rep,name=genres,proto3" json:"genres,omitempty"`
+	ReleaseDate       string   `protobuf:"bytes,8,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"` // YYYY-MM-DD
+	Isrc              string   `protobuf:"bytes,9,opt,name=isrc,proto3" json:"isrc,omitempty"`                                  // Например US-RC1-76-07839; хранится без дефисов
+	DiscNumber        int32    `protobuf:"varint,10,opt,name=disc_number,json=discNumber,proto3" json:"disc_number,omitempty"`
+	TrackNumber       int32    `protobuf:"varint,11,opt,name=track_number,json=trackNumber,proto3" json:"track_number,omitempty"`
+	Language          string   `protobuf:"bytes,12,opt,name=language,proto3" json:"language,omitempty"` // ISO 639, "zxx" — без слов
+	Bpm               float64  `protobuf:"fixed64,13,opt,name=bpm,proto3" json:"bpm,omitempty"`
+	Key               string   `protobuf:"bytes,14,opt,name=key,proto3" json:"key,omitempty"` // Тональность: C, F#, Bb, Ebm
+	ArtistId          string   `protobuf:"bytes,15,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	FeaturedArtistIds []string `protobuf:"bytes,16,rep,name=featured_artist_ids,json=featuredArtistIds,proto3" json:"featured_artist_ids,omitempty"`
+	AlbumId           string   `protobuf:"bytes,17,opt,name=album_id,json=albumId,proto3" json:"album_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateTrackRequest) Reset() {
+	*x = UpdateTrackRequest{}
+	mi := &file_proto_track_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTrackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTrackRequest) ProtoMessage() {}
+
+func (x *UpdateTrackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTrackRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTrackRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateTrackRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetDurationSec() int32 {
+	if x != nil {
+		return x.DurationSec
+	}
+	return 0
+}
+
+func (x *UpdateTrackRequest) GetExplicit() bool {
+	if x != nil && x.Explicit != nil {
+		return *x.Explicit
+	}
+	return false
+}
+
+func (x *UpdateTrackRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *UpdateTrackRequest) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetIsrc() string {
+	if x != nil {
+		return x.Isrc
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetDiscNumber() int32 {
+	if x != nil {
+		return x.DiscNumber
+	}
+	return 0
+}
+
+func (x *UpdateTrackRequest) GetTrackNumber() int32 {
+	if x != nil {
+		return x.TrackNumber
+	}
+	return 0
+}
+
+func (x *UpdateTrackRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetBpm() float64 {
+	if x != nil {
+		return x.Bpm
+	}
+	return 0
+}
+
+func (x *UpdateTrackRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetArtistId() string {
+	if x != nil {
+		return x.ArtistId
+	}
+	return ""
+}
+
+func (x *UpdateTrackRequest) GetFeaturedArtistIds() []string {
+	if x != nil {
+		return x.FeaturedArtistIds
+	}
+	return nil
+}
+
+func (x *UpdateTrackRequest) GetAlbumId() string {
+	if x != nil {
+		return x.AlbumId
+	}
+	return ""
+}
+
+type UpdateTrackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTrackResponse) Reset() {
+	*x = UpdateTrackResponse{}
+	mi := &file_proto_track_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTrackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTrackResponse) ProtoMessage() {}
+
+func (x *UpdateTrackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTrackResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTrackResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateTrackResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteTrackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTrackRequest) Reset() {
+	*x = DeleteTrackRequest{}
+	mi := &file_proto_track_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTrackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTrackRequest) ProtoMessage() {}
+
+func (x *DeleteTrackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTrackRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTrackRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteTrackRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTrackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTrackResponse) Reset() {
+	*x = DeleteTrackResponse{}
+	mi := &file_proto_track_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTrackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTrackResponse) ProtoMessage() {}
+
+func (x *DeleteTrackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTrackResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTrackResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteTrackResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Artist struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artist) Reset() {
+	*x = Artist{}
+	mi := &file_proto_track_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artist) ProtoMessage() {}
+
+func (x *Artist) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artist.ProtoReflect.Descriptor instead.
+func (*Artist) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Artist) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Artist) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Artist) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateArtistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateArtistRequest) Reset() {
+	*x = CreateArtistRequest{}
+	mi := &file_proto_track_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateArtistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateArtistRequest) ProtoMessage() {}
+
+func (x *CreateArtistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateArtistRequest.ProtoReflect.Descriptor instead.
+func (*CreateArtistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateArtistRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateArtistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artist        *Artist                `protobuf:"bytes,1,opt,name=artist,proto3" json:"artist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateArtistResponse) Reset() {
+	*x = CreateArtistResponse{}
+	mi := &file_proto_track_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateArtistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateArtistResponse) ProtoMessage() {}
+
+func (x *CreateArtistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateArtistResponse.ProtoReflect.Descriptor instead.
+func (*CreateArtistResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateArtistResponse) GetArtist() *Artist {
+	if x != nil {
+		return x.Artist
+	}
+	return nil
+}
+
+type GetArtistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArtistRequest) Reset() {
+	*x = GetArtistRequest{}
+	mi := &file_proto_track_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArtistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArtistRequest) ProtoMessage() {}
+
+func (x *GetArtistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArtistRequest.ProtoReflect.Descriptor instead.
+func (*GetArtistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetArtistRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetArtistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artist        *Artist                `protobuf:"bytes,1,opt,name=artist,proto3" json:"artist,omitempty"`
+	TrackCount    int64                  `protobuf:"varint,2,opt,name=track_count,json=trackCount,proto3" json:"track_count,omitempty"`
+	AlbumCount    int64                  `protobuf:"varint,3,opt,name=album_count,json=albumCount,proto3" json:"album_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArtistResponse) Reset() {
+	*x = GetArtistResponse{}
+	mi := &file_proto_track_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArtistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArtistResponse) ProtoMessage() {}
+
+func (x *GetArtistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArtistResponse.ProtoReflect.Descriptor instead.
+func (*GetArtistResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetArtistResponse) GetArtist() *Artist {
+	if x != nil {
+		return x.Artist
+	}
+	return nil
+}
+
+func (x *GetArtistResponse) GetTrackCount() int64 {
+	if x != nil {
+		return x.TrackCount
+	}
+	return 0
+}
+
+func (x *GetArtistResponse) GetAlbumCount() int64 {
+	if x != nil {
+		return x.AlbumCount
+	}
+	return 0
+}
+
+type ListArtistsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"` // Начало имени, без учета регистра
+	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArtistsRequest) Reset() {
+	*x = ListArtistsRequest{}
+	mi := &file_proto_track_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArtistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArtistsRequest) ProtoMessage() {}
+
+func (x *ListArtistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArtistsRequest.ProtoReflect.Descriptor instead.
+func (*ListArtistsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListArtistsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListArtistsRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListArtistsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListArtistsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artists       []*Artist              `protobuf:"bytes,1,rep,name=artists,proto3" json:"artists,omitempty"` // По алфавиту
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArtistsResponse) Reset() {
+	*x = ListArtistsResponse{}
+	mi := &file_proto_track_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArtistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArtistsResponse) ProtoMessage() {}
+
+func (x *ListArtistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArtistsResponse.ProtoReflect.Descriptor instead.
+func (*ListArtistsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListArtistsResponse) GetArtists() []*Artist {
+	if x != nil {
+		return x.Artists
+	}
+	return nil
+}
+
+type UpdateArtistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateArtistRequest) Reset() {
+	*x = UpdateArtistRequest{}
+	mi := &file_proto_track_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateArtistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateArtistRequest) ProtoMessage() {}
+
+func (x *UpdateArtistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateArtistRequest.ProtoReflect.Descriptor instead.
+func (*UpdateArtistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UpdateArtistRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateArtistRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateArtistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artist        *Artist                `protobuf:"bytes,1,opt,name=artist,proto3" json:"artist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateArtistResponse) Reset() {
+	*x = UpdateArtistResponse{}
+	mi := &file_proto_track_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateArtistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateArtistResponse) ProtoMessage() {}
+
+func (x *UpdateArtistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateArtistResponse.ProtoReflect.Descriptor instead.
+func (*UpdateArtistResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateArtistResponse) GetArtist() *Artist {
+	if x != nil {
+		return x.Artist
+	}
+	return nil
+}
+
+type DeleteArtistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteArtistRequest) Reset() {
+	*x = DeleteArtistRequest{}
+	mi := &file_proto_track_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteArtistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteArtistRequest) ProtoMessage() {}
+
+func (x *DeleteArtistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteArtistRequest.ProtoReflect.Descriptor instead.
+func (*DeleteArtistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeleteArtistRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteArtistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteArtistResponse) Reset() {
+	*x = DeleteArtistResponse{}
+	mi := &file_proto_track_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteArtistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteArtistResponse) ProtoMessage() {}
+
+func (x *DeleteArtistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteArtistResponse.ProtoReflect.Descriptor instead.
+func (*DeleteArtistResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteArtistResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Album struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	ArtistId      string                 `protobuf:"bytes,3,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	Artist        string                 `protobuf:"bytes,4,opt,name=artist,proto3" json:"artist,omitempty"`
+	ReleaseDate   string                 `protobuf:"bytes,5,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"` // YYYY-MM-DD
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Cover         *Artwork               `protobuf:"bytes,7,opt,name=cover,proto3" json:"cover,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Album) Reset() {
+	*x = Album{}
+	mi := &file_proto_track_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Album) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Album) ProtoMessage() {}
+
+func (x *Album) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Album.ProtoReflect.Descriptor instead.
+func (*Album) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Album) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Album) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Album) GetArtistId() string {
+	if x != nil {
+		return x.ArtistId
+	}
+	return ""
+}
+
+func (x *Album) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *Album) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Album) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Album) GetCover() *Artwork {
+	if x != nil {
+		return x.Cover
+	}
+	return nil
+}
+
+type CreateAlbumRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	ArtistId      string                 `protobuf:"bytes,2,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	ReleaseDate   string                 `protobuf:"bytes,3,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlbumRequest) Reset() {
+	*x = CreateAlbumRequest{}
+	mi := &file_proto_track_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlbumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlbumRequest) ProtoMessage() {}
+
+func (x *CreateAlbumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlbumRequest.ProtoReflect.Descriptor instead.
+func (*CreateAlbumRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateAlbumRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateAlbumRequest) GetArtistId() string {
+	if x != nil {
+		return x.ArtistId
+	}
+	return ""
+}
+
+func (x *CreateAlbumRequest) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+type CreateAlbumResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Album         *Album                 `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlbumResponse) Reset() {
+	*x = CreateAlbumResponse{}
+	mi := &file_proto_track_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlbumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlbumResponse) ProtoMessage() {}
+
+func (x *CreateAlbumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlbumResponse.ProtoReflect.Descriptor instead.
+func (*CreateAlbumResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateAlbumResponse) GetAlbum() *Album {
+	if x != nil {
+		return x.Album
+	}
+	return nil
+}
+
+type GetAlbumRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Устарело и не учитывается: настройки (фильтр explicit-контента) берутся
+	// у пользователя, чей токен передан в метаданных authorization
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlbumRequest) Reset() {
+	*x = GetAlbumRequest{}
+	mi := &file_proto_track_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlbumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlbumRequest) ProtoMessage() {}
+
+func (x *GetAlbumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlbumRequest.ProtoReflect.Descriptor instead.
+func (*GetAlbumRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetAlbumRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetAlbumRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetAlbumResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Album         *Album                 `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"`
+	Tracks        []*Track               `protobuf:"bytes,2,rep,name=tracks,proto3" json:"tracks,omitempty"` // По номеру диска и трека
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlbumResponse) Reset() {
+	*x = GetAlbumResponse{}
+	mi := &file_proto_track_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlbumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlbumResponse) ProtoMessage() {}
+
+func (x *GetAlbumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *Track) GetAlbum() string {
-	if x != nil {
-		return x.Album
-	}
-	return ""
+// Deprecated: Use GetAlbumResponse.ProtoReflect.Descriptor instead.
+func (*GetAlbumResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *Track) GetDurationSec() int32 {
+func (x *GetAlbumResponse) GetAlbum() *Album {
 	if x != nil {
-		return x.DurationSec
+		return x.Album
 	}
-	return 0
+	return nil
 }
 
-func (x *Track) GetCreatedAt() int64 {
+func (x *GetAlbumResponse) GetTracks() []*Track {
 	if x != nil {
-		return x.CreatedAt
+		return x.Tracks
 	}
-	return 0
+	return nil
 }
 
-type CreateTrackRequest struct {
+type ListAlbumsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Artist        string                 `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`
-	Album         string                 `protobuf:"bytes,3,opt,name=album,proto3" json:"album,omitempty"`
-	DurationSec   int32                  `protobuf:"varint,4,opt,name=duration_sec,json=durationSec,proto3" json:"duration_sec,omitempty"`
+	ArtistId      string                 `protobuf:"bytes,1,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTrackRequest) Reset() {
-	*x = CreateTrackRequest{}
-	mi := &file_proto_track_proto_msgTypes[1]
+func (x *ListAlbumsRequest) Reset() {
+	*x = ListAlbumsRequest{}
+	mi := &file_proto_track_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTrackRequest) String() string {
+func (x *ListAlbumsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTrackRequest) ProtoMessage() {}
+func (*ListAlbumsRequest) ProtoMessage() {}
 
-func (x *CreateTrackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[1]
+func (x *ListAlbumsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -140,61 +3497,54 @@ func (x *CreateTrackRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTrackRequest.ProtoReflect.Descriptor instead.
-func (*CreateTrackRequest) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *CreateTrackRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+// Deprecated: Use ListAlbumsRequest.ProtoReflect.Descriptor instead.
+func (*ListAlbumsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *CreateTrackRequest) GetArtist() string {
+func (x *ListAlbumsRequest) GetArtistId() string {
 	if x != nil {
-		return x.Artist
+		return x.ArtistId
 	}
 	return ""
 }
 
-func (x *CreateTrackRequest) GetAlbum() string {
+func (x *ListAlbumsRequest) GetPage() int64 {
 	if x != nil {
-		return x.Album
+		return x.Page
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateTrackRequest) GetDurationSec() int32 {
+func (x *ListAlbumsRequest) GetLimit() int64 {
 	if x != nil {
-		return x.DurationSec
+		return x.Limit
 	}
 	return 0
 }
 
-type CreateTrackResponse struct {
+type ListAlbumsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Track         *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	Albums        []*Album               `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"` // Новые релизы первыми
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTrackResponse) Reset() {
-	*x = CreateTrackResponse{}
-	mi := &file_proto_track_proto_msgTypes[2]
+func (x *ListAlbumsResponse) Reset() {
+	*x = ListAlbumsResponse{}
+	mi := &file_proto_track_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTrackResponse) String() string {
+func (x *ListAlbumsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTrackResponse) ProtoMessage() {}
+func (*ListAlbumsResponse) ProtoMessage() {}
 
-func (x *CreateTrackResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[2]
+func (x *ListAlbumsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,40 +3555,43 @@ func (x *CreateTrackResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTrackResponse.ProtoReflect.Descriptor instead.
-func (*CreateTrackResponse) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListAlbumsResponse.ProtoReflect.Descriptor instead.
+func (*ListAlbumsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *CreateTrackResponse) GetTrack() *Track {
+func (x *ListAlbumsResponse) GetAlbums() []*Album {
 	if x != nil {
-		return x.Track
+		return x.Albums
 	}
 	return nil
 }
 
-type GetTrackByIDRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+type UpdateAlbumRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Пустые значения не меняют поле
+	Title         string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	ReleaseDate   string `protobuf:"bytes,3,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTrackByIDRequest) Reset() {
-	*x = GetTrackByIDRequest{}
-	mi := &file_proto_track_proto_msgTypes[3]
+func (x *UpdateAlbumRequest) Reset() {
+	*x = UpdateAlbumRequest{}
+	mi := &file_proto_track_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTrackByIDRequest) String() string {
+func (x *UpdateAlbumRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTrackByIDRequest) ProtoMessage() {}
+func (*UpdateAlbumRequest) ProtoMessage() {}
 
-func (x *GetTrackByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[3]
+func (x *UpdateAlbumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,40 +3602,54 @@ func (x *GetTrackByIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTrackByIDRequest.ProtoReflect.Descriptor instead.
-func (*GetTrackByIDRequest) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use UpdateAlbumRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAlbumRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *GetTrackByIDRequest) GetId() string {
+func (x *UpdateAlbumRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetTrackByIDResponse struct {
+func (x *UpdateAlbumRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateAlbumRequest) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+type UpdateAlbumResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Track         *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	Album         *Album                 `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTrackByIDResponse) Reset() {
-	*x = GetTrackByIDResponse{}
-	mi := &file_proto_track_proto_msgTypes[4]
+func (x *UpdateAlbumResponse) Reset() {
+	*x = UpdateAlbumResponse{}
+	mi := &file_proto_track_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTrackByIDResponse) String() string {
+func (x *UpdateAlbumResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTrackByIDResponse) ProtoMessage() {}
+func (*UpdateAlbumResponse) ProtoMessage() {}
 
-func (x *GetTrackByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[4]
+func (x *UpdateAlbumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,43 +3660,40 @@ func (x *GetTrackByIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTrackByIDResponse.ProtoReflect.Descriptor instead.
-func (*GetTrackByIDResponse) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use UpdateAlbumResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAlbumResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *GetTrackByIDResponse) GetTrack() *Track {
+func (x *UpdateAlbumResponse) GetAlbum() *Album {
 	if x != nil {
-		return x.Track
+		return x.Album
 	}
 	return nil
 }
 
-type GetAllTracksRequest struct {
+type DeleteAlbumRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Artist        string                 `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`
-	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int64                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllTracksRequest) Reset() {
-	*x = GetAllTracksRequest{}
-	mi := &file_proto_track_proto_msgTypes[5]
+func (x *DeleteAlbumRequest) Reset() {
+	*x = DeleteAlbumRequest{}
+	mi := &file_proto_track_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllTracksRequest) String() string {
+func (x *DeleteAlbumRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllTracksRequest) ProtoMessage() {}
+func (*DeleteAlbumRequest) ProtoMessage() {}
 
-func (x *GetAllTracksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[5]
+func (x *DeleteAlbumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,61 +3704,40 @@ func (x *GetAllTracksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllTracksRequest.ProtoReflect.Descriptor instead.
-func (*GetAllTracksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *GetAllTracksRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+// Deprecated: Use DeleteAlbumRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAlbumRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *GetAllTracksRequest) GetArtist() string {
+func (x *DeleteAlbumRequest) GetId() string {
 	if x != nil {
-		return x.Artist
+		return x.Id
 	}
 	return ""
 }
 
-func (x *GetAllTracksRequest) GetPage() int64 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *GetAllTracksRequest) GetLimit() int64 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-type GetAllTracksResponse struct {
+type DeleteAlbumResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tracks        []*Track               `protobuf:"bytes,1,rep,name=tracks,proto3" json:"tracks,omitempty"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllTracksResponse) Reset() {
-	*x = GetAllTracksResponse{}
-	mi := &file_proto_track_proto_msgTypes[6]
+func (x *DeleteAlbumResponse) Reset() {
+	*x = DeleteAlbumResponse{}
+	mi := &file_proto_track_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllTracksResponse) String() string {
+func (x *DeleteAlbumResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllTracksResponse) ProtoMessage() {}
+func (*DeleteAlbumResponse) ProtoMessage() {}
 
-func (x *GetAllTracksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[6]
+func (x *DeleteAlbumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,44 +3748,41 @@ func (x *GetAllTracksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllTracksResponse.ProtoReflect.Descriptor instead.
-func (*GetAllTracksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use DeleteAlbumResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAlbumResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *GetAllTracksResponse) GetTracks() []*Track {
+func (x *DeleteAlbumResponse) GetMessage() string {
 	if x != nil {
-		return x.Tracks
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-type UpdateTrackRequest struct {
+type UploadAlbumCoverRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Artist        string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
-	Album         string                 `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
-	DurationSec   int32                  `protobuf:"varint,5,opt,name=duration_sec,json=durationSec,proto3" json:"duration_sec,omitempty"`
+	AlbumId       string                 `protobuf:"bytes,1,opt,name=album_id,json=albumId,proto3" json:"album_id,omitempty"`
+	Image         []byte                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"` // Те же требования, что и к обложке трека
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTrackRequest) Reset() {
-	*x = UpdateTrackRequest{}
-	mi := &file_proto_track_proto_msgTypes[7]
+func (x *UploadAlbumCoverRequest) Reset() {
+	*x = UploadAlbumCoverRequest{}
+	mi := &file_proto_track_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTrackRequest) String() string {
+func (x *UploadAlbumCoverRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTrackRequest) ProtoMessage() {}
+func (*UploadAlbumCoverRequest) ProtoMessage() {}
 
-func (x *UpdateTrackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[7]
+func (x *UploadAlbumCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -453,68 +3793,47 @@ func (x *UpdateTrackRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTrackRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTrackRequest) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *UpdateTrackRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *UpdateTrackRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
-}
-
-func (x *UpdateTrackRequest) GetArtist() string {
-	if x != nil {
-		return x.Artist
-	}
-	return ""
+// Deprecated: Use UploadAlbumCoverRequest.ProtoReflect.Descriptor instead.
+func (*UploadAlbumCoverRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *UpdateTrackRequest) GetAlbum() string {
+func (x *UploadAlbumCoverRequest) GetAlbumId() string {
 	if x != nil {
-		return x.Album
+		return x.AlbumId
 	}
 	return ""
 }
 
-func (x *UpdateTrackRequest) GetDurationSec() int32 {
+func (x *UploadAlbumCoverRequest) GetImage() []byte {
 	if x != nil {
-		return x.DurationSec
+		return x.Image
 	}
-	return 0
+	return nil
 }
 
-type UpdateTrackResponse struct {
+type UploadAlbumCoverResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Album         *Album                 `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTrackResponse) Reset() {
-	*x = UpdateTrackResponse{}
-	mi := &file_proto_track_proto_msgTypes[8]
+func (x *UploadAlbumCoverResponse) Reset() {
+	*x = UploadAlbumCoverResponse{}
+	mi := &file_proto_track_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTrackResponse) String() string {
+func (x *UploadAlbumCoverResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTrackResponse) ProtoMessage() {}
+func (*UploadAlbumCoverResponse) ProtoMessage() {}
 
-func (x *UpdateTrackResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[8]
+func (x *UploadAlbumCoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,40 +3844,40 @@ func (x *UpdateTrackResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTrackResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTrackResponse) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use UploadAlbumCoverResponse.ProtoReflect.Descriptor instead.
+func (*UploadAlbumCoverResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *UpdateTrackResponse) GetMessage() string {
+func (x *UploadAlbumCoverResponse) GetAlbum() *Album {
 	if x != nil {
-		return x.Message
+		return x.Album
 	}
-	return ""
+	return nil
 }
 
-type DeleteTrackRequest struct {
+type DeleteAlbumCoverRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AlbumId       string                 `protobuf:"bytes,1,opt,name=album_id,json=albumId,proto3" json:"album_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTrackRequest) Reset() {
-	*x = DeleteTrackRequest{}
-	mi := &file_proto_track_proto_msgTypes[9]
+func (x *DeleteAlbumCoverRequest) Reset() {
+	*x = DeleteAlbumCoverRequest{}
+	mi := &file_proto_track_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTrackRequest) String() string {
+func (x *DeleteAlbumCoverRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTrackRequest) ProtoMessage() {}
+func (*DeleteAlbumCoverRequest) ProtoMessage() {}
 
-func (x *DeleteTrackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[9]
+func (x *DeleteAlbumCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -569,40 +3888,40 @@ func (x *DeleteTrackRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTrackRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTrackRequest) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use DeleteAlbumCoverRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAlbumCoverRequest) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *DeleteTrackRequest) GetId() string {
+func (x *DeleteAlbumCoverRequest) GetAlbumId() string {
 	if x != nil {
-		return x.Id
+		return x.AlbumId
 	}
 	return ""
 }
 
-type DeleteTrackResponse struct {
+type DeleteAlbumCoverResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Album         *Album                 `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTrackResponse) Reset() {
-	*x = DeleteTrackResponse{}
-	mi := &file_proto_track_proto_msgTypes[10]
+func (x *DeleteAlbumCoverResponse) Reset() {
+	*x = DeleteAlbumCoverResponse{}
+	mi := &file_proto_track_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTrackResponse) String() string {
+func (x *DeleteAlbumCoverResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTrackResponse) ProtoMessage() {}
+func (*DeleteAlbumCoverResponse) ProtoMessage() {}
 
-func (x *DeleteTrackResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_track_proto_msgTypes[10]
+func (x *DeleteAlbumCoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_track_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -613,23 +3932,23 @@ func (x *DeleteTrackResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTrackResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTrackResponse) Descriptor() ([]byte, []int) {
-	return file_proto_track_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DeleteAlbumCoverResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAlbumCoverResponse) Descriptor() ([]byte, []int) {
+	return file_proto_track_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *DeleteTrackResponse) GetMessage() string {
+func (x *DeleteAlbumCoverResponse) GetAlbum() *Album {
 	if x != nil {
-		return x.Message
+		return x.Album
 	}
-	return ""
+	return nil
 }
 
 var File_proto_track_proto protoreflect.FileDescriptor
 
 const file_proto_track_proto_rawDesc = "" +
 	"\n" +
-	"\x11proto/track.proto\x12\x05track\"\x9d\x01\n" +
+	"\x11proto/track.proto\x12\x05track\"\xde\x04\n" +
 	"\x05Track\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
@@ -637,43 +3956,328 @@ const file_proto_track_proto_rawDesc = "" +
 	"\x05album\x18\x04 \x01(\tR\x05album\x12!\n" +
 	"\fduration_sec\x18\x05 \x01(\x05R\vdurationSec\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"{\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1a\n" +
+	"\bexplicit\x18\a \x01(\bR\bexplicit\x12\x1e\n" +
+	"\n" +
+	"popularity\x18\b \x01(\x03R\n" +
+	"popularity\x12\x16\n" +
+	"\x06genres\x18\t \x03(\tR\x06genres\x12!\n" +
+	"\frelease_date\x18\n" +
+	" \x01(\tR\vreleaseDate\x12\x12\n" +
+	"\x04isrc\x18\v \x01(\tR\x04isrc\x12\x1f\n" +
+	"\vdisc_number\x18\f \x01(\x05R\n" +
+	"discNumber\x12!\n" +
+	"\ftrack_number\x18\r \x01(\x05R\vtrackNumber\x12\x1a\n" +
+	"\blanguage\x18\x0e \x01(\tR\blanguage\x12\x10\n" +
+	"\x03bpm\x18\x0f \x01(\x01R\x03bpm\x12\x10\n" +
+	"\x03key\x18\x10 \x01(\tR\x03key\x12-\n" +
+	"\aartists\x18\x11 \x03(\v2\x13.track.ArtistCreditR\aartists\x12\x19\n" +
+	"\balbum_id\x18\x12 \x01(\tR\aalbumId\x12&\n" +
+	"\x05audio\x18\x13 \x01(\v2\x10.track.AudioFileR\x05audio\x12$\n" +
+	"\x05cover\x18\x14 \x01(\v2\x0e.track.ArtworkR\x05cover\x12\x18\n" +
+	"\aversion\x18\x15 \x01(\x03R\aversion\"\xe3\x01\n" +
+	"\aArtwork\x12\x1b\n" +
+	"\tmime_type\x18\x01 \x01(\tR\bmimeType\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x12\x10\n" +
+	"\x03url\x18\a \x01(\tR\x03url\x127\n" +
+	"\n" +
+	"thumbnails\x18\b \x03(\v2\x17.track.ArtworkThumbnailR\n" +
+	"thumbnails\"f\n" +
+	"\x10ArtworkThumbnail\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x05R\x04size\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\"\x9b\x02\n" +
+	"\tAudioFile\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x14\n" +
+	"\x05codec\x18\x02 \x01(\tR\x05codec\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x18\n" +
+	"\abitrate\x18\x05 \x01(\x05R\abitrate\x12\x1f\n" +
+	"\vsample_rate\x18\x06 \x01(\x05R\n" +
+	"sampleRate\x12\x1a\n" +
+	"\bchannels\x18\a \x01(\x05R\bchannels\x12\x1f\n" +
+	"\vduration_ms\x18\b \x01(\x03R\n" +
+	"durationMs\x12\x16\n" +
+	"\x06sha256\x18\t \x01(\tR\x06sha256\x12\x1f\n" +
+	"\vuploaded_at\x18\n" +
+	" \x01(\x03R\n" +
+	"uploadedAt\"[\n" +
+	"\fArtistCredit\x12\x1b\n" +
+	"\tartist_id\x18\x01 \x01(\tR\bartistId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bfeatured\x18\x03 \x01(\bR\bfeatured\"\xd2\x03\n" +
 	"\x12CreateTrackRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
 	"\x06artist\x18\x02 \x01(\tR\x06artist\x12\x14\n" +
 	"\x05album\x18\x03 \x01(\tR\x05album\x12!\n" +
-	"\fduration_sec\x18\x04 \x01(\x05R\vdurationSec\"9\n" +
+	"\fduration_sec\x18\x04 \x01(\x05R\vdurationSec\x12\x1a\n" +
+	"\bexplicit\x18\x05 \x01(\bR\bexplicit\x12\x16\n" +
+	"\x06genres\x18\x06 \x03(\tR\x06genres\x12!\n" +
+	"\frelease_date\x18\a \x01(\tR\vreleaseDate\x12\x12\n" +
+	"\x04isrc\x18\b \x01(\tR\x04isrc\x12\x1f\n" +
+	"\vdisc_number\x18\t \x01(\x05R\n" +
+	"discNumber\x12!\n" +
+	"\ftrack_number\x18\n" +
+	" \x01(\x05R\vtrackNumber\x12\x1a\n" +
+	"\blanguage\x18\v \x01(\tR\blanguage\x12\x10\n" +
+	"\x03bpm\x18\f \x01(\x01R\x03bpm\x12\x10\n" +
+	"\x03key\x18\r \x01(\tR\x03key\x12\x1b\n" +
+	"\tartist_id\x18\x0e \x01(\tR\bartistId\x12.\n" +
+	"\x13featured_artist_ids\x18\x0f \x03(\tR\x11featuredArtistIds\x12\x19\n" +
+	"\balbum_id\x18\x10 \x01(\tR\aalbumId\"9\n" +
 	"\x13CreateTrackResponse\x12\"\n" +
 	"\x05track\x18\x01 \x01(\v2\f.track.TrackR\x05track\"%\n" +
 	"\x13GetTrackByIDRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\":\n" +
 	"\x14GetTrackByIDResponse\x12\"\n" +
-	"\x05track\x18\x01 \x01(\v2\f.track.TrackR\x05track\"m\n" +
+	"\x05track\x18\x01 \x01(\v2\f.track.TrackR\x05track\"\xb8\x04\n" +
 	"\x13GetAllTracksRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
 	"\x06artist\x18\x02 \x01(\tR\x06artist\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x03R\x04page\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x03R\x05limit\"<\n" +
+	"\x05limit\x18\x04 \x01(\x03R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x17\n" +
+	"\asort_by\x18\a \x01(\tR\x06sortBy\x12\x14\n" +
+	"\x05order\x18\b \x01(\tR\x05order\x12#\n" +
+	"\rinclude_total\x18\t \x01(\bR\fincludeTotal\x12\x16\n" +
+	"\x06genres\x18\n" +
+	" \x03(\tR\x06genres\x12*\n" +
+	"\x11release_date_from\x18\v \x01(\tR\x0freleaseDateFrom\x12&\n" +
+	"\x0frelease_date_to\x18\f \x01(\tR\rreleaseDateTo\x12\x12\n" +
+	"\x04isrc\x18\r \x01(\tR\x04isrc\x12\x1a\n" +
+	"\blanguage\x18\x0e \x01(\tR\blanguage\x12\x1f\n" +
+	"\bexplicit\x18\x0f \x01(\bH\x00R\bexplicit\x88\x01\x01\x12\x17\n" +
+	"\abpm_min\x18\x10 \x01(\x01R\x06bpmMin\x12\x17\n" +
+	"\abpm_max\x18\x11 \x01(\x01R\x06bpmMax\x12\x10\n" +
+	"\x03key\x18\x12 \x01(\tR\x03key\x12\x1b\n" +
+	"\tartist_id\x18\x13 \x01(\tR\bartistId\x12\x19\n" +
+	"\balbum_id\x18\x14 \x01(\tR\aalbumIdB\v\n" +
+	"\t_explicit\"~\n" +
 	"\x14GetAllTracksResponse\x12$\n" +
-	"\x06tracks\x18\x01 \x03(\v2\f.track.TrackR\x06tracks\"\x8b\x01\n" +
+	"\x06tracks\x18\x01 \x03(\v2\f.track.TrackR\x06tracks\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x03R\n" +
+	"totalCount\"n\n" +
+	"\x13SearchTracksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\"G\n" +
+	"\vScoredTrack\x12\"\n" +
+	"\x05track\x18\x01 \x01(\v2\f.track.TrackR\x05track\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"D\n" +
+	"\x14SearchTracksResponse\x12,\n" +
+	"\aresults\x18\x01 \x03(\v2\x12.track.ScoredTrackR\aresults\"W\n" +
+	"\x0eSuggestRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\xa2\x01\n" +
+	"\n" +
+	"Suggestion\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1a\n" +
+	"\bsubtitle\x18\x03 \x01(\tR\bsubtitle\x12\x19\n" +
+	"\btrack_id\x18\x04 \x01(\tR\atrackId\x12\x1f\n" +
+	"\vtrack_count\x18\x05 \x01(\x05R\n" +
+	"trackCount\x12\x14\n" +
+	"\x05fuzzy\x18\x06 \x01(\bR\x05fuzzy\"F\n" +
+	"\x0fSuggestResponse\x123\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x11.track.SuggestionR\vsuggestions\"\x82\x02\n" +
+	"\n" +
+	"TrackEvent\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x19\n" +
+	"\btrack_id\x18\x04 \x01(\tR\atrackId\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x1f\n" +
+	"\voccurred_at\x18\x06 \x01(\x03R\n" +
+	"occurredAt\x12$\n" +
+	"\x06before\x18\a \x01(\v2\f.track.TrackR\x06before\x12\"\n" +
+	"\x05after\x18\b \x01(\v2\f.track.TrackR\x05after\"j\n" +
+	"\x17UploadTrackAudioRequest\x12,\n" +
+	"\x04info\x18\x01 \x01(\v2\x16.track.AudioUploadInfoH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"H\n" +
+	"\x0fAudioUploadInfo\x12\x19\n" +
+	"\btrack_id\x18\x01 \x01(\tR\atrackId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"\x80\x01\n" +
+	"\x18UploadTrackAudioResponse\x12\"\n" +
+	"\x05track\x18\x01 \x01(\v2\f.track.TrackR\x05track\x12\"\n" +
+	"\fdeduplicated\x18\x02 \x01(\bR\fdeduplicated\x12\x1c\n" +
+	"\tprefilled\x18\x03 \x03(\tR\tprefilled\"d\n" +
+	"\x17StreamTrackAudioRequest\x12\x19\n" +
+	"\btrack_id\x18\x01 \x01(\tR\atrackId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\" \n" +
+	"\n" +
+	"AudioChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"J\n" +
+	"\x17UploadTrackCoverRequest\x12\x19\n" +
+	"\btrack_id\x18\x01 \x01(\tR\atrackId\x12\x14\n" +
+	"\x05image\x18\x02 \x01(\fR\x05image\">\n" +
+	"\x18UploadTrackCoverResponse\x12\"\n" +
+	"\x05track\x18\x01 \x01(\v2\f.track.TrackR\x05track\"4\n" +
+	"\x17DeleteTrackCoverRequest\x12\x19\n" +
+	"\btrack_id\x18\x01 \x01(\tR\atrackId\">\n" +
+	"\x18DeleteTrackCoverResponse\x12\"\n" +
+	"\x05track\x18\x01 \x01(\v2\f.track.TrackR\x05track\",\n" +
+	"\x16GetArtworkImageRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"J\n" +
+	"\x17GetArtworkImageResponse\x12\x1b\n" +
+	"\tmime_type\x18\x01 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"4\n" +
+	"\x17GetTrackAnalysisRequest\x12\x19\n" +
+	"\btrack_id\x18\x01 \x01(\tR\atrackId\"\xed\x01\n" +
+	"\x18GetTrackAnalysisResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1a\n" +
+	"\bwaveform\x18\x02 \x01(\fR\bwaveform\x12'\n" +
+	"\x0fintegrated_lufs\x18\x03 \x01(\x01R\x0eintegratedLufs\x12$\n" +
+	"\x0etrue_peak_dbtp\x18\x04 \x01(\x01R\ftruePeakDbtp\x12\x17\n" +
+	"\again_db\x18\x05 \x01(\x01R\x06gainDb\x12\x1f\n" +
+	"\vanalyzed_at\x18\x06 \x01(\x03R\n" +
+	"analyzedAt\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\xf4\x03\n" +
 	"\x12UpdateTrackRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
 	"\x06artist\x18\x03 \x01(\tR\x06artist\x12\x14\n" +
 	"\x05album\x18\x04 \x01(\tR\x05album\x12!\n" +
-	"\fduration_sec\x18\x05 \x01(\x05R\vdurationSec\"/\n" +
+	"\fduration_sec\x18\x05 \x01(\x05R\vdurationSec\x12\x1f\n" +
+	"\bexplicit\x18\x06 \x01(\bH\x00R\bexplicit\x88\x01\x01\x12\x16\n" +
+	"\x06genres\x18\a \x03(\tR\x06genres\x12!\n" +
+	"\frelease_date\x18\b \x01(\tR\vreleaseDate\x12\x12\n" +
+	"\x04isrc\x18\t \x01(\tR\x04isrc\x12\x1f\n" +
+	"\vdisc_number\x18\n" +
+	" \x01(\x05R\n" +
+	"discNumber\x12!\n" +
+	"\ftrack_number\x18\v \x01(\x05R\vtrackNumber\x12\x1a\n" +
+	"\blanguage\x18\f \x01(\tR\blanguage\x12\x10\n" +
+	"\x03bpm\x18\r \x01(\x01R\x03bpm\x12\x10\n" +
+	"\x03key\x18\x0e \x01(\tR\x03key\x12\x1b\n" +
+	"\tartist_id\x18\x0f \x01(\tR\bartistId\x12.\n" +
+	"\x13featured_artist_ids\x18\x10 \x03(\tR\x11featuredArtistIds\x12\x19\n" +
+	"\balbum_id\x18\x11 \x01(\tR\aalbumIdB\v\n" +
+	"\t_explicit\"/\n" +
 	"\x13UpdateTrackResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"$\n" +
 	"\x12DeleteTrackRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
 	"\x13DeleteTrackResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\xf2\x02\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"K\n" +
+	"\x06Artist\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\")\n" +
+	"\x13CreateArtistRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"=\n" +
+	"\x14CreateArtistResponse\x12%\n" +
+	"\x06artist\x18\x01 \x01(\v2\r.track.ArtistR\x06artist\"\"\n" +
+	"\x10GetArtistRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"|\n" +
+	"\x11GetArtistResponse\x12%\n" +
+	"\x06artist\x18\x01 \x01(\v2\r.track.ArtistR\x06artist\x12\x1f\n" +
+	"\vtrack_count\x18\x02 \x01(\x03R\n" +
+	"trackCount\x12\x1f\n" +
+	"\valbum_count\x18\x03 \x01(\x03R\n" +
+	"albumCount\"V\n" +
+	"\x12ListArtistsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\">\n" +
+	"\x13ListArtistsResponse\x12'\n" +
+	"\aartists\x18\x01 \x03(\v2\r.track.ArtistR\aartists\"9\n" +
+	"\x13UpdateArtistRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"=\n" +
+	"\x14UpdateArtistResponse\x12%\n" +
+	"\x06artist\x18\x01 \x01(\v2\r.track.ArtistR\x06artist\"%\n" +
+	"\x13DeleteArtistRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x14DeleteArtistResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xca\x01\n" +
+	"\x05Album\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1b\n" +
+	"\tartist_id\x18\x03 \x01(\tR\bartistId\x12\x16\n" +
+	"\x06artist\x18\x04 \x01(\tR\x06artist\x12!\n" +
+	"\frelease_date\x18\x05 \x01(\tR\vreleaseDate\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12$\n" +
+	"\x05cover\x18\a \x01(\v2\x0e.track.ArtworkR\x05cover\"j\n" +
+	"\x12CreateAlbumRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1b\n" +
+	"\tartist_id\x18\x02 \x01(\tR\bartistId\x12!\n" +
+	"\frelease_date\x18\x03 \x01(\tR\vreleaseDate\"9\n" +
+	"\x13CreateAlbumResponse\x12\"\n" +
+	"\x05album\x18\x01 \x01(\v2\f.track.AlbumR\x05album\":\n" +
+	"\x0fGetAlbumRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\\\n" +
+	"\x10GetAlbumResponse\x12\"\n" +
+	"\x05album\x18\x01 \x01(\v2\f.track.AlbumR\x05album\x12$\n" +
+	"\x06tracks\x18\x02 \x03(\v2\f.track.TrackR\x06tracks\"Z\n" +
+	"\x11ListAlbumsRequest\x12\x1b\n" +
+	"\tartist_id\x18\x01 \x01(\tR\bartistId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\":\n" +
+	"\x12ListAlbumsResponse\x12$\n" +
+	"\x06albums\x18\x01 \x03(\v2\f.track.AlbumR\x06albums\"]\n" +
+	"\x12UpdateAlbumRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12!\n" +
+	"\frelease_date\x18\x03 \x01(\tR\vreleaseDate\"9\n" +
+	"\x13UpdateAlbumResponse\x12\"\n" +
+	"\x05album\x18\x01 \x01(\v2\f.track.AlbumR\x05album\"$\n" +
+	"\x12DeleteAlbumRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	"\x13DeleteAlbumResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"J\n" +
+	"\x17UploadAlbumCoverRequest\x12\x19\n" +
+	"\balbum_id\x18\x01 \x01(\tR\aalbumId\x12\x14\n" +
+	"\x05image\x18\x02 \x01(\fR\x05image\">\n" +
+	"\x18UploadAlbumCoverResponse\x12\"\n" +
+	"\x05album\x18\x01 \x01(\v2\f.track.AlbumR\x05album\"4\n" +
+	"\x17DeleteAlbumCoverRequest\x12\x19\n" +
+	"\balbum_id\x18\x01 \x01(\tR\aalbumId\">\n" +
+	"\x18DeleteAlbumCoverResponse\x12\"\n" +
+	"\x05album\x18\x01 \x01(\v2\f.track.AlbumR\x05album2\xe6\a\n" +
 	"\fTrackService\x12D\n" +
 	"\vCreateTrack\x12\x19.track.CreateTrackRequest\x1a\x1a.track.CreateTrackResponse\x12G\n" +
 	"\fGetTrackByID\x12\x1a.track.GetTrackByIDRequest\x1a\x1b.track.GetTrackByIDResponse\x12G\n" +
-	"\fGetAllTracks\x12\x1a.track.GetAllTracksRequest\x1a\x1b.track.GetAllTracksResponse\x12D\n" +
-	"\vUpdateTrack\x12\x19.track.UpdateTrackRequest\x1a\x1a.track.UpdateTrackResponse\x12D\n" +
-	"\vDeleteTrack\x12\x19.track.DeleteTrackRequest\x1a\x1a.track.DeleteTrackResponseB)Z'github.com/your-org/track-service/protob\x06proto3"
+	"\fGetAllTracks\x12\x1a.track.GetAllTracksRequest\x1a\x1b.track.GetAllTracksResponse\x12G\n" +
+	"\fSearchTracks\x12\x1a.track.SearchTracksRequest\x1a\x1b.track.SearchTracksResponse\x128\n" +
+	"\aSuggest\x12\x15.track.SuggestRequest\x1a\x16.track.SuggestResponse\x12D\n" +
+	"\vUpdateTrack\x12\x19.track.UpdateTrackRequest\x1a\x1a.track.UpdateTrackResponse\x12U\n" +
+	"\x10UploadTrackAudio\x12\x1e.track.UploadTrackAudioRequest\x1a\x1f.track.UploadTrackAudioResponse(\x01\x12G\n" +
+	"\x10StreamTrackAudio\x12\x1e.track.StreamTrackAudioRequest\x1a\x11.track.AudioChunk0\x01\x12S\n" +
+	"\x10GetTrackAnalysis\x12\x1e.track.GetTrackAnalysisRequest\x1a\x1f.track.GetTrackAnalysisResponse\x12D\n" +
+	"\vDeleteTrack\x12\x19.track.DeleteTrackRequest\x1a\x1a.track.DeleteTrackResponse\x12S\n" +
+	"\x10UploadTrackCover\x12\x1e.track.UploadTrackCoverRequest\x1a\x1f.track.UploadTrackCoverResponse\x12S\n" +
+	"\x10DeleteTrackCover\x12\x1e.track.DeleteTrackCoverRequest\x1a\x1f.track.DeleteTrackCoverResponse\x12P\n" +
+	"\x0fGetArtworkImage\x12\x1d.track.GetArtworkImageRequest\x1a\x1e.track.GetArtworkImageResponse2\xf0\x02\n" +
+	"\rArtistService\x12G\n" +
+	"\fCreateArtist\x12\x1a.track.CreateArtistRequest\x1a\x1b.track.CreateArtistResponse\x12>\n" +
+	"\tGetArtist\x12\x17.track.GetArtistRequest\x1a\x18.track.GetArtistResponse\x12D\n" +
+	"\vListArtists\x12\x19.track.ListArtistsRequest\x1a\x1a.track.ListArtistsResponse\x12G\n" +
+	"\fUpdateArtist\x12\x1a.track.UpdateArtistRequest\x1a\x1b.track.UpdateArtistResponse\x12G\n" +
+	"\fDeleteArtist\x12\x1a.track.DeleteArtistRequest\x1a\x1b.track.DeleteArtistResponse2\x8a\x04\n" +
+	"\fAlbumService\x12D\n" +
+	"\vCreateAlbum\x12\x19.track.CreateAlbumRequest\x1a\x1a.track.CreateAlbumResponse\x12;\n" +
+	"\bGetAlbum\x12\x16.track.GetAlbumRequest\x1a\x17.track.GetAlbumResponse\x12A\n" +
+	"\n" +
+	"ListAlbums\x12\x18.track.ListAlbumsRequest\x1a\x19.track.ListAlbumsResponse\x12D\n" +
+	"\vUpdateAlbum\x12\x19.track.UpdateAlbumRequest\x1a\x1a.track.UpdateAlbumResponse\x12D\n" +
+	"\vDeleteAlbum\x12\x19.track.DeleteAlbumRequest\x1a\x1a.track.DeleteAlbumResponse\x12S\n" +
+	"\x10UploadAlbumCover\x12\x1e.track.UploadAlbumCoverRequest\x1a\x1f.track.UploadAlbumCoverResponse\x12S\n" +
+	"\x10DeleteAlbumCover\x12\x1e.track.DeleteAlbumCoverRequest\x1a\x1f.track.DeleteAlbumCoverResponseB6Z4github.com/Zhanbatyr06/ADP2_ASS1/track-service/protob\x06proto3"
 
 var (
 	file_proto_track_proto_rawDescOnce sync.Once
@@ -687,39 +4291,154 @@ func file_proto_track_proto_rawDescGZIP() []byte {
 	return file_proto_track_proto_rawDescData
 }
 
-var file_proto_track_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_track_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_proto_track_proto_goTypes = []any{
-	(*Track)(nil),                // 0: track.Track
-	(*CreateTrackRequest)(nil),   // 1: track.CreateTrackRequest
-	(*CreateTrackResponse)(nil),  // 2: track.CreateTrackResponse
-	(*GetTrackByIDRequest)(nil),  // 3: track.GetTrackByIDRequest
-	(*GetTrackByIDResponse)(nil), // 4: track.GetTrackByIDResponse
-	(*GetAllTracksRequest)(nil),  // 5: track.GetAllTracksRequest
-	(*GetAllTracksResponse)(nil), // 6: track.GetAllTracksResponse
-	(*UpdateTrackRequest)(nil),   // 7: track.UpdateTrackRequest
-	(*UpdateTrackResponse)(nil),  // 8: track.UpdateTrackResponse
-	(*DeleteTrackRequest)(nil),   // 9: track.DeleteTrackRequest
-	(*DeleteTrackResponse)(nil),  // 10: track.DeleteTrackResponse
+	(*Track)(nil),                    // 0: track.Track
+	(*Artwork)(nil),                  // 1: track.Artwork
+	(*ArtworkThumbnail)(nil),         // 2: track.ArtworkThumbnail
+	(*AudioFile)(nil),                // 3: track.AudioFile
+	(*ArtistCredit)(nil),             // 4: track.ArtistCredit
+	(*CreateTrackRequest)(nil),       // 5: track.CreateTrackRequest
+	(*CreateTrackResponse)(nil),      // 6: track.CreateTrackResponse
+	(*GetTrackByIDRequest)(nil),      // 7: track.GetTrackByIDRequest
+	(*GetTrackByIDResponse)(nil),     // 8: track.GetTrackByIDResponse
+	(*GetAllTracksRequest)(nil),      // 9: track.GetAllTracksRequest
+	(*GetAllTracksResponse)(nil),     // 10: track.GetAllTracksResponse
+	(*SearchTracksRequest)(nil),      // 11: track.SearchTracksRequest
+	(*ScoredTrack)(nil),              // 12: track.ScoredTrack
+	(*SearchTracksResponse)(nil),     // 13: track.SearchTracksResponse
+	(*SuggestRequest)(nil),           // 14: track.SuggestRequest
+	(*Suggestion)(nil),               // 15: track.Suggestion
+	(*SuggestResponse)(nil),          // 16: track.SuggestResponse
+	(*TrackEvent)(nil),               // 17: track.TrackEvent
+	(*UploadTrackAudioRequest)(nil),  // 18: track.UploadTrackAudioRequest
+	(*AudioUploadInfo)(nil),          // 19: track.AudioUploadInfo
+	(*UploadTrackAudioResponse)(nil), // 20: track.UploadTrackAudioResponse
+	(*StreamTrackAudioRequest)(nil),  // 21: track.StreamTrackAudioRequest
+	(*AudioChunk)(nil),               // 22: track.AudioChunk
+	(*UploadTrackCoverRequest)(nil),  // 23: track.UploadTrackCoverRequest
+	(*UploadTrackCoverResponse)(nil), // 24: track.UploadTrackCoverResponse
+	(*DeleteTrackCoverRequest)(nil),  // 25: track.DeleteTrackCoverRequest
+	(*DeleteTrackCoverResponse)(nil), // 26: track.DeleteTrackCoverResponse
+	(*GetArtworkImageRequest)(nil),   // 27: track.GetArtworkImageRequest
+	(*GetArtworkImageResponse)(nil),  // 28: track.GetArtworkImageResponse
+	(*GetTrackAnalysisRequest)(nil),  // 29: track.GetTrackAnalysisRequest
+	(*GetTrackAnalysisResponse)(nil), // 30: track.GetTrackAnalysisResponse
+	(*UpdateTrackRequest)(nil),       // 31: track.UpdateTrackRequest
+	(*UpdateTrackResponse)(nil),      // 32: track.UpdateTrackResponse
+	(*DeleteTrackRequest)(nil),       // 33: track.DeleteTrackRequest
+	(*DeleteTrackResponse)(nil),      // 34: track.DeleteTrackResponse
+	(*Artist)(nil),                   // 35: track.Artist
+	(*CreateArtistRequest)(nil),      // 36: track.CreateArtistRequest
+	(*CreateArtistResponse)(nil),     // 37: track.CreateArtistResponse
+	(*GetArtistRequest)(nil),         // 38: track.GetArtistRequest
+	(*GetArtistResponse)(nil),        // 39: track.GetArtistResponse
+	(*ListArtistsRequest)(nil),       // 40: track.ListArtistsRequest
+	(*ListArtistsResponse)(nil),      // 41: track.ListArtistsResponse
+	(*UpdateArtistRequest)(nil),      // 42: track.UpdateArtistRequest
+	(*UpdateArtistResponse)(nil),     // 43: track.UpdateArtistResponse
+	(*DeleteArtistRequest)(nil),      // 44: track.DeleteArtistRequest
+	(*DeleteArtistResponse)(nil),     // 45: track.DeleteArtistResponse
+	(*Album)(nil),                    // 46: track.Album
+	(*CreateAlbumRequest)(nil),       // 47: track.CreateAlbumRequest
+	(*CreateAlbumResponse)(nil),      // 48: track.CreateAlbumResponse
+	(*GetAlbumRequest)(nil),          // 49: track.GetAlbumRequest
+	(*GetAlbumResponse)(nil),         // 50: track.GetAlbumResponse
+	(*ListAlbumsRequest)(nil),        // 51: track.ListAlbumsRequest
+	(*ListAlbumsResponse)(nil),       // 52: track.ListAlbumsResponse
+	(*UpdateAlbumRequest)(nil),       // 53: track.UpdateAlbumRequest
+	(*UpdateAlbumResponse)(nil),      // 54: track.UpdateAlbumResponse
+	(*DeleteAlbumRequest)(nil),       // 55: track.DeleteAlbumRequest
+	(*DeleteAlbumResponse)(nil),      // 56: track.DeleteAlbumResponse
+	(*UploadAlbumCoverRequest)(nil),  // 57: track.UploadAlbumCoverRequest
+	(*UploadAlbumCoverResponse)(nil), // 58: track.UploadAlbumCoverResponse
+	(*DeleteAlbumCoverRequest)(nil),  // 59: track.DeleteAlbumCoverRequest
+	(*DeleteAlbumCoverResponse)(nil), // 60: track.DeleteAlbumCoverResponse
 }
 var file_proto_track_proto_depIdxs = []int32{
-	0,  // 0: track.CreateTrackResponse.track:type_name -> track.Track
-	0,  // 1: track.GetTrackByIDResponse.track:type_name -> track.Track
-	0,  // 2: track.GetAllTracksResponse.tracks:type_name -> track.Track
-	1,  // 3: track.TrackService.CreateTrack:input_type -> track.CreateTrackRequest
-	3,  // 4: track.TrackService.GetTrackByID:input_type -> track.GetTrackByIDRequest
-	5,  // 5: track.TrackService.GetAllTracks:input_type -> track.GetAllTracksRequest
-	7,  // 6: track.TrackService.UpdateTrack:input_type -> track.UpdateTrackRequest
-	9,  // 7: track.TrackService.DeleteTrack:input_type -> track.DeleteTrackRequest
-	2,  // 8: track.TrackService.CreateTrack:output_type -> track.CreateTrackResponse
-	4,  // 9: track.TrackService.GetTrackByID:output_type -> track.GetTrackByIDResponse
-	6,  // 10: track.TrackService.GetAllTracks:output_type -> track.GetAllTracksResponse
-	8,  // 11: track.TrackService.UpdateTrack:output_type -> track.UpdateTrackResponse
-	10, // 12: track.TrackService.DeleteTrack:output_type -> track.DeleteTrackResponse
-	8,  // [8:13] is the sub-list for method output_type
-	3,  // [3:8] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	4,  // 0: track.Track.artists:type_name -> track.ArtistCredit
+	3,  // 1: track.Track.audio:type_name -> track.AudioFile
+	1,  // 2: track.Track.cover:type_name -> track.Artwork
+	2,  // 3: track.Artwork.thumbnails:type_name -> track.ArtworkThumbnail
+	0,  // 4: track.CreateTrackResponse.track:type_name -> track.Track
+	0,  // 5: track.GetTrackByIDResponse.track:type_name -> track.Track
+	0,  // 6: track.GetAllTracksResponse.tracks:type_name -> track.Track
+	0,  // 7: track.ScoredTrack.track:type_name -> track.Track
+	12, // 8: track.SearchTracksResponse.results:type_name -> track.ScoredTrack
+	15, // 9: track.SuggestResponse.suggestions:type_name -> track.Suggestion
+	0,  // 10: track.TrackEvent.before:type_name -> track.Track
+	0,  // 11: track.TrackEvent.after:type_name -> track.Track
+	19, // 12: track.UploadTrackAudioRequest.info:type_name -> track.AudioUploadInfo
+	0,  // 13: track.UploadTrackAudioResponse.track:type_name -> track.Track
+	0,  // 14: track.UploadTrackCoverResponse.track:type_name -> track.Track
+	0,  // 15: track.DeleteTrackCoverResponse.track:type_name -> track.Track
+	35, // 16: track.CreateArtistResponse.artist:type_name -> track.Artist
+	35, // 17: track.GetArtistResponse.artist:type_name -> track.Artist
+	35, // 18: track.ListArtistsResponse.artists:type_name -> track.Artist
+	35, // 19: track.UpdateArtistResponse.artist:type_name -> track.Artist
+	1,  // 20: track.Album.cover:type_name -> track.Artwork
+	46, // 21: track.CreateAlbumResponse.album:type_name -> track.Album
+	46, // 22: track.GetAlbumResponse.album:type_name -> track.Album
+	0,  // 23: track.GetAlbumResponse.tracks:type_name -> track.Track
+	46, // 24: track.ListAlbumsResponse.albums:type_name -> track.Album
+	46, // 25: track.UpdateAlbumResponse.album:type_name -> track.Album
+	46, // 26: track.UploadAlbumCoverResponse.album:type_name -> track.Album
+	46, // 27: track.DeleteAlbumCoverResponse.album:type_name -> track.Album
+	5,  // 28: track.TrackService.CreateTrack:input_type -> track.CreateTrackRequest
+	7,  // 29: track.TrackService.GetTrackByID:input_type -> track.GetTrackByIDRequest
+	9,  // 30: track.TrackService.GetAllTracks:input_type -> track.GetAllTracksRequest
+	11, // 31: track.TrackService.SearchTracks:input_type -> track.SearchTracksRequest
+	14, // 32: track.TrackService.Suggest:input_type -> track.SuggestRequest
+	31, // 33: track.TrackService.UpdateTrack:input_type -> track.UpdateTrackRequest
+	18, // 34: track.TrackService.UploadTrackAudio:input_type -> track.UploadTrackAudioRequest
+	21, // 35: track.TrackService.StreamTrackAudio:input_type -> track.StreamTrackAudioRequest
+	29, // 36: track.TrackService.GetTrackAnalysis:input_type -> track.GetTrackAnalysisRequest
+	33, // 37: track.TrackService.DeleteTrack:input_type -> track.DeleteTrackRequest
+	23, // 38: track.TrackService.UploadTrackCover:input_type -> track.UploadTrackCoverRequest
+	25, // 39: track.TrackService.DeleteTrackCover:input_type -> track.DeleteTrackCoverRequest
+	27, // 40: track.TrackService.GetArtworkImage:input_type -> track.GetArtworkImageRequest
+	36, // 41: track.ArtistService.CreateArtist:input_type -> track.CreateArtistRequest
+	38, // 42: track.ArtistService.GetArtist:input_type -> track.GetArtistRequest
+	40, // 43: track.ArtistService.ListArtists:input_type -> track.ListArtistsRequest
+	42, // 44: track.ArtistService.UpdateArtist:input_type -> track.UpdateArtistRequest
+	44, // 45: track.ArtistService.DeleteArtist:input_type -> track.DeleteArtistRequest
+	47, // 46: track.AlbumService.CreateAlbum:input_type -> track.CreateAlbumRequest
+	49, // 47: track.AlbumService.GetAlbum:input_type -> track.GetAlbumRequest
+	51, // 48: track.AlbumService.ListAlbums:input_type -> track.ListAlbumsRequest
+	53, // 49: track.AlbumService.UpdateAlbum:input_type -> track.UpdateAlbumRequest
+	55, // 50: track.AlbumService.DeleteAlbum:input_type -> track.DeleteAlbumRequest
+	57, // 51: track.AlbumService.UploadAlbumCover:input_type -> track.UploadAlbumCoverRequest
+	59, // 52: track.AlbumService.DeleteAlbumCover:input_type -> track.DeleteAlbumCoverRequest
+	6,  // 53: track.TrackService.CreateTrack:output_type -> track.CreateTrackResponse
+	8,  // 54: track.TrackService.GetTrackByID:output_type -> track.GetTrackByIDResponse
+	10, // 55: track.TrackService.GetAllTracks:output_type -> track.GetAllTracksResponse
+	13, // 56: track.TrackService.SearchTracks:output_type -> track.SearchTracksResponse
+	16, // 57: track.TrackService.Suggest:output_type -> track.SuggestResponse
+	32, // 58: track.TrackService.UpdateTrack:output_type -> track.UpdateTrackResponse
+	20, // 59: track.TrackService.UploadTrackAudio:output_type -> track.UploadTrackAudioResponse
+	22, // 60: track.TrackService.StreamTrackAudio:output_type -> track.AudioChunk
+	30, // 61: track.TrackService.GetTrackAnalysis:output_type -> track.GetTrackAnalysisResponse
+	34, // 62: track.TrackService.DeleteTrack:output_type -> track.DeleteTrackResponse
+	24, // 63: track.TrackService.UploadTrackCover:output_type -> track.UploadTrackCoverResponse
+	26, // 64: track.TrackService.DeleteTrackCover:output_type -> track.DeleteTrackCoverResponse
+	28, // 65: track.TrackService.GetArtworkImage:output_type -> track.GetArtworkImageResponse
+	37, // 66: track.ArtistService.CreateArtist:output_type -> track.CreateArtistResponse
+	39, // 67: track.ArtistService.GetArtist:output_type -> track.GetArtistResponse
+	41, // 68: track.ArtistService.ListArtists:output_type -> track.ListArtistsResponse
+	43, // 69: track.ArtistService.UpdateArtist:output_type -> track.UpdateArtistResponse
+	45, // 70: track.ArtistService.DeleteArtist:output_type -> track.DeleteArtistResponse
+	48, // 71: track.AlbumService.CreateAlbum:output_type -> track.CreateAlbumResponse
+	50, // 72: track.AlbumService.GetAlbum:output_type -> track.GetAlbumResponse
+	52, // 73: track.AlbumService.ListAlbums:output_type -> track.ListAlbumsResponse
+	54, // 74: track.AlbumService.UpdateAlbum:output_type -> track.UpdateAlbumResponse
+	56, // 75: track.AlbumService.DeleteAlbum:output_type -> track.DeleteAlbumResponse
+	58, // 76: track.AlbumService.UploadAlbumCover:output_type -> track.UploadAlbumCoverResponse
+	60, // 77: track.AlbumService.DeleteAlbumCover:output_type -> track.DeleteAlbumCoverResponse
+	53, // [53:78] is the sub-list for method output_type
+	28, // [28:53] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_track_proto_init() }
@@ -727,15 +4446,21 @@ func file_proto_track_proto_init() {
 	if File_proto_track_proto != nil {
 		return
 	}
+	file_proto_track_proto_msgTypes[9].OneofWrappers = []any{}
+	file_proto_track_proto_msgTypes[18].OneofWrappers = []any{
+		(*UploadTrackAudioRequest_Info)(nil),
+		(*UploadTrackAudioRequest_Chunk)(nil),
+	}
+	file_proto_track_proto_msgTypes[31].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_track_proto_rawDesc), len(file_proto_track_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   61,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_proto_track_proto_goTypes,
 		DependencyIndexes: file_proto_track_proto_depIdxs,
@@ -19,11 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TrackService_CreateTrack_FullMethodName  = "/track.TrackService/CreateTrack"
-	TrackService_GetTrackByID_FullMethodName = "/track.TrackService/GetTrackByID"
-	TrackService_GetAllTracks_FullMethodName = "/track.TrackService/GetAllTracks"
-	TrackService_UpdateTrack_FullMethodName  = "/track.TrackService/UpdateTrack"
-	TrackService_DeleteTrack_FullMethodName  = "/track.TrackService/DeleteTrack"
+	TrackService_CreateTrack_FullMethodName      = "/track.TrackService/CreateTrack"
+	TrackService_GetTrackByID_FullMethodName     = "/track.TrackService/GetTrackByID"
+	TrackService_GetAllTracks_FullMethodName     = "/track.TrackService/GetAllTracks"
+	TrackService_SearchTracks_FullMethodName     = "/track.TrackService/SearchTracks"
+	TrackService_Suggest_FullMethodName          = "/track.TrackService/Suggest"
+	TrackService_UpdateTrack_FullMethodName      = "/track.TrackService/UpdateTrack"
+	TrackService_UploadTrackAudio_FullMethodName = "/track.TrackService/UploadTrackAudio"
+	TrackService_StreamTrackAudio_FullMethodName = "/track.TrackService/StreamTrackAudio"
+	TrackService_GetTrackAnalysis_FullMethodName = "/track.TrackService/GetTrackAnalysis"
+	TrackService_DeleteTrack_FullMethodName      = "/track.TrackService/DeleteTrack"
+	TrackService_UploadTrackCover_FullMethodName = "/track.TrackService/UploadTrackCover"
+	TrackService_DeleteTrackCover_FullMethodName = "/track.TrackService/DeleteTrackCover"
+	TrackService_GetArtworkImage_FullMethodName  = "/track.TrackService/GetArtworkImage"
 )
 
 // TrackServiceClient is the client API for TrackService service.
@@ -33,8 +41,23 @@ type TrackServiceClient interface {
 	CreateTrack(ctx context.Context, in *CreateTrackRequest, opts ...grpc.CallOption) (*CreateTrackResponse, error)
 	GetTrackByID(ctx context.Context, in *GetTrackByIDRequest, opts ...grpc.CallOption) (*GetTrackByIDResponse, error)
 	GetAllTracks(ctx context.Context, in *GetAllTracksRequest, opts ...grpc.CallOption) (*GetAllTracksResponse, error)
+	SearchTracks(ctx context.Context, in *SearchTracksRequest, opts ...grpc.CallOption) (*SearchTracksResponse, error)
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
 	UpdateTrack(ctx context.Context, in *UpdateTrackRequest, opts ...grpc.CallOption) (*UpdateTrackResponse, error)
+	// Заменяет аудио трека; прежний файл удаляется, если на него больше не ссылаются.
+	// Пустые поля трека заполняются из тегов файла, обложка — из встроенной картинки.
+	UploadTrackAudio(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadTrackAudioRequest, UploadTrackAudioResponse], error)
+	// Отдает аудиофайл трека или его диапазон частями. Нужен bearer-токен слушателя,
+	// чей тариф включает качество файла
+	StreamTrackAudio(ctx context.Context, in *StreamTrackAudioRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AudioChunk], error)
+	// Огибающая и громкость аудио трека; NOT_FOUND, если аудио не загружено
+	GetTrackAnalysis(ctx context.Context, in *GetTrackAnalysisRequest, opts ...grpc.CallOption) (*GetTrackAnalysisResponse, error)
 	DeleteTrack(ctx context.Context, in *DeleteTrackRequest, opts ...grpc.CallOption) (*DeleteTrackResponse, error)
+	// Заменяет обложку трека; превью строятся при загрузке
+	UploadTrackCover(ctx context.Context, in *UploadTrackCoverRequest, opts ...grpc.CallOption) (*UploadTrackCoverResponse, error)
+	DeleteTrackCover(ctx context.Context, in *DeleteTrackCoverRequest, opts ...grpc.CallOption) (*DeleteTrackCoverResponse, error)
+	// Отдает картинку обложки или превью по имени из url; обложки трека и альбомов
+	GetArtworkImage(ctx context.Context, in *GetArtworkImageRequest, opts ...grpc.CallOption) (*GetArtworkImageResponse, error)
 }
 
 type trackServiceClient struct {
@@ -75,6 +98,26 @@ func (c *trackServiceClient) GetAllTracks(ctx context.Context, in *GetAllTracksR
 	return out, nil
 }
 
+func (c *trackServiceClient) SearchTracks(ctx context.Context, in *SearchTracksRequest, opts ...grpc.CallOption) (*SearchTracksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTracksResponse)
+	err := c.cc.Invoke(ctx, TrackService_SearchTracks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackServiceClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, TrackService_Suggest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *trackServiceClient) UpdateTrack(ctx context.Context, in *UpdateTrackRequest, opts ...grpc.CallOption) (*UpdateTrackResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateTrackResponse)
@@ -85,6 +128,48 @@ func (c *trackServiceClient) UpdateTrack(ctx context.Context, in *UpdateTrackReq
 	return out, nil
 }
 
+func (c *trackServiceClient) UploadTrackAudio(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadTrackAudioRequest, UploadTrackAudioResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TrackService_ServiceDesc.Streams[0], TrackService_UploadTrackAudio_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadTrackAudioRequest, UploadTrackAudioResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrackService_UploadTrackAudioClient = grpc.ClientStreamingClient[UploadTrackAudioRequest, UploadTrackAudioResponse]
+
+func (c *trackServiceClient) StreamTrackAudio(ctx context.Context, in *StreamTrackAudioRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AudioChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TrackService_ServiceDesc.Streams[1], TrackService_StreamTrackAudio_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTrackAudioRequest, AudioChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrackService_StreamTrackAudioClient = grpc.ServerStreamingClient[AudioChunk]
+
+func (c *trackServiceClient) GetTrackAnalysis(ctx context.Context, in *GetTrackAnalysisRequest, opts ...grpc.CallOption) (*GetTrackAnalysisResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrackAnalysisResponse)
+	err := c.cc.Invoke(ctx, TrackService_GetTrackAnalysis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *trackServiceClient) DeleteTrack(ctx context.Context, in *DeleteTrackRequest, opts ...grpc.CallOption) (*DeleteTrackResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteTrackResponse)
@@ -95,6 +180,36 @@ func (c *trackServiceClient) DeleteTrack(ctx context.Context, in *DeleteTrackReq
 	return out, nil
 }
 
+func (c *trackServiceClient) UploadTrackCover(ctx context.Context, in *UploadTrackCoverRequest, opts ...grpc.CallOption) (*UploadTrackCoverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadTrackCoverResponse)
+	err := c.cc.Invoke(ctx, TrackService_UploadTrackCover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackServiceClient) DeleteTrackCover(ctx context.Context, in *DeleteTrackCoverRequest, opts ...grpc.CallOption) (*DeleteTrackCoverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTrackCoverResponse)
+	err := c.cc.Invoke(ctx, TrackService_DeleteTrackCover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackServiceClient) GetArtworkImage(ctx context.Context, in *GetArtworkImageRequest, opts ...grpc.CallOption) (*GetArtworkImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetArtworkImageResponse)
+	err := c.cc.Invoke(ctx, TrackService_GetArtworkImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrackServiceServer is the server API for TrackService service.
 // All implementations must embed UnimplementedTrackServiceServer
 // for forward compatibility.
@@ -102,8 +217,23 @@ type TrackServiceServer interface {
 	CreateTrack(context.Context, *CreateTrackRequest) (*CreateTrackResponse, error)
 	GetTrackByID(context.Context, *GetTrackByIDRequest) (*GetTrackByIDResponse, error)
 	GetAllTracks(context.Context, *GetAllTracksRequest) (*GetAllTracksResponse, error)
+	SearchTracks(context.Context, *SearchTracksRequest) (*SearchTracksResponse, error)
+	Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error)
 	UpdateTrack(context.Context, *UpdateTrackRequest) (*UpdateTrackResponse, error)
+	// Заменяет аудио трека; прежний файл удаляется, если на него больше не ссылаются.
+	// Пустые поля трека заполняются из тегов файла, обложка — из встроенной картинки.
+	UploadTrackAudio(grpc.ClientStreamingServer[UploadTrackAudioRequest, UploadTrackAudioResponse]) error
+	// Отдает аудиофайл трека или его диапазон частями. Нужен bearer-токен слушателя,
+	// чей тариф включает качество файла
+	StreamTrackAudio(*StreamTrackAudioRequest, grpc.ServerStreamingServer[AudioChunk]) error
+	// Огибающая и громкость аудио трека; NOT_FOUND, если аудио не загружено
+	GetTrackAnalysis(context.Context, *GetTrackAnalysisRequest) (*GetTrackAnalysisResponse, error)
 	DeleteTrack(context.Context, *DeleteTrackRequest) (*DeleteTrackResponse, error)
+	// Заменяет обложку трека; превью строятся при загрузке
+	UploadTrackCover(context.Context, *UploadTrackCoverRequest) (*UploadTrackCoverResponse, error)
+	DeleteTrackCover(context.Context, *DeleteTrackCoverRequest) (*DeleteTrackCoverResponse, error)
+	// Отдает картинку обложки или превью по имени из url; обложки трека и альбомов
+	GetArtworkImage(context.Context, *GetArtworkImageRequest) (*GetArtworkImageResponse, error)
 	mustEmbedUnimplementedTrackServiceServer()
 }
 
@@ -123,12 +253,36 @@ func (UnimplementedTrackServiceServer) GetTrackByID(context.Context, *GetTrackBy
 func (UnimplementedTrackServiceServer) GetAllTracks(context.Context, *GetAllTracksRequest) (*GetAllTracksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllTracks not implemented")
 }
+func (UnimplementedTrackServiceServer) SearchTracks(context.Context, *SearchTracksRequest) (*SearchTracksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTracks not implemented")
+}
+func (UnimplementedTrackServiceServer) Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
 func (UnimplementedTrackServiceServer) UpdateTrack(context.Context, *UpdateTrackRequest) (*UpdateTrackResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTrack not implemented")
 }
+func (UnimplementedTrackServiceServer) UploadTrackAudio(grpc.ClientStreamingServer[UploadTrackAudioRequest, UploadTrackAudioResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadTrackAudio not implemented")
+}
+func (UnimplementedTrackServiceServer) StreamTrackAudio(*StreamTrackAudioRequest, grpc.ServerStreamingServer[AudioChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTrackAudio not implemented")
+}
+func (UnimplementedTrackServiceServer) GetTrackAnalysis(context.Context, *GetTrackAnalysisRequest) (*GetTrackAnalysisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrackAnalysis not implemented")
+}
 func (UnimplementedTrackServiceServer) DeleteTrack(context.Context, *DeleteTrackRequest) (*DeleteTrackResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTrack not implemented")
 }
+func (UnimplementedTrackServiceServer) UploadTrackCover(context.Context, *UploadTrackCoverRequest) (*UploadTrackCoverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadTrackCover not implemented")
+}
+func (UnimplementedTrackServiceServer) DeleteTrackCover(context.Context, *DeleteTrackCoverRequest) (*DeleteTrackCoverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTrackCover not implemented")
+}
+func (UnimplementedTrackServiceServer) GetArtworkImage(context.Context, *GetArtworkImageRequest) (*GetArtworkImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArtworkImage not implemented")
+}
 func (UnimplementedTrackServiceServer) mustEmbedUnimplementedTrackServiceServer() {}
 func (UnimplementedTrackServiceServer) testEmbeddedByValue()                      {}
 
@@ -204,6 +358,42 @@ func _TrackService_GetAllTracks_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrackService_SearchTracks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTracksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackServiceServer).SearchTracks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackService_SearchTracks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackServiceServer).SearchTracks(ctx, req.(*SearchTracksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackService_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackServiceServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackService_Suggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackServiceServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TrackService_UpdateTrack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateTrackRequest)
 	if err := dec(in); err != nil {
@@ -222,6 +412,42 @@ func _TrackService_UpdateTrack_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrackService_UploadTrackAudio_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TrackServiceServer).UploadTrackAudio(&grpc.GenericServerStream[UploadTrackAudioRequest, UploadTrackAudioResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrackService_UploadTrackAudioServer = grpc.ClientStreamingServer[UploadTrackAudioRequest, UploadTrackAudioResponse]
+
+func _TrackService_StreamTrackAudio_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTrackAudioRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrackServiceServer).StreamTrackAudio(m, &grpc.GenericServerStream[StreamTrackAudioRequest, AudioChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrackService_StreamTrackAudioServer = grpc.ServerStreamingServer[AudioChunk]
+
+func _TrackService_GetTrackAnalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrackAnalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackServiceServer).GetTrackAnalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackService_GetTrackAnalysis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackServiceServer).GetTrackAnalysis(ctx, req.(*GetTrackAnalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TrackService_DeleteTrack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteTrackRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +466,60 @@ func _TrackService_DeleteTrack_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrackService_UploadTrackCover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadTrackCoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackServiceServer).UploadTrackCover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackService_UploadTrackCover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackServiceServer).UploadTrackCover(ctx, req.(*UploadTrackCoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackService_DeleteTrackCover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTrackCoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackServiceServer).DeleteTrackCover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackService_DeleteTrackCover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackServiceServer).DeleteTrackCover(ctx, req.(*DeleteTrackCoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackService_GetArtworkImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtworkImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackServiceServer).GetArtworkImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackService_GetArtworkImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackServiceServer).GetArtworkImage(ctx, req.(*GetArtworkImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrackService_ServiceDesc is the grpc.ServiceDesc for TrackService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,14 +539,641 @@ var TrackService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllTracks",
 			Handler:    _TrackService_GetAllTracks_Handler,
 		},
+		{
+			MethodName: "SearchTracks",
+			Handler:    _TrackService_SearchTracks_Handler,
+		},
+		{
+			MethodName: "Suggest",
+			Handler:    _TrackService_Suggest_Handler,
+		},
 		{
 			MethodName: "UpdateTrack",
 			Handler:    _TrackService_UpdateTrack_Handler,
 		},
+		{
+			MethodName: "GetTrackAnalysis",
+			Handler:    _TrackService_GetTrackAnalysis_Handler,
+		},
 		{
 			MethodName: "DeleteTrack",
 			Handler:    _TrackService_DeleteTrack_Handler,
 		},
+		{
+			MethodName: "UploadTrackCover",
+			Handler:    _TrackService_UploadTrackCover_Handler,
+		},
+		{
+			MethodName: "DeleteTrackCover",
+			Handler:    _TrackService_DeleteTrackCover_Handler,
+		},
+		{
+			MethodName: "GetArtworkImage",
+			Handler:    _TrackService_GetArtworkImage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadTrackAudio",
+			Handler:       _TrackService_UploadTrackAudio_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamTrackAudio",
+			Handler:       _TrackService_StreamTrackAudio_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/track.proto",
+}
+
+const (
+	ArtistService_CreateArtist_FullMethodName = "/track.ArtistService/CreateArtist"
+	ArtistService_GetArtist_FullMethodName    = "/track.ArtistService/GetArtist"
+	ArtistService_ListArtists_FullMethodName  = "/track.ArtistService/ListArtists"
+	ArtistService_UpdateArtist_FullMethodName = "/track.ArtistService/UpdateArtist"
+	ArtistService_DeleteArtist_FullMethodName = "/track.ArtistService/DeleteArtist"
+)
+
+// ArtistServiceClient is the client API for ArtistService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Исполнители удаляются, только когда у них не осталось треков и альбомов
+type ArtistServiceClient interface {
+	CreateArtist(ctx context.Context, in *CreateArtistRequest, opts ...grpc.CallOption) (*CreateArtistResponse, error)
+	GetArtist(ctx context.Context, in *GetArtistRequest, opts ...grpc.CallOption) (*GetArtistResponse, error)
+	ListArtists(ctx context.Context, in *ListArtistsRequest, opts ...grpc.CallOption) (*ListArtistsResponse, error)
+	UpdateArtist(ctx context.Context, in *UpdateArtistRequest, opts ...grpc.CallOption) (*UpdateArtistResponse, error)
+	DeleteArtist(ctx context.Context, in *DeleteArtistRequest, opts ...grpc.CallOption) (*DeleteArtistResponse, error)
+}
+
+type artistServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArtistServiceClient(cc grpc.ClientConnInterface) ArtistServiceClient {
+	return &artistServiceClient{cc}
+}
+
+func (c *artistServiceClient) CreateArtist(ctx context.Context, in *CreateArtistRequest, opts ...grpc.CallOption) (*CreateArtistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateArtistResponse)
+	err := c.cc.Invoke(ctx, ArtistService_CreateArtist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artistServiceClient) GetArtist(ctx context.Context, in *GetArtistRequest, opts ...grpc.CallOption) (*GetArtistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetArtistResponse)
+	err := c.cc.Invoke(ctx, ArtistService_GetArtist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artistServiceClient) ListArtists(ctx context.Context, in *ListArtistsRequest, opts ...grpc.CallOption) (*ListArtistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArtistsResponse)
+	err := c.cc.Invoke(ctx, ArtistService_ListArtists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artistServiceClient) UpdateArtist(ctx context.Context, in *UpdateArtistRequest, opts ...grpc.CallOption) (*UpdateArtistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateArtistResponse)
+	err := c.cc.Invoke(ctx, ArtistService_UpdateArtist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artistServiceClient) DeleteArtist(ctx context.Context, in *DeleteArtistRequest, opts ...grpc.CallOption) (*DeleteArtistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteArtistResponse)
+	err := c.cc.Invoke(ctx, ArtistService_DeleteArtist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArtistServiceServer is the server API for ArtistService service.
+// All implementations must embed UnimplementedArtistServiceServer
+// for forward compatibility.
+//
+// Исполнители удаляются, только когда у них не осталось треков и альбомов
+type ArtistServiceServer interface {
+	CreateArtist(context.Context, *CreateArtistRequest) (*CreateArtistResponse, error)
+	GetArtist(context.Context, *GetArtistRequest) (*GetArtistResponse, error)
+	ListArtists(context.Context, *ListArtistsRequest) (*ListArtistsResponse, error)
+	UpdateArtist(context.Context, *UpdateArtistRequest) (*UpdateArtistResponse, error)
+	DeleteArtist(context.Context, *DeleteArtistRequest) (*DeleteArtistResponse, error)
+	mustEmbedUnimplementedArtistServiceServer()
+}
+
+// UnimplementedArtistServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedArtistServiceServer struct{}
+
+func (UnimplementedArtistServiceServer) CreateArtist(context.Context, *CreateArtistRequest) (*CreateArtistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateArtist not implemented")
+}
+func (UnimplementedArtistServiceServer) GetArtist(context.Context, *GetArtistRequest) (*GetArtistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArtist not implemented")
+}
+func (UnimplementedArtistServiceServer) ListArtists(context.Context, *ListArtistsRequest) (*ListArtistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtists not implemented")
+}
+func (UnimplementedArtistServiceServer) UpdateArtist(context.Context, *UpdateArtistRequest) (*UpdateArtistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateArtist not implemented")
+}
+func (UnimplementedArtistServiceServer) DeleteArtist(context.Context, *DeleteArtistRequest) (*DeleteArtistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteArtist not implemented")
+}
+func (UnimplementedArtistServiceServer) mustEmbedUnimplementedArtistServiceServer() {}
+func (UnimplementedArtistServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeArtistServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArtistServiceServer will
+// result in compilation errors.
+type UnsafeArtistServiceServer interface {
+	mustEmbedUnimplementedArtistServiceServer()
+}
+
+func RegisterArtistServiceServer(s grpc.ServiceRegistrar, srv ArtistServiceServer) {
+	// If the following call pancis, it indicates UnimplementedArtistServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ArtistService_ServiceDesc, srv)
+}
+
+func _ArtistService_CreateArtist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateArtistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtistServiceServer).CreateArtist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtistService_CreateArtist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtistServiceServer).CreateArtist(ctx, req.(*CreateArtistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtistService_GetArtist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtistServiceServer).GetArtist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtistService_GetArtist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtistServiceServer).GetArtist(ctx, req.(*GetArtistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtistService_ListArtists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtistServiceServer).ListArtists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtistService_ListArtists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtistServiceServer).ListArtists(ctx, req.(*ListArtistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtistService_UpdateArtist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateArtistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtistServiceServer).UpdateArtist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtistService_UpdateArtist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtistServiceServer).UpdateArtist(ctx, req.(*UpdateArtistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtistService_DeleteArtist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteArtistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtistServiceServer).DeleteArtist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtistService_DeleteArtist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtistServiceServer).DeleteArtist(ctx, req.(*DeleteArtistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ArtistService_ServiceDesc is the grpc.ServiceDesc for ArtistService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ArtistService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "track.ArtistService",
+	HandlerType: (*ArtistServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateArtist",
+			Handler:    _ArtistService_CreateArtist_Handler,
+		},
+		{
+			MethodName: "GetArtist",
+			Handler:    _ArtistService_GetArtist_Handler,
+		},
+		{
+			MethodName: "ListArtists",
+			Handler:    _ArtistService_ListArtists_Handler,
+		},
+		{
+			MethodName: "UpdateArtist",
+			Handler:    _ArtistService_UpdateArtist_Handler,
+		},
+		{
+			MethodName: "DeleteArtist",
+			Handler:    _ArtistService_DeleteArtist_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/track.proto",
+}
+
+const (
+	AlbumService_CreateAlbum_FullMethodName      = "/track.AlbumService/CreateAlbum"
+	AlbumService_GetAlbum_FullMethodName         = "/track.AlbumService/GetAlbum"
+	AlbumService_ListAlbums_FullMethodName       = "/track.AlbumService/ListAlbums"
+	AlbumService_UpdateAlbum_FullMethodName      = "/track.AlbumService/UpdateAlbum"
+	AlbumService_DeleteAlbum_FullMethodName      = "/track.AlbumService/DeleteAlbum"
+	AlbumService_UploadAlbumCover_FullMethodName = "/track.AlbumService/UploadAlbumCover"
+	AlbumService_DeleteAlbumCover_FullMethodName = "/track.AlbumService/DeleteAlbumCover"
+)
+
+// AlbumServiceClient is the client API for AlbumService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Альбомы удаляются, только когда в них не осталось треков
+type AlbumServiceClient interface {
+	CreateAlbum(ctx context.Context, in *CreateAlbumRequest, opts ...grpc.CallOption) (*CreateAlbumResponse, error)
+	GetAlbum(ctx context.Context, in *GetAlbumRequest, opts ...grpc.CallOption) (*GetAlbumResponse, error)
+	ListAlbums(ctx context.Context, in *ListAlbumsRequest, opts ...grpc.CallOption) (*ListAlbumsResponse, error)
+	UpdateAlbum(ctx context.Context, in *UpdateAlbumRequest, opts ...grpc.CallOption) (*UpdateAlbumResponse, error)
+	DeleteAlbum(ctx context.Context, in *DeleteAlbumRequest, opts ...grpc.CallOption) (*DeleteAlbumResponse, error)
+	UploadAlbumCover(ctx context.Context, in *UploadAlbumCoverRequest, opts ...grpc.CallOption) (*UploadAlbumCoverResponse, error)
+	DeleteAlbumCover(ctx context.Context, in *DeleteAlbumCoverRequest, opts ...grpc.CallOption) (*DeleteAlbumCoverResponse, error)
+}
+
+type albumServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAlbumServiceClient(cc grpc.ClientConnInterface) AlbumServiceClient {
+	return &albumServiceClient{cc}
+}
+
+func (c *albumServiceClient) CreateAlbum(ctx context.Context, in *CreateAlbumRequest, opts ...grpc.CallOption) (*CreateAlbumResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAlbumResponse)
+	err := c.cc.Invoke(ctx, AlbumService_CreateAlbum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *albumServiceClient) GetAlbum(ctx context.Context, in *GetAlbumRequest, opts ...grpc.CallOption) (*GetAlbumResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAlbumResponse)
+	err := c.cc.Invoke(ctx, AlbumService_GetAlbum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *albumServiceClient) ListAlbums(ctx context.Context, in *ListAlbumsRequest, opts ...grpc.CallOption) (*ListAlbumsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlbumsResponse)
+	err := c.cc.Invoke(ctx, AlbumService_ListAlbums_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *albumServiceClient) UpdateAlbum(ctx context.Context, in *UpdateAlbumRequest, opts ...grpc.CallOption) (*UpdateAlbumResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAlbumResponse)
+	err := c.cc.Invoke(ctx, AlbumService_UpdateAlbum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *albumServiceClient) DeleteAlbum(ctx context.Context, in *DeleteAlbumRequest, opts ...grpc.CallOption) (*DeleteAlbumResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAlbumResponse)
+	err := c.cc.Invoke(ctx, AlbumService_DeleteAlbum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *albumServiceClient) UploadAlbumCover(ctx context.Context, in *UploadAlbumCoverRequest, opts ...grpc.CallOption) (*UploadAlbumCoverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadAlbumCoverResponse)
+	err := c.cc.Invoke(ctx, AlbumService_UploadAlbumCover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *albumServiceClient) DeleteAlbumCover(ctx context.Context, in *DeleteAlbumCoverRequest, opts ...grpc.CallOption) (*DeleteAlbumCoverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAlbumCoverResponse)
+	err := c.cc.Invoke(ctx, AlbumService_DeleteAlbumCover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AlbumServiceServer is the server API for AlbumService service.
+// All implementations must embed UnimplementedAlbumServiceServer
+// for forward compatibility.
+//
+// Альбомы удаляются, только когда в них не осталось треков
+type AlbumServiceServer interface {
+	CreateAlbum(context.Context, *CreateAlbumRequest) (*CreateAlbumResponse, error)
+	GetAlbum(context.Context, *GetAlbumRequest) (*GetAlbumResponse, error)
+	ListAlbums(context.Context, *ListAlbumsRequest) (*ListAlbumsResponse, error)
+	UpdateAlbum(context.Context, *UpdateAlbumRequest) (*UpdateAlbumResponse, error)
+	DeleteAlbum(context.Context, *DeleteAlbumRequest) (*DeleteAlbumResponse, error)
+	UploadAlbumCover(context.Context, *UploadAlbumCoverRequest) (*UploadAlbumCoverResponse, error)
+	DeleteAlbumCover(context.Context, *DeleteAlbumCoverRequest) (*DeleteAlbumCoverResponse, error)
+	mustEmbedUnimplementedAlbumServiceServer()
+}
+
+// UnimplementedAlbumServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAlbumServiceServer struct{}
+
+func (UnimplementedAlbumServiceServer) CreateAlbum(context.Context, *CreateAlbumRequest) (*CreateAlbumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAlbum not implemented")
+}
+func (UnimplementedAlbumServiceServer) GetAlbum(context.Context, *GetAlbumRequest) (*GetAlbumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlbum not implemented")
+}
+func (UnimplementedAlbumServiceServer) ListAlbums(context.Context, *ListAlbumsRequest) (*ListAlbumsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlbums not implemented")
+}
+func (UnimplementedAlbumServiceServer) UpdateAlbum(context.Context, *UpdateAlbumRequest) (*UpdateAlbumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAlbum not implemented")
+}
+func (UnimplementedAlbumServiceServer) DeleteAlbum(context.Context, *DeleteAlbumRequest) (*DeleteAlbumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAlbum not implemented")
+}
+func (UnimplementedAlbumServiceServer) UploadAlbumCover(context.Context, *UploadAlbumCoverRequest) (*UploadAlbumCoverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadAlbumCover not implemented")
+}
+func (UnimplementedAlbumServiceServer) DeleteAlbumCover(context.Context, *DeleteAlbumCoverRequest) (*DeleteAlbumCoverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAlbumCover not implemented")
+}
+func (UnimplementedAlbumServiceServer) mustEmbedUnimplementedAlbumServiceServer() {}
+func (UnimplementedAlbumServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAlbumServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AlbumServiceServer will
+// result in compilation errors.
+type UnsafeAlbumServiceServer interface {
+	mustEmbedUnimplementedAlbumServiceServer()
+}
+
+func RegisterAlbumServiceServer(s grpc.ServiceRegistrar, srv AlbumServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAlbumServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AlbumService_ServiceDesc, srv)
+}
+
+func _AlbumService_CreateAlbum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAlbumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlbumServiceServer).CreateAlbum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlbumService_CreateAlbum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlbumServiceServer).CreateAlbum(ctx, req.(*CreateAlbumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlbumService_GetAlbum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlbumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlbumServiceServer).GetAlbum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlbumService_GetAlbum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlbumServiceServer).GetAlbum(ctx, req.(*GetAlbumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlbumService_ListAlbums_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlbumsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlbumServiceServer).ListAlbums(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlbumService_ListAlbums_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlbumServiceServer).ListAlbums(ctx, req.(*ListAlbumsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlbumService_UpdateAlbum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAlbumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlbumServiceServer).UpdateAlbum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlbumService_UpdateAlbum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlbumServiceServer).UpdateAlbum(ctx, req.(*UpdateAlbumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlbumService_DeleteAlbum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAlbumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlbumServiceServer).DeleteAlbum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlbumService_DeleteAlbum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlbumServiceServer).DeleteAlbum(ctx, req.(*DeleteAlbumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlbumService_UploadAlbumCover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadAlbumCoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlbumServiceServer).UploadAlbumCover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlbumService_UploadAlbumCover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlbumServiceServer).UploadAlbumCover(ctx, req.(*UploadAlbumCoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlbumService_DeleteAlbumCover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAlbumCoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlbumServiceServer).DeleteAlbumCover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlbumService_DeleteAlbumCover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlbumServiceServer).DeleteAlbumCover(ctx, req.(*DeleteAlbumCoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AlbumService_ServiceDesc is the grpc.ServiceDesc for AlbumService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AlbumService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "track.AlbumService",
+	HandlerType: (*AlbumServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAlbum",
+			Handler:    _AlbumService_CreateAlbum_Handler,
+		},
+		{
+			MethodName: "GetAlbum",
+			Handler:    _AlbumService_GetAlbum_Handler,
+		},
+		{
+			MethodName: "ListAlbums",
+			Handler:    _AlbumService_ListAlbums_Handler,
+		},
+		{
+			MethodName: "UpdateAlbum",
+			Handler:    _AlbumService_UpdateAlbum_Handler,
+		},
+		{
+			MethodName: "DeleteAlbum",
+			Handler:    _AlbumService_DeleteAlbum_Handler,
+		},
+		{
+			MethodName: "UploadAlbumCover",
+			Handler:    _AlbumService_UploadAlbumCover_Handler,
+		},
+		{
+			MethodName: "DeleteAlbumCover",
+			Handler:    _AlbumService_DeleteAlbumCover_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/track.proto",
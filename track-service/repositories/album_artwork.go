@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SetAlbumCover заменяет обложку альбома, nil — убирает ее. Возвращает альбом
+// до изменения.
+func (r *AlbumRepo) SetAlbumCover(ctx context.Context, id primitive.ObjectID, cover *models.Artwork) (models.Album, error) {
+	update := bson.M{"$unset": bson.M{"cover": ""}}
+	if cover != nil {
+		update = bson.M{"$set": bson.M{"cover": cover}}
+	}
+	var previous models.Album
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&previous)
+	return previous, err
+}
+
+// SetMissingAlbumCover задает обложку альбому, у которого ее еще нет.
+// Возвращает false, если обложка уже была.
+func (r *AlbumRepo) SetMissingAlbumCover(ctx context.Context, id primitive.ObjectID, cover models.Artwork) (bool, error) {
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "cover": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"cover": cover}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// CountCoverReferences считает альбомы, которые ссылаются на обложку с этим ключом
+func (r *AlbumRepo) CountCoverReferences(ctx context.Context, key string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"cover.key": key})
+}
+
+// EnsureCoverIndexes создает индекс для подсчета ссылок на обложки
+func (r *AlbumRepo) EnsureCoverIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "cover.key", Value: 1}},
+		Options: options.Index().SetName("albums_cover_key").SetSparse(true),
+	})
+	return err
+}
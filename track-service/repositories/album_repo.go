@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AlbumRepo struct {
+	collection *mongo.Collection
+}
+
+func NewAlbumRepo(db *mongo.Database) *AlbumRepo {
+	return &AlbumRepo{
+		collection: db.Collection("albums"),
+	}
+}
+
+// EnsureIndexes создает уникальный индекс по исполнителю и ключу названия:
+// у исполнителя не может быть двух альбомов с одинаковым названием
+func (r *AlbumRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "artist_id", Value: 1}, {Key: "title_key", Value: 1}},
+		Options: options.Index().SetName("albums_artist_title_key").SetUnique(true),
+	})
+	return err
+}
+
+// CreateAlbum сохраняет новый альбом; если у исполнителя уже есть альбом с
+// таким названием, возвращается ошибка дубликата ключа
+func (r *AlbumRepo) CreateAlbum(ctx context.Context, album models.Album) (models.Album, error) {
+	album.ID = primitive.NewObjectID()
+	album.Title = models.CleanName(album.Title)
+	album.TitleKey = models.NameKey(album.Title)
+	album.CreatedAt = time.Now().Unix()
+	_, err := r.collection.InsertOne(ctx, album)
+	return album, err
+}
+
+// FindOrCreateAlbum возвращает альбом исполнителя с таким названием,
+// создавая его при необходимости
+func (r *AlbumRepo) FindOrCreateAlbum(ctx context.Context, artist models.Artist, title string) (models.Album, error) {
+	title = models.CleanName(title)
+	filter := bson.M{"artist_id": artist.ID, "title_key": models.NameKey(title)}
+	update := bson.M{"$setOnInsert": bson.M{
+		"title":        title,
+		"artist":       artist.Name,
+		"release_date": "",
+		"created_at":   time.Now().Unix(),
+	}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var album models.Album
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&album)
+	if mongo.IsDuplicateKeyError(err) {
+		err = r.collection.FindOne(ctx, filter).Decode(&album)
+	}
+	return album, err
+}
+
+func (r *AlbumRepo) GetAlbumByID(ctx context.Context, id primitive.ObjectID) (models.Album, error) {
+	var album models.Album
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&album)
+	return album, err
+}
+
+// ListAlbums возвращает альбомы, новые релизы первыми; artistID — только
+// альбомы этого исполнителя, если задан
+func (r *AlbumRepo) ListAlbums(ctx context.Context, artistID primitive.ObjectID, limit, skip int64) ([]models.Album, error) {
+	filter := bson.M{}
+	if !artistID.IsZero() {
+		filter["artist_id"] = artistID
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "release_date", Value: -1}, {Key: "title_key", Value: 1}}).
+		SetLimit(limit).
+		SetSkip(skip)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	var albums []models.Album
+	if err := cursor.All(ctx, &albums); err != nil {
+		return nil, err
+	}
+	return albums, nil
+}
+
+// UpdateAlbum меняет название и дату релиза альбома
+func (r *AlbumRepo) UpdateAlbum(ctx context.Context, id primitive.ObjectID, updateData bson.M) (models.Album, error) {
+	if title, ok := updateData["title"].(string); ok {
+		updateData["title"] = models.CleanName(title)
+		updateData["title_key"] = models.NameKey(title)
+	}
+	var album models.Album
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": updateData},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&album)
+	return album, err
+}
+
+// SetArtistName обновляет копию имени исполнителя в его альбомах
+func (r *AlbumRepo) SetArtistName(ctx context.Context, artist models.Artist) error {
+	_, err := r.collection.UpdateMany(ctx, bson.M{"artist_id": artist.ID}, bson.M{"$set": bson.M{"artist": artist.Name}})
+	return err
+}
+
+// CountAlbums считает альбомы исполнителя
+func (r *AlbumRepo) CountAlbums(ctx context.Context, artistID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"artist_id": artistID})
+}
+
+func (r *AlbumRepo) DeleteAlbum(ctx context.Context, id primitive.ObjectID) error {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
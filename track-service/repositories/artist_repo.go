@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ArtistRepo struct {
+	collection *mongo.Collection
+}
+
+func NewArtistRepo(db *mongo.Database) *ArtistRepo {
+	return &ArtistRepo{
+		collection: db.Collection("artists"),
+	}
+}
+
+// EnsureIndexes создает уникальный индекс по ключу имени: двух исполнителей
+// с одинаковым с точностью до регистра и пробелов именем быть не может
+func (r *ArtistRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "name_key", Value: 1}},
+		Options: options.Index().SetName("artists_name_key").SetUnique(true),
+	})
+	return err
+}
+
+// CreateArtist сохраняет нового исполнителя; если имя занято, возвращается
+// ошибка дубликата ключа (mongo.IsDuplicateKeyError)
+func (r *ArtistRepo) CreateArtist(ctx context.Context, artist models.Artist) (models.Artist, error) {
+	artist.ID = primitive.NewObjectID()
+	artist.Name = models.CleanName(artist.Name)
+	artist.NameKey = models.NameKey(artist.Name)
+	artist.CreatedAt = time.Now().Unix()
+	_, err := r.collection.InsertOne(ctx, artist)
+	return artist, err
+}
+
+// FindOrCreateArtist возвращает исполнителя с таким именем, создавая его при
+// необходимости. Уникальный индекс не дает двум параллельным вызовам создать
+// двух исполнителей.
+func (r *ArtistRepo) FindOrCreateArtist(ctx context.Context, name string) (models.Artist, error) {
+	name = models.CleanName(name)
+	filter := bson.M{"name_key": models.NameKey(name)}
+	update := bson.M{"$setOnInsert": bson.M{
+		"name":       name,
+		"created_at": time.Now().Unix(),
+	}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var artist models.Artist
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&artist)
+	if mongo.IsDuplicateKeyError(err) {
+		// Параллельный вызов успел вставить исполнителя первым
+		err = r.collection.FindOne(ctx, filter).Decode(&artist)
+	}
+	return artist, err
+}
+
+func (r *ArtistRepo) GetArtistByID(ctx context.Context, id primitive.ObjectID) (models.Artist, error) {
+	var artist models.Artist
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&artist)
+	return artist, err
+}
+
+// GetArtistsByIDs возвращает исполнителей в порядке ids; если кого-то нет,
+// возвращается mongo.ErrNoDocuments
+func (r *ArtistRepo) GetArtistsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Artist, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	var found []models.Artist
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[primitive.ObjectID]models.Artist, len(found))
+	for _, a := range found {
+		byID[a.ID] = a
+	}
+	artists := make([]models.Artist, len(ids))
+	for i, id := range ids {
+		a, ok := byID[id]
+		if !ok {
+			return nil, mongo.ErrNoDocuments
+		}
+		artists[i] = a
+	}
+	return artists, nil
+}
+
+// ListArtists возвращает исполнителей по алфавиту; prefix — начало имени
+// без учета регистра
+func (r *ArtistRepo) ListArtists(ctx context.Context, prefix string, limit, skip int64) ([]models.Artist, error) {
+	filter := bson.M{}
+	if key := models.NameKey(prefix); key != "" {
+		filter["name_key"] = bson.M{"$regex": "^" + regexp.QuoteMeta(key)}
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "name_key", Value: 1}}).
+		SetLimit(limit).
+		SetSkip(skip)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	var artists []models.Artist
+	if err := cursor.All(ctx, &artists); err != nil {
+		return nil, err
+	}
+	return artists, nil
+}
+
+// RenameArtist меняет имя исполнителя; если новое имя занято другим
+// исполнителем, возвращается ошибка дубликата ключа
+func (r *ArtistRepo) RenameArtist(ctx context.Context, id primitive.ObjectID, name string) (models.Artist, error) {
+	name = models.CleanName(name)
+	update := bson.M{"$set": bson.M{"name": name, "name_key": models.NameKey(name)}}
+	var artist models.Artist
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&artist)
+	return artist, err
+}
+
+func (r *ArtistRepo) DeleteArtist(ctx context.Context, id primitive.ObjectID) error {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// outboxRetention — сколько хранятся опубликованные события
+const outboxRetention = 7 * 24 * time.Hour
+
+type OutboxRepo struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepo(db *mongo.Database) *OutboxRepo {
+	return &OutboxRepo{
+		collection: db.Collection("outbox"),
+	}
+}
+
+// Record выполняет fn в транзакции и записывает возвращенные ею события в
+// той же транзакции: либо сохраняются и изменения, и события, либо ничего.
+// fn может быть вызвана повторно при временных ошибках транзакции, поэтому
+// не должна иметь побочных эффектов вне базы. Транзакциям MongoDB нужен
+// набор реплик.
+func (r *OutboxRepo) Record(ctx context.Context, fn func(ctx context.Context) ([]models.OutboxEvent, error)) error {
+	session, err := r.collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		events, err := fn(sc)
+		if err != nil || len(events) == 0 {
+			return nil, err
+		}
+		docs := make([]interface{}, len(events))
+		for i, e := range events {
+			docs[i] = e
+		}
+		_, err = r.collection.InsertMany(sc, docs)
+		return nil, err
+	})
+	return err
+}
+
+// Pending возвращает до limit неопубликованных событий в порядке записи
+func (r *OutboxRepo) Pending(ctx context.Context, limit int64) ([]models.OutboxEvent, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"published": false},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	var events []models.OutboxEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// MarkPublished отмечает событие опубликованным
+func (r *OutboxRepo) MarkPublished(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id},
+		bson.M{"$set": bson.M{"published": true, "published_at": time.Now()}})
+	return err
+}
+
+// EnsureIndexes создает индекс для выборки неопубликованных событий и
+// TTL-индекс, удаляющий опубликованные через outboxRetention
+func (r *OutboxRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "published", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("outbox_pending"),
+		},
+		{
+			Keys:    bson.D{{Key: "published_at", Value: 1}},
+			Options: options.Index().SetName("outbox_published_ttl").SetExpireAfterSeconds(int32(outboxRetention.Seconds())),
+		},
+	})
+	return err
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// analysisFor — выражение агрегации с начальным анализом файла: ожидание
+// для декодируемых форматов, unsupported для остальных
+func analysisFor(key, format interface{}) bson.M {
+	return bson.M{
+		"status": bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{format, models.AnalysableFormats}},
+			models.AnalysisPending,
+			models.AnalysisUnsupported,
+		}},
+		"audio_key": key,
+		"attempts":  0,
+	}
+}
+
+// ClaimTrackAnalysis берет в работу трек, ожидающий анализа, или трек,
+// анализ которого начат раньше staleBefore и, видимо, прерван. Прерванный
+// анализ берется заново, только пока попыток меньше maxAttempts. Возвращает
+// трек после изменения или mongo.ErrNoDocuments, если анализировать нечего.
+func (r *TrackRepo) ClaimTrackAnalysis(ctx context.Context, staleBefore int64, maxAttempts int) (models.Track, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"analysis.status": models.AnalysisPending},
+		bson.M{
+			"analysis.status":     models.AnalysisRunning,
+			"analysis.started_at": bson.M{"$lt": staleBefore},
+			"analysis.attempts":   bson.M{"$lt": maxAttempts},
+		},
+	}}
+	update := bson.M{
+		"$set": bson.M{
+			"analysis.status":     models.AnalysisRunning,
+			"analysis.started_at": time.Now().Unix(),
+		},
+		"$inc": bson.M{"analysis.attempts": 1},
+	}
+
+	var track models.Track
+	err := r.collection.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&track)
+	return track, err
+}
+
+// FailStaleAnalyses отмечает неудачным анализ, который прерывался
+// maxAttempts раз: файл, на котором падает сервис, не должен ронять его
+// снова и снова. Возвращает число измененных треков.
+func (r *TrackRepo) FailStaleAnalyses(ctx context.Context, staleBefore int64, maxAttempts int) (int64, error) {
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{
+			"analysis.status":     models.AnalysisRunning,
+			"analysis.started_at": bson.M{"$lt": staleBefore},
+			"analysis.attempts":   bson.M{"$gte": maxAttempts},
+		},
+		bson.M{"$set": bson.M{
+			"analysis.status": models.AnalysisFailed,
+			"analysis.error":  "the analysis was interrupted too many times",
+		}})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// SetTrackAnalysis записывает анализ трека, если у трека все еще тот файл,
+// который анализировался. Иначе возвращает mongo.ErrNoDocuments.
+func (r *TrackRepo) SetTrackAnalysis(ctx context.Context, id primitive.ObjectID, analysis models.TrackAnalysis) error {
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "audio.key": analysis.AudioKey},
+		bson.M{"$set": bson.M{"analysis": analysis}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// QueueTrackAnalyses ставит в очередь анализа треки с аудио, загруженным до
+// появления анализа. Возвращает число измененных треков.
+func (r *TrackRepo) QueueTrackAnalyses(ctx context.Context) (int64, error) {
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"audio": bson.M{"$exists": true}, "analysis": bson.M{"$exists": false}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"analysis": analysisFor("$audio.key", "$audio.format")}}},
+		})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// EnsureAnalysisIndexes создает индекс для поиска треков, ожидающих анализа
+func (r *TrackRepo) EnsureAnalysisIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "analysis.status", Value: 1}, {Key: "analysis.started_at", Value: 1}},
+		Options: options.Index().SetName("tracks_analysis_status").SetSparse(true),
+	})
+	return err
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SetTrackCover заменяет обложку трека, nil — убирает ее, и увеличивает
+// версию трека. Возвращает трек до изменения.
+func (r *TrackRepo) SetTrackCover(ctx context.Context, id primitive.ObjectID, cover *models.Artwork) (models.Track, error) {
+	update := bson.M{"$unset": bson.M{"cover": ""}, "$inc": bson.M{"version": 1}}
+	if cover != nil {
+		update = bson.M{"$set": bson.M{"cover": cover}, "$inc": bson.M{"version": 1}}
+	}
+	var previous models.Track
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&previous)
+	return previous, err
+}
+
+// CountCoverReferences считает треки, которые ссылаются на обложку с этим ключом
+func (r *TrackRepo) CountCoverReferences(ctx context.Context, key string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"cover.key": key})
+}
+
+// EnsureCoverIndexes создает индекс для подсчета ссылок на обложки
+func (r *TrackRepo) EnsureCoverIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "cover.key", Value: 1}},
+		Options: options.Index().SetName("tracks_cover_key").SetSparse(true),
+	})
+	return err
+}
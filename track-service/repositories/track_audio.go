@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SetTrackAudio записывает аудиофайл трека, увеличивает версию трека и
+// возвращает трек до изменения. Длительность трека берется из файла, если
+// ее удалось определить. Анализ нового файла ставится в очередь; при
+// повторной загрузке того же файла прежний анализ сохраняется.
+func (r *TrackRepo) SetTrackAudio(ctx context.Context, id primitive.ObjectID, audio models.AudioFile) (models.Track, error) {
+	key, format := bson.M{"$literal": audio.Key}, bson.M{"$literal": audio.Format}
+	set := bson.M{
+		"audio": bson.M{"$literal": audio},
+		"analysis": bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{"$analysis.audio_key", key}},
+			"$analysis",
+			analysisFor(key, format),
+		}},
+		"version": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}},
+	}
+	if audio.DurationMs > 0 {
+		set["duration_sec"] = bson.M{"$literal": int32((audio.DurationMs + 500) / 1000)}
+	}
+
+	var previous models.Track
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id},
+		mongo.Pipeline{{{Key: "$set", Value: set}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&previous)
+	return previous, err
+}
+
+// CountAudioReferences считает треки, которые ссылаются на файл с этим ключом
+func (r *TrackRepo) CountAudioReferences(ctx context.Context, key string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"audio.key": key})
+}
+
+// EnsureAudioIndexes создает индекс для подсчета ссылок на аудиофайлы
+func (r *TrackRepo) EnsureAudioIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "audio.key", Value: 1}},
+		Options: options.Index().SetName("tracks_audio_key").SetSparse(true),
+	})
+	return err
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureLinkIndexes создает индексы для выборки треков исполнителя и
+// трек-листа альбома
+func (r *TrackRepo) EnsureLinkIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "artists.id", Value: 1}},
+			Options: options.Index().SetName("tracks_artists"),
+		},
+		{
+			Keys:    bson.D{{Key: "album_id", Value: 1}, {Key: "disc_number", Value: 1}, {Key: "track_number", Value: 1}},
+			Options: options.Index().SetName("tracks_album_order"),
+		},
+	})
+	return err
+}
+
+// ListAlbumTracks возвращает трек-лист альбома по номеру диска и трека;
+// треки без номера идут в конце диска по названию
+func (r *TrackRepo) ListAlbumTracks(ctx context.Context, albumID primitive.ObjectID, filter bson.M) ([]models.Track, error) {
+	query := bson.M{"album_id": albumID}
+	for k, v := range filter {
+		query[k] = v
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$addFields", Value: bson.M{"unnumbered": bson.M{"$eq": bson.A{"$track_number", 0}}}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "disc_number", Value: 1},
+			{Key: "unnumbered", Value: 1},
+			{Key: "track_number", Value: 1},
+			{Key: "title", Value: 1},
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var tracks []models.Track
+	if err := cursor.All(ctx, &tracks); err != nil {
+		return nil, err
+	}
+	return tracks, nil
+}
+
+// CountArtistTracks считает треки, в которых участвует исполнитель
+func (r *TrackRepo) CountArtistTracks(ctx context.Context, artistID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"artists.id": artistID})
+}
+
+// CountAlbumTracks считает треки альбома
+func (r *TrackRepo) CountAlbumTracks(ctx context.Context, albumID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"album_id": albumID})
+}
+
+// SetArtistName обновляет копии имени исполнителя в его треках и их ключи
+// поиска и увеличивает версии треков. Возвращает треки до и после изменения.
+func (r *TrackRepo) SetArtistName(ctx context.Context, artist models.Artist) (before, after []models.Track, err error) {
+	filter := bson.M{"artists.id": artist.ID}
+	if before, err = r.GetAllTracks(ctx, filter, 0, 0); err != nil || len(before) == 0 {
+		return nil, nil, err
+	}
+	_, err = r.collection.UpdateMany(ctx, filter,
+		bson.M{
+			"$set": bson.M{"artists.$[credit].name": artist.Name},
+			"$inc": bson.M{"version": 1},
+		},
+		options.Update().SetArrayFilters(options.ArrayFilters{
+			Filters: []interface{}{bson.M{"credit.id": artist.ID}},
+		}))
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"artists.0.id": artist.ID},
+		bson.M{"$set": bson.M{"artist": artist.Name}})
+	if err != nil {
+		return nil, nil, err
+	}
+	if after, err = r.refreshSearchKeys(ctx, filter); err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// SetAlbumTitle обновляет копию названия альбома в его треках и их ключи
+// поиска и увеличивает версии треков. Возвращает треки до и после изменения.
+func (r *TrackRepo) SetAlbumTitle(ctx context.Context, album models.Album) (before, after []models.Track, err error) {
+	filter := bson.M{"album_id": album.ID}
+	if before, err = r.GetAllTracks(ctx, filter, 0, 0); err != nil || len(before) == 0 {
+		return nil, nil, err
+	}
+	_, err = r.collection.UpdateMany(ctx, filter,
+		bson.M{"$set": bson.M{"album": album.Title}, "$inc": bson.M{"version": 1}})
+	if err != nil {
+		return nil, nil, err
+	}
+	if after, err = r.refreshSearchKeys(ctx, filter); err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+func (r *TrackRepo) refreshSearchKeys(ctx context.Context, filter bson.M) ([]models.Track, error) {
+	tracks, err := r.GetAllTracks(ctx, filter, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tracks {
+		if err := r.setSearchKeys(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	return tracks, nil
+}
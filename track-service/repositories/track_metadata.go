@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// metadataDefaults — значения полей метаданных у треков, для которых они
+// неизвестны. Поле должно присутствовать в документе: условие
+// {explicit: false} не находит документы, где поля нет вовсе.
+var metadataDefaults = bson.D{
+	{Key: "genres", Value: bson.A{}},
+	{Key: "release_date", Value: ""},
+	{Key: "isrc", Value: ""},
+	{Key: "disc_number", Value: int32(0)},
+	{Key: "track_number", Value: int32(0)},
+	{Key: "language", Value: ""},
+	{Key: "explicit", Value: false},
+	{Key: "bpm", Value: float64(0)},
+	{Key: "key", Value: ""},
+}
+
+// BackfillMetadata добавляет недостающие поля метаданных со значениями по
+// умолчанию и возвращает число измененных документов по каждому полю
+func (r *TrackRepo) BackfillMetadata(ctx context.Context) (map[string]int64, error) {
+	updated := make(map[string]int64, len(metadataDefaults))
+	for _, field := range metadataDefaults {
+		res, err := r.collection.UpdateMany(ctx,
+			bson.M{field.Key: bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{field.Key: field.Value}})
+		if err != nil {
+			return updated, err
+		}
+		updated[field.Key] = res.ModifiedCount
+	}
+	return updated, nil
+}
+
+// EnsureMetadataIndexes создает индексы под фильтры каталога по метаданным
+func (r *TrackRepo) EnsureMetadataIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "genres", Value: 1}}, Options: options.Index().SetName("tracks_genres")},
+		{Keys: bson.D{{Key: "release_date", Value: 1}}, Options: options.Index().SetName("tracks_release_date")},
+		{Keys: bson.D{{Key: "isrc", Value: 1}}, Options: options.Index().SetName("tracks_isrc")},
+		{Keys: bson.D{{Key: "language", Value: 1}}, Options: options.Index().SetName("tracks_language")},
+		{Keys: bson.D{{Key: "bpm", Value: 1}}, Options: options.Index().SetName("tracks_bpm")},
+	})
+	return err
+}
@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TrackSort — поле, по которому упорядочивается каталог
+type TrackSort string
+
+const (
+	SortByTitle      TrackSort = "title"
+	SortByArtist     TrackSort = "artist"
+	SortByCreatedAt  TrackSort = "created_at"
+	SortByDuration   TrackSort = "duration"
+	SortByPopularity TrackSort = "popularity"
+)
+
+// sortFields — поле документа для каждого порядка сортировки
+var sortFields = map[TrackSort]string{
+	SortByTitle:      "title",
+	SortByArtist:     "artist",
+	SortByCreatedAt:  "created_at",
+	SortByDuration:   "duration_sec",
+	SortByPopularity: "popularity",
+}
+
+var (
+	ErrUnknownSort    = errors.New("unknown sort field")
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrCursorMismatch = errors.New("cursor was issued for a different sort order")
+)
+
+// ParseTrackSort проверяет имя поля сортировки
+func ParseTrackSort(s string) (TrackSort, error) {
+	sort := TrackSort(s)
+	if _, ok := sortFields[sort]; !ok {
+		return "", ErrUnknownSort
+	}
+	return sort, nil
+}
+
+// DefaultDescending — направление сортировки по умолчанию: новые и
+// популярные треки первыми, текст и длительность по возрастанию
+func (s TrackSort) DefaultDescending() bool {
+	return s == SortByCreatedAt || s == SortByPopularity
+}
+
+// TrackCursor — позиция в каталоге: значение поля сортировки и _id
+// последнего отданного трека. _id разрешает равенство значений, поэтому
+// порядок строгий, и вставка новых треков не сдвигает следующие страницы.
+type TrackCursor struct {
+	Sort       TrackSort          `bson:"s"`
+	Descending bool               `bson:"d"`
+	Value      interface{}        `bson:"v"`
+	ID         primitive.ObjectID `bson:"id"`
+}
+
+// TrackPage — параметры выборки страницы каталога
+type TrackPage struct {
+	Sort       TrackSort
+	Descending bool
+	After      *TrackCursor // Продолжить после этой позиции; nil — с начала
+	Limit      int64
+	Skip       int64 // Устаревшая навигация по номеру страницы, без After
+}
+
+// CursorAfter возвращает курсор, указывающий на трек t
+func (p TrackPage) CursorAfter(t models.Track) *TrackCursor {
+	var value interface{}
+	switch p.Sort {
+	case SortByTitle:
+		value = t.Title
+	case SortByArtist:
+		value = t.Artist
+	case SortByCreatedAt:
+		value = t.CreatedAt
+	case SortByDuration:
+		value = t.Duration
+	case SortByPopularity:
+		value = t.Popularity
+	}
+	return &TrackCursor{Sort: p.Sort, Descending: p.Descending, Value: value, ID: t.ID}
+}
+
+// Encode упаковывает курсор в непрозрачную для клиента строку
+func (c *TrackCursor) Encode() (string, error) {
+	raw, err := bson.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeTrackCursor разбирает курсор и проверяет, что он выдан для того же
+// порядка сортировки
+func DecodeTrackCursor(s string, sort TrackSort, descending bool) (*TrackCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c TrackCursor
+	if err := bson.Unmarshal(raw, &c); err != nil || c.ID.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	if _, ok := sortFields[c.Sort]; !ok {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != sort || c.Descending != descending {
+		return nil, ErrCursorMismatch
+	}
+	return &c, nil
+}
+
+// ListTracks возвращает страницу каталога в порядке page.Sort с _id для
+// равных значений. Выбирается на один трек больше, чем page.Limit: по нему
+// видно, есть ли следующая страница.
+func (r *TrackRepo) ListTracks(ctx context.Context, filter bson.M, page TrackPage) ([]models.Track, bool, error) {
+	field := sortFields[page.Sort]
+	direction, after := 1, "$gt"
+	if page.Descending {
+		direction, after = -1, "$lt"
+	}
+
+	query := filter
+	if page.After != nil {
+		keyset := bson.M{"$or": bson.A{
+			bson.M{field: bson.M{after: page.After.Value}},
+			bson.M{field: page.After.Value, "_id": bson.M{after: page.After.ID}},
+		}}
+		query = bson.M{"$and": bson.A{filter, keyset}}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(page.Limit + 1)
+	if page.Skip > 0 {
+		findOptions.SetSkip(page.Skip)
+	}
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(ctx)
+
+	var tracks []models.Track
+	if err := cursor.All(ctx, &tracks); err != nil {
+		return nil, false, err
+	}
+	if int64(len(tracks)) > page.Limit {
+		return tracks[:page.Limit], true, nil
+	}
+	return tracks, false, nil
+}
+
+// CountTracks считает треки, подходящие под фильтр
+func (r *TrackRepo) CountTracks(ctx context.Context, filter bson.M) (int64, error) {
+	return r.collection.CountDocuments(ctx, filter)
+}
+
+// EnsureSortIndexes создает индексы под каждый порядок сортировки каталога
+func (r *TrackRepo) EnsureSortIndexes(ctx context.Context) error {
+	indexes := make([]mongo.IndexModel, 0, len(sortFields))
+	for sort, field := range sortFields {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: field, Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("tracks_sort_" + string(sort)),
+		})
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// BackfillPopularity проставляет нулевую популярность трекам, созданным до
+// появления поля: иначе при сортировке по популярности курсор их пропускает
+func (r *TrackRepo) BackfillPopularity(ctx context.Context) (int64, error) {
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"popularity": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"popularity": int64(0)}})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// IncrementPopularity засчитывает одно прослушивание трека. Это не изменение
+// каталога: version не растет и событие track.updated не публикуется.
+func (r *TrackRepo) IncrementPopularity(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"popularity": int64(1)}})
+	return err
+}
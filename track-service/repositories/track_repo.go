@@ -21,8 +21,19 @@ func NewTrackRepo(db *mongo.Database) *TrackRepo {
 	}
 }
 
+// textIndexName — имя полнотекстового индекса; в коллекции он может быть только один
+const textIndexName = "tracks_text"
+
+// ScoredTrack — трек, найденный полнотекстовым поиском, с оценкой релевантности
+type ScoredTrack struct {
+	models.Track `bson:",inline"`
+	Score        float64 `bson:"score"`
+}
+
 func (r *TrackRepo) CreateTrack(ctx context.Context, track models.Track) (*mongo.InsertOneResult, error) {
 	track.CreatedAt = time.Now().Unix()
+	track.Version = 1
+	track.Search = models.NewSearchKeys(track.Title, track.Artist, track.Album)
 	return r.collection.InsertOne(ctx, track)
 }
 
@@ -64,8 +75,116 @@ func (r *TrackRepo) GetAllTracks(ctx context.Context, filter bson.M, limit int64
 	return tracks, nil
 }
 
+// UpdateTrack меняет поля трека и увеличивает его версию
 func (r *TrackRepo) UpdateTrack(ctx context.Context, id primitive.ObjectID, updateData bson.M) error {
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": updateData})
+	update := bson.M{"$set": updateData, "$inc": bson.M{"version": 1}}
+	_, title := updateData["title"]
+	_, artist := updateData["artist"]
+	_, album := updateData["album"]
+	if !title && !artist && !album {
+		_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+		return err
+	}
+
+	// Ключи поиска зависят от всех трех полей, поэтому пересчитываются по
+	// документу после обновления
+	var track models.Track
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&track)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return r.setSearchKeys(ctx, track)
+}
+
+// SearchTracks ищет треки по текстовому индексу и возвращает их по убыванию
+// релевантности. text — строка в синтаксисе $text.$search, filter — дополнительные условия.
+func (r *TrackRepo) SearchTracks(ctx context.Context, text string, filter bson.M, limit int64, skip int64) ([]ScoredTrack, error) {
+	query := bson.M{"$text": bson.M{"$search": text}}
+	for k, v := range filter {
+		query[k] = v
+	}
+
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}})
+	if limit > 0 {
+		findOptions.SetLimit(limit)
+	}
+	if skip > 0 {
+		findOptions.SetSkip(skip)
+	}
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tracks []ScoredTrack
+	if err := cursor.All(ctx, &tracks); err != nil {
+		return nil, err
+	}
+	return tracks, nil
+}
+
+// EnsureTextIndex создает полнотекстовый индекс по названию, исполнителю и
+// альбому и их латинской записи. Совпадение в названии весит больше всего.
+// Стемминг отключен (язык "none"): в каталоге вперемешку русские, казахские
+// и английские названия, и правила одного языка портили бы остальные.
+func (r *TrackRepo) EnsureTextIndex(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "artist", Value: "text"},
+			{Key: "album", Value: "text"},
+			{Key: "search.title", Value: "text"},
+			{Key: "search.artist", Value: "text"},
+			{Key: "search.album", Value: "text"},
+		},
+		Options: options.Index().
+			SetName(textIndexName).
+			SetDefaultLanguage("none").
+			SetWeights(bson.D{
+				{Key: "title", Value: 10},
+				{Key: "search.title", Value: 8},
+				{Key: "artist", Value: 5},
+				{Key: "search.artist", Value: 4},
+				{Key: "album", Value: 2},
+				{Key: "search.album", Value: 2},
+			}),
+	})
+	return err
+}
+
+// BackfillSearchKeys заполняет ключи поиска у треков, созданных до их появления
+func (r *TrackRepo) BackfillSearchKeys(ctx context.Context) (int, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"search": bson.M{"$exists": false}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var track models.Track
+		if err := cursor.Decode(&track); err != nil {
+			return updated, err
+		}
+		if err := r.setSearchKeys(ctx, track); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, cursor.Err()
+}
+
+func (r *TrackRepo) setSearchKeys(ctx context.Context, track models.Track) error {
+	keys := models.NewSearchKeys(track.Title, track.Artist, track.Album)
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": track.ID}, bson.M{"$set": bson.M{"search": keys}})
 	return err
 }
 
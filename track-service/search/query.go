@@ -0,0 +1,92 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	maxQueryRunes = 200
+	maxQueryParts = 16
+)
+
+// Query — разобранный поисковый запрос пользователя
+type Query struct {
+	Terms   []string // Отдельные слова, достаточно совпадения любого из них
+	Phrases []string // Фразы в кавычках, должны встретиться целиком
+}
+
+// ParseQuery разбирает ввод пользователя. Фразы берутся в двойные кавычки,
+// все остальное считается словами. Служебный синтаксис $text (отрицание через
+// "-", вложенные кавычки) пользователю недоступен и отбрасывается.
+func ParseQuery(input string) Query {
+	if runes := []rune(input); len(runes) > maxQueryRunes {
+		input = string(runes[:maxQueryRunes])
+	}
+
+	var q Query
+	parts := strings.Split(input, `"`)
+	for i, part := range parts {
+		// Нечетные части стоят между кавычками. Незакрытая кавычка
+		// в конце превращает хвост в обычные слова.
+		if i%2 == 1 && i < len(parts)-1 {
+			if phrase := strings.Join(words(part), " "); phrase != "" {
+				q.Phrases = append(q.Phrases, phrase)
+			}
+			continue
+		}
+		q.Terms = append(q.Terms, words(part)...)
+	}
+
+	if len(q.Phrases) > maxQueryParts {
+		q.Phrases = q.Phrases[:maxQueryParts]
+	}
+	if len(q.Terms) > maxQueryParts {
+		q.Terms = q.Terms[:maxQueryParts]
+	}
+	return q
+}
+
+// Empty сообщает, что искать нечего
+func (q Query) Empty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0
+}
+
+// TextSearch строит строку для $text.$search. Каждое слово ищется как есть
+// и в латинской записи, поэтому "кино" находит и "Кино", и "Kino", а "kino" —
+// и то, и другое через латинские копии полей. Фразы ищутся только в
+// латинской записи: она хранится для каждого трека (см. models.SearchKeys).
+func (q Query) TextSearch() string {
+	seen := map[string]bool{}
+	var parts []string
+	add := func(s string) {
+		if s != "" && !seen[s] {
+			seen[s] = true
+			parts = append(parts, s)
+		}
+	}
+
+	for _, phrase := range q.Phrases {
+		add(`"` + Latin(phrase) + `"`)
+	}
+	for _, term := range q.Terms {
+		add(strings.ToLower(term))
+		add(Latin(term))
+	}
+	return strings.Join(parts, " ")
+}
+
+// words делит текст на слова, убирая знаки, которые $text понимает как
+// операторы: ведущие дефисы (отрицание) и кавычки
+func words(s string) []string {
+	var result []string
+	for _, w := range strings.FieldsFunc(s, unicode.IsSpace) {
+		w = strings.TrimLeft(w, "-")
+		w = strings.ReplaceAll(w, `"`, "")
+		w = strings.ReplaceAll(w, `\`, "")
+		if w != "" {
+			result = append(result, w)
+		}
+	}
+	return result
+}
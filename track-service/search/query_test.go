@@ -0,0 +1,114 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  Query
+	}{
+		{name: "empty", input: "   ", want: Query{}},
+		{name: "words", input: "  группа  Кино ", want: Query{Terms: []string{"группа", "Кино"}}},
+		{
+			name:  "quoted phrase",
+			input: `"звезда по имени солнце" кино`,
+			want:  Query{Terms: []string{"кино"}, Phrases: []string{"звезда по имени солнце"}},
+		},
+		{
+			name:  "phrase whitespace is collapsed",
+			input: `"  group   blood  "`,
+			want:  Query{Phrases: []string{"group blood"}},
+		},
+		{name: "empty phrase is dropped", input: `"" kino`, want: Query{Terms: []string{"kino"}}},
+		{
+			name:  "unclosed quote turns the tail into words",
+			input: `"kino gruppa krovi`,
+			want:  Query{Terms: []string{"kino", "gruppa", "krovi"}},
+		},
+		{
+			name:  "several phrases",
+			input: `"a b" c "d e"`,
+			want:  Query{Terms: []string{"c"}, Phrases: []string{"a b", "d e"}},
+		},
+		// $text понимает "-слово" как исключение; пользователю это недоступно
+		{name: "exclusions are searched as words", input: "kino -tsoi --live", want: Query{Terms: []string{"kino", "tsoi", "live"}}},
+		{name: "lone dash is dropped", input: "kino - live", want: Query{Terms: []string{"kino", "live"}}},
+		{name: "backslashes are removed", input: `ki\no`, want: Query{Terms: []string{"kino"}}},
+		// Префиксов полей нет: "artist:" — часть слова, а не фильтр
+		{name: "field prefixes are plain words", input: "artist:kino title:krovi", want: Query{Terms: []string{"artist:kino", "title:krovi"}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ParseQuery(tc.input); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParseQuery(%q) = %#v, want %#v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryLimits(t *testing.T) {
+	q := ParseQuery(strings.Repeat("w ", 40))
+	if len(q.Terms) != maxQueryParts {
+		t.Errorf("got %d terms, want %d", len(q.Terms), maxQueryParts)
+	}
+
+	q = ParseQuery(strings.Repeat(`"a b" `, 40))
+	if len(q.Phrases) != maxQueryParts {
+		t.Errorf("got %d phrases, want %d", len(q.Phrases), maxQueryParts)
+	}
+
+	// Ввод обрезается по рунам, а не по байтам
+	q = ParseQuery(strings.Repeat("я", maxQueryRunes+50))
+	if len(q.Terms) != 1 || len([]rune(q.Terms[0])) != maxQueryRunes {
+		t.Errorf("long input parsed as %d terms of %d runes", len(q.Terms), len([]rune(strings.Join(q.Terms, ""))))
+	}
+}
+
+func TestQueryTextSearch(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{input: "Кино", want: "кино kino"},
+		{input: "Kino", want: "kino"},
+		{input: `"Группа крови" Цой`, want: `"gruppa krovi" цой tsoy`},
+		{input: "kino KINO кино", want: "kino кино"},
+	}
+
+	for _, tc := range cases {
+		if got := ParseQuery(tc.input).TextSearch(); got != tc.want {
+			t.Errorf("TextSearch(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestLatin(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "russian", input: "Звезда по имени Солнце", want: "zvezda po imeni solntse"},
+		{name: "multi-letter", input: "Жёлтый щавель", want: "zheltyy shchavel"},
+		{name: "signs are dropped", input: "Подъезд Мальчик", want: "podezd malchik"},
+		{name: "kazakh", input: "Қазақстан Әні", want: "kazakstan ani"},
+		{name: "ukrainian", input: "Їжак Євген Ґанок", want: "yizhak yevgen ganok"},
+		{name: "latin is lowercased", input: "Kino", want: "kino"},
+		{name: "other characters are kept", input: "Café 1984!", want: "café 1984!"},
+		{name: "mixed", input: "DDT Что такое осень", want: "ddt chto takoe osen"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Latin(tc.input); got != tc.want {
+				t.Errorf("Latin(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
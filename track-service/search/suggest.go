@@ -0,0 +1,621 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Виды подсказок
+const (
+	SuggestionTrack  = "track"
+	SuggestionArtist = "artist"
+	SuggestionAlbum  = "album"
+)
+
+const (
+	// maxSuggestions — сколько лучших подсказок кэшируется в каждом узле
+	// дерева; больше этого Suggest не вернет
+	maxSuggestions = 20
+	// minFuzzyPrefix — с какой длины префикса допускается опечатка: на более
+	// коротких одна правка подходит почти ко всему каталогу
+	minFuzzyPrefix  = 3
+	maxIndexedWords = 8
+	maxIndexedRunes = 64
+)
+
+// SuggestDoc — то, что индекс подсказок знает о треке
+type SuggestDoc struct {
+	TrackID  string
+	Title    string
+	Artist   string
+	Album    string
+	Explicit bool
+}
+
+// Suggestion — одна подсказка поиска
+type Suggestion struct {
+	Kind       string // SuggestionTrack, SuggestionArtist или SuggestionAlbum
+	Text       string // Название трека, имя исполнителя или название альбома
+	Subtitle   string // Исполнитель трека или альбома
+	TrackID    string // Только для треков
+	TrackCount int    // Сколько треков у исполнителя или в альбоме
+	Fuzzy      bool   // Найдено с исправлением опечатки
+}
+
+type suggestEntry struct {
+	kind     string
+	text     string
+	subtitle string
+	trackID  string
+	explicit bool
+	count    int      // Сколько треков ссылаются на исполнителя или альбом
+	keys     []string // Ключи в дереве, по одному на каждое слово
+}
+
+type trieHit struct {
+	entry *suggestEntry
+	pos   int // Номер слова, с которого начинается ключ; 0 — совпадение с начала
+}
+
+// trieNode — узел сжатого префиксного дерева: ребро от родителя помечено
+// строкой label, а не одним символом
+type trieNode struct {
+	label     []rune
+	children  []*trieNode // По первому символу label
+	terminals []trieHit   // Записи, чей ключ заканчивается в этом узле
+	size      int         // Сколько ключей заканчивается во всем поддереве
+	// top — лучшие записи поддерева. Хранится только для больших поддеревьев
+	// (size > maxSuggestions), маленькие дешевле обойти при запросе.
+	top []trieHit
+}
+
+// SuggestIndex — префиксное дерево для подсказок по мере ввода. Каждая
+// запись индексируется с начала каждого слова, поэтому "krov" находит
+// "Группа крови". Узлы больших поддеревьев хранят отсортированный список
+// лучших записей, так что запрос не обходит поддерево целиком; изменения
+// пересчитывают только узлы на пути измененного ключа.
+//
+// Индекс живет в памяти процесса и видит только изменения, сделанные через
+// этот экземпляр сервиса; при запуске он строится заново.
+type SuggestIndex struct {
+	mu      sync.RWMutex
+	root    *trieNode
+	tracks  map[string]SuggestDoc    // ID трека -> проиндексированная версия
+	entries map[string]*suggestEntry // Ключ записи (см. entryKey) -> запись
+}
+
+func NewSuggestIndex() *SuggestIndex {
+	return &SuggestIndex{
+		root:    &trieNode{},
+		tracks:  make(map[string]SuggestDoc),
+		entries: make(map[string]*suggestEntry),
+	}
+}
+
+// Build заполняет индекс треками каталога. Лучшие записи узлов считаются
+// один раз в конце, а не после каждого трека.
+func (x *SuggestIndex) Build(docs []SuggestDoc) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, doc := range docs {
+		if old, ok := x.tracks[doc.TrackID]; ok {
+			x.remove(old, false)
+		}
+		x.add(doc, false)
+	}
+	recomputeAll(x.root)
+}
+
+// Put добавляет трек или обновляет его проиндексированную версию
+func (x *SuggestIndex) Put(doc SuggestDoc) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if old, ok := x.tracks[doc.TrackID]; ok {
+		x.remove(old, true)
+	}
+	x.add(doc, true)
+}
+
+// Remove убирает трек из индекса
+func (x *SuggestIndex) Remove(trackID string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if old, ok := x.tracks[trackID]; ok {
+		x.remove(old, true)
+	}
+}
+
+// Suggest возвращает до limit подсказок для начала ввода prefix. Точные
+// совпадения идут раньше исправленных опечаток, совпадения с начала
+// названия — раньше совпадений со середины, затем исполнители и альбомы с
+// большим числом треков. hideExplicit убирает explicit-треки.
+func (x *SuggestIndex) Suggest(prefix string, limit int, hideExplicit bool) []Suggestion {
+	p := []rune(fold(prefix))
+	if len(p) == 0 {
+		return nil
+	}
+	if len(p) > maxIndexedRunes {
+		p = p[:maxIndexedRunes]
+	}
+	if limit <= 0 || limit > maxSuggestions {
+		limit = maxSuggestions
+	}
+	maxEdits := 0
+	if len(p) >= minFuzzyPrefix {
+		maxEdits = 1
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// Исправления опечаток ранжируются после точных совпадений, поэтому
+	// ищутся, только если точных не хватило
+	hits := x.collect(p, 0, limit, hideExplicit, nil)
+	if len(hits) < limit && maxEdits > 0 {
+		hits = x.collect(p, maxEdits, limit, hideExplicit, hits)
+	}
+
+	suggestions := make([]Suggestion, len(hits))
+	for i, h := range hits {
+		suggestions[i] = Suggestion{
+			Kind:     h.entry.kind,
+			Text:     h.entry.text,
+			Subtitle: h.entry.subtitle,
+			TrackID:  h.entry.trackID,
+			Fuzzy:    h.edits > 0,
+		}
+		if h.entry.kind != SuggestionTrack {
+			suggestions[i].TrackCount = h.entry.count
+		}
+	}
+	return suggestions
+}
+
+type rankedHit struct {
+	trieHit
+	edits int
+}
+
+func rankedLess(a, b rankedHit) bool {
+	if a.edits != b.edits {
+		return a.edits < b.edits
+	}
+	return hitLess(a.trieHit, b.trieHit)
+}
+
+// collect дополняет found лучшими записями, до которых p доходит не более
+// чем с maxEdits правками, и возвращает не больше limit записей по порядку
+func (x *SuggestIndex) collect(p []rune, maxEdits, limit int, hideExplicit bool, found []rankedHit) []rankedHit {
+	nodes := map[*trieNode]int{}
+	matchPrefix(x.root, 0, p, 0, maxEdits, nodes)
+
+	best := make(map[*suggestEntry]rankedHit, len(found))
+	for _, h := range found {
+		best[h.entry] = h
+	}
+	for n, edits := range nodes {
+		for _, h := range n.hits() {
+			if hideExplicit && h.entry.explicit {
+				continue
+			}
+			candidate := rankedHit{h, edits}
+			if cur, ok := best[h.entry]; !ok || rankedLess(candidate, cur) {
+				best[h.entry] = candidate
+			}
+		}
+	}
+
+	// Нужны только первые limit записей: держим их упорядоченными вставкой
+	// вместо сортировки всех кандидатов
+	top := make([]rankedHit, 0, limit+1)
+	for _, h := range best {
+		if len(top) == limit && !rankedLess(h, top[limit-1]) {
+			continue
+		}
+		i := sort.Search(len(top), func(i int) bool { return rankedLess(h, top[i]) })
+		top = append(top, rankedHit{})
+		copy(top[i+1:], top[i:])
+		top[i] = h
+		if len(top) > limit {
+			top = top[:limit]
+		}
+	}
+	return top
+}
+
+// add индексирует трек, его исполнителя и альбом. update пересчитывает
+// лучшие записи затронутых узлов сразу.
+func (x *SuggestIndex) add(doc SuggestDoc, update bool) {
+	x.tracks[doc.TrackID] = doc
+
+	artist, album := fold(doc.Artist), fold(doc.Album)
+	x.acquire(entryKey(SuggestionTrack, doc.TrackID), update, func() *suggestEntry {
+		return &suggestEntry{kind: SuggestionTrack, text: doc.Title, subtitle: doc.Artist, trackID: doc.TrackID, explicit: doc.Explicit}
+	})
+	if artist != "" {
+		x.acquire(entryKey(SuggestionArtist, artist), update, func() *suggestEntry {
+			return &suggestEntry{kind: SuggestionArtist, text: doc.Artist}
+		})
+	}
+	if album != "" {
+		x.acquire(entryKey(SuggestionAlbum, artist, album), update, func() *suggestEntry {
+			return &suggestEntry{kind: SuggestionAlbum, text: doc.Album, subtitle: doc.Artist}
+		})
+	}
+}
+
+func (x *SuggestIndex) remove(doc SuggestDoc, update bool) {
+	delete(x.tracks, doc.TrackID)
+
+	artist, album := fold(doc.Artist), fold(doc.Album)
+	x.release(entryKey(SuggestionTrack, doc.TrackID), update)
+	if artist != "" {
+		x.release(entryKey(SuggestionArtist, artist), update)
+	}
+	if album != "" {
+		x.release(entryKey(SuggestionAlbum, artist, album), update)
+	}
+}
+
+// acquire увеличивает счетчик записи, создавая ее при первом обращении
+func (x *SuggestIndex) acquire(key string, update bool, create func() *suggestEntry) {
+	e, ok := x.entries[key]
+	if !ok {
+		e = create()
+		x.entries[key] = e
+		e.count = 1
+		for i, k := range wordKeys(e.text) {
+			e.keys = append(e.keys, k)
+			x.insert(k, e, i, update)
+		}
+		return
+	}
+	e.count++
+	if update {
+		x.refresh(e)
+	}
+}
+
+// release уменьшает счетчик записи и удаляет ее, когда ссылок не осталось
+func (x *SuggestIndex) release(key string, update bool) {
+	e, ok := x.entries[key]
+	if !ok {
+		return
+	}
+	e.count--
+	if e.count > 0 {
+		if update {
+			x.refresh(e)
+		}
+		return
+	}
+	delete(x.entries, key)
+	for _, k := range e.keys {
+		x.delete(k, e, update)
+	}
+}
+
+func (x *SuggestIndex) insert(key string, e *suggestEntry, pos int, update bool) {
+	rest := []rune(key)
+	path := []*trieNode{x.root}
+	n := x.root
+	for len(rest) > 0 {
+		i, child := n.child(rest[0])
+		if child == nil {
+			child = &trieNode{label: rest}
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = child
+			n, rest = child, nil
+			path = append(path, n)
+			break
+		}
+
+		common := commonPrefix(child.label, rest)
+		if common < len(child.label) {
+			// Ключ расходится с меткой ребра посередине: ребро делится
+			mid := &trieNode{
+				label:    append([]rune(nil), child.label[:common]...),
+				children: []*trieNode{child},
+				size:     child.size,
+			}
+			child.label = append([]rune(nil), child.label[common:]...)
+			n.children[i] = mid
+			child = mid
+		}
+		n, rest = child, rest[common:]
+		path = append(path, n)
+	}
+
+	n.terminals = append(n.terminals, trieHit{e, pos})
+	for _, node := range path {
+		node.size++
+	}
+	if update {
+		recomputePath(path)
+	}
+}
+
+func (x *SuggestIndex) delete(key string, e *suggestEntry, update bool) {
+	rest := []rune(key)
+	path := []*trieNode{x.root}
+	n := x.root
+	for len(rest) > 0 {
+		_, child := n.child(rest[0])
+		if child == nil || len(rest) < len(child.label) || commonPrefix(child.label, rest) < len(child.label) {
+			return
+		}
+		n, rest = child, rest[len(child.label):]
+		path = append(path, n)
+	}
+
+	found := false
+	for i, h := range n.terminals {
+		if h.entry == e {
+			n.terminals = append(n.terminals[:i], n.terminals[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	for _, node := range path {
+		node.size--
+	}
+
+	// Убираем опустевшие узлы и склеиваем цепочки снизу вверх
+	for i := len(path) - 1; i > 0; i-- {
+		node, parent := path[i], path[i-1]
+		if len(node.terminals) == 0 && len(node.children) == 0 {
+			parent.removeChild(node)
+		} else if len(node.terminals) == 0 && len(node.children) == 1 {
+			node.absorbChild()
+		}
+	}
+	if update {
+		recomputePath(path)
+	}
+}
+
+// refresh пересчитывает узлы всех ключей записи после изменения ее счетчика
+func (x *SuggestIndex) refresh(e *suggestEntry) {
+	for _, key := range e.keys {
+		rest := []rune(key)
+		path := []*trieNode{x.root}
+		n := x.root
+		for len(rest) > 0 {
+			_, child := n.child(rest[0])
+			if child == nil || len(rest) < len(child.label) {
+				break
+			}
+			n, rest = child, rest[len(child.label):]
+			path = append(path, n)
+		}
+		recomputePath(path)
+	}
+}
+
+// child находит ребенка, метка которого начинается с r, или место для него
+func (n *trieNode) child(r rune) (int, *trieNode) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= r })
+	if i < len(n.children) && n.children[i].label[0] == r {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+func (n *trieNode) removeChild(child *trieNode) {
+	for i, c := range n.children {
+		if c == child {
+			n.children = append(n.children[:i], n.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// absorbChild склеивает узел без своих записей с единственным ребенком
+func (n *trieNode) absorbChild() {
+	child := n.children[0]
+	n.label = append(append([]rune(nil), n.label...), child.label...)
+	n.children = child.children
+	n.terminals = child.terminals
+	n.size = child.size
+	n.top = child.top
+}
+
+// hits возвращает лучшие записи поддерева: из кэша или обходом маленького поддерева
+func (n *trieNode) hits() []trieHit {
+	if n.size > maxSuggestions {
+		return n.top
+	}
+	var all []trieHit
+	var walk func(*trieNode)
+	walk = func(node *trieNode) {
+		all = append(all, node.terminals...)
+		for _, c := range node.children {
+			walk(c)
+		}
+	}
+	walk(n)
+	return all
+}
+
+// recompute обновляет кэш лучших записей по записям узла и его детей
+func (n *trieNode) recompute() {
+	if n.size <= maxSuggestions {
+		n.top = nil
+		return
+	}
+
+	best := make(map[*suggestEntry]int)
+	consider := func(h trieHit) {
+		if pos, ok := best[h.entry]; !ok || h.pos < pos {
+			best[h.entry] = h.pos
+		}
+	}
+	for _, h := range n.terminals {
+		consider(h)
+	}
+	for _, child := range n.children {
+		for _, h := range child.hits() {
+			consider(h)
+		}
+	}
+
+	top := make([]trieHit, 0, len(best))
+	for e, pos := range best {
+		top = append(top, trieHit{e, pos})
+	}
+	sort.Slice(top, func(i, j int) bool { return hitLess(top[i], top[j]) })
+	if len(top) > maxSuggestions {
+		top = top[:maxSuggestions]
+	}
+	n.top = top
+}
+
+func recomputePath(path []*trieNode) {
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i].recompute()
+	}
+}
+
+func recomputeAll(n *trieNode) {
+	for _, child := range n.children {
+		recomputeAll(child)
+	}
+	n.recompute()
+}
+
+// matchPrefix находит узлы, до которых prefix доходит не более чем с
+// maxEdits правками (замена, вставка, удаление или перестановка соседних
+// символов), и запоминает для каждого наименьшее число правок. Позиция в
+// дереве — узел n и число уже пройденных символов его метки i.
+func matchPrefix(n *trieNode, i int, p []rune, edits, maxEdits int, out map[*trieNode]int) {
+	if len(p) == 0 {
+		if cur, ok := out[n]; !ok || edits < cur {
+			out[n] = edits
+		}
+		return
+	}
+
+	if m, j, ok := step(n, i, p[0]); ok {
+		matchPrefix(m, j, p[1:], edits, maxEdits, out)
+	}
+	if edits >= maxEdits {
+		return
+	}
+
+	// Лишний символ во вводе
+	matchPrefix(n, i, p[1:], edits+1, maxEdits, out)
+	forEachStep(n, i, func(r rune, m *trieNode, j int) {
+		// Неверный символ
+		if r != p[0] {
+			matchPrefix(m, j, p[1:], edits+1, maxEdits, out)
+		}
+		// Пропущенный символ
+		matchPrefix(m, j, p, edits+1, maxEdits, out)
+	})
+	// Переставленные соседние символы
+	if len(p) >= 2 && p[0] != p[1] {
+		if m, j, ok := step(n, i, p[1]); ok {
+			if m, j, ok := step(m, j, p[0]); ok {
+				matchPrefix(m, j, p[2:], edits+1, maxEdits, out)
+			}
+		}
+	}
+}
+
+// step переходит из позиции (n, i) по символу r
+func step(n *trieNode, i int, r rune) (*trieNode, int, bool) {
+	if i < len(n.label) {
+		return n, i + 1, n.label[i] == r
+	}
+	_, child := n.child(r)
+	if child == nil {
+		return nil, 0, false
+	}
+	return child, 1, true
+}
+
+// forEachStep перебирает все переходы из позиции (n, i)
+func forEachStep(n *trieNode, i int, fn func(r rune, m *trieNode, j int)) {
+	if i < len(n.label) {
+		fn(n.label[i], n, i+1)
+		return
+	}
+	for _, child := range n.children {
+		fn(child.label[0], child, 1)
+	}
+}
+
+func commonPrefix(a, b []rune) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// hitLess задает порядок подсказок: совпадения с начала, затем больше
+// треков, затем по алфавиту
+func hitLess(a, b trieHit) bool {
+	if (a.pos == 0) != (b.pos == 0) {
+		return a.pos == 0
+	}
+	if a.entry.count != b.entry.count {
+		return a.entry.count > b.entry.count
+	}
+	if a.entry.text != b.entry.text {
+		return a.entry.text < b.entry.text
+	}
+	if a.entry.kind != b.entry.kind {
+		return a.entry.kind < b.entry.kind
+	}
+	return a.entry.trackID < b.entry.trackID
+}
+
+func entryKey(kind string, parts ...string) string {
+	return kind + "\x00" + strings.Join(parts, "\x00")
+}
+
+// wordKeys возвращает ключи записи: нормализованный текст, начиная с каждого слова
+func wordKeys(text string) []string {
+	words := strings.Fields(fold(text))
+	if len(words) > maxIndexedWords {
+		words = words[:maxIndexedWords]
+	}
+	keys := make([]string, 0, len(words))
+	for i := range words {
+		key := []rune(strings.Join(words[i:], " "))
+		if len(key) > maxIndexedRunes {
+			key = key[:maxIndexedRunes]
+		}
+		keys = append(keys, string(key))
+	}
+	return keys
+}
+
+// fold приводит текст к виду для сравнения: латиница в нижнем регистре без
+// диакритики, знаки препинания заменены пробелами, пробелы схлопнуты
+func fold(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(Latin(norm.NFC.String(s))) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Диакритический знак отделился от буквы при разложении
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,286 @@
+package search
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// texts возвращает подсказки в виде "вид:текст" для сравнения
+func texts(suggestions []Suggestion) []string {
+	result := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = s.Kind + ":" + s.Text
+	}
+	return result
+}
+
+func newTestIndex() *SuggestIndex {
+	x := NewSuggestIndex()
+	x.Build([]SuggestDoc{
+		{TrackID: "t1", Title: "Группа крови", Artist: "Кино", Album: "Группа крови"},
+		{TrackID: "t2", Title: "Звезда по имени Солнце", Artist: "Кино", Album: "Звезда по имени Солнце"},
+		{TrackID: "t3", Title: "Кукушка", Artist: "Кино", Album: "Черный альбом"},
+		{TrackID: "t4", Title: "Kids", Artist: "MGMT", Album: "Oracular Spectacular", Explicit: true},
+	})
+	return x
+}
+
+func TestSuggestPrefix(t *testing.T) {
+	x := newTestIndex()
+
+	cases := []struct {
+		prefix string
+		want   []string
+	}{
+		// Кириллица и латиница ищутся одинаково; "kid" отличается одной
+		// заменой и идет после точного совпадения
+		{prefix: "кин", want: []string{"artist:Кино", "track:Kids"}},
+		{prefix: "kin", want: []string{"artist:Кино", "track:Kids"}},
+		// Совпадение с начала названия идет раньше совпадения со второго слова
+		{prefix: "grup", want: []string{"album:Группа крови", "track:Группа крови"}},
+		{prefix: "krov", want: []string{"album:Группа крови", "track:Группа крови"}},
+		{prefix: "Звезда по", want: []string{"album:Звезда по имени Солнце", "track:Звезда по имени Солнце"}},
+		{prefix: "solnts", want: []string{"album:Звезда по имени Солнце", "track:Звезда по имени Солнце"}},
+		{prefix: "ora", want: []string{"album:Oracular Spectacular"}},
+		// Знаки препинания и регистр не важны
+		{prefix: "  KUKU!! ", want: []string{"track:Кукушка"}},
+		{prefix: "", want: []string{}},
+		{prefix: "?!", want: []string{}},
+		{prefix: "zz", want: []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			if got := texts(x.Suggest(tc.prefix, 10, false)); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Suggest(%q) = %v, want %v", tc.prefix, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSuggestDetails(t *testing.T) {
+	x := newTestIndex()
+
+	got := x.Suggest("kino", 10, false)
+	want := []Suggestion{{Kind: SuggestionArtist, Text: "Кино", TrackCount: 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Suggest(kino) = %+v, want %+v", got, want)
+	}
+
+	got = x.Suggest("kuku", 10, false)
+	want = []Suggestion{{Kind: SuggestionTrack, Text: "Кукушка", Subtitle: "Кино", TrackID: "t3"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Suggest(kuku) = %+v, want %+v", got, want)
+	}
+}
+
+func TestSuggestHideExplicit(t *testing.T) {
+	x := newTestIndex()
+
+	if got := texts(x.Suggest("kids", 10, false)); !reflect.DeepEqual(got, []string{"track:Kids"}) {
+		t.Errorf("Suggest(kids) = %v", got)
+	}
+	if got := x.Suggest("kids", 10, true); len(got) != 0 {
+		t.Errorf("explicit track suggested: %v", texts(got))
+	}
+	// Исполнитель explicit-трека не скрывается
+	if got := texts(x.Suggest("mgmt", 10, true)); !reflect.DeepEqual(got, []string{"artist:MGMT"}) {
+		t.Errorf("Suggest(mgmt) = %v", got)
+	}
+}
+
+func TestSuggestFuzzy(t *testing.T) {
+	x := newTestIndex()
+
+	cases := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "kion", want: []string{"artist:Кино"}},   // Перестановка
+		{prefix: "kuko", want: []string{"track:Кукушка"}}, // Замена
+		{prefix: "kkuk", want: []string{"track:Кукушка"}}, // Лишний символ
+		{prefix: "kuh", want: []string{"track:Кукушка"}},  // Замена на коротком префиксе
+		// Короче minFuzzyPrefix: только точные совпадения, опечатки не исправляются
+		{prefix: "ki", want: []string{"artist:Кино", "track:Kids"}},
+		{prefix: "kx", want: []string{}},
+		{prefix: "kxyz", want: []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			got := x.Suggest(tc.prefix, 10, false)
+			if !reflect.DeepEqual(texts(got), tc.want) {
+				t.Fatalf("Suggest(%q) = %v, want %v", tc.prefix, texts(got), tc.want)
+			}
+		})
+	}
+
+	got := x.Suggest("kion", 10, false)
+	if len(got) != 1 || !got[0].Fuzzy {
+		t.Errorf("Suggest(kion) = %+v, want a fuzzy match", got)
+	}
+	got = x.Suggest("kino", 10, false)
+	if len(got) != 1 || got[0].Fuzzy {
+		t.Errorf("Suggest(kino) = %+v, want an exact match", got)
+	}
+
+	// Точное совпадение идет раньше исправленного, даже если у того больше треков
+	x.Put(SuggestDoc{TrackID: "t5", Title: "Kion", Artist: "Somebody"})
+	if got := texts(x.Suggest("kion", 10, false)); !reflect.DeepEqual(got, []string{"track:Kion", "artist:Кино"}) {
+		t.Errorf("Suggest(kion) = %v", got)
+	}
+}
+
+func TestSuggestRanking(t *testing.T) {
+	x := NewSuggestIndex()
+	x.Build([]SuggestDoc{
+		{TrackID: "t1", Title: "Blue", Artist: "Blur"},
+		{TrackID: "t2", Title: "Song 2", Artist: "Blur"},
+		{TrackID: "t3", Title: "Beetlebum", Artist: "Blur"},
+		{TrackID: "t4", Title: "Blue Monday", Artist: "New Order"},
+		{TrackID: "t5", Title: "Kind of Blue", Artist: "Miles Davis"},
+	})
+
+	got := texts(x.Suggest("blu", 10, false))
+	want := []string{
+		"artist:Blur",        // С начала и три трека
+		"track:Blue",         // С начала, по алфавиту
+		"track:Blue Monday",  //
+		"track:Kind of Blue", // Совпадение с середины названия
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Suggest(blu) = %v, want %v", got, want)
+	}
+}
+
+func TestSuggestUpdates(t *testing.T) {
+	x := newTestIndex()
+
+	// Новое название заменяет старое
+	x.Put(SuggestDoc{TrackID: "t3", Title: "Пачка сигарет", Artist: "Кино", Album: "Черный альбом"})
+	if got := x.Suggest("kuku", 10, false); len(got) != 0 {
+		t.Errorf("old title still suggested: %v", texts(got))
+	}
+	if got := texts(x.Suggest("pachka", 10, false)); !reflect.DeepEqual(got, []string{"track:Пачка сигарет"}) {
+		t.Errorf("Suggest(pachka) = %v", got)
+	}
+	if got := x.Suggest("kino", 10, false); len(got) != 1 || got[0].TrackCount != 3 {
+		t.Errorf("artist after rename = %+v, want 3 tracks", got)
+	}
+
+	// Смена исполнителя переносит трек между записями исполнителей
+	x.Put(SuggestDoc{TrackID: "t3", Title: "Пачка сигарет", Artist: "Виктор Цой"})
+	if got := x.Suggest("kino", 10, false); len(got) != 1 || got[0].TrackCount != 2 {
+		t.Errorf("Кино after moving a track = %+v, want 2 tracks", got)
+	}
+	if got := x.Suggest("cherny", 10, false); len(got) != 0 {
+		t.Errorf("album without tracks still suggested: %v", texts(got))
+	}
+
+	// Удаление последнего трека убирает исполнителя и альбом
+	x.Remove("t1")
+	x.Remove("t2")
+	if got := x.Suggest("kino", 10, false); len(got) != 0 {
+		t.Errorf("artist without tracks still suggested: %v", texts(got))
+	}
+	if got := x.Suggest("zvezda", 10, false); len(got) != 0 {
+		t.Errorf("removed track still suggested: %v", texts(got))
+	}
+	if got := texts(x.Suggest("tsoy", 10, false)); !reflect.DeepEqual(got, []string{"artist:Виктор Цой"}) {
+		t.Errorf("Suggest(tsoy) = %v", got)
+	}
+
+	// Повторное удаление и удаление неизвестного трека ничего не ломают
+	x.Remove("t1")
+	x.Remove("missing")
+}
+
+func TestSuggestLimit(t *testing.T) {
+	x := NewSuggestIndex()
+	var docs []SuggestDoc
+	for i := 0; i < 50; i++ {
+		docs = append(docs, SuggestDoc{TrackID: fmt.Sprintf("t%02d", i), Title: fmt.Sprintf("Love song %02d", i)})
+	}
+	x.Build(docs)
+
+	if got := x.Suggest("love", 5, false); len(got) != 5 || got[0].Text != "Love song 00" || got[4].Text != "Love song 04" {
+		t.Errorf("Suggest(love, 5) = %v", texts(got))
+	}
+	for _, limit := range []int{0, -1, 100} {
+		if got := x.Suggest("love", limit, false); len(got) != maxSuggestions {
+			t.Errorf("Suggest(love, %d) returned %d, want %d", limit, len(got), maxSuggestions)
+		}
+	}
+}
+
+// bruteSuggest — точные подсказки полным перебором записей индекса
+func bruteSuggest(x *SuggestIndex, prefix string, limit int) []string {
+	p := fold(prefix)
+	var hits []trieHit
+	for _, e := range x.entries {
+		best := -1
+		for pos, key := range e.keys {
+			if strings.HasPrefix(key, p) && best < 0 {
+				best = pos
+			}
+		}
+		if best >= 0 {
+			hits = append(hits, trieHit{e, best})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hitLess(hits[i], hits[j]) })
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	result := make([]string, len(hits))
+	for i, h := range hits {
+		result[i] = h.entry.kind + ":" + h.entry.text
+	}
+	return result
+}
+
+// Кэш лучших записей в узлах должен давать тот же результат, что и полный
+// перебор, после любой последовательности изменений
+func TestSuggestMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	words := []string{"ka", "kab", "kaba", "kb", "lo", "lov", "love", "mo", "moon", "ba", "bab"}
+	phrase := func() string {
+		n := 1 + rng.Intn(3)
+		parts := make([]string, n)
+		for i := range parts {
+			parts[i] = words[rng.Intn(len(words))]
+		}
+		return strings.Join(parts, " ")
+	}
+
+	x := NewSuggestIndex()
+	for step := 0; step < 2000; step++ {
+		id := fmt.Sprintf("t%d", rng.Intn(120))
+		if rng.Intn(4) == 0 {
+			x.Remove(id)
+		} else {
+			x.Put(SuggestDoc{TrackID: id, Title: phrase(), Artist: words[rng.Intn(len(words))], Album: phrase()})
+		}
+
+		if step%50 != 0 {
+			continue
+		}
+		for _, prefix := range []string{"k", "ka", "kab", "l", "love", "m", "b"} {
+			want := bruteSuggest(x, prefix, maxSuggestions)
+			x.mu.RLock()
+			exact := x.collect([]rune(fold(prefix)), 0, maxSuggestions, false, nil)
+			x.mu.RUnlock()
+			got := make([]string, len(exact))
+			for i, h := range exact {
+				got[i] = h.entry.kind + ":" + h.entry.text
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("step %d, prefix %q:\n got %v\nwant %v", step, prefix, got, want)
+			}
+		}
+	}
+}
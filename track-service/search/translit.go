@@ -0,0 +1,35 @@
+package search
+
+import (
+	"strings"
+)
+
+// cyrillicToLatin — упрощенная транслитерация, близкая к тому, как слушатели
+// сами пишут кириллические названия латиницей. Кроме русского алфавита
+// покрыты казахские и украинские буквы.
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	// Казахский
+	'ә': "a", 'ғ': "g", 'қ': "k", 'ң': "n", 'ө': "o", 'ұ': "u", 'ү': "u", 'һ': "h", 'і': "i",
+	// Украинский
+	'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+// Latin приводит строку к нижнему регистру и записывает кириллицу латиницей.
+// Остальные символы не меняются: диакритику снимает сам текстовый индекс Mongo.
+func Latin(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range strings.ToLower(s) {
+		if latin, ok := cyrillicToLatin[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
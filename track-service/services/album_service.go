@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/search"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AlbumGRPCService struct {
+	albums  *repositories.AlbumRepo
+	artists *repositories.ArtistRepo
+	tracks  *repositories.TrackRepo
+	prefs   PreferenceProvider
+	suggest *search.SuggestIndex
+	blobs   storage.BlobStore
+	outbox  *repositories.OutboxRepo
+	pb.UnimplementedAlbumServiceServer
+}
+
+func NewAlbumGRPCService(albums *repositories.AlbumRepo, artists *repositories.ArtistRepo, tracks *repositories.TrackRepo, prefs PreferenceProvider, suggest *search.SuggestIndex, blobs storage.BlobStore, outbox *repositories.OutboxRepo) *AlbumGRPCService {
+	return &AlbumGRPCService{albums: albums, artists: artists, tracks: tracks, prefs: prefs, suggest: suggest, blobs: blobs, outbox: outbox}
+}
+
+func (s *AlbumGRPCService) CreateAlbum(ctx context.Context, req *pb.CreateAlbumRequest) (*pb.CreateAlbumResponse, error) {
+	if strings.TrimSpace(req.GetTitle()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+	artistID, err := primitive.ObjectIDFromHex(req.GetArtistId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid artist_id")
+	}
+	album := models.Album{Title: req.GetTitle(), ArtistID: artistID}
+	if v := req.GetReleaseDate(); v != "" {
+		if album.ReleaseDate, err = models.NormalizeReleaseDate(v); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	artist, err := s.artists.GetArtistByID(ctx, artistID)
+	if err != nil {
+		return nil, catalogError(err, "artist")
+	}
+	album.Artist = artist.Name
+
+	album, err = s.albums.CreateAlbum(ctx, album)
+	if err != nil {
+		return nil, catalogError(err, "album")
+	}
+
+	return &pb.CreateAlbumResponse{Album: albumToProto(album)}, nil
+}
+
+// GetAlbum возвращает альбом с трек-листом
+func (s *AlbumGRPCService) GetAlbum(ctx context.Context, req *pb.GetAlbumRequest) (*pb.GetAlbumResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	album, err := s.albums.GetAlbumByID(ctx, objID)
+	if err != nil {
+		return nil, catalogError(err, "album")
+	}
+
+	filter := bson.M{}
+	if hideExplicit(ctx, s.prefs) {
+		filter["explicit"] = bson.M{"$ne": true}
+	}
+	tracks, err := s.tracks.ListAlbumTracks(ctx, objID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetAlbumResponse{Album: albumToProto(album), Tracks: make([]*pb.Track, len(tracks))}
+	for i, t := range tracks {
+		resp.Tracks[i] = toProto(t)
+	}
+	return resp, nil
+}
+
+func (s *AlbumGRPCService) ListAlbums(ctx context.Context, req *pb.ListAlbumsRequest) (*pb.ListAlbumsResponse, error) {
+	var artistID primitive.ObjectID
+	if id := req.GetArtistId(); id != "" {
+		var err error
+		if artistID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid artist_id")
+		}
+	}
+	limit, skip := pagination(req.GetPage(), req.GetLimit())
+
+	albums, err := s.albums.ListAlbums(ctx, artistID, limit, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListAlbumsResponse{Albums: make([]*pb.Album, len(albums))}
+	for i, a := range albums {
+		resp.Albums[i] = albumToProto(a)
+	}
+	return resp, nil
+}
+
+// UpdateAlbum меняет название и дату релиза; новое название копируется в треки
+func (s *AlbumGRPCService) UpdateAlbum(ctx context.Context, req *pb.UpdateAlbumRequest) (*pb.UpdateAlbumResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	updateData := bson.M{}
+	if title := req.GetTitle(); strings.TrimSpace(title) != "" {
+		updateData["title"] = title
+	}
+	if v := req.GetReleaseDate(); v != "" {
+		date, err := models.NormalizeReleaseDate(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		updateData["release_date"] = date
+	}
+	if len(updateData) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no fields to update")
+	}
+
+	album, err := s.albums.UpdateAlbum(ctx, objID, updateData)
+	if err != nil {
+		return nil, catalogError(err, "album")
+	}
+	if _, renamed := updateData["title"]; renamed {
+		var tracks []models.Track
+		err = s.outbox.Record(ctx, func(ctx context.Context) ([]models.OutboxEvent, error) {
+			before, after, err := s.tracks.SetAlbumTitle(ctx, album)
+			if err != nil {
+				return nil, err
+			}
+			tracks = after
+			return tracksUpdated(before, after)
+		})
+		if err != nil {
+			return nil, err
+		}
+		putTracks(s.suggest, tracks)
+	}
+
+	return &pb.UpdateAlbumResponse{Album: albumToProto(album)}, nil
+}
+
+func (s *AlbumGRPCService) DeleteAlbum(ctx context.Context, req *pb.DeleteAlbumRequest) (*pb.DeleteAlbumResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	tracks, err := s.tracks.CountAlbumTracks(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
+	if tracks > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "album still has %d tracks", tracks)
+	}
+
+	album, err := s.albums.GetAlbumByID(ctx, objID)
+	if err != nil {
+		return nil, catalogError(err, "album")
+	}
+	if err := s.albums.DeleteAlbum(ctx, objID); err != nil {
+		return nil, catalogError(err, "album")
+	}
+	releaseArtwork(ctx, s.blobs, s.tracks, s.albums, album.Cover)
+
+	return &pb.DeleteAlbumResponse{Message: "Album deleted successfully"}, nil
+}
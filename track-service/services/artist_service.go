@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/search"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ArtistGRPCService struct {
+	artists *repositories.ArtistRepo
+	albums  *repositories.AlbumRepo
+	tracks  *repositories.TrackRepo
+	suggest *search.SuggestIndex
+	outbox  *repositories.OutboxRepo
+	pb.UnimplementedArtistServiceServer
+}
+
+func NewArtistGRPCService(artists *repositories.ArtistRepo, albums *repositories.AlbumRepo, tracks *repositories.TrackRepo, suggest *search.SuggestIndex, outbox *repositories.OutboxRepo) *ArtistGRPCService {
+	return &ArtistGRPCService{artists: artists, albums: albums, tracks: tracks, suggest: suggest, outbox: outbox}
+}
+
+func (s *ArtistGRPCService) CreateArtist(ctx context.Context, req *pb.CreateArtistRequest) (*pb.CreateArtistResponse, error) {
+	if strings.TrimSpace(req.GetName()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	artist, err := s.artists.CreateArtist(ctx, models.Artist{Name: req.GetName()})
+	if err != nil {
+		return nil, catalogError(err, "artist")
+	}
+
+	return &pb.CreateArtistResponse{Artist: artistToProto(artist)}, nil
+}
+
+func (s *ArtistGRPCService) GetArtist(ctx context.Context, req *pb.GetArtistRequest) (*pb.GetArtistResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	artist, err := s.artists.GetArtistByID(ctx, objID)
+	if err != nil {
+		return nil, catalogError(err, "artist")
+	}
+	trackCount, err := s.tracks.CountArtistTracks(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
+	albumCount, err := s.albums.CountAlbums(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetArtistResponse{
+		Artist:     artistToProto(artist),
+		TrackCount: trackCount,
+		AlbumCount: albumCount,
+	}, nil
+}
+
+func (s *ArtistGRPCService) ListArtists(ctx context.Context, req *pb.ListArtistsRequest) (*pb.ListArtistsResponse, error) {
+	limit, skip := pagination(req.GetPage(), req.GetLimit())
+
+	artists, err := s.artists.ListArtists(ctx, req.GetPrefix(), limit, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListArtistsResponse{Artists: make([]*pb.Artist, len(artists))}
+	for i, a := range artists {
+		resp.Artists[i] = artistToProto(a)
+	}
+	return resp, nil
+}
+
+// UpdateArtist переименовывает исполнителя; имя обновляется и в его альбомах
+// и треках, о каждом треке публикуется track.updated
+func (s *ArtistGRPCService) UpdateArtist(ctx context.Context, req *pb.UpdateArtistRequest) (*pb.UpdateArtistResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	if strings.TrimSpace(req.GetName()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	artist, err := s.artists.RenameArtist(ctx, objID, req.GetName())
+	if err != nil {
+		return nil, catalogError(err, "artist")
+	}
+	if err := s.albums.SetArtistName(ctx, artist); err != nil {
+		return nil, err
+	}
+	var tracks []models.Track
+	err = s.outbox.Record(ctx, func(ctx context.Context) ([]models.OutboxEvent, error) {
+		before, after, err := s.tracks.SetArtistName(ctx, artist)
+		if err != nil {
+			return nil, err
+		}
+		tracks = after
+		return tracksUpdated(before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	putTracks(s.suggest, tracks)
+
+	return &pb.UpdateArtistResponse{Artist: artistToProto(artist)}, nil
+}
+
+func (s *ArtistGRPCService) DeleteArtist(ctx context.Context, req *pb.DeleteArtistRequest) (*pb.DeleteArtistResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	tracks, err := s.tracks.CountArtistTracks(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
+	albums, err := s.albums.CountAlbums(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
+	if tracks > 0 || albums > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "artist still has %d tracks and %d albums", tracks, albums)
+	}
+
+	if err := s.artists.DeleteArtist(ctx, objID); err != nil {
+		return nil, catalogError(err, "artist")
+	}
+
+	return &pb.DeleteArtistResponse{Message: "Artist deleted successfully"}, nil
+}
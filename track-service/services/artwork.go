@@ -0,0 +1,230 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"path"
+	"regexp"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/imaging"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxCoverSize — предельный размер обложки: картинка передается одним
+	// сообщением и должна уложиться в ограничение gRPC в 4 МБ
+	maxCoverSize = 3 << 20
+	// Допустимые размеры сторон обложки в пикселях. Верхний предел заодно
+	// ограничивает память на декодирование.
+	minCoverSide = 200
+	maxCoverSide = 4000
+
+	thumbnailQuality = 85
+	// coverURLPrefix — путь шлюза, по которому отдаются картинки обложек
+	coverURLPrefix = "/covers/"
+)
+
+// coverThumbnailSizes — размеры превью по большей стороне: для списков,
+// карточек и страницы альбома
+var coverThumbnailSizes = []int{64, 300, 640}
+
+// Расширения ключей обложек по формату картинки
+var artworkExtensions = map[string]string{
+	"jpeg": "jpg",
+	"png":  "png",
+	"gif":  "gif",
+}
+
+// Имя картинки в url: SHA-256 оригинала, размер превью и расширение
+var artworkName = regexp.MustCompile(`^[0-9a-f]{64}(_[0-9]+)?\.(jpg|png|gif)$`)
+
+// storeArtwork проверяет, что data — картинка поддерживаемого формата и
+// размера, и сохраняет ее и превью в хранилище под ключами из SHA-256
+// содержимого
+func storeArtwork(ctx context.Context, blobs storage.BlobStore, data []byte, source string) (models.Artwork, error) {
+	if len(data) > maxCoverSize {
+		return models.Artwork{}, status.Errorf(codes.InvalidArgument, "covers are limited to %d MB", maxCoverSize>>20)
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return models.Artwork{}, status.Errorf(codes.InvalidArgument, "unsupported image: %v", err)
+	}
+	ext, ok := artworkExtensions[format]
+	if !ok {
+		return models.Artwork{}, status.Errorf(codes.InvalidArgument, "unsupported image format %s", format)
+	}
+	if min(config.Width, config.Height) < minCoverSide || max(config.Width, config.Height) > maxCoverSide {
+		return models.Artwork{}, status.Errorf(codes.InvalidArgument, "the cover is %dx%d pixels, each side must be between %d and %d",
+			config.Width, config.Height, minCoverSide, maxCoverSide)
+	}
+
+	hash := sha256.Sum256(data)
+	sum := hex.EncodeToString(hash[:])
+	base := fmt.Sprintf("covers/%s/%s", sum[:2], sum)
+	key := base + "." + ext
+	exists, err := blobs.Exists(ctx, key)
+	if err != nil {
+		return models.Artwork{}, err
+	}
+	if !exists {
+		if err := blobs.Put(ctx, key, bytes.NewReader(data)); err != nil {
+			return models.Artwork{}, err
+		}
+	}
+	thumbnails, err := storeThumbnails(ctx, blobs, base, data, config)
+	if err != nil {
+		return models.Artwork{}, err
+	}
+
+	return models.Artwork{
+		Key:        key,
+		SHA256:     sum,
+		MimeType:   "image/" + format,
+		Width:      config.Width,
+		Height:     config.Height,
+		Size:       int64(len(data)),
+		Source:     source,
+		Thumbnails: thumbnails,
+	}, nil
+}
+
+// storeThumbnails сохраняет превью размеров меньше оригинала. Картинка
+// декодируется, только если каких-то превью еще нет в хранилище.
+func storeThumbnails(ctx context.Context, blobs storage.BlobStore, base string, data []byte, config image.Config) ([]models.Thumbnail, error) {
+	var thumbnails []models.Thumbnail
+	var img image.Image
+	for _, size := range coverThumbnailSizes {
+		if size >= max(config.Width, config.Height) {
+			break
+		}
+		thumb := models.Thumbnail{Size: size, Key: fmt.Sprintf("%s_%d.jpg", base, size)}
+		thumb.Width, thumb.Height = imaging.FitSize(config.Width, config.Height, size)
+		thumbnails = append(thumbnails, thumb)
+
+		exists, err := blobs.Exists(ctx, thumb.Key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+		if img == nil {
+			if img, _, err = image.Decode(bytes.NewReader(data)); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "unsupported image: %v", err)
+			}
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, imaging.Fit(img, size), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, err
+		}
+		if err := blobs.Put(ctx, thumb.Key, &buf); err != nil {
+			return nil, err
+		}
+	}
+	return thumbnails, nil
+}
+
+// releaseArtwork удаляет обложку и ее превью из хранилища, если на нее больше
+// не ссылается ни один трек или альбом. Ошибки только журналируются.
+func releaseArtwork(ctx context.Context, blobs storage.BlobStore, tracks *repositories.TrackRepo, albums *repositories.AlbumRepo, cover *models.Artwork) {
+	if cover == nil {
+		return
+	}
+	trackRefs, err := tracks.CountCoverReferences(ctx, cover.Key)
+	if err != nil {
+		log.Printf("failed to count references to %s: %v", cover.Key, err)
+		return
+	}
+	albumRefs, err := albums.CountCoverReferences(ctx, cover.Key)
+	if err != nil {
+		log.Printf("failed to count references to %s: %v", cover.Key, err)
+		return
+	}
+	if trackRefs+albumRefs > 0 {
+		return
+	}
+
+	keys := []string{cover.Key}
+	for _, t := range cover.Thumbnails {
+		keys = append(keys, t.Key)
+	}
+	for _, key := range keys {
+		if err := blobs.Delete(ctx, key); err != nil {
+			log.Printf("failed to delete %s: %v", key, err)
+		}
+	}
+}
+
+// GetArtworkImage отдает картинку обложки или превью по имени из url
+func (s *TrackGRPCService) GetArtworkImage(ctx context.Context, req *pb.GetArtworkImageRequest) (*pb.GetArtworkImageResponse, error) {
+	name := req.GetName()
+	if !artworkName.MatchString(name) {
+		return nil, status.Error(codes.InvalidArgument, "invalid artwork name")
+	}
+
+	r, err := s.blobs.Get(ctx, "covers/"+name[:2]+"/"+name)
+	if err == storage.ErrBlobNotFound {
+		return nil, status.Error(codes.NotFound, "artwork not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := "image/jpeg"
+	switch path.Ext(name) {
+	case ".png":
+		mimeType = "image/png"
+	case ".gif":
+		mimeType = "image/gif"
+	}
+	return &pb.GetArtworkImageResponse{MimeType: mimeType, Data: data}, nil
+}
+
+func artworkToProto(a *models.Artwork) *pb.Artwork {
+	if a == nil {
+		return nil
+	}
+	cover := &pb.Artwork{
+		MimeType:   a.MimeType,
+		Width:      int32(a.Width),
+		Height:     int32(a.Height),
+		Size:       a.Size,
+		Sha256:     a.SHA256,
+		Source:     a.Source,
+		Url:        artworkURL(a.Key),
+		Thumbnails: make([]*pb.ArtworkThumbnail, len(a.Thumbnails)),
+	}
+	for i, t := range a.Thumbnails {
+		cover.Thumbnails[i] = &pb.ArtworkThumbnail{
+			Size:   int32(t.Size),
+			Width:  int32(t.Width),
+			Height: int32(t.Height),
+			Url:    artworkURL(t.Key),
+		}
+	}
+	return cover
+}
+
+// artworkURL — путь картинки на шлюзе: имя файла из ключа хранилища
+func artworkURL(key string) string {
+	return coverURLPrefix + path.Base(key)
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/analysis"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/audio"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	waveformBuckets     = 1000
+	maxAnalysisAttempts = 3
+	// analysisTimeout — после этого срока анализ в состоянии running
+	// считается прерванным (например, падением сервиса) и берется заново
+	analysisTimeout = 15 * time.Minute
+
+	// Нормализация громкости при воспроизведении: целевая громкость и
+	// предельный истинный пик после усиления
+	targetLoudnessLUFS = -14.0
+	truePeakCeiling    = -1.0
+)
+
+// RunAnalysisWorker анализирует ожидающие анализа файлы каждые interval и
+// сразу после загрузок, пока ctx не отменен
+func (s *TrackGRPCService) RunAnalysisWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.analysePending(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.analysisQueued:
+		}
+	}
+}
+
+// queueAnalysis будит фоновый анализ, не дожидаясь следующего тика
+func (s *TrackGRPCService) queueAnalysis() {
+	select {
+	case s.analysisQueued <- struct{}{}:
+	default:
+	}
+}
+
+// analysePending анализирует треки по одному, пока очередь не опустеет
+func (s *TrackGRPCService) analysePending(ctx context.Context) {
+	staleBefore := time.Now().Add(-analysisTimeout).Unix()
+	if n, err := s.repo.FailStaleAnalyses(ctx, staleBefore, maxAnalysisAttempts); err != nil {
+		log.Printf("audio analysis: %v", err)
+	} else if n > 0 {
+		log.Printf("audio analysis: %d tracks failed after %d interrupted attempts", n, maxAnalysisAttempts)
+	}
+
+	for ctx.Err() == nil {
+		track, err := s.repo.ClaimTrackAnalysis(ctx, staleBefore, maxAnalysisAttempts)
+		if err == mongo.ErrNoDocuments {
+			return
+		}
+		if err != nil {
+			log.Printf("audio analysis: %v", err)
+			return
+		}
+		s.analyseTrack(ctx, track)
+	}
+}
+
+func (s *TrackGRPCService) analyseTrack(ctx context.Context, track models.Track) {
+	result := *track.Analysis
+	measured, err := s.analyseAudio(ctx, result.AudioKey)
+	switch {
+	case err == nil:
+		result.Status = models.AnalysisDone
+		result.Waveform = measured.Waveform
+		result.IntegratedLUFS = measured.IntegratedLUFS
+		result.TruePeakDBTP = measured.TruePeakDBTP
+		result.Error = ""
+		result.AnalyzedAt = time.Now().Unix()
+	case ctx.Err() != nil:
+		// Остановка сервиса — не вина файла: попытка не засчитывается
+		result.Status = models.AnalysisPending
+		result.Attempts--
+		ctx = context.WithoutCancel(ctx)
+	case errors.Is(err, audio.ErrMalformed) || errors.Is(err, audio.ErrUnsupportedFormat) ||
+		result.Attempts >= maxAnalysisAttempts:
+		// Битый файл при повторе не исправится
+		result.Status = models.AnalysisFailed
+		result.Error = err.Error()
+		log.Printf("failed to analyse the audio of track %s: %v", track.ID.Hex(), err)
+	default:
+		result.Status = models.AnalysisPending
+		result.Error = err.Error()
+		log.Printf("failed to analyse the audio of track %s (attempt %d), will retry: %v", track.ID.Hex(), result.Attempts, err)
+	}
+
+	err = s.repo.SetTrackAnalysis(ctx, track.ID, result)
+	if err == mongo.ErrNoDocuments {
+		// Пока шел анализ, трек удалили или загрузили другой файл
+		return
+	}
+	if err != nil {
+		log.Printf("failed to save the audio analysis of track %s: %v", track.ID.Hex(), err)
+	}
+}
+
+// analyseAudio копирует файл во временный файл — декодеру нужен
+// произвольный доступ — и анализирует его
+func (s *TrackGRPCService) analyseAudio(ctx context.Context, key string) (analysis.Result, error) {
+	r, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		return analysis.Result{}, err
+	}
+	defer r.Close()
+
+	tmp, err := os.CreateTemp("", "track-analysis-*")
+	if err != nil {
+		return analysis.Result{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return analysis.Result{}, err
+	}
+	dec, err := audio.NewDecoder(tmp, size)
+	if err != nil {
+		return analysis.Result{}, err
+	}
+	return analysis.Analyze(dec, waveformBuckets)
+}
+
+// GetTrackAnalysis отдает форму волны и громкость аудиофайла трека
+func (s *TrackGRPCService) GetTrackAnalysis(ctx context.Context, req *pb.GetTrackAnalysisRequest) (*pb.GetTrackAnalysisResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetTrackId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid track_id")
+	}
+	track, err := s.repo.GetTrackByID(ctx, objID)
+	if err != nil {
+		return nil, catalogError(err, "track")
+	}
+	if track.Audio == nil || track.Analysis == nil {
+		return nil, status.Error(codes.NotFound, "the track has no audio")
+	}
+
+	a := track.Analysis
+	resp := &pb.GetTrackAnalysisResponse{
+		Status: a.Status,
+		Error:  a.Error,
+	}
+	if a.Status == models.AnalysisDone {
+		resp.Waveform = a.Waveform
+		resp.IntegratedLufs = a.IntegratedLUFS
+		resp.TruePeakDbtp = a.TruePeakDBTP
+		resp.GainDb = math.Min(targetLoudnessLUFS-a.IntegratedLUFS, truePeakCeiling-a.TruePeakDBTP)
+		resp.AnalyzedAt = a.AnalyzedAt
+	}
+	return resp, nil
+}
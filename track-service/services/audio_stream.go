@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// audioChunkSize — размер одного сообщения потока; намного меньше
+// ограничения gRPC на сообщение в 4 МБ
+const audioChunkSize = 256 << 10
+
+// audioQualityRank упорядочивает значения max_audio_quality тарифов
+var audioQualityRank = map[string]int{
+	"low":      1,
+	"normal":   2,
+	"high":     3,
+	"lossless": 4,
+}
+
+// audioQuality сопоставляет файл с уровнями качества тарифов
+func audioQuality(file models.AudioFile) string {
+	switch {
+	case file.Codec == "flac" || file.Codec == "pcm" || file.Codec == "alac":
+		return "lossless"
+	case file.Bitrate >= 256000:
+		return "high"
+	case file.Bitrate >= 128000:
+		return "normal"
+	default:
+		return "low"
+	}
+}
+
+// checkStreamAccess пускает к аудио только слушателя с токеном, чей тариф
+// включает качество файла. api_gateway проверяет то же самое до начала
+// потока, но порт сервиса может быть доступен и в обход шлюза.
+func checkStreamAccess(ctx context.Context, prefs PreferenceProvider, file models.AudioFile) error {
+	if prefs == nil {
+		return nil
+	}
+	maxQuality, err := prefs.MaxAudioQuality(ctx)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated, codes.PermissionDenied:
+			return err
+		default:
+			log.Printf("failed to load entitlements of the caller: %v", err)
+			return status.Error(codes.Unavailable, "failed to check the subscription plan")
+		}
+	}
+	if quality := audioQuality(file); audioQualityRank[quality] > audioQualityRank[maxQuality] {
+		return status.Errorf(codes.PermissionDenied, "your plan does not include %s audio", quality)
+	}
+	return nil
+}
+
+// playCountMs — сколько нужно прослушать, чтобы засчитать прослушивание
+const playCountMs = 30_000
+
+// playThreshold возвращает позицию в файле, после отдачи которой
+// прослушивание засчитывается: 30 секунд звука, но не больше половины
+// файла, чтобы короткие треки тоже засчитывались. Без длительности
+// берется половина файла.
+func playThreshold(track models.Track) int64 {
+	size := track.Audio.Size
+	durationMs := track.Audio.DurationMs
+	if durationMs <= 0 {
+		durationMs = int64(track.Duration) * 1000
+	}
+	if durationMs <= 0 || durationMs <= 2*playCountMs {
+		return size / 2
+	}
+	return size * playCountMs / durationMs
+}
+
+// StreamTrackAudio отдает аудиофайл трека начиная с offset. Прослушивание
+// засчитывается (увеличивает popularity) тем потоком, который отдает байт на
+// позиции playThreshold: начатый и сразу брошенный поток не считается, а
+// докачка частями или перемотка вперед того же прослушивания не считается
+// повторно.
+func (s *TrackGRPCService) StreamTrackAudio(req *pb.StreamTrackAudioRequest, stream pb.TrackService_StreamTrackAudioServer) error {
+	ctx := stream.Context()
+
+	objID, err := primitive.ObjectIDFromHex(req.GetTrackId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid track_id")
+	}
+	track, err := s.repo.GetTrackByID(ctx, objID)
+	if err != nil {
+		return catalogError(err, "track")
+	}
+	if err := checkExplicit(ctx, s.prefs, track); err != nil {
+		return err
+	}
+	if track.Audio == nil {
+		return status.Error(codes.NotFound, "the track has no audio")
+	}
+	if err := checkStreamAccess(ctx, s.prefs, *track.Audio); err != nil {
+		return err
+	}
+
+	offset, length := req.GetOffset(), req.GetLength()
+	if offset < 0 || length < 0 || offset > track.Audio.Size {
+		return status.Errorf(codes.OutOfRange, "range %d+%d is outside the %d byte file", offset, length, track.Audio.Size)
+	}
+
+	r, err := s.blobs.GetRange(ctx, track.Audio.Key, offset, length)
+	if err == storage.ErrBlobNotFound {
+		return status.Error(codes.NotFound, "the audio file is missing from storage")
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	buf := make([]byte, audioChunkSize)
+	threshold := playThreshold(track)
+	counted := offset > threshold
+	pos := offset
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := stream.Send(&pb.AudioChunk{Data: buf[:n]}); err != nil {
+				return err
+			}
+			pos += int64(n)
+			// Засчитываем, только когда данные действительно ушли слушателю
+			if !counted && pos > threshold {
+				counted = true
+				if err := s.repo.IncrementPopularity(ctx, objID); err != nil {
+					log.Printf("failed to count a play of track %s: %v", objID.Hex(), err)
+				}
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// planProvider отдает тариф слушателя или ошибку user-service
+type planProvider struct {
+	quality string
+	err     error
+}
+
+func (p planProvider) ExplicitContentFiltered(context.Context) (bool, error) { return false, nil }
+func (p planProvider) MaxAudioQuality(context.Context) (string, error)       { return p.quality, p.err }
+
+func TestCheckStreamAccess(t *testing.T) {
+	lossless := models.AudioFile{Codec: "flac", Bitrate: 900000}
+	normal := models.AudioFile{Codec: "mp3", Bitrate: 192000}
+
+	cases := []struct {
+		name  string
+		prefs planProvider
+		file  models.AudioFile
+		want  codes.Code
+	}{
+		{name: "plan includes the quality", prefs: planProvider{quality: "high"}, file: normal, want: codes.OK},
+		{name: "plan below the quality", prefs: planProvider{quality: "high"}, file: lossless, want: codes.PermissionDenied},
+		{name: "no token", prefs: planProvider{err: status.Error(codes.Unauthenticated, "missing bearer token")}, file: normal, want: codes.Unauthenticated},
+		{name: "user-service is down", prefs: planProvider{err: errors.New("connection refused")}, file: normal, want: codes.Unavailable},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkStreamAccess(context.Background(), tc.prefs, tc.file)
+			if status.Code(err) != tc.want {
+				t.Errorf("checkStreamAccess = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestPlayThreshold(t *testing.T) {
+	const size = 6_000_000
+	cases := []struct {
+		name       string
+		durationMs int64
+		durationS  int32
+		want       int64
+	}{
+		// 30 секунд из 4 минут — восьмая часть файла
+		{name: "long track", durationMs: 240_000, want: size / 8},
+		{name: "duration from the catalog", durationS: 240, want: size / 8},
+		// Для трека короче минуты хватает половины
+		{name: "short track", durationMs: 45_000, want: size / 2},
+		{name: "unknown duration", want: size / 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			track := models.Track{Duration: tc.durationS, Audio: &models.AudioFile{Size: size, DurationMs: tc.durationMs}}
+			if got := playThreshold(track); got != tc.want {
+				t.Errorf("playThreshold = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
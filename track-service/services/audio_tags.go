@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/audio"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// tagMetadata переводит теги файла в поля трека. Значения проверяются так же,
+// как в запросах; не прошедшие проверку теги пропускаются: кривой тег — не
+// повод отклонять сам файл.
+func tagMetadata(tags audio.Tags) models.Track {
+	meta := models.Track{
+		Title:  models.CleanName(tags.Title),
+		Artist: models.CleanName(tags.Artist),
+		Album:  models.CleanName(tags.Album),
+	}
+	if genres, err := models.NormalizeGenres(tags.Genres); err == nil {
+		meta.Genres = genres
+	}
+	// Дата бывает и годом, и полной датой со временем; берем только полную
+	if len(tags.Date) >= len(models.ReleaseDateLayout) {
+		if date, err := models.NormalizeReleaseDate(tags.Date[:len(models.ReleaseDateLayout)]); err == nil {
+			meta.ReleaseDate = date
+		}
+	}
+	if isrc, err := models.NormalizeISRC(tags.ISRC); err == nil {
+		meta.ISRC = isrc
+	}
+	if tags.Language != "" {
+		if lang, err := models.NormalizeLanguage(tags.Language); err == nil {
+			meta.Language = lang
+		}
+	}
+	if tags.Key != "" {
+		if key, err := models.NormalizeKey(tags.Key); err == nil {
+			meta.Key = key
+		}
+	}
+	if models.ValidateBPM(tags.BPM) == nil {
+		meta.BPM = tags.BPM
+	}
+	if n := tags.DiscNumber; n > 0 && models.ValidateTrackNumber(int32(min(n, 1000))) == nil {
+		meta.DiscNumber = int32(n)
+	}
+	if n := tags.TrackNumber; n > 0 && models.ValidateTrackNumber(int32(min(n, 1000))) == nil {
+		meta.TrackNumber = int32(n)
+	}
+	return meta
+}
+
+// prefillTrack дополняет трек значениями из тегов файла. Заполняются только
+// пустые поля: заданное вручную не меняется. Возвращает изменения для $set и
+// названия заполненных полей.
+func (s *TrackGRPCService) prefillTrack(ctx context.Context, track *models.Track, tags audio.Tags) (bson.M, []string, error) {
+	meta := tagMetadata(tags)
+	update := bson.M{}
+	var filled []string
+	fill := func(field string, value interface{}) {
+		update[field] = value
+		filled = append(filled, field)
+	}
+
+	if track.Title == "" && meta.Title != "" {
+		track.Title = meta.Title
+		fill("title", meta.Title)
+	}
+	if len(track.Genres) == 0 && len(meta.Genres) > 0 {
+		track.Genres = meta.Genres
+		fill("genres", meta.Genres)
+	}
+	if track.ReleaseDate == "" && meta.ReleaseDate != "" {
+		track.ReleaseDate = meta.ReleaseDate
+		fill("release_date", meta.ReleaseDate)
+	}
+	if track.ISRC == "" && meta.ISRC != "" {
+		track.ISRC = meta.ISRC
+		fill("isrc", meta.ISRC)
+	}
+	if track.DiscNumber == 0 && meta.DiscNumber != 0 {
+		track.DiscNumber = meta.DiscNumber
+		fill("disc_number", meta.DiscNumber)
+	}
+	if track.TrackNumber == 0 && meta.TrackNumber != 0 {
+		track.TrackNumber = meta.TrackNumber
+		fill("track_number", meta.TrackNumber)
+	}
+	if track.Language == "" && meta.Language != "" {
+		track.Language = meta.Language
+		fill("language", meta.Language)
+	}
+	if track.BPM == 0 && meta.BPM != 0 {
+		track.BPM = meta.BPM
+		fill("bpm", meta.BPM)
+	}
+	if track.Key == "" && meta.Key != "" {
+		track.Key = meta.Key
+		fill("key", meta.Key)
+	}
+
+	if err := s.prefillLinks(ctx, track, meta, tags.AlbumArtist, fill, update); err != nil {
+		return nil, nil, err
+	}
+
+	if track.Cover == nil && tags.Picture != nil {
+		cover, err := storeArtwork(ctx, s.blobs, tags.Picture.Data, models.ArtworkEmbedded)
+		if err != nil {
+			log.Printf("skipping the embedded cover of track %s: %v", track.ID.Hex(), err)
+		} else {
+			track.Cover = &cover
+			fill("cover", cover)
+			// Обложка из файла подходит и альбому, если своей у него нет
+			if !track.AlbumID.IsZero() {
+				if _, err := s.catalog.albums.SetMissingAlbumCover(ctx, track.AlbumID, cover); err != nil {
+					log.Printf("failed to set the cover of album %s: %v", track.AlbumID.Hex(), err)
+				}
+			}
+		}
+	}
+	return update, filled, nil
+}
+
+// prefillLinks связывает трек без исполнителя или альбома с исполнителем и
+// альбомом из тегов, создавая их при необходимости. У сборников альбом
+// принадлежит исполнителю альбома, а не исполнителю трека.
+func (s *TrackGRPCService) prefillLinks(ctx context.Context, track *models.Track, meta models.Track, albumArtist string, fill func(string, interface{}), update bson.M) error {
+	needArtist := len(track.Artists) == 0 && meta.Artist != ""
+	needAlbum := track.AlbumID.IsZero() && meta.Album != "" && (needArtist || len(track.Artists) > 0)
+	if !needArtist && !needAlbum {
+		return nil
+	}
+
+	linked := *track
+	linked.Album = ""
+	var artistID, albumID string
+	var featuredIDs []string
+	if needArtist {
+		linked.Artist = meta.Artist
+	} else {
+		artistID = track.Artists[0].ID.Hex()
+		for _, c := range track.Artists[1:] {
+			featuredIDs = append(featuredIDs, c.ID.Hex())
+		}
+	}
+
+	switch {
+	case !needAlbum:
+		if !track.AlbumID.IsZero() {
+			albumID = track.AlbumID.Hex()
+		}
+	case albumArtist != "" && models.NameKey(albumArtist) != models.NameKey(meta.Artist):
+		owner, err := s.catalog.resolveArtist(ctx, "", models.CleanName(albumArtist))
+		if err != nil {
+			return catalogError(err, "artist")
+		}
+		album, err := s.catalog.resolveAlbum(ctx, owner, "", meta.Album)
+		if err != nil {
+			return catalogError(err, "album")
+		}
+		albumID = album.ID.Hex()
+	default:
+		linked.Album = meta.Album
+	}
+
+	if err := s.linkTrack(ctx, &linked, artistID, featuredIDs, albumID); err != nil {
+		return err
+	}
+	if needArtist {
+		track.Artist, track.Artists = linked.Artist, linked.Artists
+		update["artists"] = linked.Artists
+		fill("artist", linked.Artist)
+	}
+	if needAlbum {
+		track.AlbumID, track.Album = linked.AlbumID, linked.Album
+		update["album_id"] = linked.AlbumID
+		fill("album", linked.Album)
+	}
+	return nil
+}
+
+// titleFromFilename — название трека без тега: имя файла без расширения
+func titleFromFilename(name string) string {
+	base := filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	if base == "." || base == "/" {
+		return ""
+	}
+	return models.CleanName(strings.TrimSuffix(base, filepath.Ext(base)))
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/audio"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxAudioSize — предельный размер загружаемого аудиофайла
+const maxAudioSize = 500 << 20
+
+// UploadTrackAudio принимает аудиофайл трека частями. Файл сначала пишется во
+// временный файл: формат проверяется по заголовкам целиком полученного файла,
+// а в хранилище попадают только распознанные файлы. Пустые поля трека
+// заполняются из тегов файла; без track_id трек создается по тегам.
+func (s *TrackGRPCService) UploadTrackAudio(stream pb.TrackService_UploadTrackAudioServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the upload info")
+	}
+	var track models.Track
+	existing := info.GetTrackId() != ""
+	if existing {
+		objID, err := primitive.ObjectIDFromHex(info.GetTrackId())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid track_id")
+		}
+		if track, err = s.repo.GetTrackByID(ctx, objID); err != nil {
+			return catalogError(err, "track")
+		}
+	}
+
+	tmp, err := os.CreateTemp("", "track-audio-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := receiveAudio(stream, io.MultiWriter(tmp, hash))
+	if err != nil {
+		return err
+	}
+
+	probe, err := audio.Probe(tmp, size)
+	if errors.Is(err, audio.ErrUnsupportedFormat) || errors.Is(err, audio.ErrMalformed) {
+		return status.Errorf(codes.InvalidArgument, "%s: %v", info.GetFilename(), err)
+	}
+	if err != nil {
+		return err
+	}
+	tags, err := audio.ReadTags(tmp, size)
+	if err != nil {
+		log.Printf("failed to read the tags of %s: %v", info.GetFilename(), err)
+	}
+
+	// Ключ зависит только от содержимого: повторная загрузка того же файла
+	// не занимает места
+	sum := hex.EncodeToString(hash.Sum(nil))
+	key := fmt.Sprintf("audio/%s/%s.%s", sum[:2], sum, probe.Format)
+	// Пока ссылка на файл не записана в трек, releaseAudio не должен
+	// удалить его как неиспользуемый
+	defer s.uploading.hold(key)()
+	exists, err := s.blobs.Exists(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if err := s.blobs.Put(ctx, key, tmp); err != nil {
+			return err
+		}
+	}
+
+	update, prefilled, err := s.prefillTrack(ctx, &track, tags)
+	if err != nil {
+		return err
+	}
+	if !existing {
+		track.ID = primitive.NewObjectID()
+		if track.Title == "" {
+			track.Title = titleFromFilename(info.GetFilename())
+		}
+		if track.Artists == nil {
+			track.Artists = []models.ArtistCredit{}
+		}
+		if track.Genres == nil {
+			track.Genres = []string{}
+		}
+		if track, err = s.createTrack(ctx, track); err != nil {
+			return err
+		}
+	} else if len(update) > 0 {
+		if track, err = s.updateTrack(ctx, track.ID, update); err != nil {
+			return err
+		}
+	}
+
+	file := models.AudioFile{
+		Key:        key,
+		SHA256:     sum,
+		Size:       size,
+		Format:     probe.Format,
+		Codec:      probe.Codec,
+		MimeType:   probe.MimeType,
+		Bitrate:    probe.Bitrate,
+		SampleRate: probe.SampleRate,
+		Channels:   probe.Channels,
+		DurationMs: probe.Duration.Milliseconds(),
+		UploadedAt: time.Now().Unix(),
+	}
+	previous, err := s.setTrackAudio(ctx, track.ID, file)
+	if err != nil {
+		return err
+	}
+	// Другой экземпляр сервиса мог удалить файл, пока ссылки на него еще не
+	// было: теперь она есть, и файл загружается заново
+	if stored, err := s.blobs.Exists(ctx, key); err != nil {
+		log.Printf("failed to check %s after the upload: %v", key, err)
+	} else if !stored {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if err := s.blobs.Put(ctx, key, tmp); err != nil {
+			return err
+		}
+	}
+	if previous.Audio != nil && previous.Audio.Key != key {
+		s.releaseAudio(ctx, previous.Audio.Key)
+	}
+	s.queueAnalysis()
+	log.Printf("audio %s (%s, %d bytes) stored for track %s", info.GetFilename(), probe.Format, size, track.ID.Hex())
+
+	track, err = s.repo.GetTrackByID(ctx, track.ID)
+	if err != nil {
+		return err
+	}
+	s.suggest.Put(suggestDoc(track))
+	return stream.SendAndClose(&pb.UploadTrackAudioResponse{
+		Track:        toProto(track),
+		Deduplicated: exists,
+		Prefilled:    prefilled,
+	})
+}
+
+// receiveAudio копирует части файла из потока в w и возвращает размер файла
+func receiveAudio(stream pb.TrackService_UploadTrackAudioServer, w io.Writer) (int64, error) {
+	var size int64
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetInfo() != nil {
+			return 0, status.Error(codes.InvalidArgument, "the upload info must be sent once")
+		}
+
+		chunk := msg.GetChunk()
+		size += int64(len(chunk))
+		if size > maxAudioSize {
+			return 0, status.Errorf(codes.InvalidArgument, "audio files are limited to %d MB", maxAudioSize>>20)
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return 0, err
+		}
+	}
+	if size == 0 {
+		return 0, status.Error(codes.InvalidArgument, "the audio file is empty")
+	}
+	return size, nil
+}
+
+// releaseAudio удаляет файл из хранилища, если на него больше не ссылается
+// ни один трек и его не загружают прямо сейчас. Ошибки только журналируются:
+// лишний файл не мешает работе.
+func (s *TrackGRPCService) releaseAudio(ctx context.Context, key string) {
+	if s.uploading.busy(key) {
+		return
+	}
+	refs, err := s.repo.CountAudioReferences(ctx, key)
+	if err != nil {
+		log.Printf("failed to count references to %s: %v", key, err)
+		return
+	}
+	if refs > 0 {
+		return
+	}
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		log.Printf("failed to delete %s: %v", key, err)
+	}
+}
+
+// uploadingKeys — ключи файлов, загрузки которых еще не записали ссылку в трек
+type uploadingKeys struct {
+	mu   sync.Mutex
+	keys map[string]int
+}
+
+// hold отмечает загрузку key и возвращает функцию, снимающую отметку
+func (u *uploadingKeys) hold(key string) func() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.keys == nil {
+		u.keys = make(map[string]int)
+	}
+	u.keys[key]++
+	return func() {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		if u.keys[key]--; u.keys[key] == 0 {
+			delete(u.keys, key)
+		}
+	}
+}
+
+func (u *uploadingKeys) busy(key string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.keys[key] > 0
+}
+
+func audioToProto(a *models.AudioFile) *pb.AudioFile {
+	if a == nil {
+		return nil
+	}
+	return &pb.AudioFile{
+		Format:     a.Format,
+		Codec:      a.Codec,
+		MimeType:   a.MimeType,
+		Size:       a.Size,
+		Bitrate:    int32(a.Bitrate),
+		SampleRate: int32(a.SampleRate),
+		Channels:   int32(a.Channels),
+		DurationMs: a.DurationMs,
+		Sha256:     a.SHA256,
+		UploadedAt: a.UploadedAt,
+	}
+}
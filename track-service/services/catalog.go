@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/search"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// catalog находит и создает исполнителей и альбомы, на которые ссылаются треки
+type catalog struct {
+	artists *repositories.ArtistRepo
+	albums  *repositories.AlbumRepo
+}
+
+// resolveArtist возвращает основного исполнителя трека по ID или по имени.
+// По имени исполнитель создается, если его еще нет.
+func (c catalog) resolveArtist(ctx context.Context, id, name string) (models.Artist, error) {
+	if id != "" {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return models.Artist{}, status.Errorf(codes.InvalidArgument, "invalid artist_id")
+		}
+		artist, err := c.artists.GetArtistByID(ctx, objID)
+		if err == mongo.ErrNoDocuments {
+			return artist, status.Errorf(codes.InvalidArgument, "artist %s not found", id)
+		}
+		return artist, err
+	}
+	return c.artists.FindOrCreateArtist(ctx, name)
+}
+
+// resolveFeatured возвращает приглашенных исполнителей без повторов и без
+// основного исполнителя
+func (c catalog) resolveFeatured(ctx context.Context, primary models.Artist, ids []string) ([]models.Artist, error) {
+	seen := map[primitive.ObjectID]bool{primary.ID: true}
+	var objIDs []primitive.ObjectID
+	for _, id := range ids {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid featured artist id %q", id)
+		}
+		if !seen[objID] {
+			seen[objID] = true
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if len(objIDs) == 0 {
+		return nil, nil
+	}
+
+	featured, err := c.artists.GetArtistsByIDs(ctx, objIDs)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.InvalidArgument, "featured artist not found")
+	}
+	return featured, err
+}
+
+// resolveAlbum возвращает альбом трека по ID или по названию среди альбомов
+// основного исполнителя. По названию альбом создается, если его еще нет.
+func (c catalog) resolveAlbum(ctx context.Context, primary models.Artist, id, title string) (models.Album, error) {
+	if id != "" {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return models.Album{}, status.Errorf(codes.InvalidArgument, "invalid album_id")
+		}
+		album, err := c.albums.GetAlbumByID(ctx, objID)
+		if err == mongo.ErrNoDocuments {
+			return album, status.Errorf(codes.InvalidArgument, "album %s not found", id)
+		}
+		return album, err
+	}
+	if primary.ID.IsZero() {
+		return models.Album{}, status.Errorf(codes.InvalidArgument, "an album needs an artist")
+	}
+	return c.albums.FindOrCreateAlbum(ctx, primary, title)
+}
+
+// credits строит список исполнителей трека: основной первым
+func credits(primary models.Artist, featured []models.Artist) []models.ArtistCredit {
+	list := []models.ArtistCredit{{ID: primary.ID, Name: primary.Name}}
+	for _, a := range featured {
+		list = append(list, models.ArtistCredit{ID: a.ID, Name: a.Name, Featured: true})
+	}
+	return list
+}
+
+// catalogError переводит ошибки репозиториев исполнителей и альбомов в
+// статусы gRPC
+func catalogError(err error, what string) error {
+	switch {
+	case err == mongo.ErrNoDocuments:
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	case mongo.IsDuplicateKeyError(err):
+		return status.Errorf(codes.AlreadyExists, "%s already exists", what)
+	case status.Code(err) != codes.Unknown:
+		return err
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", what, err)
+	}
+}
+
+// hideExplicit решает, нужно ли скрыть explicit-треки от автора запроса.
+// Если настройки получить не удалось, треки скрываются: лучше показать меньше,
+// чем показать explicit-контент тому, кто от него отказался.
+func hideExplicit(ctx context.Context, prefs PreferenceProvider) bool {
+	if prefs == nil {
+		return false
+	}
+	filtered, err := prefs.ExplicitContentFiltered(ctx)
+	if err != nil {
+		log.Printf("failed to load preferences of the caller: %v", err)
+		return true
+	}
+	return filtered
+}
+
+// checkExplicit не дает открыть explicit-трек по ID тому, у кого включен
+// фильтр explicit-контента
+func checkExplicit(ctx context.Context, prefs PreferenceProvider, track models.Track) error {
+	if track.Explicit && hideExplicit(ctx, prefs) {
+		return status.Error(codes.PermissionDenied, "explicit content is filtered for this user")
+	}
+	return nil
+}
+
+// putTracks обновляет треки в индексе подсказок
+func putTracks(index *search.SuggestIndex, tracks []models.Track) {
+	for _, t := range tracks {
+		index.Put(suggestDoc(t))
+	}
+}
+
+func artistToProto(a models.Artist) *pb.Artist {
+	return &pb.Artist{
+		Id:        a.ID.Hex(),
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func albumToProto(a models.Album) *pb.Album {
+	return &pb.Album{
+		Id:          a.ID.Hex(),
+		Title:       a.Title,
+		ArtistId:    a.ArtistID.Hex(),
+		Artist:      a.Artist,
+		ReleaseDate: a.ReleaseDate,
+		CreatedAt:   a.CreatedAt,
+		Cover:       artworkToProto(a.Cover),
+	}
+}
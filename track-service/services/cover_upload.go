@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/events"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UploadTrackCover заменяет обложку трека загруженной картинкой; прежняя
+// удаляется, если на нее больше не ссылаются
+func (s *TrackGRPCService) UploadTrackCover(ctx context.Context, req *pb.UploadTrackCoverRequest) (*pb.UploadTrackCoverResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetTrackId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid track_id")
+	}
+	if len(req.GetImage()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "image is required")
+	}
+	if _, err := s.repo.GetTrackByID(ctx, objID); err != nil {
+		return nil, catalogError(err, "track")
+	}
+
+	cover, err := storeArtwork(ctx, s.blobs, req.GetImage(), models.ArtworkUploaded)
+	if err != nil {
+		return nil, err
+	}
+	track, err := s.setTrackCover(ctx, objID, &cover)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UploadTrackCoverResponse{Track: toProto(track)}, nil
+}
+
+// DeleteTrackCover убирает обложку трека
+func (s *TrackGRPCService) DeleteTrackCover(ctx context.Context, req *pb.DeleteTrackCoverRequest) (*pb.DeleteTrackCoverResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetTrackId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid track_id")
+	}
+	track, err := s.setTrackCover(ctx, objID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteTrackCoverResponse{Track: toProto(track)}, nil
+}
+
+// setTrackCover записывает обложку трека и событие track.updated в одной
+// транзакции, освобождает прежнюю обложку и возвращает трек после изменения
+func (s *TrackGRPCService) setTrackCover(ctx context.Context, id primitive.ObjectID, cover *models.Artwork) (models.Track, error) {
+	var previous, updated models.Track
+	err := s.outbox.Record(ctx, func(ctx context.Context) ([]models.OutboxEvent, error) {
+		var err error
+		if previous, err = s.repo.SetTrackCover(ctx, id, cover); err != nil {
+			return nil, catalogError(err, "track")
+		}
+		if updated, err = s.repo.GetTrackByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return trackEvent(events.SubjectTrackUpdated, &previous, &updated)
+	})
+	if err != nil {
+		return models.Track{}, err
+	}
+	if previous.Cover != nil && (cover == nil || previous.Cover.Key != cover.Key) {
+		releaseArtwork(ctx, s.blobs, s.repo, s.catalog.albums, previous.Cover)
+	}
+	return updated, nil
+}
+
+// UploadAlbumCover заменяет обложку альбома загруженной картинкой
+func (s *AlbumGRPCService) UploadAlbumCover(ctx context.Context, req *pb.UploadAlbumCoverRequest) (*pb.UploadAlbumCoverResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetAlbumId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid album_id")
+	}
+	if len(req.GetImage()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "image is required")
+	}
+	if _, err := s.albums.GetAlbumByID(ctx, objID); err != nil {
+		return nil, catalogError(err, "album")
+	}
+
+	cover, err := storeArtwork(ctx, s.blobs, req.GetImage(), models.ArtworkUploaded)
+	if err != nil {
+		return nil, err
+	}
+	album, err := s.setAlbumCover(ctx, objID, &cover)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UploadAlbumCoverResponse{Album: albumToProto(album)}, nil
+}
+
+// DeleteAlbumCover убирает обложку альбома
+func (s *AlbumGRPCService) DeleteAlbumCover(ctx context.Context, req *pb.DeleteAlbumCoverRequest) (*pb.DeleteAlbumCoverResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(req.GetAlbumId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid album_id")
+	}
+	album, err := s.setAlbumCover(ctx, objID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteAlbumCoverResponse{Album: albumToProto(album)}, nil
+}
+
+func (s *AlbumGRPCService) setAlbumCover(ctx context.Context, id primitive.ObjectID, cover *models.Artwork) (models.Album, error) {
+	previous, err := s.albums.SetAlbumCover(ctx, id, cover)
+	if err != nil {
+		return models.Album{}, catalogError(err, "album")
+	}
+	if previous.Cover != nil && (cover == nil || previous.Cover.Key != cover.Key) {
+		releaseArtwork(ctx, s.blobs, s.tracks, s.albums, previous.Cover)
+	}
+	previous.Cover = cover
+	return previous, nil
+}
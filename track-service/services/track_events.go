@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/events"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+)
+
+// createTrack сохраняет новый трек и событие track.created в одной
+// транзакции. Возвращает трек в том виде, в каком он записан.
+func (s *TrackGRPCService) createTrack(ctx context.Context, track models.Track) (models.Track, error) {
+	var created models.Track
+	err := s.outbox.Record(ctx, func(ctx context.Context) ([]models.OutboxEvent, error) {
+		if _, err := s.repo.CreateTrack(ctx, track); err != nil {
+			return nil, err
+		}
+		var err error
+		if created, err = s.repo.GetTrackByID(ctx, track.ID); err != nil {
+			return nil, err
+		}
+		return trackEvent(events.SubjectTrackCreated, nil, &created)
+	})
+	return created, err
+}
+
+// updateTrack меняет поля трека и записывает событие track.updated с
+// треком до и после изменения в одной транзакции
+func (s *TrackGRPCService) updateTrack(ctx context.Context, id primitive.ObjectID, updateData bson.M) (models.Track, error) {
+	var updated models.Track
+	err := s.outbox.Record(ctx, func(ctx context.Context) ([]models.OutboxEvent, error) {
+		before, err := s.repo.GetTrackByID(ctx, id)
+		if err != nil {
+			return nil, catalogError(err, "track")
+		}
+		if err := s.repo.UpdateTrack(ctx, id, updateData); err != nil {
+			return nil, err
+		}
+		if updated, err = s.repo.GetTrackByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return trackEvent(events.SubjectTrackUpdated, &before, &updated)
+	})
+	return updated, err
+}
+
+// deleteTrack удаляет трек и записывает событие track.deleted в одной
+// транзакции. Возвращает удаленный трек.
+func (s *TrackGRPCService) deleteTrack(ctx context.Context, id primitive.ObjectID) (models.Track, error) {
+	var deleted models.Track
+	err := s.outbox.Record(ctx, func(ctx context.Context) ([]models.OutboxEvent, error) {
+		var err error
+		if deleted, err = s.repo.GetTrackByID(ctx, id); err != nil {
+			return nil, catalogError(err, "track")
+		}
+		if err := s.repo.DeleteTrack(ctx, id); err != nil {
+			return nil, err
+		}
+		return trackEvent(events.SubjectTrackDeleted, &deleted, nil)
+	})
+	return deleted, err
+}
+
+// setTrackAudio записывает аудиофайл трека и событие track.updated в одной
+// транзакции. Возвращает трек до изменения.
+func (s *TrackGRPCService) setTrackAudio(ctx context.Context, id primitive.ObjectID, file models.AudioFile) (models.Track, error) {
+	var previous models.Track
+	err := s.outbox.Record(ctx, func(ctx context.Context) ([]models.OutboxEvent, error) {
+		var err error
+		if previous, err = s.repo.SetTrackAudio(ctx, id, file); err != nil {
+			return nil, catalogError(err, "track")
+		}
+		updated, err := s.repo.GetTrackByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return trackEvent(events.SubjectTrackUpdated, &previous, &updated)
+	})
+	return previous, err
+}
+
+// tracksUpdated собирает события track.updated для треков, измененных одной
+// операцией. Треки до и после изменения сопоставляются по идентификатору.
+func tracksUpdated(before, after []models.Track) ([]models.OutboxEvent, error) {
+	previous := make(map[primitive.ObjectID]models.Track, len(before))
+	for _, t := range before {
+		previous[t.ID] = t
+	}
+	var result []models.OutboxEvent
+	for i := range after {
+		old, ok := previous[after[i].ID]
+		if !ok {
+			continue
+		}
+		e, err := trackEvent(events.SubjectTrackUpdated, &old, &after[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, e...)
+	}
+	return result, nil
+}
+
+// trackEvent собирает событие об изменении трека для outbox. У созданного
+// трека нет before, у удаленного — after.
+func trackEvent(subject string, before, after *models.Track) ([]models.OutboxEvent, error) {
+	id := primitive.NewObjectID()
+	now := time.Now()
+	event := &pb.TrackEvent{
+		SchemaVersion: events.SchemaVersion,
+		EventId:       id.Hex(),
+		Type:          subject,
+		OccurredAt:    now.UnixMilli(),
+	}
+	if before != nil {
+		event.TrackId = before.ID.Hex()
+		event.Version = before.Version + 1
+		event.Before = toProto(*before)
+	}
+	if after != nil {
+		event.TrackId = after.ID.Hex()
+		event.Version = after.Version
+		event.After = toProto(*after)
+	}
+
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return []models.OutboxEvent{{
+		ID:        id,
+		Subject:   subject,
+		Payload:   payload,
+		CreatedAt: now.Unix(),
+	}}, nil
+}
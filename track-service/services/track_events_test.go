@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/events"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
+	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/search"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+func decodeEvent(t *testing.T, e models.OutboxEvent) *pb.TrackEvent {
+	t.Helper()
+	var event pb.TrackEvent
+	if err := proto.Unmarshal(e.Payload, &event); err != nil {
+		t.Fatalf("unmarshal %s: %v", e.Subject, err)
+	}
+	if event.GetEventId() != e.ID.Hex() || event.GetType() != e.Subject || event.GetSchemaVersion() != events.SchemaVersion {
+		t.Errorf("event header = %s %s v%d, outbox record = %s %s",
+			event.GetEventId(), event.GetType(), event.GetSchemaVersion(), e.ID.Hex(), e.Subject)
+	}
+	return &event
+}
+
+func TestTrackEvent(t *testing.T) {
+	before := models.Track{ID: primitive.NewObjectID(), Title: "Kukushka", Artist: "Kino", Version: 3}
+	after := before
+	after.Title, after.Version = "Kukushka (live)", 4
+
+	cases := []struct {
+		name          string
+		subject       string
+		before, after *models.Track
+		wantVersion   int64
+	}{
+		{name: "created", subject: events.SubjectTrackCreated, after: &before, wantVersion: 3},
+		{name: "updated", subject: events.SubjectTrackUpdated, before: &before, after: &after, wantVersion: 4},
+		// У удаленного трека версия следует за последней записанной
+		{name: "deleted", subject: events.SubjectTrackDeleted, before: &after, wantVersion: 5},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := trackEvent(tc.subject, tc.before, tc.after)
+			if err != nil {
+				t.Fatalf("trackEvent: %v", err)
+			}
+			if len(records) != 1 {
+				t.Fatalf("got %d records, want 1", len(records))
+			}
+			event := decodeEvent(t, records[0])
+			if event.GetTrackId() != before.ID.Hex() || event.GetVersion() != tc.wantVersion {
+				t.Errorf("track %s version %d, want %s version %d", event.GetTrackId(), event.GetVersion(), before.ID.Hex(), tc.wantVersion)
+			}
+			if tc.before == nil && event.Before != nil || tc.before != nil && !proto.Equal(event.Before, toProto(*tc.before)) {
+				t.Errorf("before = %v, want %v", event.Before, tc.before)
+			}
+			if tc.after == nil && event.After != nil || tc.after != nil && !proto.Equal(event.After, toProto(*tc.after)) {
+				t.Errorf("after = %v, want %v", event.After, tc.after)
+			}
+		})
+	}
+}
+
+func TestTracksUpdated(t *testing.T) {
+	a := models.Track{ID: primitive.NewObjectID(), Album: "Gruppa krovi", Version: 1}
+	b := models.Track{ID: primitive.NewObjectID(), Album: "Gruppa krovi", Version: 7}
+	a2, b2 := a, b
+	a2.Album, a2.Version = "Группа крови", 2
+	b2.Album, b2.Version = "Группа крови", 8
+	// Трек, которого не было до изменения, пропускается
+	extra := models.Track{ID: primitive.NewObjectID(), Version: 1}
+
+	records, err := tracksUpdated([]models.Track{a, b}, []models.Track{b2, extra, a2})
+	if err != nil {
+		t.Fatalf("tracksUpdated: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d events, want 2", len(records))
+	}
+	for i, want := range []struct{ before, after models.Track }{{b, b2}, {a, a2}} {
+		event := decodeEvent(t, records[i])
+		if records[i].Subject != events.SubjectTrackUpdated ||
+			!proto.Equal(event.Before, toProto(want.before)) || !proto.Equal(event.After, toProto(want.after)) {
+			t.Errorf("event %d = %v", i, event)
+		}
+	}
+}
+
+// TestTrackEventsRecorded проверяет события, которые пишутся в outbox при
+// изменениях через gRPC, на настоящей MongoDB. Транзакциям нужен набор реплик:
+// MONGO_TEST_URI=mongodb://localhost:27017/?replicaSet=rs0 go test ./services/...
+func TestTrackEventsRecorded(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	db := client.Database("trackdb_test_" + primitive.NewObjectID().Hex())
+	defer db.Drop(context.Background())
+	// Коллекции создаются заранее: в транзакции их создание не везде разрешено
+	for _, name := range []string{"tracks", "artists", "albums", "outbox"} {
+		if err := db.CreateCollection(ctx, name); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+	}
+
+	trackRepo := repositories.NewTrackRepo(db)
+	artistRepo := repositories.NewArtistRepo(db)
+	albumRepo := repositories.NewAlbumRepo(db)
+	outbox := repositories.NewOutboxRepo(db)
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("blob store: %v", err)
+	}
+	suggest := search.NewSuggestIndex()
+	tracks := NewTrackGRPCService(trackRepo, artistRepo, albumRepo, nil, suggest, blobs, outbox)
+	artists := NewArtistGRPCService(artistRepo, albumRepo, trackRepo, suggest, outbox)
+	albums := NewAlbumGRPCService(albumRepo, artistRepo, trackRepo, nil, suggest, blobs, outbox)
+
+	// next возвращает единственное событие, записанное после прошлого вызова
+	var seen int
+	next := func(t *testing.T, subject string) *pb.TrackEvent {
+		t.Helper()
+		pending, err := outbox.Pending(ctx, 100)
+		if err != nil {
+			t.Fatalf("Pending: %v", err)
+		}
+		if len(pending) != seen+1 {
+			t.Fatalf("outbox has %d new events, want 1", len(pending)-seen)
+		}
+		e := pending[seen]
+		seen++
+		if e.Subject != subject {
+			t.Fatalf("subject = %s, want %s", e.Subject, subject)
+		}
+		return decodeEvent(t, e)
+	}
+
+	created, err := tracks.CreateTrack(ctx, &pb.CreateTrackRequest{Title: "Kukushka", Artist: "Kino", Album: "Gruppa krovi", DurationSec: 399})
+	if err != nil {
+		t.Fatalf("CreateTrack: %v", err)
+	}
+	id := created.GetTrack().GetId()
+	event := next(t, events.SubjectTrackCreated)
+	if event.GetTrackId() != id || event.GetVersion() != 1 || event.Before != nil ||
+		!proto.Equal(event.After, created.GetTrack()) {
+		t.Errorf("track.created = %v, want after = %v", event, created.GetTrack())
+	}
+
+	if _, err := tracks.UpdateTrack(ctx, &pb.UpdateTrackRequest{Id: id, Title: "Kukushka (live)"}); err != nil {
+		t.Fatalf("UpdateTrack: %v", err)
+	}
+	event = next(t, events.SubjectTrackUpdated)
+	if event.GetVersion() != 2 || event.GetBefore().GetTitle() != "Kukushka" || event.GetBefore().GetVersion() != 1 ||
+		event.GetAfter().GetTitle() != "Kukushka (live)" || event.GetAfter().GetVersion() != 2 ||
+		event.GetAfter().GetDurationSec() != 399 {
+		t.Errorf("track.updated = %v", event)
+	}
+
+	// Копии имени исполнителя и названия альбома в треке тоже меняют версию
+	stored, err := trackRepo.GetTrackByID(ctx, mustObjectID(t, id))
+	if err != nil {
+		t.Fatalf("GetTrackByID: %v", err)
+	}
+	if _, err := artists.UpdateArtist(ctx, &pb.UpdateArtistRequest{Id: stored.Artists[0].ID.Hex(), Name: "Кино"}); err != nil {
+		t.Fatalf("UpdateArtist: %v", err)
+	}
+	event = next(t, events.SubjectTrackUpdated)
+	if event.GetVersion() != 3 || event.GetBefore().GetArtist() != "Kino" || event.GetAfter().GetArtist() != "Кино" {
+		t.Errorf("track.updated after renaming the artist = %v", event)
+	}
+	if _, err := albums.UpdateAlbum(ctx, &pb.UpdateAlbumRequest{Id: stored.AlbumID.Hex(), Title: "Группа крови"}); err != nil {
+		t.Fatalf("UpdateAlbum: %v", err)
+	}
+	event = next(t, events.SubjectTrackUpdated)
+	if event.GetVersion() != 4 || event.GetBefore().GetAlbum() != "Gruppa krovi" || event.GetAfter().GetAlbum() != "Группа крови" {
+		t.Errorf("track.updated after renaming the album = %v", event)
+	}
+
+	if _, err := tracks.DeleteTrack(ctx, &pb.DeleteTrackRequest{Id: id}); err != nil {
+		t.Fatalf("DeleteTrack: %v", err)
+	}
+	event = next(t, events.SubjectTrackDeleted)
+	if event.GetVersion() != 5 || event.After != nil || event.GetBefore().GetTitle() != "Kukushka (live)" ||
+		event.GetBefore().GetVersion() != 4 {
+		t.Errorf("track.deleted = %v", event)
+	}
+}
+
+func mustObjectID(t *testing.T, hex string) primitive.ObjectID {
+	t.Helper()
+	id, err := primitive.ObjectIDFromHex(hex)
+	if err != nil {
+		t.Fatalf("bad id %q: %v", hex, err)
+	}
+	return id
+}
@@ -2,21 +2,70 @@ package services
 
 import (
 	"context"
+	"regexp"
+	"strings"
 
 	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/models"
 	pb "github.com/Zhanbatyr06/ADP2_ASS1/track-service/proto"
 	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/repositories"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/search"
+	"github.com/Zhanbatyr06/ADP2_ASS1/track-service/storage"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// PreferenceProvider отдает настройки и тариф слушателя, влияющие на выдачу
+// каталога. Слушатель определяется по токену в метаданных входящего запроса ctx.
+type PreferenceProvider interface {
+	ExplicitContentFiltered(ctx context.Context) (bool, error)
+	// MaxAudioQuality возвращает наибольшее качество звука по тарифу
+	// слушателя; без токена — ошибку Unauthenticated
+	MaxAudioQuality(ctx context.Context) (string, error)
+}
+
 type TrackGRPCService struct {
-	repo *repositories.TrackRepo
+	repo    *repositories.TrackRepo
+	catalog catalog
+	prefs   PreferenceProvider
+	suggest *search.SuggestIndex
+	blobs   storage.BlobStore
+	outbox  *repositories.OutboxRepo
+	// uploading — загрузки аудио, еще не записанные в треки
+	uploading uploadingKeys
+	// analysisQueued будит фоновый анализ аудио после загрузки
+	analysisQueued chan struct{}
 	pb.UnimplementedTrackServiceServer
 }
 
-func NewTrackGRPCService(repo *repositories.TrackRepo) *TrackGRPCService {
-	return &TrackGRPCService{repo: repo}
+func NewTrackGRPCService(repo *repositories.TrackRepo, artists *repositories.ArtistRepo, albums *repositories.AlbumRepo, prefs PreferenceProvider, suggest *search.SuggestIndex, blobs storage.BlobStore, outbox *repositories.OutboxRepo) *TrackGRPCService {
+	return &TrackGRPCService{
+		repo:    repo,
+		catalog: catalog{artists: artists, albums: albums},
+		prefs:   prefs,
+		suggest: suggest,
+		blobs:   blobs,
+		outbox:  outbox,
+
+		analysisQueued: make(chan struct{}, 1),
+	}
+}
+
+// LoadSuggestIndex строит индекс подсказок по всему каталогу
+func LoadSuggestIndex(ctx context.Context, repo *repositories.TrackRepo) (*search.SuggestIndex, error) {
+	tracks, err := repo.GetAllTracks(ctx, bson.M{}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]search.SuggestDoc, len(tracks))
+	for i, t := range tracks {
+		docs[i] = suggestDoc(t)
+	}
+	index := search.NewSuggestIndex()
+	index.Build(docs)
+	return index, nil
 }
 
 func (s *TrackGRPCService) CreateTrack(ctx context.Context, req *pb.CreateTrackRequest) (*pb.CreateTrackResponse, error) {
@@ -26,12 +75,21 @@ func (s *TrackGRPCService) CreateTrack(ctx context.Context, req *pb.CreateTrackR
 		Artist:   req.GetArtist(),
 		Album:    req.GetAlbum(),
 		Duration: req.GetDurationSec(),
+		Explicit: req.GetExplicit(),
+	}
+	if err := parseMetadata(req, &track); err != nil {
+		return nil, err
+	}
+	if err := s.linkTrack(ctx, &track, req.GetArtistId(), req.GetFeaturedArtistIds(), req.GetAlbumId()); err != nil {
+		return nil, err
 	}
 
-	// CreatedAt заполнится внутри репозитория
-	if _, err := s.repo.CreateTrack(ctx, track); err != nil {
+	// CreatedAt и версия заполнятся внутри репозитория
+	track, err := s.createTrack(ctx, track)
+	if err != nil {
 		return nil, err
 	}
+	s.suggest.Put(suggestDoc(track))
 
 	return &pb.CreateTrackResponse{
 		Track: toProto(track),
@@ -48,6 +106,9 @@ func (s *TrackGRPCService) GetTrackByID(ctx context.Context, req *pb.GetTrackByI
 	if err != nil {
 		return nil, err
 	}
+	if err := checkExplicit(ctx, s.prefs, track); err != nil {
+		return nil, err
+	}
 
 	return &pb.GetTrackByIDResponse{
 		Track: toProto(track),
@@ -55,35 +116,100 @@ func (s *TrackGRPCService) GetTrackByID(ctx context.Context, req *pb.GetTrackByI
 }
 
 func (s *TrackGRPCService) GetAllTracks(ctx context.Context, req *pb.GetAllTracksRequest) (*pb.GetAllTracksResponse, error) {
+	page, err := trackPage(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ввод пользователя экранируется: это поиск подстроки, а не регулярное
+	// выражение. Для поиска по смыслу есть SearchTracks.
 	filter := bson.M{}
 	if title := req.GetTitle(); title != "" {
-		filter["title"] = bson.M{"$regex": title, "$options": "i"}
+		filter["title"] = bson.M{"$regex": regexp.QuoteMeta(title), "$options": "i"}
 	}
 	if artist := req.GetArtist(); artist != "" {
-		filter["artist"] = bson.M{"$regex": artist, "$options": "i"}
+		filter["artist"] = bson.M{"$regex": regexp.QuoteMeta(artist), "$options": "i"}
+	}
+	if err := metadataFilter(req, filter); err != nil {
+		return nil, err
+	}
+	if err := linkFilter(req, filter); err != nil {
+		return nil, err
+	}
+	if hideExplicit(ctx, s.prefs) {
+		if req.Explicit != nil && req.GetExplicit() {
+			// Запрошены только explicit-треки, а пользователь их скрыл
+			return &pb.GetAllTracksResponse{}, nil
+		}
+		filter["explicit"] = bson.M{"$ne": true}
 	}
 
-	limit := req.GetLimit()
-	if limit <= 0 {
-		limit = 10
+	tracks, hasMore, err := s.repo.ListTracks(ctx, filter, page)
+	if err != nil {
+		return nil, err
 	}
-	page := req.GetPage()
-	if page <= 0 {
-		page = 1
+
+	resp := &pb.GetAllTracksResponse{Tracks: make([]*pb.Track, len(tracks))}
+	for i, t := range tracks {
+		resp.Tracks[i] = toProto(t)
+	}
+	if hasMore {
+		if resp.NextCursor, err = page.CursorAfter(tracks[len(tracks)-1]).Encode(); err != nil {
+			return nil, err
+		}
+	}
+	if req.GetIncludeTotal() {
+		if resp.TotalCount, err = s.repo.CountTracks(ctx, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// Suggest подсказывает треки, исполнителей и альбомы по мере ввода
+func (s *TrackGRPCService) Suggest(ctx context.Context, req *pb.SuggestRequest) (*pb.SuggestResponse, error) {
+	found := s.suggest.Suggest(req.GetPrefix(), int(req.GetLimit()), hideExplicit(ctx, s.prefs))
+
+	suggestions := make([]*pb.Suggestion, len(found))
+	for i, sg := range found {
+		suggestions[i] = &pb.Suggestion{
+			Kind:       sg.Kind,
+			Text:       sg.Text,
+			Subtitle:   sg.Subtitle,
+			TrackId:    sg.TrackID,
+			TrackCount: int32(sg.TrackCount),
+			Fuzzy:      sg.Fuzzy,
+		}
+	}
+
+	return &pb.SuggestResponse{Suggestions: suggestions}, nil
+}
+
+// SearchTracks выполняет полнотекстовый поиск по каталогу с ранжированием по релевантности
+func (s *TrackGRPCService) SearchTracks(ctx context.Context, req *pb.SearchTracksRequest) (*pb.SearchTracksResponse, error) {
+	query := search.ParseQuery(req.GetQuery())
+	if query.Empty() {
+		return nil, status.Error(codes.InvalidArgument, "query is empty")
 	}
-	skip := (page - 1) * limit
 
-	tracks, err := s.repo.GetAllTracks(ctx, filter, limit, skip)
+	filter := bson.M{}
+	if hideExplicit(ctx, s.prefs) {
+		filter["explicit"] = bson.M{"$ne": true}
+	}
+	limit, skip := pagination(req.GetPage(), req.GetLimit())
+
+	tracks, err := s.repo.SearchTracks(ctx, query.TextSearch(), filter, limit, skip)
 	if err != nil {
 		return nil, err
 	}
 
-	protoTracks := make([]*pb.Track, len(tracks))
+	results := make([]*pb.ScoredTrack, len(tracks))
 	for i, t := range tracks {
-		protoTracks[i] = toProto(t)
+		results[i] = &pb.ScoredTrack{Track: toProto(t.Track), Score: t.Score}
 	}
 
-	return &pb.GetAllTracksResponse{Tracks: protoTracks}, nil
+	return &pb.SearchTracksResponse{Results: results}, nil
 }
 
 func (s *TrackGRPCService) UpdateTrack(ctx context.Context, req *pb.UpdateTrackRequest) (*pb.UpdateTrackResponse, error) {
@@ -96,23 +222,32 @@ func (s *TrackGRPCService) UpdateTrack(ctx context.Context, req *pb.UpdateTrackR
 	if title := req.GetTitle(); title != "" {
 		updateData["title"] = title
 	}
-	if artist := req.GetArtist(); artist != "" {
-		updateData["artist"] = artist
-	}
-	if album := req.GetAlbum(); album != "" {
-		updateData["album"] = album
-	}
 	if dur := req.GetDurationSec(); dur != 0 {
 		updateData["duration_sec"] = dur
 	}
+	if req.Explicit != nil {
+		updateData["explicit"] = req.GetExplicit()
+	}
+	var meta models.Track
+	if err := parseMetadata(req, &meta); err != nil {
+		return nil, err
+	}
+	for field, value := range metadataUpdate(meta) {
+		updateData[field] = value
+	}
+	if err := s.relinkTrack(ctx, objID, req, updateData); err != nil {
+		return nil, err
+	}
 
 	if len(updateData) == 0 {
 		return &pb.UpdateTrackResponse{Message: "No fields to update"}, nil
 	}
 
-	if err := s.repo.UpdateTrack(ctx, objID, updateData); err != nil {
+	track, err := s.updateTrack(ctx, objID, updateData)
+	if err != nil {
 		return nil, err
 	}
+	s.suggest.Put(suggestDoc(track))
 
 	return &pb.UpdateTrackResponse{Message: "Track updated successfully"}, nil
 }
@@ -123,13 +258,326 @@ func (s *TrackGRPCService) DeleteTrack(ctx context.Context, req *pb.DeleteTrackR
 		return nil, err
 	}
 
-	if err := s.repo.DeleteTrack(ctx, objID); err != nil {
+	track, err := s.deleteTrack(ctx, objID)
+	if err != nil {
 		return nil, err
 	}
+	s.suggest.Remove(objID.Hex())
+	if track.Audio != nil {
+		s.releaseAudio(ctx, track.Audio.Key)
+	}
+	releaseArtwork(ctx, s.blobs, s.repo, s.catalog.albums, track.Cover)
 
 	return &pb.DeleteTrackResponse{Message: "Track deleted successfully"}, nil
 }
 
+// linkTrack связывает новый трек с исполнителями и альбомом. Исполнитель и
+// альбом задаются по ID или по имени из track.Artist и track.Album; имена в
+// треке заменяются на имена из найденных записей.
+func (s *TrackGRPCService) linkTrack(ctx context.Context, track *models.Track, artistID string, featuredIDs []string, albumID string) error {
+	var primary models.Artist
+	switch {
+	case artistID != "" || strings.TrimSpace(track.Artist) != "":
+		var err error
+		if primary, err = s.catalog.resolveArtist(ctx, artistID, track.Artist); err != nil {
+			return catalogError(err, "artist")
+		}
+		featured, err := s.catalog.resolveFeatured(ctx, primary, featuredIDs)
+		if err != nil {
+			return catalogError(err, "artist")
+		}
+		track.Artists = credits(primary, featured)
+		track.Artist = primary.Name
+	case len(featuredIDs) > 0:
+		return status.Error(codes.InvalidArgument, "featured artists need a main artist")
+	default:
+		track.Artists = []models.ArtistCredit{}
+	}
+
+	if albumID != "" || strings.TrimSpace(track.Album) != "" {
+		album, err := s.catalog.resolveAlbum(ctx, primary, albumID, track.Album)
+		if err != nil {
+			return catalogError(err, "album")
+		}
+		track.AlbumID, track.Album = album.ID, album.Title
+	}
+	return nil
+}
+
+// relinkTrack добавляет в updateData новые ссылки трека на исполнителей и
+// альбом, если запрос их меняет. Не заданное в запросе берется из трека.
+func (s *TrackGRPCService) relinkTrack(ctx context.Context, id primitive.ObjectID, req *pb.UpdateTrackRequest, updateData bson.M) error {
+	artistChanged := req.GetArtistId() != "" || req.GetArtist() != ""
+	featuredChanged := len(req.GetFeaturedArtistIds()) > 0
+	albumChanged := req.GetAlbumId() != "" || req.GetAlbum() != ""
+	if !artistChanged && !featuredChanged && !albumChanged {
+		return nil
+	}
+
+	track, err := s.repo.GetTrackByID(ctx, id)
+	if err != nil {
+		return catalogError(err, "track")
+	}
+
+	artistID, featuredIDs, albumID := req.GetArtistId(), req.GetFeaturedArtistIds(), req.GetAlbumId()
+	if artistChanged {
+		track.Artist = req.GetArtist()
+	} else if len(track.Artists) > 0 {
+		artistID = track.Artists[0].ID.Hex()
+	}
+	if !featuredChanged {
+		for _, c := range track.Artists {
+			if c.Featured {
+				featuredIDs = append(featuredIDs, c.ID.Hex())
+			}
+		}
+	}
+	if albumChanged {
+		track.Album = req.GetAlbum()
+	} else if !track.AlbumID.IsZero() {
+		albumID = track.AlbumID.Hex()
+	}
+
+	if err := s.linkTrack(ctx, &track, artistID, featuredIDs, albumID); err != nil {
+		return err
+	}
+	updateData["artists"] = track.Artists
+	updateData["artist"] = track.Artist
+	if !track.AlbumID.IsZero() {
+		updateData["album_id"] = track.AlbumID
+		updateData["album"] = track.Album
+	}
+	return nil
+}
+
+// linkFilter добавляет в filter условия по исполнителю и альбому
+func linkFilter(req *pb.GetAllTracksRequest, filter bson.M) error {
+	if id := req.GetArtistId(); id != "" {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid artist_id")
+		}
+		filter["artists.id"] = objID
+	}
+	if id := req.GetAlbumId(); id != "" {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid album_id")
+		}
+		filter["album_id"] = objID
+	}
+	return nil
+}
+
+// metadataRequest — общие поля метаданных запросов создания и изменения трека
+type metadataRequest interface {
+	GetGenres() []string
+	GetReleaseDate() string
+	GetIsrc() string
+	GetDiscNumber() int32
+	GetTrackNumber() int32
+	GetLanguage() string
+	GetBpm() float64
+	GetKey() string
+}
+
+// parseMetadata проверяет метаданные запроса и записывает их в track в
+// нормализованном виде. Пустые значения пропускаются.
+func parseMetadata(req metadataRequest, track *models.Track) error {
+	var err error
+	if track.Genres, err = models.NormalizeGenres(req.GetGenres()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if v := req.GetReleaseDate(); v != "" {
+		if track.ReleaseDate, err = models.NormalizeReleaseDate(v); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	if v := req.GetIsrc(); v != "" {
+		if track.ISRC, err = models.NormalizeISRC(v); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	if v := req.GetLanguage(); v != "" {
+		if track.Language, err = models.NormalizeLanguage(v); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	if v := req.GetKey(); v != "" {
+		if track.Key, err = models.NormalizeKey(v); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	if err := models.ValidateBPM(req.GetBpm()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	track.BPM = req.GetBpm()
+	for _, n := range []int32{req.GetDiscNumber(), req.GetTrackNumber()} {
+		if err := models.ValidateTrackNumber(n); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	track.DiscNumber, track.TrackNumber = req.GetDiscNumber(), req.GetTrackNumber()
+	return nil
+}
+
+// metadataUpdate возвращает заданные поля метаданных для $set
+func metadataUpdate(meta models.Track) bson.M {
+	update := bson.M{}
+	if len(meta.Genres) > 0 {
+		update["genres"] = meta.Genres
+	}
+	if meta.ReleaseDate != "" {
+		update["release_date"] = meta.ReleaseDate
+	}
+	if meta.ISRC != "" {
+		update["isrc"] = meta.ISRC
+	}
+	if meta.DiscNumber != 0 {
+		update["disc_number"] = meta.DiscNumber
+	}
+	if meta.TrackNumber != 0 {
+		update["track_number"] = meta.TrackNumber
+	}
+	if meta.Language != "" {
+		update["language"] = meta.Language
+	}
+	if meta.BPM != 0 {
+		update["bpm"] = meta.BPM
+	}
+	if meta.Key != "" {
+		update["key"] = meta.Key
+	}
+	return update
+}
+
+// metadataFilter добавляет в filter условия по метаданным из запроса каталога.
+// Значения нормализуются так же, как при сохранении трека.
+func metadataFilter(req *pb.GetAllTracksRequest, filter bson.M) error {
+	if len(req.GetGenres()) > 0 {
+		genres, err := models.NormalizeGenres(req.GetGenres())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		filter["genres"] = bson.M{"$in": genres}
+	}
+
+	releaseDate := bson.M{}
+	if v := req.GetReleaseDateFrom(); v != "" {
+		from, err := models.NormalizeReleaseDate(v)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "release_date_from: %v", err)
+		}
+		releaseDate["$gte"] = from
+	}
+	if v := req.GetReleaseDateTo(); v != "" {
+		to, err := models.NormalizeReleaseDate(v)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "release_date_to: %v", err)
+		}
+		releaseDate["$lte"] = to
+		// Треки без даты хранят "" (см. миграцию 5), а "" меньше любой даты
+		if _, ok := releaseDate["$gte"]; !ok {
+			releaseDate["$gt"] = ""
+		}
+	}
+	if len(releaseDate) > 0 {
+		filter["release_date"] = releaseDate
+	}
+
+	if v := req.GetIsrc(); v != "" {
+		isrc, err := models.NormalizeISRC(v)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		filter["isrc"] = isrc
+	}
+	if v := req.GetLanguage(); v != "" {
+		lang, err := models.NormalizeLanguage(v)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		filter["language"] = lang
+	}
+	if v := req.GetKey(); v != "" {
+		key, err := models.NormalizeKey(v)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		filter["key"] = key
+	}
+	if req.Explicit != nil {
+		filter["explicit"] = req.GetExplicit()
+	}
+
+	bpm := bson.M{}
+	if v := req.GetBpmMin(); v > 0 {
+		bpm["$gte"] = v
+	}
+	if v := req.GetBpmMax(); v > 0 {
+		bpm["$lte"] = v
+	}
+	if len(bpm) > 0 {
+		filter["bpm"] = bpm
+	}
+	return nil
+}
+
+// trackPage разбирает сортировку и курсор запроса каталога. Без курсора
+// поддерживается старая навигация по номеру страницы.
+func trackPage(req *pb.GetAllTracksRequest) (repositories.TrackPage, error) {
+	page := repositories.TrackPage{Sort: repositories.SortByCreatedAt}
+	if sortBy := req.GetSortBy(); sortBy != "" {
+		sort, err := repositories.ParseTrackSort(sortBy)
+		if err != nil {
+			return page, status.Errorf(codes.InvalidArgument, "sort_by %q: %v", sortBy, err)
+		}
+		page.Sort = sort
+	}
+
+	switch req.GetOrder() {
+	case "":
+		page.Descending = page.Sort.DefaultDescending()
+	case "asc":
+		page.Descending = false
+	case "desc":
+		page.Descending = true
+	default:
+		return page, status.Errorf(codes.InvalidArgument, "order must be asc or desc")
+	}
+
+	page.Limit, page.Skip = pagination(req.GetPage(), req.GetLimit())
+	if c := req.GetCursor(); c != "" {
+		after, err := repositories.DecodeTrackCursor(c, page.Sort, page.Descending)
+		if err != nil {
+			return page, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		page.After, page.Skip = after, 0
+	}
+	return page, nil
+}
+
+// pagination переводит номер страницы и ее размер в limit и skip
+func pagination(page, limit int64) (int64, int64) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return limit, (page - 1) * limit
+}
+
+func suggestDoc(t models.Track) search.SuggestDoc {
+	return search.SuggestDoc{
+		TrackID:  t.ID.Hex(),
+		Title:    t.Title,
+		Artist:   t.Artist,
+		Album:    t.Album,
+		Explicit: t.Explicit,
+	}
+}
+
 func toProto(t models.Track) *pb.Track {
 	return &pb.Track{
 		Id:          t.ID.Hex(),
@@ -138,5 +586,35 @@ func toProto(t models.Track) *pb.Track {
 		Album:       t.Album,
 		DurationSec: t.Duration,
 		CreatedAt:   t.CreatedAt,
+		Explicit:    t.Explicit,
+		Popularity:  t.Popularity,
+		Genres:      t.Genres,
+		ReleaseDate: t.ReleaseDate,
+		Isrc:        t.ISRC,
+		DiscNumber:  t.DiscNumber,
+		TrackNumber: t.TrackNumber,
+		Language:    t.Language,
+		Bpm:         t.BPM,
+		Key:         t.Key,
+		Artists:     creditsToProto(t.Artists),
+		AlbumId:     albumID(t.AlbumID),
+		Audio:       audioToProto(t.Audio),
+		Cover:       artworkToProto(t.Cover),
+		Version:     t.Version,
+	}
+}
+
+func creditsToProto(credits []models.ArtistCredit) []*pb.ArtistCredit {
+	list := make([]*pb.ArtistCredit, len(credits))
+	for i, c := range credits {
+		list[i] = &pb.ArtistCredit{ArtistId: c.ID.Hex(), Name: c.Name, Featured: c.Featured}
+	}
+	return list
+}
+
+func albumID(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
 	}
+	return id.Hex()
 }
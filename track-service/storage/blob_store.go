@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore хранит двоичные объекты (аудиофайлы, обложки) под строковыми
+// ключами — относительными путями через "/", например "audio/ab/<sha256>.mp3"
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetRange читает length байт начиная с offset; length 0 — до конца объекта
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,65 @@
+// hashbench measures argon2id on the current machine and suggests parameters
+// for ARGON2_MEMORY_KIB / ARGON2_ITERATIONS / ARGON2_PARALLELISM that keep a
+// single hash close to the target duration.
+//
+//	go run ./cmd/hashbench -target 250ms
+//
+// The cost of the default parameters alone is measured by
+//
+//	go test -bench Argon2id ./internal/hasher
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/facelessEmptiness/user_service/userService/internal/hasher"
+	"log"
+	"time"
+)
+
+func main() {
+	target := flag.Duration("target", 250*time.Millisecond, "desired time for one password hash")
+	parallelism := flag.Uint("parallelism", 2, "argon2id parallelism (threads)")
+	rounds := flag.Int("rounds", 5, "hashes per measurement")
+	flag.Parse()
+
+	fmt.Printf("%10s %6s %12s\n", "memory", "time", "duration")
+
+	var best *hasher.Argon2idParams
+	for _, memory := range []uint32{19 * 1024, 32 * 1024, 64 * 1024, 128 * 1024, 256 * 1024} {
+		for iterations := uint32(1); iterations <= 6; iterations++ {
+			params := hasher.DefaultArgon2idParams()
+			params.Memory = memory
+			params.Iterations = iterations
+			params.Parallelism = uint8(*parallelism)
+
+			d := measure(params, *rounds)
+			fmt.Printf("%8dKiB %6d %12s\n", memory, iterations, d.Round(time.Millisecond))
+
+			if d > *target {
+				break // Longer runs only get slower
+			}
+			p := params
+			best = &p
+		}
+	}
+
+	if best == nil {
+		fmt.Println("\neven the cheapest parameters exceed the target")
+		return
+	}
+	fmt.Printf("\nARGON2_MEMORY_KIB=%d ARGON2_ITERATIONS=%d ARGON2_PARALLELISM=%d\n",
+		best.Memory, best.Iterations, best.Parallelism)
+}
+
+// measure returns the average time of one hash with params
+func measure(params hasher.Argon2idParams, rounds int) time.Duration {
+	h := hasher.New(params)
+	start := time.Now()
+	for i := 0; i < rounds; i++ {
+		if _, err := h.Hash("correct horse battery staple"); err != nil {
+			log.Fatalf("hash: %v", err)
+		}
+	}
+	return time.Since(start) / time.Duration(rounds)
+}
@@ -2,14 +2,21 @@ package main
 
 import (
 	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/breach"
+	serviceClient "github.com/facelessEmptiness/user_service/userService/internal/client"
 	grpcHandler "github.com/facelessEmptiness/user_service/userService/internal/delivery/grpc"
+	"github.com/facelessEmptiness/user_service/userService/internal/hasher"
+	"github.com/facelessEmptiness/user_service/userService/internal/oidc"
 	"github.com/facelessEmptiness/user_service/userService/internal/repository"
+	"github.com/facelessEmptiness/user_service/userService/internal/storage"
 	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
 	pb "github.com/facelessEmptiness/user_service/userService/proto"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -23,15 +30,26 @@ func main() {
 	// Загрузка конфигурации из переменных окружения
 	mongoURI := os.Getenv("MONGO_URI")
 	dbName := os.Getenv("DB_NAME")
-	grpcPort := os.Getenv("GRPC_PORT")
+	grpcPort := getEnv("GRPC_PORT", "50051")
 	jwtSecret := os.Getenv("JWT_SECRET")
 	tokenExpStr := os.Getenv("TOKEN_EXP")
+	exportDir := getEnv("EXPORT_DIR", "exports")
+	playlistAddr := getEnv("PLAYLIST_SERVICE_ADDR", "localhost:50052")
+	serviceToken := os.Getenv("SERVICE_TOKEN")
+	mediaDir := getEnv("MEDIA_DIR", "media")
+	mediaBaseURL := getEnv("MEDIA_BASE_URL", "/media")
+	breachedPasswordsDir := os.Getenv("BREACHED_PASSWORDS_DIR")
+	impersonationExpStr := getEnv("IMPERSONATION_TOKEN_EXP", "15m")
 
 	// Парсинг длительности токена
 	tokenExp, err := time.ParseDuration(tokenExpStr)
 	if err != nil {
 		log.Fatalf("Неверная длительность токена: %v", err)
 	}
+	impersonationExp, err := time.ParseDuration(impersonationExpStr)
+	if err != nil {
+		log.Fatalf("Неверная длительность токена имперсонации: %v", err)
+	}
 
 	// Подключение к MongoDB
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -56,23 +74,114 @@ func main() {
 	// Инициализация базы данных
 	db := client.Database(dbName)
 
+	if err = repository.EnsureUserIndexes(db); err != nil {
+		log.Fatalf("Не удалось создать индексы: %v", err)
+	}
+	if err = repository.EnsureFollowIndexes(db); err != nil {
+		log.Fatalf("Не удалось создать индексы подписок: %v", err)
+	}
+	if err = repository.EnsureOAuthIndexes(db); err != nil {
+		log.Fatalf("Не удалось создать индексы OAuth: %v", err)
+	}
+	if err = repository.EnsureExternalIdentityIndexes(db); err != nil {
+		log.Fatalf("Не удалось создать индексы внешних аккаунтов: %v", err)
+	}
+	if err = repository.EnsureAuditIndexes(db); err != nil {
+		log.Fatalf("Не удалось создать индексы журнала аудита: %v", err)
+	}
+
 	// Инициализация репозитория
 	repo := repository.NewMongoUserRepository(db)
+	exportRepo := repository.NewMongoDataExportRepository(db)
+	deletionRepo := repository.NewMongoUserDeletionRepository(db)
+	followRepo := repository.NewMongoFollowRepository(db)
+	householdRepo := repository.NewMongoHouseholdRepository(db)
+	oauthRepo := repository.NewMongoOAuthRepository(db)
+	identityRepo := repository.NewMongoExternalIdentityRepository(db)
+	auditRepo := repository.NewMongoAuditRepository(db)
+
+	// Хранилище файлов (аватары)
+	blobStore, err := storage.NewLocalBlobStore(mediaDir, mediaBaseURL)
+	if err != nil {
+		log.Fatalf("Не удалось инициализировать хранилище файлов: %v", err)
+	}
+
+	// Клиент сервиса плейлистов; SERVICE_TOKEN должен совпадать с настройкой
+	// playlist-service, иначе очистка и экспорт плейлистов не пройдут
+	playlistClient, err := serviceClient.NewPlaylistClient(playlistAddr, serviceToken)
+	if err != nil {
+		log.Fatalf("Не удалось создать клиент сервиса плейлистов: %v", err)
+	}
+	defer playlistClient.Close()
+
+	// Парольная политика
+	passwordPolicy := usecase.DefaultPasswordPolicy()
+	passwordPolicy.MinLength = getEnvInt("PASSWORD_MIN_LENGTH", passwordPolicy.MinLength)
+	passwordPolicy.HistorySize = getEnvInt("PASSWORD_HISTORY_SIZE", passwordPolicy.HistorySize)
+	passwordPolicy.RequireUpper = getEnvBool("PASSWORD_REQUIRE_UPPER", passwordPolicy.RequireUpper)
+	passwordPolicy.RequireLower = getEnvBool("PASSWORD_REQUIRE_LOWER", passwordPolicy.RequireLower)
+	passwordPolicy.RequireDigit = getEnvBool("PASSWORD_REQUIRE_DIGIT", passwordPolicy.RequireDigit)
+	passwordPolicy.RequireSymbol = getEnvBool("PASSWORD_REQUIRE_SYMBOL", passwordPolicy.RequireSymbol)
+	if breachedPasswordsDir != "" {
+		breached, err := breach.NewPrefixList(breachedPasswordsDir)
+		if err != nil {
+			log.Fatalf("Не удалось загрузить список утекших паролей: %v", err)
+		}
+		passwordPolicy.Breached = breached
+	}
+
+	// Параметры argon2id (подобрать можно утилитой cmd/hashbench)
+	argonParams := hasher.DefaultArgon2idParams()
+	// Значения вне диапазона проверяются до приведения типов: 0 роняет
+	// argon2.IDKey на первом же входе, а 256 для uint8 превратилось бы в 0
+	argonMemory := getEnvInt("ARGON2_MEMORY_KIB", int(argonParams.Memory))
+	argonIterations := getEnvInt("ARGON2_ITERATIONS", int(argonParams.Iterations))
+	argonParallelism := getEnvInt("ARGON2_PARALLELISM", int(argonParams.Parallelism))
+	if argonParallelism < 1 || argonParallelism > 255 {
+		log.Fatalf("ARGON2_PARALLELISM должен быть от 1 до 255, получено %d", argonParallelism)
+	}
+	if argonIterations < 1 || argonIterations > 1000 {
+		log.Fatalf("ARGON2_ITERATIONS должен быть от 1 до 1000, получено %d", argonIterations)
+	}
+	// argon2 требует не меньше 8 КиБ на поток; больше 4 ГиБ на хеш — явная ошибка
+	if argonMemory < 8*argonParallelism || argonMemory > 4<<20 {
+		log.Fatalf("ARGON2_MEMORY_KIB должен быть от %d (8 КиБ на поток) до %d, получено %d", 8*argonParallelism, 4<<20, argonMemory)
+	}
+	argonParams.Memory = uint32(argonMemory)
+	argonParams.Iterations = uint32(argonIterations)
+	argonParams.Parallelism = uint8(argonParallelism)
+	passwordHasher := hasher.New(argonParams)
 
 	// Инициализация use case
-	uc := usecase.NewUserUseCase(repo)
+	uc := usecase.NewUserUseCase(repo, deletionRepo, householdRepo, identityRepo, followRepo, playlistClient, passwordPolicy, passwordHasher)
+	externalLoginUC := usecase.NewExternalLoginUseCase(repo, identityRepo, loadIdentityProviders()...)
+	impersonationUC := usecase.NewImpersonationUseCase(repo, auditRepo)
+	exportUC := usecase.NewExportUseCase(repo, exportRepo, exportDir, playlistClient, externalLoginUC, impersonationUC)
+	avatarUC := usecase.NewAvatarUseCase(repo, blobStore)
+	followUC := usecase.NewFollowUseCase(repo, followRepo)
+	householdUC := usecase.NewHouseholdUseCase(repo, householdRepo, uc)
+	oauthUC := usecase.NewOAuthUseCase(repo, oauthRepo)
 
 	// Инициализация gRPC обработчика (добавлен параметр JWT, если ваш обработчик поддерживает это)
 	// Если ваш обработчик не принимает эти параметры, измените эту строку соответственно
-	handler := grpcHandler.NewUserServiceHandler(uc, jwtSecret, tokenExp)
+	handler := grpcHandler.NewUserServiceHandler(uc, exportUC, avatarUC, followUC, householdUC, oauthUC, externalLoginUC, impersonationUC, jwtSecret, tokenExp, impersonationExp)
 
 	// Создание gRPC сервера
-	grpcServer := grpc.NewServer()
+	// Каждый вызов с токеном имперсонации записывается в журнал аудита
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(handler.AuditImpersonation))
 	pb.RegisterUserServiceServer(grpcServer, handler)
 
 	// Включение reflection для инструментов типа grpcurl
 	reflection.Register(grpcServer)
 
+	// Фоновая досылка очистки плейлистов удаленных пользователей
+	reconcileCtx, stopReconciler := context.WithCancel(context.Background())
+	defer stopReconciler()
+	go uc.RunDeletionReconciler(reconcileCtx, time.Minute)
+
+	// Периодический пересчет счетчиков подписок по самим подпискам
+	go followUC.RunFollowCounterRepair(reconcileCtx, time.Hour)
+
 	// Запуск gRPC сервера
 	lis, err := net.Listen("tcp", ":"+grpcPort)
 	if err != nil {
@@ -93,4 +202,69 @@ func main() {
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("Не удалось обслужить: %v", err)
 	}
+
+	// Прерываем незавершенные выгрузки данных и ждем, пока их статус сохранится
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelShutdown()
+	if err := exportUC.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Не удалось дождаться завершения выгрузок данных: %v", err)
+	}
+}
+
+// getEnv получает значение переменной окружения или возвращает значение по умолчанию
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// loadIdentityProviders подключает OIDC-провайдеров из OIDC_PROVIDERS
+// (например "google,mock"); для каждого нужны OIDC_<NAME>_ISSUER,
+// OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET и OIDC_<NAME>_REDIRECT_URL.
+// Недоступный провайдер пропускается, чтобы не мешать запуску сервиса.
+func loadIdentityProviders() []usecase.IdentityProvider {
+	var providers []usecase.IdentityProvider
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := oidc.Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       []string{"email", "profile"},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		client, err := oidc.NewClient(ctx, config)
+		cancel()
+		if err != nil {
+			log.Printf("OIDC-провайдер %s недоступен: %v", name, err)
+			continue
+		}
+		providers = append(providers, client)
+	}
+	return providers
 }
@@ -0,0 +1,40 @@
+// mockoidc runs a local OpenID Connect provider for trying out external
+// login without a real identity provider. Point the user service at it with
+//
+//	OIDC_PROVIDERS=mock
+//	OIDC_MOCK_ISSUER=http://localhost:9400
+//	OIDC_MOCK_CLIENT_ID=music-service
+//	OIDC_MOCK_CLIENT_SECRET=secret
+//	OIDC_MOCK_REDIRECT_URL=http://localhost:8080/auth/mock/callback
+package main
+
+import (
+	"flag"
+	"github.com/facelessEmptiness/user_service/userService/internal/oidc/mockprovider"
+	"log"
+	"net/http"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9400", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9400", "issuer URL as seen by the user service")
+	clientID := flag.String("client-id", "music-service", "accepted client id")
+	clientSecret := flag.String("client-secret", "secret", "accepted client secret")
+	subject := flag.String("sub", "mock-user-1", "subject of the signed-in user")
+	email := flag.String("email", "mock.user@example.com", "email of the signed-in user")
+	name := flag.String("name", "Mock User", "name of the signed-in user")
+	flag.Parse()
+
+	provider, err := mockprovider.New(*issuer, *clientID, *clientSecret, mockprovider.Identity{
+		Subject:       *subject,
+		Email:         *email,
+		EmailVerified: true,
+		Name:          *name,
+	})
+	if err != nil {
+		log.Fatalf("failed to create provider: %v", err)
+	}
+
+	log.Printf("mock OIDC provider %s listening on %s", *issuer, *addr)
+	log.Fatal(http.ListenAndServe(*addr, provider.Handler()))
+}
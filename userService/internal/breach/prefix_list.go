@@ -0,0 +1,65 @@
+// Package breach checks passwords against an offline copy of a breached
+// password corpus laid out the way the k-anonymity range API serves it.
+package breach
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const prefixLen = 5
+
+// PrefixList looks passwords up in a directory of range files. Every file is
+// named after the first five hex characters of the SHA-1 hash (e.g. "5BAA6")
+// and holds one "SUFFIX:COUNT" line per breached hash with that prefix, so a
+// lookup only ever reads the small file for one prefix.
+type PrefixList struct {
+	dir string
+}
+
+func NewPrefixList(dir string) (*PrefixList, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	return &PrefixList{dir: dir}, nil
+}
+
+// IsBreached reports whether the password appears in the list
+func (l *PrefixList) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLen], hash[prefixLen:]
+
+	f, err := os.Open(filepath.Join(l.dir, prefix))
+	if errors.Is(err, os.ErrNotExist) {
+		f, err = os.Open(filepath.Join(l.dir, prefix+".txt"))
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil // No breached hash starts with this prefix
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		entry, count, _ := strings.Cut(line, ":")
+		// Padding entries of the range format have a zero count
+		if strings.EqualFold(entry, suffix) && count != "0" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
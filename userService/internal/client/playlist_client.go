@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	playlistpb "github.com/Zhan028/Music_Service/playlistService/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// serviceTokenHeader carries the secret the playlist service checks on calls
+// made without a user's bearer token
+const serviceTokenHeader = "x-service-token"
+
+// PlaylistClient talks to the playlist service on behalf of the user service
+type PlaylistClient struct {
+	conn    *grpc.ClientConn
+	client  playlistpb.PlaylistServiceClient
+	timeout time.Duration
+	token   string
+}
+
+// NewPlaylistClient connects to the playlist service; serviceToken is the
+// SERVICE_TOKEN shared with it
+func NewPlaylistClient(addr, serviceToken string) (*PlaylistClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &PlaylistClient{
+		conn:    conn,
+		client:  playlistpb.NewPlaylistServiceClient(conn),
+		timeout: 10 * time.Second,
+		token:   serviceToken,
+	}, nil
+}
+
+// serviceContext marks the call as made by this service rather than a user
+func (c *PlaylistClient) serviceContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	return metadata.AppendToOutgoingContext(ctx, serviceTokenHeader, c.token), cancel
+}
+
+func (c *PlaylistClient) Close() error {
+	return c.conn.Close()
+}
+
+// Name implements usecase.ExportSource
+func (c *PlaylistClient) Name() string { return "playlists" }
+
+// Collect implements usecase.ExportSource and returns all playlists of the user with their tracks
+func (c *PlaylistClient) Collect(ctx context.Context, userID string) (interface{}, error) {
+	ctx, cancel := c.serviceContext(ctx)
+	defer cancel()
+
+	resp, err := c.client.GetUserPlaylists(ctx, &playlistpb.GetUserPlaylistsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Playlists, nil
+}
+
+// DeleteUserPlaylists removes every playlist owned by the user. The playlist
+// service treats repeated calls for the same user as a no-op.
+func (c *PlaylistClient) DeleteUserPlaylists(userID string) (int64, error) {
+	ctx, cancel := c.serviceContext(context.Background())
+	defer cancel()
+
+	resp, err := c.client.DeleteUserPlaylists(ctx, &playlistpb.DeleteUserPlaylistsRequest{UserId: userID})
+	if err != nil {
+		return 0, err
+	}
+	return resp.DeletedCount, nil
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/facelessEmptiness/user_service/userService/internal/domain"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// issueToken signs a new access token for the user
+func (h *UserServiceHandler) issueToken(user *domain.User) (*proto.AuthResponse, error) {
+	tokenString, expiresAt, err := h.signToken(user, h.tokenExp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.AuthResponse{
+		Token:     tokenString,
+		UserId:    user.ID,
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
+// signToken signs a JWT valid for ttl with the standard user claims plus extra
+func (h *UserServiceHandler) signToken(user *domain.User, ttl time.Duration, extra jwt.MapClaims) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl)
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"role":    user.Role,
+		"exp":     expiresAt.Unix(),
+	}
+	if user.IsChild {
+		claims["managed_by"] = user.ManagedBy
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(h.jwtSecret)
+	if err != nil {
+		return "", time.Time{}, status.Errorf(codes.Internal, "failed to generate token")
+	}
+	return tokenString, expiresAt, nil
+}
+
+// tokenClaims parses and verifies the bearer token sent in the "authorization" metadata
+func (h *UserServiceHandler) tokenClaims(ctx context.Context) (jwt.MapClaims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+		return nil, status.Errorf(codes.Unauthenticated, "missing bearer token")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(values[0], "Bearer "), claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return h.jwtSecret, nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+	return claims, nil
+}
+
+// currentUser returns the user the bearer token was issued to
+func (h *UserServiceHandler) currentUser(ctx context.Context) (*domain.User, jwt.MapClaims, error) {
+	claims, err := h.tokenClaims(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	userID, _ := claims["user_id"].(string)
+	user, err := h.userUseCase.GetByID(userID)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+	return user, claims, nil
+}
+
+// firstPartyUser is currentUser for operations third-party apps must never
+// perform on behalf of a user, such as granting access to other apps
+func (h *UserServiceHandler) firstPartyUser(ctx context.Context) (*domain.User, error) {
+	user, claims, err := h.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := claims["client_id"]; ok {
+		return nil, status.Errorf(codes.PermissionDenied, "not allowed with a third-party token")
+	}
+	if err := h.rejectImpersonation(ctx); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// requireScope lets a third-party token through only if it was granted
+// scope. First-party tokens carry no scopes and are always allowed.
+func requireScope(claims jwt.MapClaims, scope string) error {
+	if _, ok := claims["client_id"]; !ok {
+		return nil
+	}
+	granted, _ := claims["scope"].(string)
+	if !containsScope(granted, scope) {
+		return status.Errorf(codes.PermissionDenied, "the %s scope is required", scope)
+	}
+	return nil
+}
+
+// selfUser is firstPartyUser for operations a user may only perform on their
+// own account. The account is taken from the token; a user ID sent in the
+// request must name the same user.
+func (h *UserServiceHandler) selfUser(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if userID != "" && userID != user.ID {
+		return nil, status.Errorf(codes.PermissionDenied, "not allowed for another user")
+	}
+	return user, nil
+}
+
+// impersonationSession describes a token issued by ImpersonateUser
+type impersonationSession struct {
+	ActorID   string // Admin acting as the user
+	SubjectID string // User being impersonated
+	SessionID string
+}
+
+// impersonation reports whether the bearer token was issued by ImpersonateUser
+func (h *UserServiceHandler) impersonation(ctx context.Context) (impersonationSession, bool) {
+	claims, err := h.tokenClaims(ctx)
+	if err != nil {
+		return impersonationSession{}, false
+	}
+	act, _ := claims["act"].(map[string]interface{})
+	var session impersonationSession
+	session.ActorID, _ = act["sub"].(string)
+	session.SubjectID, _ = claims["user_id"].(string)
+	session.SessionID, _ = claims["impersonation_session"].(string)
+	return session, session.ActorID != ""
+}
+
+// rejectImpersonation refuses actions support staff must never take on a
+// user's behalf, such as changing the password or deleting the account
+func (h *UserServiceHandler) rejectImpersonation(ctx context.Context) error {
+	if _, ok := h.impersonation(ctx); ok {
+		return status.Errorf(codes.PermissionDenied, "not allowed while impersonating a user")
+	}
+	return nil
+}
+
+// requireAdmin makes sure the caller is an admin. The role is read from the
+// database rather than the token, so a revoked admin loses access immediately.
+// Neither third-party apps nor an admin impersonating a user get admin
+// access, whatever the role of the token's user.
+func (h *UserServiceHandler) requireAdmin(ctx context.Context) (*domain.User, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAdmin() {
+		return nil, status.Errorf(codes.PermissionDenied, "admin role required")
+	}
+	return user, nil
+}
+
+// passwordPolicyStatus turns a policy error into InvalidArgument with field-level details
+func passwordPolicyStatus(err *usecase.PasswordPolicyError) error {
+	br := &errdetails.BadRequest{}
+	for _, v := range err.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(br)
+	if detailErr != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return st.Err()
+}
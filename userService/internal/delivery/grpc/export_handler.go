@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/domain"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize bounds each DownloadDataExport message well below the
+// default 4 MB gRPC message limit
+const exportChunkSize = 256 << 10
+
+// ExportMyData starts an asynchronous export of all personal data of the caller
+func (h *UserServiceHandler) ExportMyData(ctx context.Context, req *proto.UserID) (*proto.DataExportStatus, error) {
+	user, err := h.selfUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := h.exportUseCase.RequestExport(user.ID)
+	if err != nil {
+		if err == usecase.ErrUserNotFound {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to start data export: %v", err)
+	}
+
+	return convertExportToStatus(export), nil
+}
+
+// GetDataExportStatus reports the progress of an export previously requested by the caller
+func (h *UserServiceHandler) GetDataExportStatus(ctx context.Context, req *proto.DataExportRequest) (*proto.DataExportStatus, error) {
+	user, err := h.selfUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := h.exportUseCase.GetExport(user.ID, req.ExportId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "data export not found")
+	}
+
+	return convertExportToStatus(export), nil
+}
+
+// DownloadDataExport streams the caller's finished ZIP archive in chunks
+func (h *UserServiceHandler) DownloadDataExport(req *proto.DataExportRequest, stream proto.UserService_DownloadDataExportServer) error {
+	user, err := h.selfUser(stream.Context(), req.UserId)
+	if err != nil {
+		return err
+	}
+
+	archive, filename, err := h.exportUseCase.OpenArchive(user.ID, req.ExportId)
+	if err != nil {
+		switch err {
+		case usecase.ErrExportNotFound:
+			return status.Errorf(codes.NotFound, "data export not found")
+		case usecase.ErrExportNotReady:
+			return status.Errorf(codes.FailedPrecondition, "data export is not ready yet")
+		default:
+			return status.Errorf(codes.Internal, "failed to read data export: %v", err)
+		}
+	}
+	defer archive.Close()
+
+	msg := &proto.DataExportArchive{
+		ExportId:    req.ExportId,
+		Filename:    filename,
+		ContentType: "application/zip",
+	}
+	buf := make([]byte, exportChunkSize)
+	for {
+		n, err := io.ReadFull(archive, buf)
+		if n > 0 {
+			msg.Data = buf[:n]
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+			msg = &proto.DataExportArchive{}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read data export: %v", err)
+		}
+	}
+}
+
+// Helper function to convert domain.DataExport to pb.DataExportStatus
+func convertExportToStatus(export *domain.DataExport) *proto.DataExportStatus {
+	var createdAt, completedAt int64
+	if export.CreatedAt != nil {
+		createdAt = export.CreatedAt.Unix()
+	}
+	if export.CompletedAt != nil {
+		completedAt = export.CompletedAt.Unix()
+	}
+
+	return &proto.DataExportStatus{
+		ExportId:    export.ID,
+		UserId:      export.UserID,
+		Status:      export.Status,
+		Error:       export.Error,
+		SizeBytes:   export.SizeBytes,
+		CreatedAt:   createdAt,
+		CompletedAt: completedAt,
+	}
+}
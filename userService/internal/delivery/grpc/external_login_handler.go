@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListIdentityProviders returns the external providers users can sign in with
+func (h *UserServiceHandler) ListIdentityProviders(ctx context.Context, req *proto.IdentityProviderRequest) (*proto.IdentityProviderList, error) {
+	return &proto.IdentityProviderList{Providers: h.externalLoginUseCase.Providers()}, nil
+}
+
+// BeginExternalLogin returns the provider URL to send the user to
+func (h *UserServiceHandler) BeginExternalLogin(ctx context.Context, req *proto.ExternalLoginRequest) (*proto.ExternalLoginRedirect, error) {
+	var linkUserID string
+	if req.Link {
+		user, err := h.firstPartyUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		linkUserID = user.ID
+	}
+
+	authURL, err := h.externalLoginUseCase.BeginLogin(req.Provider, linkUserID)
+	if err != nil {
+		return nil, externalLoginError(err, "failed to start login")
+	}
+	return &proto.ExternalLoginRedirect{AuthorizationUrl: authURL}, nil
+}
+
+// CompleteExternalLogin handles the provider callback and issues our own token
+func (h *UserServiceHandler) CompleteExternalLogin(ctx context.Context, req *proto.ExternalLoginCallback) (*proto.ExternalLoginResponse, error) {
+	user, created, err := h.externalLoginUseCase.CompleteLogin(req.Provider, req.State, req.Code)
+	if err != nil {
+		return nil, externalLoginError(err, "failed to complete login")
+	}
+
+	auth, err := h.issueToken(user)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ExternalLoginResponse{
+		Token:     auth.Token,
+		UserId:    auth.UserId,
+		ExpiresAt: auth.ExpiresAt,
+		Created:   created,
+	}, nil
+}
+
+// ListExternalIdentities lists the providers linked to the caller's account
+func (h *UserServiceHandler) ListExternalIdentities(ctx context.Context, req *proto.IdentityProviderRequest) (*proto.ExternalIdentityList, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := h.externalLoginUseCase.ListIdentities(user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list identities: %v", err)
+	}
+
+	resp := &proto.ExternalIdentityList{}
+	for _, identity := range identities {
+		var linkedAt, lastLoginAt int64
+		if identity.LinkedAt != nil {
+			linkedAt = identity.LinkedAt.Unix()
+		}
+		if identity.LastLoginAt != nil {
+			lastLoginAt = identity.LastLoginAt.Unix()
+		}
+		resp.Identities = append(resp.Identities, &proto.ExternalIdentity{
+			Provider:    identity.Provider,
+			Subject:     identity.Subject,
+			Email:       identity.Email,
+			LinkedAt:    linkedAt,
+			LastLoginAt: lastLoginAt,
+		})
+	}
+	return resp, nil
+}
+
+// UnlinkExternalIdentity removes a linked provider from the caller's account
+func (h *UserServiceHandler) UnlinkExternalIdentity(ctx context.Context, req *proto.IdentityProviderRequest) (*proto.StatusResponse, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.externalLoginUseCase.Unlink(user.ID, req.Provider); err != nil {
+		return nil, externalLoginError(err, "failed to unlink identity")
+	}
+
+	return &proto.StatusResponse{
+		Success: true,
+		Message: "identity unlinked successfully",
+	}, nil
+}
+
+func externalLoginError(err error, msg string) error {
+	switch err {
+	case usecase.ErrUnknownProvider, usecase.ErrUserNotFound:
+		return status.Errorf(codes.NotFound, "%v", err)
+	case usecase.ErrInvalidLoginState:
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case usecase.ErrExternalAuthFailed:
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	case usecase.ErrIdentityLinkedElsewhere, usecase.ErrExternalEmailInUse:
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	case usecase.ErrLastLoginMethod:
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/domain"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Follow makes a user follow another user or an artist
+func (h *UserServiceHandler) Follow(ctx context.Context, req *proto.FollowRequest) (*proto.StatusResponse, error) {
+	follower, err := h.selfUser(ctx, req.FollowerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.followUseCase.Follow(follower.ID, req.TargetType, req.TargetId); err != nil {
+		return nil, followError(err, "failed to follow")
+	}
+
+	return &proto.StatusResponse{
+		Success: true,
+		Message: "followed successfully",
+	}, nil
+}
+
+// Unfollow removes a follow edge
+func (h *UserServiceHandler) Unfollow(ctx context.Context, req *proto.FollowRequest) (*proto.StatusResponse, error) {
+	follower, err := h.selfUser(ctx, req.FollowerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.followUseCase.Unfollow(follower.ID, req.TargetType, req.TargetId); err != nil {
+		return nil, followError(err, "failed to unfollow")
+	}
+
+	return &proto.StatusResponse{
+		Success: true,
+		Message: "unfollowed successfully",
+	}, nil
+}
+
+// IsFollowing checks whether a follow edge exists
+func (h *UserServiceHandler) IsFollowing(ctx context.Context, req *proto.FollowRequest) (*proto.FollowStatus, error) {
+	following, err := h.followUseCase.IsFollowing(req.FollowerId, req.TargetType, req.TargetId)
+	if err != nil {
+		return nil, followError(err, "failed to check follow")
+	}
+
+	return &proto.FollowStatus{Following: following}, nil
+}
+
+// ListFollowers retrieves a paginated list of followers of a user or artist
+func (h *UserServiceHandler) ListFollowers(ctx context.Context, req *proto.FollowListRequest) (*proto.FollowList, error) {
+	follows, total, err := h.followUseCase.ListFollowers(req.TargetType, req.Id, req.Page, req.Limit)
+	if err != nil {
+		return nil, followError(err, "failed to list followers")
+	}
+
+	return convertFollowsToList(follows, total, req), nil
+}
+
+// ListFollowing retrieves a paginated list of users and artists the user follows
+func (h *UserServiceHandler) ListFollowing(ctx context.Context, req *proto.FollowListRequest) (*proto.FollowList, error) {
+	follows, total, err := h.followUseCase.ListFollowing(req.Id, req.TargetType, req.Page, req.Limit)
+	if err != nil {
+		return nil, followError(err, "failed to list following")
+	}
+
+	return convertFollowsToList(follows, total, req), nil
+}
+
+// BlockUser prevents a user from following the blocker
+func (h *UserServiceHandler) BlockUser(ctx context.Context, req *proto.BlockRequest) (*proto.StatusResponse, error) {
+	blocker, err := h.selfUser(ctx, req.BlockerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.followUseCase.Block(blocker.ID, req.BlockedId); err != nil {
+		return nil, followError(err, "failed to block user")
+	}
+
+	return &proto.StatusResponse{
+		Success: true,
+		Message: "user blocked successfully",
+	}, nil
+}
+
+// UnblockUser lifts a block
+func (h *UserServiceHandler) UnblockUser(ctx context.Context, req *proto.BlockRequest) (*proto.StatusResponse, error) {
+	blocker, err := h.selfUser(ctx, req.BlockerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.followUseCase.Unblock(blocker.ID, req.BlockedId); err != nil {
+		return nil, followError(err, "failed to unblock user")
+	}
+
+	return &proto.StatusResponse{
+		Success: true,
+		Message: "user unblocked successfully",
+	}, nil
+}
+
+func followError(err error, msg string) error {
+	switch err {
+	case usecase.ErrUserNotFound:
+		return status.Errorf(codes.NotFound, "user not found")
+	case usecase.ErrInvalidFollowTarget, usecase.ErrCannotFollowSelf:
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case usecase.ErrBlocked:
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// Helper function to convert follow edges to pb.FollowList
+func convertFollowsToList(follows []*domain.Follow, total int64, req *proto.FollowListRequest) *proto.FollowList {
+	edges := make([]*proto.FollowEdge, 0, len(follows))
+	for _, f := range follows {
+		var createdAt int64
+		if f.CreatedAt != nil {
+			createdAt = f.CreatedAt.Unix()
+		}
+		edges = append(edges, &proto.FollowEdge{
+			FollowerId: f.FollowerID,
+			TargetId:   f.TargetID,
+			TargetType: f.TargetType,
+			CreatedAt:  createdAt,
+		})
+	}
+
+	return &proto.FollowList{
+		Edges:      edges,
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+}
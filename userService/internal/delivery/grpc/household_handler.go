@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/domain"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateHousehold creates a family household owned by the caller
+func (h *UserServiceHandler) CreateHousehold(ctx context.Context, req *proto.CreateHouseholdRequest) (*proto.Household, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	household, err := h.householdUseCase.Create(user.ID, req.Name)
+	if err != nil {
+		return nil, householdError(err, "failed to create household")
+	}
+	return convertHouseholdToProto(household), nil
+}
+
+// InviteHouseholdMember invites an adult by email; the returned token is shown only once
+func (h *UserServiceHandler) InviteHouseholdMember(ctx context.Context, req *proto.HouseholdInviteRequest) (*proto.HouseholdInvitation, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, token, err := h.householdUseCase.Invite(user.ID, req.HouseholdId, req.Email)
+	if err != nil {
+		return nil, householdError(err, "failed to invite member")
+	}
+
+	var expiresAt int64
+	if invitation.ExpiresAt != nil {
+		expiresAt = invitation.ExpiresAt.Unix()
+	}
+	return &proto.HouseholdInvitation{
+		Id:          invitation.ID,
+		HouseholdId: invitation.HouseholdID,
+		Email:       invitation.Email,
+		Token:       token,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// AcceptHouseholdInvitation adds the caller to the household the invitation was issued for
+func (h *UserServiceHandler) AcceptHouseholdInvitation(ctx context.Context, req *proto.AcceptInvitationRequest) (*proto.Household, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	household, err := h.householdUseCase.Accept(user.ID, req.Token)
+	if err != nil {
+		return nil, householdError(err, "failed to accept invitation")
+	}
+	return convertHouseholdToProto(household), nil
+}
+
+// CreateChildProfile creates a managed child profile in the caller's household
+func (h *UserServiceHandler) CreateChildProfile(ctx context.Context, req *proto.ChildProfileRequest) (*proto.UserProfile, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := h.householdUseCase.CreateChildProfile(user.ID, req.HouseholdId, req.Name, req.DisplayName)
+	if err != nil {
+		return nil, householdError(err, "failed to create child profile")
+	}
+	return convertUserToProfile(child), nil
+}
+
+// ListHouseholdMembers lists the members of a household the caller belongs to
+func (h *UserServiceHandler) ListHouseholdMembers(ctx context.Context, req *proto.HouseholdRequest) (*proto.HouseholdMembers, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	household, members, err := h.householdUseCase.ListMembers(user.ID, req.HouseholdId)
+	if err != nil {
+		return nil, householdError(err, "failed to list members")
+	}
+
+	resp := &proto.HouseholdMembers{
+		Household: convertHouseholdToProto(household),
+		Members:   make([]*proto.HouseholdMember, 0, len(members)),
+	}
+	for _, m := range members {
+		resp.Members = append(resp.Members, &proto.HouseholdMember{
+			Profile: convertUserToProfile(m),
+			IsOwner: m.ID == household.OwnerID,
+			IsChild: m.IsChild,
+		})
+	}
+	return resp, nil
+}
+
+// RemoveHouseholdMember removes a member (owner) or leaves the household (member)
+func (h *UserServiceHandler) RemoveHouseholdMember(ctx context.Context, req *proto.HouseholdMemberRequest) (*proto.StatusResponse, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.householdUseCase.RemoveMember(user.ID, req.HouseholdId, req.UserId); err != nil {
+		return nil, householdError(err, "failed to remove member")
+	}
+
+	return &proto.StatusResponse{
+		Success: true,
+		Message: "member removed successfully",
+	}, nil
+}
+
+// GetChildProfileToken lets the owner switch to one of their child profiles.
+// The child token is a full session, so neither a third-party app nor an
+// impersonating admin may obtain one.
+func (h *UserServiceHandler) GetChildProfileToken(ctx context.Context, req *proto.UserID) (*proto.AuthResponse, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := h.householdUseCase.ChildProfile(user.ID, req.Id)
+	if err != nil {
+		return nil, householdError(err, "failed to get child profile")
+	}
+	return h.issueToken(child)
+}
+
+func householdError(err error, msg string) error {
+	switch err {
+	case usecase.ErrUserNotFound, usecase.ErrHouseholdNotFound:
+		return status.Errorf(codes.NotFound, "%v", err)
+	case usecase.ErrNotHouseholdOwner, usecase.ErrChildProfileForbidden, usecase.ErrFamilyPlanRequired:
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	case usecase.ErrInvalidInvitation, usecase.ErrInvalidChildProfile:
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case usecase.ErrAlreadyInHousehold, usecase.ErrCannotRemoveOwner:
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case usecase.ErrHouseholdFull:
+		return status.Errorf(codes.ResourceExhausted, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// Helper function to convert domain.Household to pb.Household
+func convertHouseholdToProto(household *domain.Household) *proto.Household {
+	var createdAt int64
+	if household.CreatedAt != nil {
+		createdAt = household.CreatedAt.Unix()
+	}
+	return &proto.Household{
+		Id:        household.ID,
+		OwnerId:   household.OwnerID,
+		Name:      household.Name,
+		MemberIds: household.MemberIDs,
+		CreatedAt: createdAt,
+	}
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/domain"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+	"log"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ImpersonateUser issues a short-lived token for acting as a user (admin only).
+// The token carries an RFC 8693 "act" claim naming the admin.
+func (h *UserServiceHandler) ImpersonateUser(ctx context.Context, req *proto.ImpersonateRequest) (*proto.ImpersonationToken, error) {
+	if err := h.rejectImpersonation(ctx); err != nil {
+		return nil, err
+	}
+	admin, err := h.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target, sessionID, err := h.impersonationUseCase.Start(admin, req.UserId, req.Reason)
+	if err != nil {
+		switch err {
+		case usecase.ErrUserNotFound:
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		case usecase.ErrImpersonationReasonRequired:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case usecase.ErrCannotImpersonate:
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to start impersonation: %v", err)
+		}
+	}
+
+	token, expiresAt, err := h.signToken(target, h.impersonationExp, jwt.MapClaims{
+		"act":                   map[string]interface{}{"sub": admin.ID},
+		"impersonation_session": sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("admin %s started impersonating user %s (session %s)", admin.ID, target.ID, sessionID)
+
+	return &proto.ImpersonationToken{
+		Token:     token,
+		UserId:    target.ID,
+		ActorId:   admin.ID,
+		SessionId: sessionID,
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
+// playlistScopes are the scopes a third-party token needs for each playlist
+// service method. Methods missing here are refused to third-party tokens.
+var playlistScopes = map[string]string{
+	"/playlist.PlaylistService/GetPlaylist":             domain.ScopePlaylistsRead,
+	"/playlist.PlaylistService/GetUserPlaylists":        domain.ScopePlaylistsRead,
+	"/playlist.PlaylistService/CreatePlaylist":          domain.ScopePlaylistsWrite,
+	"/playlist.PlaylistService/AddTrackToPlaylist":      domain.ScopePlaylistsWrite,
+	"/playlist.PlaylistService/RemoveTrackFromPlaylist": domain.ScopePlaylistsWrite,
+	"/playlist.PlaylistService/DeletePlaylist":          domain.ScopePlaylistsWrite,
+}
+
+// AuthorizeCall authenticates a bearer token forwarded by another service,
+// such as the playlist service, so impersonation reaches beyond this service.
+// A third-party token is allowed only for the methods its scopes cover.
+// A call made with an impersonation token is audited under req.Method before
+// it is allowed; if the record can't be written, the call is refused.
+func (h *UserServiceHandler) AuthorizeCall(ctx context.Context, req *proto.AuthorizeCallRequest) (*proto.CallAuthorization, error) {
+	user, claims, err := h.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := claims["client_id"]; ok {
+		scope, ok := playlistScopes[req.Method]
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "not allowed with a third-party token")
+		}
+		if err := requireScope(claims, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &proto.CallAuthorization{UserId: user.ID}
+	session, ok := h.impersonation(ctx)
+	if !ok {
+		return resp, nil
+	}
+	if req.Method == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "method is required")
+	}
+	if err := h.impersonationUseCase.RecordCall(session.ActorID, session.SubjectID, session.SessionID, req.Method); err != nil {
+		log.Printf("failed to audit %s by admin %s as user %s: %v", req.Method, session.ActorID, session.SubjectID, err)
+		return nil, status.Errorf(codes.Unavailable, "failed to write audit record")
+	}
+	resp.ActorId = session.ActorID
+	resp.SessionId = session.SessionID
+	return resp, nil
+}
+
+// AuditImpersonation is a unary server interceptor that writes an audit
+// record for every call made with an impersonation token before handling it,
+// and marks the response with an "x-impersonated-by" header. A call whose
+// record cannot be written is refused.
+func (h *UserServiceHandler) AuditImpersonation(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	session, ok := h.impersonation(ctx)
+	// AuthorizeCall audits the forwarded call itself
+	if !ok || info.FullMethod == proto.UserService_AuthorizeCall_FullMethodName {
+		return handler(ctx, req)
+	}
+
+	if err := h.impersonationUseCase.RecordCall(session.ActorID, session.SubjectID, session.SessionID, info.FullMethod); err != nil {
+		log.Printf("failed to audit %s by admin %s as user %s: %v", info.FullMethod, session.ActorID, session.SubjectID, err)
+		return nil, status.Errorf(codes.Unavailable, "failed to write audit record")
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-impersonated-by", session.ActorID)); err != nil {
+		log.Printf("failed to mark impersonated response: %v", err)
+	}
+
+	return handler(ctx, req)
+}
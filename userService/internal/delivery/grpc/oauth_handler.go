@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/domain"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+	"net/url"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterOAuthClient registers a third-party application owned by the caller
+func (h *UserServiceHandler) RegisterOAuthClient(ctx context.Context, req *proto.OAuthClientRequest) (*proto.OAuthClient, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, secret, err := h.oauthUseCase.RegisterClient(user.ID, usecase.OAuthClientRegistration{
+		Name:         req.Name,
+		RedirectURIs: req.RedirectUris,
+		Scopes:       req.Scopes,
+		Public:       req.Public,
+	})
+	if err != nil {
+		return nil, oauthError(err, "failed to register client")
+	}
+
+	resp := convertOAuthClientToProto(client)
+	resp.ClientSecret = secret
+	return resp, nil
+}
+
+// AuthorizeOAuth issues an authorization code for the signed-in user, or
+// reports that the user has to consent to the requested scopes first
+func (h *UserServiceHandler) AuthorizeOAuth(ctx context.Context, req *proto.OAuthAuthorizeRequest) (*proto.OAuthAuthorizeResponse, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.oauthUseCase.Authorize(user.ID, usecase.AuthorizeRequest{
+		ClientID:            req.ClientId,
+		RedirectURI:         req.RedirectUri,
+		Scope:               req.Scope,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Approve:             req.Approve,
+	})
+	if err != nil {
+		return nil, oauthError(err, "failed to authorize")
+	}
+
+	resp := &proto.OAuthAuthorizeResponse{
+		ConsentRequired: result.ConsentRequired,
+		ClientName:      result.Client.Name,
+		Scopes:          result.Scopes,
+		Code:            result.Code,
+	}
+	if result.Code != "" {
+		// The redirect URI was matched exactly against the registered ones
+		redirect, _ := url.Parse(req.RedirectUri)
+		query := redirect.Query()
+		query.Set("code", result.Code)
+		if req.State != "" {
+			query.Set("state", req.State)
+		}
+		redirect.RawQuery = query.Encode()
+		resp.RedirectTo = redirect.String()
+	}
+	return resp, nil
+}
+
+// ExchangeOAuthToken is the token endpoint. Access tokens carry the usual
+// user claims plus client_id and scope.
+func (h *UserServiceHandler) ExchangeOAuthToken(ctx context.Context, req *proto.OAuthTokenRequest) (*proto.OAuthTokenResponse, error) {
+	grant, err := h.oauthUseCase.Token(usecase.TokenRequest{
+		GrantType:    req.GrantType,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Code:         req.Code,
+		RedirectURI:  req.RedirectUri,
+		CodeVerifier: req.CodeVerifier,
+		RefreshToken: req.RefreshToken,
+		Scope:        req.Scope,
+	})
+	if err != nil {
+		return nil, oauthError(err, "failed to issue token")
+	}
+
+	scope := strings.Join(grant.Scopes, " ")
+	accessToken, _, err := h.signToken(grant.User, h.tokenExp, jwt.MapClaims{
+		"client_id": grant.ClientID,
+		"scope":     scope,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.OAuthTokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(h.tokenExp.Seconds()),
+		RefreshToken: grant.RefreshToken,
+		Scope:        scope,
+	}, nil
+}
+
+// RevokeOAuthToken revokes a refresh token of the client
+func (h *UserServiceHandler) RevokeOAuthToken(ctx context.Context, req *proto.OAuthRevokeRequest) (*proto.StatusResponse, error) {
+	if err := h.oauthUseCase.Revoke(req.ClientId, req.ClientSecret, req.Token); err != nil {
+		return nil, oauthError(err, "failed to revoke token")
+	}
+
+	return &proto.StatusResponse{
+		Success: true,
+		Message: "token revoked",
+	}, nil
+}
+
+// ListOAuthConsents lists the applications the caller has granted access to
+func (h *UserServiceHandler) ListOAuthConsents(ctx context.Context, req *proto.OAuthConsentRequest) (*proto.OAuthConsentList, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	consents, err := h.oauthUseCase.ListConsents(user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list consents: %v", err)
+	}
+
+	resp := &proto.OAuthConsentList{}
+	for _, c := range consents {
+		if req.ClientId != "" && c.ClientID != req.ClientId {
+			continue
+		}
+		var clientName string
+		if client, err := h.oauthUseCase.GetClient(c.ClientID); err == nil {
+			clientName = client.Name
+		}
+		var grantedAt int64
+		if c.GrantedAt != nil {
+			grantedAt = c.GrantedAt.Unix()
+		}
+		resp.Consents = append(resp.Consents, &proto.OAuthConsent{
+			ClientId:   c.ClientID,
+			ClientName: clientName,
+			Scopes:     c.Scopes,
+			GrantedAt:  grantedAt,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeOAuthConsent withdraws the caller's consent for an application
+func (h *UserServiceHandler) RevokeOAuthConsent(ctx context.Context, req *proto.OAuthConsentRequest) (*proto.StatusResponse, error) {
+	user, err := h.firstPartyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.oauthUseCase.RevokeConsent(user.ID, req.ClientId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke consent: %v", err)
+	}
+
+	return &proto.StatusResponse{
+		Success: true,
+		Message: "consent revoked",
+	}, nil
+}
+
+// oauthError keeps the RFC 6749 error code as the status message, so the
+// gateway can put it into the error response unchanged
+func oauthError(err error, msg string) error {
+	switch err {
+	case usecase.ErrOAuthInvalidClient:
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	case usecase.ErrOAuthInvalidRequest, usecase.ErrOAuthInvalidGrant,
+		usecase.ErrOAuthInvalidScope, usecase.ErrOAuthUnsupportedGrantType:
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// Helper function to convert domain.OAuthClient to pb.OAuthClient
+func convertOAuthClientToProto(client *domain.OAuthClient) *proto.OAuthClient {
+	var createdAt int64
+	if client.CreatedAt != nil {
+		createdAt = client.CreatedAt.Unix()
+	}
+	return &proto.OAuthClient{
+		ClientId:     client.ID,
+		Name:         client.Name,
+		RedirectUris: client.RedirectURIs,
+		Scopes:       client.Scopes,
+		Public:       client.Public(),
+		CreatedAt:    createdAt,
+	}
+}
+
+// containsScope reports whether the space-separated scope list grants want
+func containsScope(scope, want string) bool {
+	for _, s := range strings.Fields(scope) {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
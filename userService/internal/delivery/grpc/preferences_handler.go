@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/domain"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPreferences returns the listener preferences of the user
+func (h *UserServiceHandler) GetPreferences(ctx context.Context, req *proto.UserID) (*proto.Preferences, error) {
+	prefs, err := h.userUseCase.GetPreferences(req.Id)
+	if err != nil {
+		if err == usecase.ErrUserNotFound {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get preferences: %v", err)
+	}
+
+	return convertPreferencesToProto(prefs), nil
+}
+
+// GetCurrentPreferences returns the preferences of the bearer token's user,
+// so the track service can apply the explicit content filter of the caller
+// rather than of a user ID the client chose
+func (h *UserServiceHandler) GetCurrentPreferences(ctx context.Context, req *proto.CurrentUserRequest) (*proto.Preferences, error) {
+	user, claims, err := h.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireScope(claims, domain.ScopeCatalogRead); err != nil {
+		return nil, err
+	}
+
+	return h.GetPreferences(ctx, &proto.UserID{Id: user.ID})
+}
+
+// UpdatePreferences replaces the listener preferences of the user the token was issued to
+func (h *UserServiceHandler) UpdatePreferences(ctx context.Context, req *proto.UpdatePreferencesRequest) (*proto.Preferences, error) {
+	user, err := h.selfUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	in := req.GetPreferences()
+	prefs, err := h.userUseCase.UpdatePreferences(user.ID, domain.Preferences{
+		FavoriteGenres:        in.GetFavoriteGenres(),
+		PreferredLanguages:    in.GetPreferredLanguages(),
+		ExplicitContentFilter: in.GetExplicitContentFilter(),
+		AudioQuality:          in.GetAudioQuality(),
+	})
+	if err != nil {
+		switch err {
+		case usecase.ErrUserNotFound:
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		case usecase.ErrInvalidPreferences:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid preferences: genres and languages are limited to 20 items, languages must be ISO 639-1 codes and audio quality one of low, normal, high, lossless")
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to update preferences: %v", err)
+		}
+	}
+
+	return convertPreferencesToProto(prefs), nil
+}
+
+// Helper function to convert domain.Preferences to pb.Preferences
+func convertPreferencesToProto(prefs *domain.Preferences) *proto.Preferences {
+	return &proto.Preferences{
+		FavoriteGenres:        prefs.FavoriteGenres,
+		PreferredLanguages:    prefs.PreferredLanguages,
+		ExplicitContentFilter: prefs.ExplicitContentFilter,
+		AudioQuality:          prefs.AudioQuality,
+	}
+}
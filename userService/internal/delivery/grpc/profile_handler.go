@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetUserByHandle retrieves user profile by @handle
+func (h *UserServiceHandler) GetUserByHandle(ctx context.Context, req *proto.HandleRequest) (*proto.UserProfile, error) {
+	user, err := h.userUseCase.GetByHandle(req.Handle)
+	if err != nil {
+		if err == usecase.ErrUserNotFound {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	return convertUserToProfile(user), nil
+}
+
+// CheckHandleAvailability tells whether a handle can be claimed
+func (h *UserServiceHandler) CheckHandleAvailability(ctx context.Context, req *proto.HandleRequest) (*proto.HandleAvailability, error) {
+	handle, err := h.userUseCase.CheckHandle(req.Handle)
+	resp := &proto.HandleAvailability{Handle: handle, Available: err == nil}
+
+	switch err {
+	case nil:
+	case usecase.ErrInvalidHandle, usecase.ErrHandleTaken:
+		resp.Reason = err.Error()
+	default:
+		return nil, status.Errorf(codes.Internal, "failed to check handle: %v", err)
+	}
+
+	return resp, nil
+}
+
+// UploadAvatar stores a new profile picture for the user the token was issued to
+func (h *UserServiceHandler) UploadAvatar(ctx context.Context, req *proto.AvatarUploadRequest) (*proto.UserProfile, error) {
+	caller, err := h.selfUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.avatarUseCase.Upload(caller.ID, req.Image)
+	if err != nil {
+		switch err {
+		case usecase.ErrUserNotFound:
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		case usecase.ErrInvalidAvatar:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to upload avatar: %v", err)
+		}
+	}
+
+	return convertUserToProfile(user), nil
+}
+
+// GetAvatarImage serves an avatar image for the gateway's media route.
+// Avatar keys are unique per upload, so the content never changes.
+func (h *UserServiceHandler) GetAvatarImage(ctx context.Context, req *proto.AvatarImageRequest) (*proto.AvatarImage, error) {
+	data, err := h.avatarUseCase.Open(req.Key)
+	if err != nil {
+		if err == usecase.ErrAvatarNotFound {
+			return nil, status.Errorf(codes.NotFound, "avatar not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to read avatar: %v", err)
+	}
+
+	return &proto.AvatarImage{MimeType: "image/jpeg", Data: data}, nil
+}
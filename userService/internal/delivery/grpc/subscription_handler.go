@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/userService/internal/domain"
+	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
+	"github.com/facelessEmptiness/user_service/userService/proto"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetEntitlements returns the limits and features of the user's current plan
+func (h *UserServiceHandler) GetEntitlements(ctx context.Context, req *proto.UserID) (*proto.Entitlements, error) {
+	entitlements, sub, err := h.userUseCase.GetEntitlements(req.Id)
+	if err != nil {
+		if err == usecase.ErrUserNotFound {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get entitlements: %v", err)
+	}
+
+	return convertEntitlementsToProto(req.Id, entitlements, sub), nil
+}
+
+// GetCurrentEntitlements returns the entitlements of the bearer token's user,
+// so other services can authorize a request without parsing the token
+func (h *UserServiceHandler) GetCurrentEntitlements(ctx context.Context, req *proto.CurrentUserRequest) (*proto.Entitlements, error) {
+	user, claims, err := h.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireScope(claims, domain.ScopeCatalogRead); err != nil {
+		return nil, err
+	}
+
+	return h.GetEntitlements(ctx, &proto.UserID{Id: user.ID})
+}
+
+// ChangeUserPlan attaches a subscription plan to a user (admin only)
+func (h *UserServiceHandler) ChangeUserPlan(ctx context.Context, req *proto.ChangePlanRequest) (*proto.Entitlements, error) {
+	admin, err := h.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endsAt *time.Time
+	if req.EndsAt > 0 {
+		t := time.Unix(req.EndsAt, 0)
+		endsAt = &t
+	}
+
+	user, err := h.userUseCase.ChangePlan(req.UserId, req.Plan, endsAt, admin.ID)
+	if err != nil {
+		switch err {
+		case usecase.ErrUserNotFound:
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		case usecase.ErrInvalidPlan:
+			return nil, status.Errorf(codes.InvalidArgument, "plan must be free, premium or family and end in the future")
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to change plan: %v", err)
+		}
+	}
+
+	entitlements := domain.EntitlementsFor(user.Subscription.EffectivePlan(time.Now()))
+	return convertEntitlementsToProto(user.ID, entitlements, user.Subscription), nil
+}
+
+// Helper function to convert domain.Entitlements to pb.Entitlements
+func convertEntitlementsToProto(userID string, e domain.Entitlements, sub domain.Subscription) *proto.Entitlements {
+	var startedAt, endsAt int64
+	if sub.StartedAt != nil {
+		startedAt = sub.StartedAt.Unix()
+	}
+	if sub.EndsAt != nil {
+		endsAt = sub.EndsAt.Unix()
+	}
+
+	return &proto.Entitlements{
+		UserId:               userID,
+		Plan:                 e.Plan,
+		PlanStartedAt:        startedAt,
+		PlanEndsAt:           endsAt,
+		MaxPlaylists:         e.MaxPlaylists,
+		MaxTracksPerPlaylist: e.MaxTracksPerPlaylist,
+		MaxAudioQuality:      e.MaxAudioQuality,
+		AdFree:               e.AdFree,
+		OfflineDownloads:     e.OfflineDownloads,
+		MaxHouseholdMembers:  e.MaxHouseholdMembers,
+	}
+}
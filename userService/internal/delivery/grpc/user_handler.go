@@ -2,28 +2,44 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"github.com/facelessEmptiness/user_service/userService/internal/domain"
 	"github.com/facelessEmptiness/user_service/userService/internal/usecase"
 	"github.com/facelessEmptiness/user_service/userService/proto"
 	"time"
 
-	"github.com/golang-jwt/jwt/v4"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type UserServiceHandler struct {
 	proto.UnimplementedUserServiceServer
-	userUseCase *usecase.UserUseCase
-	jwtSecret   []byte
-	tokenExp    time.Duration
+	userUseCase          *usecase.UserUseCase
+	exportUseCase        *usecase.ExportUseCase
+	avatarUseCase        *usecase.AvatarUseCase
+	followUseCase        *usecase.FollowUseCase
+	householdUseCase     *usecase.HouseholdUseCase
+	oauthUseCase         *usecase.OAuthUseCase
+	externalLoginUseCase *usecase.ExternalLoginUseCase
+	impersonationUseCase *usecase.ImpersonationUseCase
+	jwtSecret            []byte
+	tokenExp             time.Duration
+	impersonationExp     time.Duration
 }
 
-func NewUserServiceHandler(userUseCase *usecase.UserUseCase, jwtSecret string, tokenExp time.Duration) *UserServiceHandler {
+func NewUserServiceHandler(userUseCase *usecase.UserUseCase, exportUseCase *usecase.ExportUseCase, avatarUseCase *usecase.AvatarUseCase, followUseCase *usecase.FollowUseCase, householdUseCase *usecase.HouseholdUseCase, oauthUseCase *usecase.OAuthUseCase, externalLoginUseCase *usecase.ExternalLoginUseCase, impersonationUseCase *usecase.ImpersonationUseCase, jwtSecret string, tokenExp, impersonationExp time.Duration) *UserServiceHandler {
 	return &UserServiceHandler{
-		userUseCase: userUseCase,
-		jwtSecret:   []byte(jwtSecret),
-		tokenExp:    tokenExp,
+		userUseCase:          userUseCase,
+		exportUseCase:        exportUseCase,
+		avatarUseCase:        avatarUseCase,
+		followUseCase:        followUseCase,
+		householdUseCase:     householdUseCase,
+		oauthUseCase:         oauthUseCase,
+		externalLoginUseCase: externalLoginUseCase,
+		impersonationUseCase: impersonationUseCase,
+		jwtSecret:            []byte(jwtSecret),
+		tokenExp:             tokenExp,
+		impersonationExp:     impersonationExp,
 	}
 }
 
@@ -37,6 +53,10 @@ func (h *UserServiceHandler) RegisterUser(ctx context.Context, req *proto.UserRe
 
 	id, err := h.userUseCase.Register(user)
 	if err != nil {
+		var policyErr *usecase.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return nil, passwordPolicyStatus(policyErr)
+		}
 		if err == usecase.ErrEmailAlreadyExists {
 			return nil, status.Errorf(codes.AlreadyExists, "user with this email already exists")
 		}
@@ -56,25 +76,7 @@ func (h *UserServiceHandler) AuthenticateUser(ctx context.Context, req *proto.Au
 		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials")
 	}
 
-	// Generate JWT token
-	expiresAt := time.Now().Add(h.tokenExp)
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"exp":     expiresAt.Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(h.jwtSecret)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate token")
-	}
-
-	return &proto.AuthResponse{
-		Token:     tokenString,
-		UserId:    user.ID,
-		ExpiresAt: expiresAt.Unix(),
-	}, nil
+	return h.issueToken(user)
 }
 
 // GetUserProfile retrieves user profile by ID
@@ -103,39 +105,63 @@ func (h *UserServiceHandler) GetUserByEmail(ctx context.Context, req *proto.Emai
 	return convertUserToProfile(user), nil
 }
 
-// UpdateUserProfile updates user information
+// UpdateUserProfile updates the information of the user the token was issued
+// to. The email is among the fields, and external logins are linked by email,
+// so only the user themselves may change it.
 func (h *UserServiceHandler) UpdateUserProfile(ctx context.Context, req *proto.UpdateRequest) (*proto.UserResponse, error) {
-	// First get existing user to preserve password
-	existingUser, err := h.userUseCase.GetByID(req.Id)
+	// The existing user preserves the password and fields not covered by the request
+	existingUser, err := h.selfUser(ctx, req.Id)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "user not found")
+		return nil, err
 	}
 
 	// Update fields
-	user := &domain.User{
-		ID:       req.Id,
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: existingUser.Password, // Preserve password
-	}
-
-	if err := h.userUseCase.Update(user); err != nil {
-		if err == usecase.ErrEmailAlreadyExists {
+	user := *existingUser
+	user.Name = req.Name
+	user.Email = req.Email
+	user.DisplayName = req.DisplayName
+	user.Handle = req.Handle
+	user.Bio = req.Bio
+	user.Country = req.Country
+	user.Locale = req.Locale
+
+	if err := h.userUseCase.Update(&user); err != nil {
+		var policyErr *usecase.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return nil, passwordPolicyStatus(policyErr)
+		}
+		switch err {
+		case usecase.ErrEmailAlreadyExists:
 			return nil, status.Errorf(codes.AlreadyExists, "email already in use")
+		case usecase.ErrHandleTaken:
+			return nil, status.Errorf(codes.AlreadyExists, "handle already in use")
+		case usecase.ErrInvalidHandle, usecase.ErrInvalidCountry, usecase.ErrInvalidLocale, usecase.ErrBioTooLong:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
 		}
-		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
 	}
 
 	return &proto.UserResponse{
-		Id:      req.Id,
+		Id:      user.ID,
 		Message: "user updated successfully",
 	}, nil
 }
 
 // ChangePassword handles password changes
 func (h *UserServiceHandler) ChangePassword(ctx context.Context, req *proto.PasswordChangeRequest) (*proto.StatusResponse, error) {
-	err := h.userUseCase.ChangePassword(req.Id, req.CurrentPassword, req.NewPassword)
+	// Impersonation tokens are refused here as well, see firstPartyUser
+	user, err := h.selfUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.userUseCase.ChangePassword(user.ID, req.CurrentPassword, req.NewPassword)
 	if err != nil {
+		var policyErr *usecase.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return nil, passwordPolicyStatus(policyErr)
+		}
 		switch err {
 		case usecase.ErrUserNotFound:
 			return nil, status.Errorf(codes.NotFound, "user not found")
@@ -154,7 +180,12 @@ func (h *UserServiceHandler) ChangePassword(ctx context.Context, req *proto.Pass
 
 // DeleteUser handles user deletion
 func (h *UserServiceHandler) DeleteUser(ctx context.Context, req *proto.UserID) (*proto.StatusResponse, error) {
-	err := h.userUseCase.Delete(req.Id)
+	user, err := h.selfUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.userUseCase.Delete(user.ID)
 	if err != nil {
 		if err == usecase.ErrUserNotFound {
 			return nil, status.Errorf(codes.NotFound, "user not found")
@@ -200,11 +231,24 @@ func convertUserToProfile(user *domain.User) *proto.UserProfile {
 		updatedAt = user.UpdatedAt.Unix()
 	}
 
-	return &proto.UserProfile{
-		Id:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+	profile := &proto.UserProfile{
+		Id:             user.ID,
+		Name:           user.Name,
+		Email:          user.Email,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		DisplayName:    user.DisplayName,
+		Handle:         user.Handle,
+		Bio:            user.Bio,
+		Country:        user.Country,
+		Locale:         user.Locale,
+		FollowersCount: user.FollowersCount,
+		FollowingCount: user.FollowingCount,
+	}
+	if user.Avatar != nil {
+		profile.AvatarUrl = user.Avatar.URL
+		profile.AvatarThumbnails = user.Avatar.Thumbnails
 	}
+
+	return profile
 }
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+)
+
+// Audit actions
+const (
+	AuditActionImpersonationStarted = "impersonation.started"
+	AuditActionImpersonatedCall     = "impersonation.call"
+)
+
+// AuditRecord notes an action taken on a user's account by someone other than
+// the user. Records are never updated or removed, not even with the account.
+type AuditRecord struct {
+	ID        string     `bson:"_id,omitempty" json:"id"`
+	Action    string     `bson:"action" json:"action"`
+	ActorID   string     `bson:"actor_id" json:"actor_id"`     // Admin who acted
+	SubjectID string     `bson:"subject_id" json:"subject_id"` // User whose account was acted on
+	SessionID string     `bson:"session_id,omitempty" json:"session_id,omitempty"`
+	Method    string     `bson:"method,omitempty" json:"method,omitempty"` // Full gRPC method name of the call
+	Reason    string     `bson:"reason,omitempty" json:"reason,omitempty"`
+	CreatedAt *time.Time `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+)
+
+// UserDeletion is a tombstone left behind when a user is deleted. It is kept
+// until the data the user owned in other services has been cleaned up.
+type UserDeletion struct {
+	UserID             string     `bson:"_id" json:"user_id"`
+	DeletedAt          *time.Time `bson:"deleted_at" json:"deleted_at"`
+	PlaylistsCleanedAt *time.Time `bson:"playlists_cleaned_at,omitempty" json:"playlists_cleaned_at,omitempty"`
+	Attempts           int        `bson:"attempts" json:"attempts"`
+	LastError          string     `bson:"last_error,omitempty" json:"last_error,omitempty"`
+}
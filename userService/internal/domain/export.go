@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+)
+
+// Data export statuses
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// DataExport represents an asynchronous export of everything stored about a user
+type DataExport struct {
+	ID          string     `bson:"_id,omitempty" json:"id"`
+	UserID      string     `bson:"user_id" json:"user_id"`
+	Status      string     `bson:"status" json:"status"`
+	Error       string     `bson:"error,omitempty" json:"error,omitempty"`
+	FilePath    string     `bson:"file_path,omitempty" json:"-"` // Archive location is internal
+	SizeBytes   int64      `bson:"size_bytes" json:"size_bytes"`
+	CreatedAt   *time.Time `bson:"created_at" json:"created_at"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
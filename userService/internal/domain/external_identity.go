@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+)
+
+// ExternalIdentity links an account at an external OpenID Connect provider to a user
+type ExternalIdentity struct {
+	ID          string     `bson:"_id,omitempty" json:"id"`
+	UserID      string     `bson:"user_id" json:"user_id"`
+	Provider    string     `bson:"provider" json:"provider"`
+	Subject     string     `bson:"subject" json:"subject"` // "sub" claim, stable per provider
+	Email       string     `bson:"email,omitempty" json:"email,omitempty"`
+	LinkedAt    *time.Time `bson:"linked_at" json:"linked_at"`
+	LastLoginAt *time.Time `bson:"last_login_at,omitempty" json:"last_login_at,omitempty"`
+}
+
+// ExternalLoginState is kept between redirecting the user to a provider and
+// the callback. Only the hash of the state parameter is stored.
+type ExternalLoginState struct {
+	StateHash    string     `bson:"_id" json:"-"`
+	Provider     string     `bson:"provider" json:"provider"`
+	Nonce        string     `bson:"nonce" json:"-"`
+	CodeVerifier string     `bson:"code_verifier" json:"-"`
+	LinkUserID   string     `bson:"link_user_id,omitempty" json:"link_user_id,omitempty"` // Set when linking to a signed-in user
+	ExpiresAt    *time.Time `bson:"expires_at" json:"expires_at"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+// Kinds of entities a user can follow
+const (
+	FollowTargetUser   = "user"
+	FollowTargetArtist = "artist"
+)
+
+// Follow is an edge of the follow graph: FollowerID follows the target
+type Follow struct {
+	ID         string     `bson:"_id,omitempty" json:"id"`
+	FollowerID string     `bson:"follower_id" json:"follower_id"`
+	TargetType string     `bson:"target_type" json:"target_type"`
+	TargetID   string     `bson:"target_id" json:"target_id"`
+	CreatedAt  *time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Block prevents BlockedID from following BlockerID
+type Block struct {
+	ID        string     `bson:"_id,omitempty" json:"id"`
+	BlockerID string     `bson:"blocker_id" json:"blocker_id"`
+	BlockedID string     `bson:"blocked_id" json:"blocked_id"`
+	CreatedAt *time.Time `bson:"created_at" json:"created_at"`
+}
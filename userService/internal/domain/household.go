@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+)
+
+// Household groups the members of a family plan under the owner who pays for it
+type Household struct {
+	ID        string     `bson:"_id,omitempty" json:"id"`
+	OwnerID   string     `bson:"owner_id" json:"owner_id"`
+	Name      string     `bson:"name" json:"name"`
+	MemberIDs []string   `bson:"member_ids" json:"member_ids"` // Includes the owner
+	CreatedAt *time.Time `bson:"created_at" json:"created_at"`
+}
+
+// HouseholdInvitation lets the holder of the token with the given email join a household
+type HouseholdInvitation struct {
+	ID          string     `bson:"_id,omitempty" json:"id"`
+	HouseholdID string     `bson:"household_id" json:"household_id"`
+	Email       string     `bson:"email" json:"email"`
+	TokenHash   string     `bson:"token_hash" json:"-"` // Only the SHA-256 of the token is stored
+	InvitedBy   string     `bson:"invited_by" json:"invited_by"`
+	ExpiresAt   *time.Time `bson:"expires_at" json:"expires_at"`
+	AcceptedAt  *time.Time `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+	AcceptedBy  string     `bson:"accepted_by,omitempty" json:"accepted_by,omitempty"`
+	CreatedAt   *time.Time `bson:"created_at" json:"created_at"`
+}
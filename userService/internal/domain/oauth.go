@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+)
+
+// OAuth scopes third-party apps can request
+const (
+	ScopeProfile        = "profile"
+	ScopeEmail          = "email"
+	ScopeCatalogRead    = "catalog:read"
+	ScopePlaylistsRead  = "playlists:read"
+	ScopePlaylistsWrite = "playlists:write"
+)
+
+// OAuthClient is a third-party application registered by a developer
+type OAuthClient struct {
+	ID           string     `bson:"_id" json:"client_id"`
+	Name         string     `bson:"name" json:"name"`
+	OwnerID      string     `bson:"owner_id" json:"owner_id"`
+	SecretHash   string     `bson:"secret_hash,omitempty" json:"-"` // Empty for public clients
+	RedirectURIs []string   `bson:"redirect_uris" json:"redirect_uris"`
+	Scopes       []string   `bson:"scopes" json:"scopes"` // Scopes the client may request
+	CreatedAt    *time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Public clients (mobile and single-page apps) cannot keep a secret and must use PKCE
+func (c *OAuthClient) Public() bool {
+	return c.SecretHash == ""
+}
+
+// OAuthAuthorizationCode is a short-lived, single-use code of the authorization code flow.
+// Only the hash of the code is stored.
+type OAuthAuthorizationCode struct {
+	CodeHash            string     `bson:"_id" json:"-"`
+	ClientID            string     `bson:"client_id" json:"client_id"`
+	UserID              string     `bson:"user_id" json:"user_id"`
+	RedirectURI         string     `bson:"redirect_uri" json:"redirect_uri"`
+	Scopes              []string   `bson:"scopes" json:"scopes"`
+	CodeChallenge       string     `bson:"code_challenge,omitempty" json:"-"`
+	CodeChallengeMethod string     `bson:"code_challenge_method,omitempty" json:"-"`
+	ExpiresAt           *time.Time `bson:"expires_at" json:"expires_at"`
+	UsedAt              *time.Time `bson:"used_at,omitempty" json:"used_at,omitempty"`
+}
+
+// OAuthConsent records the scopes a user has granted to a client
+type OAuthConsent struct {
+	UserID    string     `bson:"user_id" json:"user_id"`
+	ClientID  string     `bson:"client_id" json:"client_id"`
+	Scopes    []string   `bson:"scopes" json:"scopes"`
+	GrantedAt *time.Time `bson:"granted_at" json:"granted_at"`
+}
+
+// OAuthRefreshToken lets a client get new access tokens without the user.
+// Tokens are rotated on every use; only the hash is stored.
+type OAuthRefreshToken struct {
+	TokenHash string     `bson:"_id" json:"-"`
+	ClientID  string     `bson:"client_id" json:"client_id"`
+	UserID    string     `bson:"user_id" json:"user_id"`
+	Scopes    []string   `bson:"scopes" json:"scopes"`
+	CreatedAt *time.Time `bson:"created_at" json:"created_at"`
+	ExpiresAt *time.Time `bson:"expires_at" json:"expires_at"`
+	RevokedAt *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
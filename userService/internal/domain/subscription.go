@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"time"
+)
+
+// Subscription plans
+const (
+	PlanFree    = "free"
+	PlanPremium = "premium"
+	PlanFamily  = "family"
+)
+
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Subscription is the plan attached to a user. A plan whose EndsAt has
+// passed falls back to the free plan.
+type Subscription struct {
+	Plan      string     `bson:"plan" json:"plan"`
+	StartedAt *time.Time `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	EndsAt    *time.Time `bson:"ends_at,omitempty" json:"ends_at,omitempty"` // nil means no end date
+	ChangedBy string     `bson:"changed_by,omitempty" json:"changed_by,omitempty"`
+}
+
+// EffectivePlan returns the plan that applies at the given moment
+func (s Subscription) EffectivePlan(now time.Time) string {
+	if s.Plan == "" || (s.EndsAt != nil && !now.Before(*s.EndsAt)) {
+		return PlanFree
+	}
+	return s.Plan
+}
+
+// Entitlements are the limits and features granted by a plan.
+// A zero limit means unlimited.
+type Entitlements struct {
+	Plan                 string `json:"plan"`
+	MaxPlaylists         int64  `json:"max_playlists"`
+	MaxTracksPerPlaylist int64  `json:"max_tracks_per_playlist"`
+	MaxAudioQuality      string `json:"max_audio_quality"`
+	AdFree               bool   `json:"ad_free"`
+	OfflineDownloads     bool   `json:"offline_downloads"`
+	MaxHouseholdMembers  int64  `json:"max_household_members"`
+}
+
+var planEntitlements = map[string]Entitlements{
+	PlanFree: {
+		Plan:                 PlanFree,
+		MaxPlaylists:         10,
+		MaxTracksPerPlaylist: 100,
+		MaxAudioQuality:      AudioQualityNormal,
+	},
+	PlanPremium: {
+		Plan:             PlanPremium,
+		MaxAudioQuality:  AudioQualityLossless,
+		AdFree:           true,
+		OfflineDownloads: true,
+	},
+	PlanFamily: {
+		Plan:                PlanFamily,
+		MaxAudioQuality:     AudioQualityLossless,
+		AdFree:              true,
+		OfflineDownloads:    true,
+		MaxHouseholdMembers: 6,
+	},
+}
+
+// IsValidPlan reports whether plan is a known subscription plan
+func IsValidPlan(plan string) bool {
+	_, ok := planEntitlements[plan]
+	return ok
+}
+
+// EntitlementsFor returns the entitlements granted by plan
+func EntitlementsFor(plan string) Entitlements {
+	if e, ok := planEntitlements[plan]; ok {
+		return e
+	}
+	return planEntitlements[PlanFree]
+}
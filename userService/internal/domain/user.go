@@ -6,10 +6,54 @@ import (
 
 // User represents the user entity
 type User struct {
-	ID        string     `bson:"_id,omitempty" json:"id"`
-	Name      string     `bson:"name" json:"name"`
-	Email     string     `bson:"email" json:"email"`
-	Password  string     `bson:"password" json:"-"` // Password is not exposed in JSON responses
-	CreatedAt *time.Time `bson:"created_at" json:"created_at"`
-	UpdatedAt *time.Time `bson:"updated_at" json:"updated_at"`
+	ID              string       `bson:"_id,omitempty" json:"id"`
+	Name            string       `bson:"name" json:"name"`
+	Email           string       `bson:"email" json:"email"`
+	Password        string       `bson:"password" json:"-"`                    // Password is not exposed in JSON responses
+	PasswordHistory []string     `bson:"password_history,omitempty" json:"-"`  // Previous password hashes, newest first
+	Role            string       `bson:"role,omitempty" json:"role,omitempty"` // Empty means RoleUser
+	DisplayName     string       `bson:"display_name,omitempty" json:"display_name,omitempty"`
+	Handle          string       `bson:"handle,omitempty" json:"handle,omitempty"` // Unique, lowercase, without the leading @
+	Bio             string       `bson:"bio,omitempty" json:"bio,omitempty"`
+	Country         string       `bson:"country,omitempty" json:"country,omitempty"` // ISO 3166-1 alpha-2
+	Locale          string       `bson:"locale,omitempty" json:"locale,omitempty"`   // BCP 47 tag, e.g. "ru-KZ"
+	Avatar          *Avatar      `bson:"avatar,omitempty" json:"avatar,omitempty"`
+	Preferences     Preferences  `bson:"preferences" json:"preferences"`
+	Subscription    Subscription `bson:"subscription" json:"subscription"`
+	HouseholdID     string       `bson:"household_id,omitempty" json:"household_id,omitempty"`
+	IsChild         bool         `bson:"is_child,omitempty" json:"is_child,omitempty"`     // Managed child profile: no email, no password login
+	ManagedBy       string       `bson:"managed_by,omitempty" json:"managed_by,omitempty"` // Household owner managing a child profile
+	FollowersCount  int64        `bson:"followers_count" json:"followers_count"`           // Only changed through $inc, see FollowUseCase
+	FollowingCount  int64        `bson:"following_count" json:"following_count"`
+	CreatedAt       *time.Time   `bson:"created_at" json:"created_at"`
+	UpdatedAt       *time.Time   `bson:"updated_at" json:"updated_at"`
+}
+
+// Avatar describes an uploaded profile picture and its generated thumbnails
+type Avatar struct {
+	URL        string            `bson:"url" json:"url"`
+	Thumbnails map[string]string `bson:"thumbnails" json:"thumbnails"` // Size (e.g. "128") -> URL
+	Keys       []string          `bson:"keys" json:"-"`                // Blob keys, used to clean up replaced avatars
+	UpdatedAt  *time.Time        `bson:"updated_at" json:"updated_at"`
+}
+
+// Audio quality levels a listener can prefer
+const (
+	AudioQualityLow      = "low"
+	AudioQualityNormal   = "normal"
+	AudioQualityHigh     = "high"
+	AudioQualityLossless = "lossless"
+)
+
+// Preferences holds the listener's taste profile and playback settings
+type Preferences struct {
+	FavoriteGenres        []string `bson:"favorite_genres" json:"favorite_genres"`
+	PreferredLanguages    []string `bson:"preferred_languages" json:"preferred_languages"` // ISO 639-1 codes
+	ExplicitContentFilter bool     `bson:"explicit_content_filter" json:"explicit_content_filter"`
+	AudioQuality          string   `bson:"audio_quality,omitempty" json:"audio_quality,omitempty"`
+}
+
+// IsAdmin reports whether the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
 }
@@ -0,0 +1,54 @@
+// Package hasher hashes and verifies passwords. Every hash carries its
+// algorithm and parameters, so hashes made with older settings keep working
+// and can be upgraded when the user next logs in.
+package hasher
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrUnknownHashFormat = errors.New("unknown password hash format")
+
+// Hasher hashes new passwords and verifies them against any supported hash
+type Hasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether password matches hash
+	Verify(hash, password string) (bool, error)
+	// NeedsRehash reports whether hash was made with another algorithm or
+	// weaker parameters than the ones new hashes are made with
+	NeedsRehash(hash string) bool
+}
+
+// New returns a Hasher that creates argon2id hashes with params and still
+// accepts bcrypt hashes created before the switch
+func New(params Argon2idParams) Hasher {
+	return &multiHasher{argon: argon2idHasher{params: params}}
+}
+
+type multiHasher struct {
+	argon argon2idHasher
+}
+
+func (h *multiHasher) Hash(password string) (string, error) {
+	return h.argon.Hash(password)
+}
+
+func (h *multiHasher) Verify(hash, password string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		return h.argon.Verify(hash, password)
+	case isBcrypt(hash):
+		return verifyBcrypt(hash, password)
+	default:
+		return false, ErrUnknownHashFormat
+	}
+}
+
+func (h *multiHasher) NeedsRehash(hash string) bool {
+	params, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return params.weakerThan(h.argon.params)
+}
@@ -0,0 +1,166 @@
+package hasher
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams keep the tests fast; the format does not depend on the cost
+var testParams = Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func mustHash(t *testing.T, h Hasher, password string) string {
+	t.Helper()
+	hash, err := h.Hash(password)
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	return hash
+}
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	h := New(testParams)
+	hash := mustHash(t, h, "correct horse")
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("hash = %s, want the PHC format with the configured parameters", hash)
+	}
+	if other := mustHash(t, h, "correct horse"); other == hash {
+		t.Error("two hashes of the same password are equal, the salt is not random")
+	}
+
+	cases := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{name: "same password", password: "correct horse", want: true},
+		{name: "other password", password: "correct horse!", want: false},
+		{name: "empty password", password: "", want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := h.Verify(hash, tc.password)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if ok != tc.want {
+				t.Errorf("Verify = %v, want %v", ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestBcryptVerify(t *testing.T) {
+	legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt: %v", err)
+	}
+	// Hashes written by other bcrypt implementations use the 2b and 2y prefixes
+	variants := map[string]string{
+		"2a": string(legacy),
+		"2b": "$2b$" + strings.TrimPrefix(string(legacy), "$2a$"),
+		"2y": "$2y$" + strings.TrimPrefix(string(legacy), "$2a$"),
+	}
+	h := New(testParams)
+
+	for name, hash := range variants {
+		t.Run(name, func(t *testing.T) {
+			if ok, err := h.Verify(hash, "correct horse"); err != nil || !ok {
+				t.Errorf("Verify(correct password) = %v, %v", ok, err)
+			}
+			if ok, err := h.Verify(hash, "wrong"); err != nil || ok {
+				t.Errorf("Verify(wrong password) = %v, %v", ok, err)
+			}
+			if !h.NeedsRehash(hash) {
+				t.Error("a bcrypt hash does not need a rehash")
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hash := mustHash(t, New(testParams), "correct horse")
+
+	with := func(change func(*Argon2idParams)) Argon2idParams {
+		p := testParams
+		change(&p)
+		return p
+	}
+	cases := []struct {
+		name    string
+		current Argon2idParams
+		want    bool
+	}{
+		{name: "same parameters", current: testParams, want: false},
+		{name: "more memory", current: with(func(p *Argon2idParams) { p.Memory *= 2 }), want: true},
+		{name: "more iterations", current: with(func(p *Argon2idParams) { p.Iterations++ }), want: true},
+		{name: "longer key", current: with(func(p *Argon2idParams) { p.KeyLength = 64 }), want: true},
+		// Weaker settings do not downgrade existing hashes
+		{name: "less memory", current: with(func(p *Argon2idParams) { p.Memory /= 2 }), want: false},
+		// Parallelism changes the speed, not the strength of the hash
+		{name: "other parallelism", current: with(func(p *Argon2idParams) { p.Parallelism = 4 }), want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := New(tc.current).NeedsRehash(hash); got != tc.want {
+				t.Errorf("NeedsRehash = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMalformedHashes(t *testing.T) {
+	valid := mustHash(t, New(testParams), "correct horse")
+	parts := strings.Split(valid, "$")
+	replace := func(i int, value string) string {
+		changed := append([]string(nil), parts...)
+		changed[i] = value
+		return strings.Join(changed, "$")
+	}
+
+	cases := []struct {
+		name string
+		hash string
+	}{
+		{name: "empty", hash: ""},
+		{name: "plain text", hash: "correct horse"},
+		{name: "unknown algorithm", hash: "$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5"},
+		{name: "missing key", hash: strings.Join(parts[:5], "$")},
+		{name: "other version", hash: replace(2, "v=16")},
+		{name: "broken parameters", hash: replace(3, "m=1024,t=1")},
+		{name: "zero iterations", hash: replace(3, "m=1024,t=0,p=1")},
+		{name: "zero parallelism", hash: replace(3, "m=1024,t=1,p=0")},
+		{name: "salt is not base64", hash: replace(4, "not base64!")},
+		{name: "empty salt", hash: replace(4, "")},
+		{name: "empty key", hash: replace(5, "")},
+	}
+	h := New(testParams)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := h.Verify(tc.hash, "correct horse")
+			if ok || !errors.Is(err, ErrUnknownHashFormat) {
+				t.Errorf("Verify = %v, %v, want ErrUnknownHashFormat", ok, err)
+			}
+			if !h.NeedsRehash(tc.hash) {
+				t.Error("NeedsRehash = false for a malformed hash")
+			}
+		})
+	}
+}
+
+func BenchmarkArgon2id(b *testing.B) {
+	h := New(DefaultArgon2idParams())
+	hash, err := h.Hash("correct horse")
+	if err != nil {
+		b.Fatalf("Hash: %v", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := h.Verify(hash, "correct horse"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
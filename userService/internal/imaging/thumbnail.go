@@ -0,0 +1,68 @@
+package imaging
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Thumbnail crops src to a centered square and scales it down to size×size.
+// Every destination pixel is the average of the source pixels it covers,
+// which keeps downscaled photos free of aliasing. Images smaller than size
+// are scaled up with the same routine (nearest neighbour in that case).
+func Thumbnail(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		b.Min.X+(b.Dx()-side)/2,
+		b.Min.Y+(b.Dy()-side)/2,
+	))
+
+	// Work on a plain RGBA copy so the inner loop can use Pix directly
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), src, crop.Min, draw.Src)
+
+	return resizeBox(square, size, size)
+}
+
+func resizeBox(src *image.RGBA, w, h int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < h; y++ {
+		y0 := y * sh / h
+		y1 := (y + 1) * sh / h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0 := x * sw / w
+			x1 := (x + 1) * sw / w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					bl += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+
+			d := dst.Pix[y*dst.Stride+x*4:]
+			d[0] = uint8(r / n)
+			d[1] = uint8(g / n)
+			d[2] = uint8(bl / n)
+			d[3] = uint8(a / n)
+		}
+	}
+	return dst
+}
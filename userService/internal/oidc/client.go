@@ -0,0 +1,183 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var ErrInvalidIDToken = errors.New("oidc: invalid ID token")
+
+// Config describes a relying party registration at one provider
+type Config struct {
+	Name         string // Our name for the provider, e.g. "google"
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string // "openid" is always requested
+}
+
+// Claims are the identity claims taken from a validated ID token
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Client is a relying party for a single provider
+type Client struct {
+	config     Config
+	discovery  *Discovery
+	keys       *keySet
+	httpClient *http.Client
+}
+
+// NewClient discovers the provider and returns a client for it
+func NewClient(ctx context.Context, config Config) (*Client, error) {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	discovery, err := Discover(ctx, httpClient, config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		config:     config,
+		discovery:  discovery,
+		keys:       newKeySet(httpClient, discovery.JWKSURI),
+		httpClient: httpClient,
+	}, nil
+}
+
+func (c *Client) Name() string { return c.config.Name }
+
+// AuthCodeURL returns the URL to send the user to. codeVerifier is kept by
+// the caller and passed to Exchange; only its S256 challenge leaves the server.
+func (c *Client) AuthCodeURL(state, nonce, codeVerifier string) string {
+	scopes := append([]string{"openid"}, c.config.Scopes...)
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.config.ClientID},
+		"redirect_uri":          {c.config.RedirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {S256Challenge(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(c.discovery.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return c.discovery.AuthorizationEndpoint + sep + query.Encode()
+}
+
+// Authenticate exchanges the code and returns the claims of the validated ID token
+func (c *Client) Authenticate(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error) {
+	rawIDToken, err := c.exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	return c.Verify(ctx, rawIDToken, nonce)
+}
+
+func (c *Client) exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.config.ClientID), url.QueryEscape(c.config.ClientSecret))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("oidc: decoding token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("oidc: token exchange failed: %s %s", body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("oidc: token response has no id_token")
+	}
+	return body.IDToken, nil
+}
+
+// Verify checks the signature, issuer, audience, expiry and nonce of an ID token
+func (c *Client) Verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		return c.keys.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	// exp is checked by the parser, but an ID token without it is not acceptable either
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("%w: missing exp", ErrInvalidIDToken)
+	}
+	if !claims.VerifyIssuer(c.discovery.Issuer, true) {
+		return nil, fmt.Errorf("%w: wrong issuer", ErrInvalidIDToken)
+	}
+	if !claims.VerifyAudience(c.config.ClientID, true) {
+		return nil, fmt.Errorf("%w: wrong audience", ErrInvalidIDToken)
+	}
+	if got, _ := claims["nonce"].(string); nonce == "" || got != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	result := &Claims{Subject: subject}
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = v
+	case string: // Some providers send it as a string
+		result.EmailVerified = v == "true"
+	}
+	return result, nil
+}
+
+// S256Challenge derives the PKCE code challenge of a verifier
+func S256Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
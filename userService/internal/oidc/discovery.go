@@ -0,0 +1,52 @@
+// Package oidc is a small OpenID Connect relying party: provider discovery,
+// the authorization code flow with PKCE and ID token validation.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Discovery is the subset of the provider metadata the relying party uses
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint,omitempty"`
+}
+
+// Discover fetches the provider metadata from the well-known location of issuer
+func Discover(ctx context.Context, httpClient *http.Client, issuer string) (*Discovery, error) {
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc discovery: %s returned %s", wellKnown, resp.Status)
+	}
+
+	var d Discovery
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+
+	// The issuer in the metadata must be exactly the one we asked for
+	if d.Issuer != issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer mismatch, expected %q got %q", issuer, d.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery: incomplete provider metadata for %s", issuer)
+	}
+	return &d, nil
+}
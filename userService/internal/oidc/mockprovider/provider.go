@@ -0,0 +1,231 @@
+// Package mockprovider is a minimal OpenID Connect provider for local
+// development and testing of the relying party. It signs every user in as
+// the configured identity without asking anything.
+package mockprovider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/facelessEmptiness/user_service/internal/oidc"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const keyID = "mock-key-1"
+
+// Identity is the user the provider signs in
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type pendingCode struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// Provider serves discovery, keys, authorization and token endpoints
+type Provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu        sync.Mutex
+	identity  Identity
+	overrides map[string]interface{}
+	codes     map[string]pendingCode
+}
+
+// New creates a provider reachable at issuer (e.g. "http://localhost:9400")
+// that accepts a single client
+func New(issuer, clientID, clientSecret string, identity Identity) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		issuer:       issuer,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		key:          key,
+		identity:     identity,
+		codes:        make(map[string]pendingCode),
+	}, nil
+}
+
+// SetIdentity changes the user signed in by subsequent authorizations
+func (p *Provider) SetIdentity(identity Identity) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.identity = identity
+}
+
+// SetClaimOverrides replaces claims such as aud, exp or nonce in the ID
+// tokens issued from now on, so tests can check that a relying party rejects
+// them. A nil value removes the claim.
+func (p *Provider) SetClaimOverrides(overrides map[string]interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.overrides = overrides
+}
+
+func (p *Provider) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	return mux
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.Discovery{
+		Issuer:                p.issuer,
+		AuthorizationEndpoint: p.issuer + "/authorize",
+		TokenEndpoint:         p.issuer + "/token",
+		JWKSURI:               p.issuer + "/jwks",
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{oidc.RSAKeyToJWK(keyID, &p.key.PublicKey)},
+	})
+}
+
+// authorize approves immediately and redirects back with a code
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != p.clientID || q.Get("response_type") != "code" {
+		http.Error(w, "invalid client or response type", http.StatusBadRequest)
+		return
+	}
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || !redirect.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if q.Get("code_challenge") != "" && q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "only S256 code challenges are supported", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	p.mu.Lock()
+	p.codes[code] = pendingCode{
+		clientID:      p.clientID,
+		redirectURI:   redirect.String(),
+		nonce:         q.Get("nonce"),
+		codeChallenge: q.Get("code_challenge"),
+		expiresAt:     time.Now().Add(time.Minute),
+	}
+	p.mu.Unlock()
+
+	query := redirect.Query()
+	query.Set("code", code)
+	if state := q.Get("state"); state != "" {
+		query.Set("state", state)
+	}
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	if clientID != p.clientID || clientSecret != p.clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	p.mu.Lock()
+	code, found := p.codes[r.PostFormValue("code")]
+	delete(p.codes, r.PostFormValue("code"))
+	identity := p.identity
+	overrides := p.overrides
+	p.mu.Unlock()
+
+	if !found || time.Now().After(code.expiresAt) || code.redirectURI != r.PostFormValue("redirect_uri") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if code.codeChallenge != "" && oidc.S256Challenge(r.PostFormValue("code_verifier")) != code.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.issuer,
+		"sub":            identity.Subject,
+		"aud":            p.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"email":          identity.Email,
+		"email_verified": identity.EmailVerified,
+		"name":           identity.Name,
+	}
+	if code.nonce != "" {
+		claims["nonce"] = code.nonce
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
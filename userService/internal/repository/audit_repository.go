@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/domain"
+)
+
+type AuditRepository interface {
+	Create(record *domain.AuditRecord) error
+	// ListBySubject returns the records about the user, newest first
+	ListBySubject(userID string, limit int64) ([]*domain.AuditRecord, error)
+}
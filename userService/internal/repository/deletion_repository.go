@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/domain"
+)
+
+type UserDeletionRepository interface {
+	Create(userID string) error
+	MarkPlaylistsCleaned(userID string) error
+	RecordFailure(userID string, reason string) error
+	ListPending(limit int64) ([]*domain.UserDeletion, error)
+	Delete(userID string) error
+}
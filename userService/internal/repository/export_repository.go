@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/domain"
+)
+
+type DataExportRepository interface {
+	Create(export *domain.DataExport) (string, error)
+	GetByID(id string) (*domain.DataExport, error)
+	Update(export *domain.DataExport) error
+	ListByUserID(userID string) ([]*domain.DataExport, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/domain"
+)
+
+type ExternalIdentityRepository interface {
+	Create(identity *domain.ExternalIdentity) (string, error)
+	GetByProviderSubject(provider, subject string) (*domain.ExternalIdentity, error)
+	ListByUserID(userID string) ([]*domain.ExternalIdentity, error)
+	TouchLogin(id string) error
+	Delete(id string) error
+	DeleteByUserID(userID string) error
+
+	CreateLoginState(state *domain.ExternalLoginState) error
+	// ConsumeLoginState returns and removes an unexpired state, at most once
+	ConsumeLoginState(stateHash string) (*domain.ExternalLoginState, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/domain"
+)
+
+type FollowRepository interface {
+	// Create stores the edge and reports whether it did not exist before
+	Create(follow *domain.Follow) (bool, error)
+	// Delete removes the edge and reports whether it existed
+	Delete(followerID, targetType, targetID string) (bool, error)
+	Exists(followerID, targetType, targetID string) (bool, error)
+	ListFollowers(targetType, targetID string, page, limit int64) ([]*domain.Follow, int64, error)
+	ListFollowing(followerID, targetType string, page, limit int64) ([]*domain.Follow, int64, error)
+	// CountFollowers and CountFollowing skip edges whose follower or
+	// followed user no longer exists
+	CountFollowers(targetType, targetID string) (int64, error)
+	CountFollowing(followerID string) (int64, error)
+	// DeleteUser removes every edge and block the user is part of and returns
+	// the users the user followed and the users who followed the user
+	DeleteUser(userID string) (followed, followers []string, err error)
+
+	// Block stores the block and reports whether it did not exist before
+	Block(blockerID, blockedID string) (bool, error)
+	Unblock(blockerID, blockedID string) error
+	IsBlocked(blockerID, blockedID string) (bool, error)
+}
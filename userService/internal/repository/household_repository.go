@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/domain"
+)
+
+type HouseholdRepository interface {
+	Create(household *domain.Household) (string, error)
+	GetByID(id string) (*domain.Household, error)
+	// AddMember adds the user only while the household has fewer than maxMembers members
+	AddMember(householdID, userID string, maxMembers int64) error
+	RemoveMember(householdID, userID string) error
+	Delete(id string) error
+
+	CreateInvitation(invitation *domain.HouseholdInvitation) (string, error)
+	GetInvitationByTokenHash(tokenHash string) (*domain.HouseholdInvitation, error)
+	MarkInvitationAccepted(id, userID string) error
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryUser struct {
+	user *domain.User
+	seq  int64 // Insertion order, breaks ties between equal created_at values
+}
+
+// memoryUserRepo is a concurrency-safe UserRepository kept in memory. It
+// behaves like the Mongo implementation, including which fields Create and
+// Update persist and the ErrUserNotFound returned for unknown users, so
+// it can replace Mongo in tests and local tools.
+type memoryUserRepo struct {
+	mu    sync.RWMutex
+	users map[string]*memoryUser
+	seq   int64
+}
+
+func NewMemoryUserRepository() UserRepository {
+	return &memoryUserRepo{users: make(map[string]*memoryUser)}
+}
+
+func (r *memoryUserRepo) Create(user *domain.User) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user.Handle != "" && r.handleTaken(user.Handle, "") {
+		return "", ErrDuplicateHandle
+	}
+
+	now := time.Now()
+	stored := &domain.User{
+		ID:        primitive.NewObjectID().Hex(),
+		Name:      user.Name,
+		Email:     user.Email,
+		Password:  user.Password,
+		Handle:    user.Handle,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+	if user.IsChild {
+		stored.IsChild = true
+		stored.ManagedBy = user.ManagedBy
+		stored.HouseholdID = user.HouseholdID
+		stored.Preferences = user.Preferences
+	}
+
+	stored, err := cloneUser(stored)
+	if err != nil {
+		return "", err
+	}
+	r.seq++
+	r.users[stored.ID] = &memoryUser{user: stored, seq: r.seq}
+	return stored.ID, nil
+}
+
+func (r *memoryUserRepo) GetByEmail(email string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Mongo returns the first match in natural (insertion) order
+	var found *memoryUser
+	for _, u := range r.users {
+		if u.user.Email == email && (found == nil || u.seq < found.seq) {
+			found = u
+		}
+	}
+	if found == nil {
+		return nil, ErrUserNotFound
+	}
+	return cloneUser(found.user)
+}
+
+func (r *memoryUserRepo) GetByID(id string) (*domain.User, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return cloneUser(u.user)
+}
+
+func (r *memoryUserRepo) GetByHandle(handle string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, u := range r.users {
+		if handle != "" && u.user.Handle == handle {
+			return cloneUser(u.user)
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (r *memoryUserRepo) Update(user *domain.User) error {
+	if _, err := primitive.ObjectIDFromHex(user.ID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.users[user.ID]
+	if !ok {
+		return nil // Like UpdateOne, updating a missing user is not an error
+	}
+	if user.Handle != "" && r.handleTaken(user.Handle, user.ID) {
+		return ErrDuplicateHandle
+	}
+
+	// The same fields the Mongo repository puts into $set
+	updated := *existing.user
+	now := time.Now()
+	updated.Name = user.Name
+	updated.Email = user.Email
+	updated.Password = user.Password
+	updated.PasswordHistory = user.PasswordHistory
+	updated.DisplayName = user.DisplayName
+	updated.Handle = user.Handle
+	updated.Bio = user.Bio
+	updated.Country = user.Country
+	updated.Locale = user.Locale
+	updated.Avatar = user.Avatar
+	updated.Preferences = user.Preferences
+	updated.Role = user.Role
+	updated.Subscription = user.Subscription
+	updated.HouseholdID = user.HouseholdID
+	updated.IsChild = user.IsChild
+	updated.ManagedBy = user.ManagedBy
+	updated.UpdatedAt = &now
+
+	stored, err := cloneUser(&updated)
+	if err != nil {
+		return err
+	}
+	existing.user = stored
+	return nil
+}
+
+func (r *memoryUserRepo) Delete(id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, id)
+	return nil
+}
+
+func (r *memoryUserRepo) List(page, limit int64) ([]*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]*memoryUser, 0, len(r.users))
+	for _, u := range r.users {
+		all = append(all, u)
+	}
+	// Newest first, like the created_at sort of the Mongo repository
+	sort.Slice(all, func(i, j int) bool {
+		a, b := all[i].user.CreatedAt, all[j].user.CreatedAt
+		if !a.Equal(*b) {
+			return a.After(*b)
+		}
+		return all[i].seq > all[j].seq
+	})
+
+	skip := (page - 1) * limit
+	if skip < 0 {
+		skip = 0
+	}
+	users := []*domain.User{}
+	for i := skip; i < int64(len(all)) && (limit <= 0 || i < skip+limit); i++ {
+		u, err := cloneUser(all[i].user)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (r *memoryUserRepo) IncrementFollowCounts(id string, followers, following int64) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if u, ok := r.users[id]; ok {
+		u.user.FollowersCount += followers
+		u.user.FollowingCount += following
+	}
+	return nil
+}
+
+func (r *memoryUserRepo) SetFollowCounts(id string, followers, following int64) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if u, ok := r.users[id]; ok {
+		u.user.FollowersCount = followers
+		u.user.FollowingCount = following
+	}
+	return nil
+}
+
+// handleTaken must be called with the lock held
+func (r *memoryUserRepo) handleTaken(handle, exceptID string) bool {
+	for id, u := range r.users {
+		if id != exceptID && u.user.Handle == handle {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneUser deep-copies a user through BSON, so callers never share memory
+// with the store and values round-trip exactly as they would through Mongo
+// (e.g. timestamps are truncated to milliseconds)
+func cloneUser(user *domain.User) (*domain.User, error) {
+	data, err := bson.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+	var clone domain.User
+	if err := bson.Unmarshal(data, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
@@ -0,0 +1,13 @@
+package repository_test
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"github.com/facelessEmptiness/user_service/internal/repository/repositorytest"
+	"testing"
+)
+
+func TestMemoryUserRepository(t *testing.T) {
+	repositorytest.UserRepositoryContract(t, func(t *testing.T) repository.UserRepository {
+		return repository.NewMemoryUserRepository()
+	})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoAuditRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoAuditRepository(db *mongo.Database) AuditRepository {
+	return &mongoAuditRepo{coll: db.Collection("audit_log")}
+}
+
+// EnsureAuditIndexes creates the index used to list the records about a user
+func EnsureAuditIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("audit_log").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "subject_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("subject_created_at"),
+	})
+	return err
+}
+
+func (r *mongoAuditRepo) Create(record *domain.AuditRecord) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	doc := bson.M{
+		"action":     record.Action,
+		"actor_id":   record.ActorID,
+		"subject_id": record.SubjectID,
+		"created_at": time.Now(),
+	}
+	if record.SessionID != "" {
+		doc["session_id"] = record.SessionID
+	}
+	if record.Method != "" {
+		doc["method"] = record.Method
+	}
+	if record.Reason != "" {
+		doc["reason"] = record.Reason
+	}
+
+	_, err := r.coll.InsertOne(ctx, doc)
+	return err
+}
+
+func (r *mongoAuditRepo) ListBySubject(userID string, limit int64) ([]*domain.AuditRecord, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetLimit(limit).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.coll.Find(ctx, bson.M{"subject_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []*domain.AuditRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoUserDeletionRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoUserDeletionRepository(db *mongo.Database) UserDeletionRepository {
+	return &mongoUserDeletionRepo{coll: db.Collection("user_deletions")}
+}
+
+// Create records the tombstone; recording the same user twice is a no-op
+func (r *mongoUserDeletionRepo) Create(userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"deleted_at": time.Now(),
+			"attempts":   0,
+		},
+	}
+
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": userID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *mongoUserDeletionRepo) MarkPlaylistsCleaned(userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set":   bson.M{"playlists_cleaned_at": time.Now()},
+		"$unset": bson.M{"last_error": ""},
+		"$inc":   bson.M{"attempts": 1},
+	}
+
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": userID}, update)
+	return err
+}
+
+// RecordFailure recreates the tombstone if it is missing, so a failed cleanup
+// is always retried by the reconciler
+func (r *mongoUserDeletionRepo) RecordFailure(userID string, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set":         bson.M{"last_error": reason},
+		"$inc":         bson.M{"attempts": 1},
+		"$setOnInsert": bson.M{"deleted_at": time.Now()},
+	}
+
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": userID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// Delete drops the tombstone of a user whose deletion did not go through
+func (r *mongoUserDeletionRepo) Delete(userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.coll.DeleteOne(ctx, bson.M{"_id": userID})
+	return err
+}
+
+func (r *mongoUserDeletionRepo) ListPending(limit int64) ([]*domain.UserDeletion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetLimit(limit).
+		SetSort(bson.D{{Key: "deleted_at", Value: 1}}) // Oldest first
+
+	cursor, err := r.coll.Find(ctx, bson.M{"playlists_cleaned_at": bson.M{"$exists": false}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deletions []*domain.UserDeletion
+	if err = cursor.All(ctx, &deletions); err != nil {
+		return nil, err
+	}
+
+	return deletions, nil
+}
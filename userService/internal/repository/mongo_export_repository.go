@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDataExportRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoDataExportRepository(db *mongo.Database) DataExportRepository {
+	return &mongoDataExportRepo{coll: db.Collection("data_exports")}
+}
+
+func (r *mongoDataExportRepo) Create(export *domain.DataExport) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	doc := bson.M{
+		"user_id":    export.UserID,
+		"status":     export.Status,
+		"size_bytes": export.SizeBytes,
+		"created_at": time.Now(),
+	}
+
+	result, err := r.coll.InsertOne(ctx, doc)
+	if err != nil {
+		return "", err
+	}
+
+	oid := result.InsertedID.(primitive.ObjectID).Hex()
+	return oid, nil
+}
+
+func (r *mongoDataExportRepo) GetByID(id string) (*domain.DataExport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var e domain.DataExport
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *mongoDataExportRepo) Update(export *domain.DataExport) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(export.ID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":       export.Status,
+			"error":        export.Error,
+			"file_path":    export.FilePath,
+			"size_bytes":   export.SizeBytes,
+			"completed_at": export.CompletedAt,
+		},
+	}
+
+	_, err = r.coll.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
+
+func (r *mongoDataExportRepo) ListByUserID(userID string) ([]*domain.DataExport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.coll.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var exports []*domain.DataExport
+	if err = cursor.All(ctx, &exports); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoExternalIdentityRepo struct {
+	identities *mongo.Collection
+	states     *mongo.Collection
+}
+
+func NewMongoExternalIdentityRepository(db *mongo.Database) ExternalIdentityRepository {
+	return &mongoExternalIdentityRepo{
+		identities: db.Collection("external_identities"),
+		states:     db.Collection("external_login_states"),
+	}
+}
+
+// EnsureExternalIdentityIndexes makes a provider account linkable to one user only
+func EnsureExternalIdentityIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("external_identities").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "subject", Value: 1}},
+			Options: options.Index().SetName("provider_subject_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("user_id"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("external_login_states").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (r *mongoExternalIdentityRepo) Create(identity *domain.ExternalIdentity) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	doc := bson.M{
+		"user_id":       identity.UserID,
+		"provider":      identity.Provider,
+		"subject":       identity.Subject,
+		"email":         identity.Email,
+		"linked_at":     time.Now(),
+		"last_login_at": time.Now(),
+	}
+
+	result, err := r.identities.InsertOne(ctx, doc)
+	if err != nil {
+		return "", err
+	}
+
+	oid := result.InsertedID.(primitive.ObjectID).Hex()
+	return oid, nil
+}
+
+func (r *mongoExternalIdentityRepo) GetByProviderSubject(provider, subject string) (*domain.ExternalIdentity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var identity domain.ExternalIdentity
+	err := r.identities.FindOne(ctx, bson.M{"provider": provider, "subject": subject}).Decode(&identity)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *mongoExternalIdentityRepo) ListByUserID(userID string) ([]*domain.ExternalIdentity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.identities.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var identities []*domain.ExternalIdentity
+	if err = cursor.All(ctx, &identities); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (r *mongoExternalIdentityRepo) TouchLogin(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.identities.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"last_login_at": time.Now()}})
+	return err
+}
+
+func (r *mongoExternalIdentityRepo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.identities.DeleteOne(ctx, bson.M{"_id": oid})
+	return err
+}
+
+func (r *mongoExternalIdentityRepo) DeleteByUserID(userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.identities.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+func (r *mongoExternalIdentityRepo) CreateLoginState(state *domain.ExternalLoginState) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.states.InsertOne(ctx, state)
+	return err
+}
+
+func (r *mongoExternalIdentityRepo) ConsumeLoginState(stateHash string) (*domain.ExternalLoginState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": stateHash, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var state domain.ExternalLoginState
+	if err := r.states.FindOneAndDelete(ctx, filter).Decode(&state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
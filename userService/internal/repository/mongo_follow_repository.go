@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoFollowRepo struct {
+	follows *mongo.Collection
+	blocks  *mongo.Collection
+}
+
+func NewMongoFollowRepository(db *mongo.Database) FollowRepository {
+	return &mongoFollowRepo{
+		follows: db.Collection("follows"),
+		blocks:  db.Collection("blocks"),
+	}
+}
+
+// EnsureFollowIndexes creates the indexes of the follow graph collections
+func EnsureFollowIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("follows").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}},
+			Options: options.Index().SetName("edge_unique").SetUnique(true),
+		},
+		{
+			// Followers of a target, newest first
+			Keys:    bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("followers"),
+		},
+		{
+			// Everything a user follows, newest first
+			Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("following"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("blocks").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "blocker_id", Value: 1}, {Key: "blocked_id", Value: 1}},
+		Options: options.Index().SetName("block_unique").SetUnique(true),
+	})
+	return err
+}
+
+func edgeFilter(followerID, targetType, targetID string) bson.M {
+	return bson.M{"follower_id": followerID, "target_type": targetType, "target_id": targetID}
+}
+
+func (r *mongoFollowRepo) Create(follow *domain.Follow) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$setOnInsert": bson.M{"created_at": time.Now()}}
+	result, err := r.follows.UpdateOne(ctx,
+		edgeFilter(follow.FollowerID, follow.TargetType, follow.TargetID),
+		update,
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		// Two concurrent upserts of the same edge: the other one won
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return result.UpsertedCount == 1, nil
+}
+
+func (r *mongoFollowRepo) Delete(followerID, targetType, targetID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.follows.DeleteOne(ctx, edgeFilter(followerID, targetType, targetID))
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount == 1, nil
+}
+
+func (r *mongoFollowRepo) Exists(followerID, targetType, targetID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n, err := r.follows.CountDocuments(ctx, edgeFilter(followerID, targetType, targetID), options.Count().SetLimit(1))
+	return n > 0, err
+}
+
+func (r *mongoFollowRepo) ListFollowers(targetType, targetID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	return r.list(bson.M{"target_type": targetType, "target_id": targetID}, page, limit)
+}
+
+func (r *mongoFollowRepo) ListFollowing(followerID, targetType string, page, limit int64) ([]*domain.Follow, int64, error) {
+	filter := bson.M{"follower_id": followerID}
+	if targetType != "" {
+		filter["target_type"] = targetType
+	}
+	return r.list(filter, page, limit)
+}
+
+func (r *mongoFollowRepo) list(filter bson.M, page, limit int64) ([]*domain.Follow, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := r.follows.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSkip((page - 1) * limit).
+		SetLimit(limit).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.follows.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var follows []*domain.Follow
+	if err = cursor.All(ctx, &follows); err != nil {
+		return nil, 0, err
+	}
+	return follows, total, nil
+}
+
+func (r *mongoFollowRepo) CountFollowers(targetType, targetID string) (int64, error) {
+	return r.countLive(bson.M{"target_type": targetType, "target_id": targetID})
+}
+
+func (r *mongoFollowRepo) CountFollowing(followerID string) (int64, error) {
+	return r.countLive(bson.M{"follower_id": followerID})
+}
+
+// countLive counts the edges matching filter whose follower and, for user
+// targets, followed user still exist in the users collection
+func (r *mongoFollowRepo) countLive(filter bson.M) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// User IDs are stored as hex strings, the users collection keys by ObjectID
+	toObjectID := func(field string) bson.M {
+		return bson.M{"$convert": bson.M{"input": field, "to": "objectId", "onError": nil, "onNull": nil}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":     "users",
+			"let":      bson.M{"id": toObjectID("$follower_id")},
+			"pipeline": bson.A{bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$id"}}}}, bson.M{"$project": bson.M{"_id": 1}}},
+			"as":       "follower",
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":     "users",
+			"let":      bson.M{"id": toObjectID("$target_id")},
+			"pipeline": bson.A{bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$id"}}}}, bson.M{"$project": bson.M{"_id": 1}}},
+			"as":       "target",
+		}}},
+		{{Key: "$match", Value: bson.M{
+			"follower.0": bson.M{"$exists": true},
+			"$or": bson.A{
+				bson.M{"target_type": bson.M{"$ne": domain.FollowTargetUser}},
+				bson.M{"target.0": bson.M{"$exists": true}},
+			},
+		}}},
+		{{Key: "$count", Value: "n"}},
+	}
+
+	cursor, err := r.follows.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		N int64 `bson:"n"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].N, nil
+}
+
+func (r *mongoFollowRepo) DeleteUser(userID string) ([]string, []string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	followedIDs, err := r.follows.Distinct(ctx, "target_id",
+		bson.M{"follower_id": userID, "target_type": domain.FollowTargetUser})
+	if err != nil {
+		return nil, nil, err
+	}
+	followerIDs, err := r.follows.Distinct(ctx, "follower_id",
+		bson.M{"target_type": domain.FollowTargetUser, "target_id": userID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = r.follows.DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"follower_id": userID},
+		bson.M{"target_type": domain.FollowTargetUser, "target_id": userID},
+	}})
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = r.blocks.DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"blocker_id": userID},
+		bson.M{"blocked_id": userID},
+	}})
+	if err != nil {
+		return nil, nil, err
+	}
+	return stringValues(followedIDs), stringValues(followerIDs), nil
+}
+
+func stringValues(values []interface{}) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func (r *mongoFollowRepo) Block(blockerID, blockedID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.blocks.UpdateOne(ctx,
+		bson.M{"blocker_id": blockerID, "blocked_id": blockedID},
+		bson.M{"$setOnInsert": bson.M{"created_at": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return result.UpsertedCount == 1, nil
+}
+
+func (r *mongoFollowRepo) Unblock(blockerID, blockedID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.blocks.DeleteOne(ctx, bson.M{"blocker_id": blockerID, "blocked_id": blockedID})
+	return err
+}
+
+func (r *mongoFollowRepo) IsBlocked(blockerID, blockedID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n, err := r.blocks.CountDocuments(ctx, bson.M{"blocker_id": blockerID, "blocked_id": blockedID}, options.Count().SetLimit(1))
+	return n > 0, err
+}
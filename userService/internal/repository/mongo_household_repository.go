@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrHouseholdFull is returned by AddMember when the membership cap is reached
+var ErrHouseholdFull = errors.New("household is full")
+
+type mongoHouseholdRepo struct {
+	households  *mongo.Collection
+	invitations *mongo.Collection
+}
+
+func NewMongoHouseholdRepository(db *mongo.Database) HouseholdRepository {
+	return &mongoHouseholdRepo{
+		households:  db.Collection("households"),
+		invitations: db.Collection("household_invitations"),
+	}
+}
+
+func (r *mongoHouseholdRepo) Create(household *domain.Household) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	doc := bson.M{
+		"owner_id":   household.OwnerID,
+		"name":       household.Name,
+		"member_ids": household.MemberIDs,
+		"created_at": time.Now(),
+	}
+
+	result, err := r.households.InsertOne(ctx, doc)
+	if err != nil {
+		return "", err
+	}
+
+	oid := result.InsertedID.(primitive.ObjectID).Hex()
+	return oid, nil
+}
+
+func (r *mongoHouseholdRepo) GetByID(id string) (*domain.Household, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var h domain.Household
+	if err := r.households.FindOne(ctx, bson.M{"_id": oid}).Decode(&h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *mongoHouseholdRepo) AddMember(householdID, userID string, maxMembers int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(householdID)
+	if err != nil {
+		return err
+	}
+
+	// The size check is part of the filter so concurrent joins cannot exceed the cap
+	filter := bson.M{
+		"_id": oid,
+		fmt.Sprintf("member_ids.%d", maxMembers-1): bson.M{"$exists": false},
+	}
+	result, err := r.households.UpdateOne(ctx, filter, bson.M{"$addToSet": bson.M{"member_ids": userID}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrHouseholdFull
+	}
+	return nil
+}
+
+func (r *mongoHouseholdRepo) RemoveMember(householdID, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(householdID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.households.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$pull": bson.M{"member_ids": userID}})
+	return err
+}
+
+func (r *mongoHouseholdRepo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = r.households.DeleteOne(ctx, bson.M{"_id": oid}); err != nil {
+		return err
+	}
+	_, err = r.invitations.DeleteMany(ctx, bson.M{"household_id": id})
+	return err
+}
+
+func (r *mongoHouseholdRepo) CreateInvitation(invitation *domain.HouseholdInvitation) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	doc := bson.M{
+		"household_id": invitation.HouseholdID,
+		"email":        invitation.Email,
+		"token_hash":   invitation.TokenHash,
+		"invited_by":   invitation.InvitedBy,
+		"expires_at":   invitation.ExpiresAt,
+		"created_at":   time.Now(),
+	}
+
+	result, err := r.invitations.InsertOne(ctx, doc)
+	if err != nil {
+		return "", err
+	}
+
+	oid := result.InsertedID.(primitive.ObjectID).Hex()
+	return oid, nil
+}
+
+func (r *mongoHouseholdRepo) GetInvitationByTokenHash(tokenHash string) (*domain.HouseholdInvitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var inv domain.HouseholdInvitation
+	if err := r.invitations.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (r *mongoHouseholdRepo) MarkInvitationAccepted(id, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"accepted_at": time.Now(),
+			"accepted_by": userID,
+		},
+	}
+
+	_, err = r.invitations.UpdateOne(ctx, bson.M{"_id": oid, "accepted_at": bson.M{"$exists": false}}, update)
+	return err
+}
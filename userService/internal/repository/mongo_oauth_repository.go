@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoOAuthRepo struct {
+	clients       *mongo.Collection
+	codes         *mongo.Collection
+	consents      *mongo.Collection
+	refreshTokens *mongo.Collection
+}
+
+func NewMongoOAuthRepository(db *mongo.Database) OAuthRepository {
+	return &mongoOAuthRepo{
+		clients:       db.Collection("oauth_clients"),
+		codes:         db.Collection("oauth_codes"),
+		consents:      db.Collection("oauth_consents"),
+		refreshTokens: db.Collection("oauth_refresh_tokens"),
+	}
+}
+
+// EnsureOAuthIndexes creates the indexes the OAuth repository relies on
+func EnsureOAuthIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := db.Collection("oauth_consents").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "client_id", Value: 1}},
+		Options: options.Index().SetName("user_client_unique").SetUnique(true),
+	}); err != nil {
+		return err
+	}
+
+	// Expired codes and refresh tokens are removed by MongoDB
+	for _, name := range []string{"oauth_codes", "oauth_refresh_tokens"} {
+		if _, err := db.Collection(name).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+		}); err != nil {
+			return err
+		}
+	}
+
+	_, err := db.Collection("oauth_refresh_tokens").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "client_id", Value: 1}},
+		Options: options.Index().SetName("user_client"),
+	})
+	return err
+}
+
+func (r *mongoOAuthRepo) CreateClient(client *domain.OAuthClient) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	client.CreatedAt = &now
+
+	_, err := r.clients.InsertOne(ctx, client)
+	return err
+}
+
+func (r *mongoOAuthRepo) GetClient(clientID string) (*domain.OAuthClient, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var c domain.OAuthClient
+	if err := r.clients.FindOne(ctx, bson.M{"_id": clientID}).Decode(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *mongoOAuthRepo) CreateCode(code *domain.OAuthAuthorizationCode) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.codes.InsertOne(ctx, code)
+	return err
+}
+
+func (r *mongoOAuthRepo) ConsumeCode(codeHash string) (*domain.OAuthAuthorizationCode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":        codeHash,
+		"used_at":    bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{"used_at": now}}
+
+	var c domain.OAuthAuthorizationCode
+	err := r.codes.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&c)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// SaveConsent stores the consent, replacing the scopes granted before
+func (r *mongoOAuthRepo) SaveConsent(consent *domain.OAuthConsent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	consent.GrantedAt = &now
+
+	filter := bson.M{"user_id": consent.UserID, "client_id": consent.ClientID}
+	update := bson.M{"$set": bson.M{
+		"scopes":     consent.Scopes,
+		"granted_at": now,
+	}}
+
+	_, err := r.consents.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *mongoOAuthRepo) GetConsent(userID, clientID string) (*domain.OAuthConsent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var c domain.OAuthConsent
+	if err := r.consents.FindOne(ctx, bson.M{"user_id": userID, "client_id": clientID}).Decode(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *mongoOAuthRepo) ListConsents(userID string) ([]*domain.OAuthConsent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "granted_at", Value: -1}})
+
+	cursor, err := r.consents.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var consents []*domain.OAuthConsent
+	if err = cursor.All(ctx, &consents); err != nil {
+		return nil, err
+	}
+
+	return consents, nil
+}
+
+func (r *mongoOAuthRepo) DeleteConsent(userID, clientID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.consents.DeleteOne(ctx, bson.M{"user_id": userID, "client_id": clientID})
+	return err
+}
+
+func (r *mongoOAuthRepo) CreateRefreshToken(token *domain.OAuthRefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	token.CreatedAt = &now
+
+	_, err := r.refreshTokens.InsertOne(ctx, token)
+	return err
+}
+
+func (r *mongoOAuthRepo) GetRefreshToken(tokenHash string) (*domain.OAuthRefreshToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var t domain.OAuthRefreshToken
+	if err := r.refreshTokens.FindOne(ctx, bson.M{"_id": tokenHash}).Decode(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *mongoOAuthRepo) RevokeRefreshToken(tokenHash string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": tokenHash, "revoked_at": bson.M{"$exists": false}}
+	result, err := r.refreshTokens.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *mongoOAuthRepo) RevokeRefreshTokens(userID, clientID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id":    userID,
+		"client_id":  clientID,
+		"revoked_at": bson.M{"$exists": false},
+	}
+	_, err := r.refreshTokens.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	return err
+}
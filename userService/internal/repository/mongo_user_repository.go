@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/facelessEmptiness/user_service/internal/domain"
+	"strings"
 
 	"time"
 
@@ -20,6 +22,22 @@ func NewMongoUserRepository(db *mongo.Database) UserRepository {
 	return &mongoUserRepo{coll: db.Collection("users")}
 }
 
+// EnsureUserIndexes creates the indexes the users collection relies on
+func EnsureUserIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("users").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "handle", Value: 1}},
+		// Users without a handle must not collide with each other
+		Options: options.Index().
+			SetName("handle_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"handle": bson.M{"$type": "string"}}),
+	})
+	return err
+}
+
 func (r *mongoUserRepo) Create(user *domain.User) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -31,10 +49,19 @@ func (r *mongoUserRepo) Create(user *domain.User) (string, error) {
 		"created_at": time.Now(),
 		"updated_at": time.Now(),
 	}
+	if user.Handle != "" {
+		doc["handle"] = user.Handle
+	}
+	if user.IsChild {
+		doc["is_child"] = true
+		doc["managed_by"] = user.ManagedBy
+		doc["household_id"] = user.HouseholdID
+		doc["preferences"] = user.Preferences
+	}
 
 	result, err := r.coll.InsertOne(ctx, doc)
 	if err != nil {
-		return "", err
+		return "", handleConflict(err)
 	}
 
 	oid := result.InsertedID.(primitive.ObjectID).Hex()
@@ -47,7 +74,18 @@ func (r *mongoUserRepo) GetByEmail(email string) (*domain.User, error) {
 
 	var u domain.User
 	if err := r.coll.FindOne(ctx, bson.M{"email": email}).Decode(&u); err != nil {
-		return nil, err
+		return nil, userNotFound(err)
+	}
+	return &u, nil
+}
+
+func (r *mongoUserRepo) GetByHandle(handle string) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var u domain.User
+	if err := r.coll.FindOne(ctx, bson.M{"handle": handle}).Decode(&u); err != nil {
+		return nil, userNotFound(err)
 	}
 	return &u, nil
 }
@@ -63,7 +101,7 @@ func (r *mongoUserRepo) GetByID(id string) (*domain.User, error) {
 
 	var u domain.User
 	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&u); err != nil {
-		return nil, err
+		return nil, userNotFound(err)
 	}
 	return &u, nil
 }
@@ -77,13 +115,30 @@ func (r *mongoUserRepo) Update(user *domain.User) error {
 		return err
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"name":       user.Name,
-			"email":      user.Email,
-			"password":   user.Password,
-			"updated_at": time.Now(),
-		},
+	set := bson.M{
+		"name":             user.Name,
+		"email":            user.Email,
+		"password":         user.Password,
+		"password_history": user.PasswordHistory,
+		"display_name":     user.DisplayName,
+		"bio":              user.Bio,
+		"country":          user.Country,
+		"locale":           user.Locale,
+		"avatar":           user.Avatar,
+		"preferences":      user.Preferences,
+		"role":             user.Role,
+		"subscription":     user.Subscription,
+		"household_id":     user.HouseholdID,
+		"is_child":         user.IsChild,
+		"managed_by":       user.ManagedBy,
+		"updated_at":       time.Now(),
+	}
+	update := bson.M{"$set": set}
+	// An empty handle is removed rather than stored, so the unique index ignores it
+	if user.Handle != "" {
+		set["handle"] = user.Handle
+	} else {
+		update["$unset"] = bson.M{"handle": ""}
 	}
 
 	_, err = r.coll.UpdateOne(
@@ -91,6 +146,24 @@ func (r *mongoUserRepo) Update(user *domain.User) error {
 		bson.M{"_id": oid},
 		update,
 	)
+	return handleConflict(err)
+}
+
+// userNotFound reports a lookup that matched no user as ErrUserNotFound
+func userNotFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// handleConflict reports a handle_unique violation as ErrDuplicateHandle, so
+// a handle claimed concurrently after the availability check is not an
+// internal error
+func handleConflict(err error) error {
+	if mongo.IsDuplicateKeyError(err) && strings.Contains(err.Error(), "handle_unique") {
+		return ErrDuplicateHandle
+	}
 	return err
 }
 
@@ -133,3 +206,43 @@ func (r *mongoUserRepo) List(page, limit int64) ([]*domain.User, error) {
 
 	return users, nil
 }
+
+func (r *mongoUserRepo) IncrementFollowCounts(id string, followers, following int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$inc": bson.M{
+			"followers_count": followers,
+			"following_count": following,
+		},
+	}
+
+	_, err = r.coll.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
+
+func (r *mongoUserRepo) SetFollowCounts(id string, followers, following int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"followers_count": followers,
+			"following_count": following,
+		},
+	}
+
+	_, err = r.coll.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
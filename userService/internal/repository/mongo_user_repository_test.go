@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"github.com/facelessEmptiness/user_service/internal/repository/repositorytest"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestMongoUserRepository runs the contract against a real MongoDB, e.g.
+// MONGO_TEST_URI=mongodb://localhost:27017 go test ./internal/repository/...
+// Every subtest gets its own database, which is dropped afterwards.
+func TestMongoUserRepository(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Fatalf("ping: %v", err)
+	}
+
+	repositorytest.UserRepositoryContract(t, func(t *testing.T) repository.UserRepository {
+		db := client.Database("user_service_test_" + primitive.NewObjectID().Hex())
+		t.Cleanup(func() {
+			if err := db.Drop(context.Background()); err != nil {
+				t.Logf("drop %s: %v", db.Name(), err)
+			}
+		})
+		if err := repository.EnsureUserIndexes(db); err != nil {
+			t.Fatalf("EnsureUserIndexes: %v", err)
+		}
+		return repository.NewMongoUserRepository(db)
+	})
+}
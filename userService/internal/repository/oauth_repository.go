@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/facelessEmptiness/user_service/internal/domain"
+)
+
+type OAuthRepository interface {
+	CreateClient(client *domain.OAuthClient) error
+	GetClient(clientID string) (*domain.OAuthClient, error)
+
+	CreateCode(code *domain.OAuthAuthorizationCode) error
+	// ConsumeCode marks an unused, unexpired code as used and returns it.
+	// Concurrent calls for the same code succeed at most once.
+	ConsumeCode(codeHash string) (*domain.OAuthAuthorizationCode, error)
+
+	SaveConsent(consent *domain.OAuthConsent) error
+	GetConsent(userID, clientID string) (*domain.OAuthConsent, error)
+	ListConsents(userID string) ([]*domain.OAuthConsent, error)
+	DeleteConsent(userID, clientID string) error
+
+	CreateRefreshToken(token *domain.OAuthRefreshToken) error
+	GetRefreshToken(tokenHash string) (*domain.OAuthRefreshToken, error)
+	// RevokeRefreshToken reports whether this call revoked the token, so a
+	// token can only be exchanged once
+	RevokeRefreshToken(tokenHash string) (bool, error)
+	RevokeRefreshTokens(userID, clientID string) error
+}
@@ -0,0 +1,324 @@
+// Package repositorytest holds contract tests shared by all implementations
+// of the repository interfaces
+package repositorytest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserRepositoryContract runs the behaviour every UserRepository must have.
+// newRepo is called once per subtest and must return an empty repository.
+func UserRepositoryContract(t *testing.T, newRepo func(t *testing.T) repository.UserRepository) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{Name: "Alice", Email: "alice@example.com", Password: "hash", Handle: "alice"})
+
+		byID, err := repo.GetByID(id)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if byID.ID != id || byID.Name != "Alice" || byID.Email != "alice@example.com" || byID.Password != "hash" {
+			t.Errorf("GetByID returned %+v", byID)
+		}
+		if byID.CreatedAt == nil || byID.UpdatedAt == nil {
+			t.Errorf("timestamps not set: %+v", byID)
+		}
+
+		byEmail, err := repo.GetByEmail("alice@example.com")
+		if err != nil || byEmail.ID != id {
+			t.Errorf("GetByEmail = %+v, %v", byEmail, err)
+		}
+		byHandle, err := repo.GetByHandle("alice")
+		if err != nil || byHandle.ID != id {
+			t.Errorf("GetByHandle = %+v, %v", byHandle, err)
+		}
+	})
+
+	t.Run("CreateIgnoresServerManagedFields", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{
+			Name:           "Bob",
+			Email:          "bob@example.com",
+			Role:           domain.RoleAdmin,
+			FollowersCount: 42,
+		})
+
+		u := mustGet(t, repo, id)
+		if u.Role != "" || u.FollowersCount != 0 {
+			t.Errorf("Create stored role %q and %d followers", u.Role, u.FollowersCount)
+		}
+	})
+
+	t.Run("CreateChildProfile", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{
+			Name:        "Kid",
+			IsChild:     true,
+			ManagedBy:   "owner",
+			HouseholdID: "household",
+			Preferences: domain.Preferences{ExplicitContentFilter: true},
+		})
+
+		u := mustGet(t, repo, id)
+		if !u.IsChild || u.ManagedBy != "owner" || u.HouseholdID != "household" || !u.Preferences.ExplicitContentFilter {
+			t.Errorf("child profile stored as %+v", u)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.GetByID(primitive.NewObjectID().Hex()); !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("GetByID of unknown id = %v, want %v", err, repository.ErrUserNotFound)
+		}
+		if _, err := repo.GetByID("not-an-object-id"); err == nil {
+			t.Error("GetByID of invalid id: expected an error")
+		}
+		if _, err := repo.GetByEmail("nobody@example.com"); !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("GetByEmail of unknown email = %v, want %v", err, repository.ErrUserNotFound)
+		}
+		if _, err := repo.GetByHandle("nobody"); !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("GetByHandle of unknown handle = %v, want %v", err, repository.ErrUserNotFound)
+		}
+	})
+
+	t.Run("ReturnedUsersAreCopies", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{Name: "Carol", Email: "carol@example.com"})
+
+		u := mustGet(t, repo, id)
+		u.Name = "Mallory"
+		if got := mustGet(t, repo, id); got.Name != "Carol" {
+			t.Errorf("modifying a returned user changed the stored name to %q", got.Name)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{Name: "Dave", Email: "dave@example.com", Password: "old"})
+
+		u := mustGet(t, repo, id)
+		created := *u.UpdatedAt
+		time.Sleep(5 * time.Millisecond)
+
+		u.Name = "David"
+		u.Email = "david@example.com"
+		u.Password = "new"
+		u.PasswordHistory = []string{"old"}
+		u.DisplayName = "D"
+		u.Handle = "david"
+		u.Bio = "Hi"
+		u.Country = "KZ"
+		u.Locale = "ru-KZ"
+		u.Role = domain.RoleAdmin
+		u.Preferences.FavoriteGenres = []string{"jazz"}
+		if err := repo.Update(u); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		got := mustGet(t, repo, id)
+		if got.Name != "David" || got.Email != "david@example.com" || got.Password != "new" ||
+			got.DisplayName != "D" || got.Handle != "david" || got.Bio != "Hi" ||
+			got.Country != "KZ" || got.Locale != "ru-KZ" || got.Role != domain.RoleAdmin {
+			t.Errorf("Update stored %+v", got)
+		}
+		if len(got.PasswordHistory) != 1 || got.PasswordHistory[0] != "old" {
+			t.Errorf("PasswordHistory = %v", got.PasswordHistory)
+		}
+		if len(got.Preferences.FavoriteGenres) != 1 || got.Preferences.FavoriteGenres[0] != "jazz" {
+			t.Errorf("Preferences.FavoriteGenres = %v", got.Preferences.FavoriteGenres)
+		}
+		if !got.UpdatedAt.After(created) {
+			t.Errorf("UpdatedAt %v not after %v", got.UpdatedAt, created)
+		}
+		if !got.CreatedAt.Equal(*u.CreatedAt) {
+			t.Errorf("CreatedAt changed from %v to %v", u.CreatedAt, got.CreatedAt)
+		}
+	})
+
+	t.Run("UpdateKeepsFollowCounts", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{Name: "Erin", Email: "erin@example.com"})
+		if err := repo.SetFollowCounts(id, 3, 4); err != nil {
+			t.Fatalf("SetFollowCounts: %v", err)
+		}
+
+		u := mustGet(t, repo, id)
+		u.FollowersCount = 100
+		if err := repo.Update(u); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if got := mustGet(t, repo, id); got.FollowersCount != 3 || got.FollowingCount != 4 {
+			t.Errorf("counts = %d/%d, want 3/4", got.FollowersCount, got.FollowingCount)
+		}
+	})
+
+	t.Run("UpdateRemovesEmptyHandle", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{Name: "Frank", Email: "frank@example.com", Handle: "frank"})
+
+		u := mustGet(t, repo, id)
+		u.Handle = ""
+		if err := repo.Update(u); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if _, err := repo.GetByHandle("frank"); err == nil {
+			t.Error("old handle still resolves after it was cleared")
+		}
+
+		// Users without a handle must not collide with each other
+		mustCreate(t, repo, &domain.User{Name: "Grace", Email: "grace@example.com"})
+		other := mustGet(t, repo, mustCreate(t, repo, &domain.User{Name: "Heidi", Email: "heidi@example.com"}))
+		if err := repo.Update(other); err != nil {
+			t.Errorf("Update of a second user without a handle: %v", err)
+		}
+	})
+
+	t.Run("HandleIsUnique", func(t *testing.T) {
+		repo := newRepo(t)
+		mustCreate(t, repo, &domain.User{Name: "Ivan", Email: "ivan@example.com", Handle: "ivan"})
+
+		if _, err := repo.Create(&domain.User{Name: "Ivan 2", Email: "ivan2@example.com", Handle: "ivan"}); err == nil {
+			t.Error("Create with a taken handle: expected an error")
+		}
+
+		u := mustGet(t, repo, mustCreate(t, repo, &domain.User{Name: "Judy", Email: "judy@example.com"}))
+		u.Handle = "ivan"
+		if err := repo.Update(u); err == nil {
+			t.Error("Update to a taken handle: expected an error")
+		}
+	})
+
+	t.Run("UpdateUnknownUser", func(t *testing.T) {
+		repo := newRepo(t)
+		if err := repo.Update(&domain.User{ID: primitive.NewObjectID().Hex(), Name: "Ghost"}); err != nil {
+			t.Errorf("Update of unknown user: %v", err)
+		}
+		if err := repo.Update(&domain.User{ID: "not-an-object-id"}); err == nil {
+			t.Error("Update with invalid id: expected an error")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{Name: "Ken", Email: "ken@example.com"})
+
+		if err := repo.Delete(id); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.GetByID(id); err == nil {
+			t.Error("deleted user is still returned")
+		}
+		if err := repo.Delete(id); err != nil {
+			t.Errorf("second Delete: %v", err)
+		}
+		if err := repo.Delete("not-an-object-id"); err == nil {
+			t.Error("Delete with invalid id: expected an error")
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		repo := newRepo(t)
+		var ids []string
+		for i := 0; i < 5; i++ {
+			ids = append(ids, mustCreate(t, repo, &domain.User{
+				Name:  fmt.Sprintf("User %d", i),
+				Email: fmt.Sprintf("user%d@example.com", i),
+			}))
+			time.Sleep(2 * time.Millisecond) // Distinct created_at values
+		}
+
+		cases := []struct {
+			page, limit int64
+			want        []string
+		}{
+			{1, 2, []string{ids[4], ids[3]}},
+			{2, 2, []string{ids[2], ids[1]}},
+			{3, 2, []string{ids[0]}},
+			{4, 2, nil},
+			{1, 10, []string{ids[4], ids[3], ids[2], ids[1], ids[0]}},
+		}
+		for _, tc := range cases {
+			users, err := repo.List(tc.page, tc.limit)
+			if err != nil {
+				t.Fatalf("List(%d, %d): %v", tc.page, tc.limit, err)
+			}
+			var got []string
+			for _, u := range users {
+				got = append(got, u.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("List(%d, %d) = %v, want %v", tc.page, tc.limit, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("FollowCounts", func(t *testing.T) {
+		repo := newRepo(t)
+		id := mustCreate(t, repo, &domain.User{Name: "Leo", Email: "leo@example.com"})
+
+		const workers = 20
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := repo.IncrementFollowCounts(id, 1, 2); err != nil {
+					t.Errorf("IncrementFollowCounts: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if u := mustGet(t, repo, id); u.FollowersCount != workers || u.FollowingCount != 2*workers {
+			t.Errorf("counts = %d/%d, want %d/%d", u.FollowersCount, u.FollowingCount, workers, 2*workers)
+		}
+
+		if err := repo.IncrementFollowCounts(id, -1, 0); err != nil {
+			t.Fatalf("IncrementFollowCounts: %v", err)
+		}
+		if u := mustGet(t, repo, id); u.FollowersCount != workers-1 {
+			t.Errorf("FollowersCount = %d after decrement, want %d", u.FollowersCount, workers-1)
+		}
+
+		if err := repo.SetFollowCounts(id, 7, 8); err != nil {
+			t.Fatalf("SetFollowCounts: %v", err)
+		}
+		if u := mustGet(t, repo, id); u.FollowersCount != 7 || u.FollowingCount != 8 {
+			t.Errorf("counts = %d/%d after SetFollowCounts, want 7/8", u.FollowersCount, u.FollowingCount)
+		}
+
+		unknown := primitive.NewObjectID().Hex()
+		if err := repo.IncrementFollowCounts(unknown, 1, 1); err != nil {
+			t.Errorf("IncrementFollowCounts of unknown user: %v", err)
+		}
+		if err := repo.SetFollowCounts(unknown, 1, 1); err != nil {
+			t.Errorf("SetFollowCounts of unknown user: %v", err)
+		}
+	})
+}
+
+func mustCreate(t *testing.T, repo repository.UserRepository, user *domain.User) string {
+	t.Helper()
+	id, err := repo.Create(user)
+	if err != nil {
+		t.Fatalf("Create(%s): %v", user.Email, err)
+	}
+	return id
+}
+
+func mustGet(t *testing.T, repo repository.UserRepository, id string) *domain.User {
+	t.Helper()
+	u, err := repo.GetByID(id)
+	if err != nil {
+		t.Fatalf("GetByID(%s): %v", id, err)
+	}
+	return u
+}
@@ -1,14 +1,26 @@
 package repository
 
 import (
+	"errors"
 	"github.com/facelessEmptiness/user_service/internal/domain"
 )
 
+var (
+	// ErrUserNotFound is returned by the lookups of a UserRepository when no user matches
+	ErrUserNotFound = errors.New("user not found")
+	// ErrDuplicateHandle is returned by Create and Update when another user
+	// already has the handle
+	ErrDuplicateHandle = errors.New("handle already exists")
+)
+
 type UserRepository interface {
 	Create(user *domain.User) (string, error)
 	GetByEmail(email string) (*domain.User, error)
 	GetByID(id string) (*domain.User, error)
+	GetByHandle(handle string) (*domain.User, error)
 	Update(user *domain.User) error
 	Delete(id string) error
 	List(page, limit int64) ([]*domain.User, error)
+	IncrementFollowCounts(id string, followers, following int64) error
+	SetFollowCounts(id string, followers, following int64) error
 }
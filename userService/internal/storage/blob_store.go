@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps binary objects (avatars, export archives, ...) under string keys.
+// Keys are slash-separated relative paths such as "avatars/<user id>/128.jpg".
+type BlobStore interface {
+	Put(key string, r io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	// URL returns the public URL the blob can be downloaded from
+	URL(key string) string
+}
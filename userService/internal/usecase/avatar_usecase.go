@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/imaging"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"github.com/facelessEmptiness/user_service/internal/storage"
+	"image"
+	_ "image/gif" // Register decoders accepted for avatars
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAvatar  = errors.New("avatar must be a JPEG, PNG or GIF image between 64x64 and 4096x4096 pixels")
+	ErrAvatarNotFound = errors.New("avatar not found")
+)
+
+const (
+	maxAvatarBytes     = 3 << 20
+	minAvatarDimension = 64
+	maxAvatarDimension = 4096
+)
+
+// AvatarSizes are the square thumbnail sizes generated for every avatar
+var AvatarSizes = []int{64, 128, 256, 512}
+
+type AvatarUseCase struct {
+	repo  repository.UserRepository
+	store storage.BlobStore
+}
+
+func NewAvatarUseCase(r repository.UserRepository, store storage.BlobStore) *AvatarUseCase {
+	return &AvatarUseCase{repo: r, store: store}
+}
+
+// Upload validates the image, stores it together with its thumbnails and
+// attaches it to the user, replacing any previous avatar
+func (u *AvatarUseCase) Upload(userID string, data []byte) (*domain.User, error) {
+	user, err := u.repo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if len(data) == 0 || len(data) > maxAvatarBytes {
+		return nil, ErrInvalidAvatar
+	}
+	// Check the dimensions before decoding the full image
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil ||
+		cfg.Width < minAvatarDimension || cfg.Height < minAvatarDimension ||
+		cfg.Width > maxAvatarDimension || cfg.Height > maxAvatarDimension {
+		return nil, ErrInvalidAvatar
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidAvatar
+	}
+
+	now := time.Now()
+	prefix := fmt.Sprintf("avatars/%s/%d", userID, now.UnixNano())
+	avatar := &domain.Avatar{
+		Thumbnails: make(map[string]string, len(AvatarSizes)),
+		UpdatedAt:  &now,
+	}
+
+	// The largest thumbnail doubles as the avatar itself: re-encoding strips
+	// metadata such as EXIF location from the uploaded file
+	for _, size := range AvatarSizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, imaging.Thumbnail(img, size), &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s/%d.jpg", prefix, size)
+		if err := u.store.Put(key, &buf); err != nil {
+			u.deleteBlobs(avatar.Keys)
+			return nil, err
+		}
+		avatar.Keys = append(avatar.Keys, key)
+		avatar.Thumbnails[strconv.Itoa(size)] = u.store.URL(key)
+		avatar.URL = u.store.URL(key)
+	}
+
+	previous := user.Avatar
+	user.Avatar = avatar
+	if err := u.repo.Update(user); err != nil {
+		u.deleteBlobs(avatar.Keys)
+		return nil, err
+	}
+	if previous != nil {
+		u.deleteBlobs(previous.Keys)
+	}
+
+	return user, nil
+}
+
+// Open returns a stored avatar image by its blob key. Only keys below
+// "avatars/" are served, so the media route can't reach other blobs.
+func (u *AvatarUseCase) Open(key string) ([]byte, error) {
+	if !strings.HasPrefix(key, "avatars/") {
+		return nil, ErrAvatarNotFound
+	}
+	r, err := u.store.Get(key)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, ErrAvatarNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func (u *AvatarUseCase) deleteBlobs(keys []string) {
+	for _, key := range keys {
+		if err := u.store.Delete(key); err != nil {
+			log.Printf("failed to delete avatar blob %s: %v", key, err)
+		}
+	}
+}
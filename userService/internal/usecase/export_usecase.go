@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	ErrExportNotFound = errors.New("data export not found")
+	ErrExportNotReady = errors.New("data export is not ready yet")
+)
+
+// ExportSource contributes one section of a user's personal data archive.
+// Each source ends up as <Name()>.json inside the archive.
+type ExportSource interface {
+	Name() string
+	Collect(ctx context.Context, userID string) (interface{}, error)
+}
+
+type ExportUseCase struct {
+	users   repository.UserRepository
+	exports repository.DataExportRepository
+	sources []ExportSource
+	dir     string
+
+	// Background builds run under ctx and are tracked by builds so that
+	// Shutdown can interrupt them and wait until their outcome is saved
+	ctx    context.Context
+	stop   context.CancelFunc
+	builds sync.WaitGroup
+}
+
+// NewExportUseCase creates a use case that writes export archives into dir.
+// The user profile and the export history are always included; additional
+// sources (playlists, audit records, ...) are appended after them.
+func NewExportUseCase(users repository.UserRepository, exports repository.DataExportRepository, dir string, sources ...ExportSource) *ExportUseCase {
+	uc := &ExportUseCase{
+		users:   users,
+		exports: exports,
+		dir:     dir,
+	}
+	uc.ctx, uc.stop = context.WithCancel(context.Background())
+	uc.sources = append([]ExportSource{profileSource{users}, exportHistorySource{exports}}, sources...)
+	return uc
+}
+
+// RequestExport registers a new export for the user and builds it in the background
+func (u *ExportUseCase) RequestExport(userID string) (*domain.DataExport, error) {
+	if _, err := u.users.GetByID(userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	export := &domain.DataExport{
+		UserID: userID,
+		Status: domain.ExportStatusPending,
+	}
+	id, err := u.exports.Create(export)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	export.ID = id
+	export.CreatedAt = &now
+
+	u.builds.Add(1)
+	go func(export domain.DataExport) {
+		defer u.builds.Done()
+		u.build(u.ctx, export)
+	}(*export)
+
+	return export, nil
+}
+
+// Shutdown interrupts the exports still being built and waits until they are
+// recorded as failed, or until ctx expires
+func (u *ExportUseCase) Shutdown(ctx context.Context) error {
+	u.stop()
+
+	done := make(chan struct{})
+	go func() {
+		u.builds.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// GetExport returns the export if it belongs to the given user
+func (u *ExportUseCase) GetExport(userID, exportID string) (*domain.DataExport, error) {
+	export, err := u.exports.GetByID(exportID)
+	if err != nil || export.UserID != userID {
+		return nil, ErrExportNotFound
+	}
+	return export, nil
+}
+
+// OpenArchive opens the finished archive for reading and returns it with a
+// suggested file name. The caller must close the file.
+func (u *ExportUseCase) OpenArchive(userID, exportID string) (*os.File, string, error) {
+	export, err := u.GetExport(userID, exportID)
+	if err != nil {
+		return nil, "", err
+	}
+	if export.Status != domain.ExportStatusCompleted {
+		return nil, "", ErrExportNotReady
+	}
+
+	f, err := os.Open(export.FilePath)
+	if err != nil {
+		return nil, "", err
+	}
+	return f, filepath.Base(export.FilePath), nil
+}
+
+// build collects all sections, writes the archive and records the outcome.
+// When ctx is cancelled the export is recorded as failed.
+func (u *ExportUseCase) build(ctx context.Context, export domain.DataExport) {
+	export.Status = domain.ExportStatusRunning
+	if err := u.exports.Update(&export); err != nil {
+		log.Printf("data export %s: failed to mark as running: %v", export.ID, err)
+	}
+
+	path, size, err := u.writeArchive(ctx, export)
+
+	now := time.Now()
+	export.CompletedAt = &now
+	if err != nil {
+		log.Printf("data export %s failed: %v", export.ID, err)
+		export.Status = domain.ExportStatusFailed
+		export.Error = err.Error()
+	} else {
+		export.Status = domain.ExportStatusCompleted
+		export.FilePath = path
+		export.SizeBytes = size
+	}
+
+	if err := u.exports.Update(&export); err != nil {
+		log.Printf("data export %s: failed to save result: %v", export.ID, err)
+	}
+}
+
+func (u *ExportUseCase) writeArchive(ctx context.Context, export domain.DataExport) (string, int64, error) {
+	if err := os.MkdirAll(u.dir, 0o700); err != nil {
+		return "", 0, err
+	}
+
+	path := filepath.Join(u.dir, fmt.Sprintf("user-data-%s-%s.zip", export.UserID, export.ID))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if err := u.writeSections(ctx, zip.NewWriter(f), export); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", 0, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", 0, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", 0, err
+	}
+	return path, info.Size(), nil
+}
+
+func (u *ExportUseCase) writeSections(ctx context.Context, zw *zip.Writer, export domain.DataExport) error {
+	sections := make([]string, 0, len(u.sources))
+	for _, source := range u.sources {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("export interrupted: %w", err)
+		}
+		data, err := source.Collect(ctx, export.UserID)
+		if err != nil {
+			return fmt.Errorf("collect %s: %w", source.Name(), err)
+		}
+		name := source.Name() + ".json"
+		if err := writeJSON(zw, name, data); err != nil {
+			return err
+		}
+		sections = append(sections, name)
+	}
+
+	manifest := map[string]interface{}{
+		"export_id":    export.ID,
+		"user_id":      export.UserID,
+		"generated_at": time.Now().UTC(),
+		"files":        sections,
+	}
+	if err := writeJSON(zw, "manifest.json", manifest); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// profileSource exports the user document itself (the password hash is never included)
+type profileSource struct {
+	users repository.UserRepository
+}
+
+func (s profileSource) Name() string { return "profile" }
+
+func (s profileSource) Collect(ctx context.Context, userID string) (interface{}, error) {
+	return s.users.GetByID(userID)
+}
+
+// exportHistorySource exports the list of previous data export requests
+type exportHistorySource struct {
+	exports repository.DataExportRepository
+}
+
+func (s exportHistorySource) Name() string { return "data_exports" }
+
+func (s exportHistorySource) Collect(ctx context.Context, userID string) (interface{}, error) {
+	return s.exports.ListByUserID(userID)
+}
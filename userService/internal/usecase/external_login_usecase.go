@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/oidc"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownProvider         = errors.New("unknown identity provider")
+	ErrInvalidLoginState       = errors.New("login request is invalid or expired")
+	ErrExternalAuthFailed      = errors.New("authentication with the identity provider failed")
+	ErrIdentityLinkedElsewhere = errors.New("this external account is linked to another user")
+	ErrExternalEmailInUse      = errors.New("an account with this email already exists, sign in and link the provider instead")
+	ErrLastLoginMethod         = errors.New("cannot unlink the only way to sign in")
+)
+
+const externalLoginTTL = 10 * time.Minute
+
+// IdentityProvider is an external OpenID Connect provider, see oidc.Client
+type IdentityProvider interface {
+	Name() string
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	Authenticate(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Claims, error)
+}
+
+type ExternalLoginUseCase struct {
+	users      repository.UserRepository
+	identities repository.ExternalIdentityRepository
+	providers  map[string]IdentityProvider
+}
+
+func NewExternalLoginUseCase(users repository.UserRepository, identities repository.ExternalIdentityRepository, providers ...IdentityProvider) *ExternalLoginUseCase {
+	uc := &ExternalLoginUseCase{
+		users:      users,
+		identities: identities,
+		providers:  make(map[string]IdentityProvider, len(providers)),
+	}
+	for _, p := range providers {
+		uc.providers[p.Name()] = p
+	}
+	return uc
+}
+
+// Providers returns the names of the configured providers
+func (u *ExternalLoginUseCase) Providers() []string {
+	names := make([]string, 0, len(u.providers))
+	for name := range u.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BeginLogin returns the URL to send the user to. With linkUserID set the
+// external account is linked to that (signed-in) user instead of signing in.
+func (u *ExternalLoginUseCase) BeginLogin(providerName, linkUserID string) (string, error) {
+	provider, ok := u.providers[providerName]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	state, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := time.Now().Add(externalLoginTTL)
+	err = u.identities.CreateLoginState(&domain.ExternalLoginState{
+		StateHash:    hashSecretToken(state),
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		LinkUserID:   linkUserID,
+		ExpiresAt:    &expiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return provider.AuthCodeURL(state, nonce, verifier), nil
+}
+
+// CompleteLogin handles the callback from the provider and returns the
+// signed-in user, creating an account on the first login
+func (u *ExternalLoginUseCase) CompleteLogin(providerName, state, code string) (*domain.User, bool, error) {
+	provider, ok := u.providers[providerName]
+	if !ok {
+		return nil, false, ErrUnknownProvider
+	}
+
+	loginState, err := u.identities.ConsumeLoginState(hashSecretToken(state))
+	if err != nil || loginState.Provider != providerName {
+		return nil, false, ErrInvalidLoginState
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	claims, err := provider.Authenticate(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		log.Printf("external login with %s failed: %v", providerName, err)
+		return nil, false, ErrExternalAuthFailed
+	}
+
+	identity, err := u.identities.GetByProviderSubject(providerName, claims.Subject)
+	if err != nil {
+		identity = nil
+	}
+
+	if loginState.LinkUserID != "" {
+		user, err := u.link(identity, loginState.LinkUserID, providerName, claims)
+		return user, false, err
+	}
+
+	if identity != nil {
+		user, err := u.users.GetByID(identity.UserID)
+		if err == nil {
+			if err := u.identities.TouchLogin(identity.ID); err != nil {
+				log.Printf("external identity %s: failed to record login: %v", identity.ID, err)
+			}
+			return user, false, nil
+		}
+		// The user is gone; drop the stale link and treat this as a first login
+		if err := u.identities.Delete(identity.ID); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// An existing account is never linked by email alone: whoever controls the
+	// address at the provider would take over the account. The owner signs in
+	// and links the provider from their account instead.
+	if claims.Email != "" {
+		if _, err := u.users.GetByEmail(claims.Email); err == nil {
+			return nil, false, ErrExternalEmailInUse
+		}
+	}
+
+	user := &domain.User{
+		Name:  externalDisplayName(claims),
+		Email: claims.Email,
+	}
+	id, err := u.users.Create(user)
+	if err != nil {
+		return nil, false, err
+	}
+	user.ID = id
+
+	if _, err := u.identities.Create(&domain.ExternalIdentity{
+		UserID:   id,
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}); err != nil {
+		// Most likely a concurrent first login that won the race
+		u.users.Delete(id)
+		return nil, false, err
+	}
+
+	created, err := u.users.GetByID(id)
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
+
+// ListIdentities returns the external accounts linked to the user
+func (u *ExternalLoginUseCase) ListIdentities(userID string) ([]*domain.ExternalIdentity, error) {
+	return u.identities.ListByUserID(userID)
+}
+
+// Unlink removes the link to a provider unless it is the user's only way to sign in
+func (u *ExternalLoginUseCase) Unlink(userID, providerName string) error {
+	user, err := u.users.GetByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	identities, err := u.identities.ListByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	var target *domain.ExternalIdentity
+	for _, identity := range identities {
+		if identity.Provider == providerName {
+			target = identity
+		}
+	}
+	if target == nil {
+		return ErrUnknownProvider
+	}
+	if user.Password == "" && len(identities) == 1 {
+		return ErrLastLoginMethod
+	}
+	return u.identities.Delete(target.ID)
+}
+
+// Name implements ExportSource
+func (u *ExternalLoginUseCase) Name() string { return "external_identities" }
+
+// Collect implements ExportSource
+func (u *ExternalLoginUseCase) Collect(ctx context.Context, userID string) (interface{}, error) {
+	return u.identities.ListByUserID(userID)
+}
+
+func (u *ExternalLoginUseCase) link(identity *domain.ExternalIdentity, userID, providerName string, claims *oidc.Claims) (*domain.User, error) {
+	user, err := u.users.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if identity != nil {
+		if identity.UserID != userID {
+			return nil, ErrIdentityLinkedElsewhere
+		}
+		return user, nil
+	}
+
+	if _, err := u.identities.Create(&domain.ExternalIdentity{
+		UserID:   userID,
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func externalDisplayName(claims *oidc.Claims) string {
+	if name := strings.TrimSpace(claims.Name); name != "" {
+		return name
+	}
+	if local, _, ok := strings.Cut(claims.Email, "@"); ok && local != "" {
+		return local
+	}
+	return "listener"
+}
@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/oidc"
+	"github.com/facelessEmptiness/user_service/internal/oidc/mockprovider"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memIdentities is an in-memory ExternalIdentityRepository
+type memIdentities struct {
+	mu         sync.Mutex
+	identities map[string]*domain.ExternalIdentity
+	states     map[string]*domain.ExternalLoginState
+}
+
+func newMemIdentities() *memIdentities {
+	return &memIdentities{
+		identities: make(map[string]*domain.ExternalIdentity),
+		states:     make(map[string]*domain.ExternalLoginState),
+	}
+}
+
+func (m *memIdentities) Create(identity *domain.ExternalIdentity) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.identities {
+		if existing.Provider == identity.Provider && existing.Subject == identity.Subject {
+			return "", errors.New("duplicate identity")
+		}
+	}
+	stored := *identity
+	stored.ID = primitive.NewObjectID().Hex()
+	m.identities[stored.ID] = &stored
+	return stored.ID, nil
+}
+
+func (m *memIdentities) GetByProviderSubject(provider, subject string) (*domain.ExternalIdentity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, identity := range m.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			found := *identity
+			return &found, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *memIdentities) ListByUserID(userID string) ([]*domain.ExternalIdentity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var list []*domain.ExternalIdentity
+	for _, identity := range m.identities {
+		if identity.UserID == userID {
+			found := *identity
+			list = append(list, &found)
+		}
+	}
+	return list, nil
+}
+
+func (m *memIdentities) TouchLogin(id string) error { return nil }
+
+func (m *memIdentities) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.identities, id)
+	return nil
+}
+
+func (m *memIdentities) DeleteByUserID(userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, identity := range m.identities {
+		if identity.UserID == userID {
+			delete(m.identities, id)
+		}
+	}
+	return nil
+}
+
+func (m *memIdentities) CreateLoginState(state *domain.ExternalLoginState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *state
+	m.states[state.StateHash] = &stored
+	return nil
+}
+
+func (m *memIdentities) ConsumeLoginState(stateHash string) (*domain.ExternalLoginState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[stateHash]
+	delete(m.states, stateHash)
+	if !ok || time.Now().After(*state.ExpiresAt) {
+		return nil, mongo.ErrNoDocuments
+	}
+	return state, nil
+}
+
+// expireStates moves every pending login state into the past
+func (m *memIdentities) expireStates() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	past := time.Now().Add(-time.Second)
+	for _, state := range m.states {
+		state.ExpiresAt = &past
+	}
+}
+
+type externalLoginFixture struct {
+	uc         *ExternalLoginUseCase
+	users      repository.UserRepository
+	identities *memIdentities
+	provider   *mockprovider.Provider
+}
+
+// newExternalLoginFixture runs a mock provider named "mock" and a use case
+// whose relying party is registered there
+func newExternalLoginFixture(t *testing.T, identity mockprovider.Identity) *externalLoginFixture {
+	t.Helper()
+
+	var provider *mockprovider.Provider
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provider.Handler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := mockprovider.New(server.URL, "music-app", "secret", identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := oidc.NewClient(context.Background(), oidc.Config{
+		Name:         "mock",
+		Issuer:       server.URL,
+		ClientID:     "music-app",
+		ClientSecret: "secret",
+		RedirectURL:  "http://app.test/auth/mock/callback",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	f := &externalLoginFixture{
+		users:      repository.NewMemoryUserRepository(),
+		identities: newMemIdentities(),
+		provider:   provider,
+	}
+	f.uc = NewExternalLoginUseCase(f.users, f.identities, client)
+	return f
+}
+
+// authorize starts a login and lets the provider approve it, returning the
+// state and code the provider sends back to the callback
+func (f *externalLoginFixture) authorize(t *testing.T, linkUserID string) (state, code string) {
+	t.Helper()
+	authURL, err := f.uc.BeginLogin("mock", linkUserID)
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+
+	noRedirects := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := noRedirects.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: status %d, location %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	return callback.Query().Get("state"), callback.Query().Get("code")
+}
+
+func TestExternalLoginUseCaseFirstLoginCreatesAccount(t *testing.T) {
+	f := newExternalLoginFixture(t, mockprovider.Identity{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true, Name: "Alice"})
+
+	state, code := f.authorize(t, "")
+	user, created, err := f.uc.CompleteLogin("mock", state, code)
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if !created || user.Name != "Alice" || user.Email != "alice@example.com" {
+		t.Fatalf("CompleteLogin = %+v, created %v", user, created)
+	}
+
+	// The next login finds the account through the identity
+	state, code = f.authorize(t, "")
+	again, created, err := f.uc.CompleteLogin("mock", state, code)
+	if err != nil {
+		t.Fatalf("second CompleteLogin: %v", err)
+	}
+	if created || again.ID != user.ID {
+		t.Errorf("second login returned %s (created %v), want %s", again.ID, created, user.ID)
+	}
+}
+
+func TestExternalLoginUseCaseRejectsInvalidState(t *testing.T) {
+	cases := []struct {
+		name   string
+		tamper func(f *externalLoginFixture, state string) string
+	}{
+		{name: "unknown state", tamper: func(f *externalLoginFixture, state string) string { return state + "x" }},
+		{name: "empty state", tamper: func(f *externalLoginFixture, state string) string { return "" }},
+		{name: "expired state", tamper: func(f *externalLoginFixture, state string) string {
+			f.identities.expireStates()
+			return state
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newExternalLoginFixture(t, mockprovider.Identity{Subject: "sub-1", Email: "alice@example.com"})
+			state, code := f.authorize(t, "")
+			if _, _, err := f.uc.CompleteLogin("mock", tc.tamper(f, state), code); err != ErrInvalidLoginState {
+				t.Fatalf("CompleteLogin = %v, want %v", err, ErrInvalidLoginState)
+			}
+		})
+	}
+
+	t.Run("state is single use", func(t *testing.T) {
+		f := newExternalLoginFixture(t, mockprovider.Identity{Subject: "sub-1", Email: "alice@example.com"})
+		state, code := f.authorize(t, "")
+		if _, _, err := f.uc.CompleteLogin("mock", state, code); err != nil {
+			t.Fatalf("CompleteLogin: %v", err)
+		}
+		if _, _, err := f.uc.CompleteLogin("mock", state, code); err != ErrInvalidLoginState {
+			t.Fatalf("replayed CompleteLogin = %v, want %v", err, ErrInvalidLoginState)
+		}
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		f := newExternalLoginFixture(t, mockprovider.Identity{Subject: "sub-1"})
+		state, code := f.authorize(t, "")
+		if _, _, err := f.uc.CompleteLogin("other", state, code); err != ErrUnknownProvider {
+			t.Fatalf("CompleteLogin = %v, want %v", err, ErrUnknownProvider)
+		}
+	})
+}
+
+func TestExternalLoginUseCaseRejectsInvalidIDToken(t *testing.T) {
+	cases := []struct {
+		name      string
+		overrides map[string]interface{}
+	}{
+		{name: "nonce of another login", overrides: map[string]interface{}{"nonce": "replayed-nonce"}},
+		{name: "missing nonce", overrides: map[string]interface{}{"nonce": nil}},
+		{name: "issued for another client", overrides: map[string]interface{}{"aud": "another-app"}},
+		{name: "expired", overrides: map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()}},
+		{name: "missing exp", overrides: map[string]interface{}{"exp": nil}},
+		{name: "missing subject", overrides: map[string]interface{}{"sub": ""}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newExternalLoginFixture(t, mockprovider.Identity{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true})
+			f.provider.SetClaimOverrides(tc.overrides)
+
+			state, code := f.authorize(t, "")
+			if _, _, err := f.uc.CompleteLogin("mock", state, code); err != ErrExternalAuthFailed {
+				t.Fatalf("CompleteLogin = %v, want %v", err, ErrExternalAuthFailed)
+			}
+			if users, _ := f.users.List(1, 10); len(users) != 0 {
+				t.Errorf("an account was created from a rejected token: %+v", users[0])
+			}
+		})
+	}
+}
+
+func TestExternalLoginUseCaseLink(t *testing.T) {
+	f := newExternalLoginFixture(t, mockprovider.Identity{Subject: "sub-1", Email: "alice@elsewhere.example"})
+	aliceID, err := f.users.Create(&domain.User{Name: "Alice", Email: "alice@example.com", Password: "hash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, code := f.authorize(t, aliceID)
+	user, created, err := f.uc.CompleteLogin("mock", state, code)
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if created || user.ID != aliceID {
+		t.Fatalf("linked to %s (created %v), want %s", user.ID, created, aliceID)
+	}
+	identities, _ := f.identities.ListByUserID(aliceID)
+	if len(identities) != 1 || identities[0].Subject != "sub-1" {
+		t.Fatalf("identities of alice = %+v", identities)
+	}
+
+	// Signing in with the provider now reaches the linked account
+	state, code = f.authorize(t, "")
+	if user, _, err := f.uc.CompleteLogin("mock", state, code); err != nil || user.ID != aliceID {
+		t.Fatalf("login after linking = %v, %v", user, err)
+	}
+
+	// The same external account can't be linked to somebody else
+	bobID, err := f.users.Create(&domain.User{Name: "Bob", Email: "bob@example.com", Password: "hash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, code = f.authorize(t, bobID)
+	if _, _, err := f.uc.CompleteLogin("mock", state, code); err != ErrIdentityLinkedElsewhere {
+		t.Fatalf("linking to bob = %v, want %v", err, ErrIdentityLinkedElsewhere)
+	}
+}
+
+func TestExternalLoginUseCaseExistingEmail(t *testing.T) {
+	cases := []struct {
+		name     string
+		verified bool
+	}{
+		{name: "unverified email", verified: false},
+		{name: "verified email", verified: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newExternalLoginFixture(t, mockprovider.Identity{Subject: "sub-1", Email: "alice@example.com", EmailVerified: tc.verified})
+			aliceID, err := f.users.Create(&domain.User{Name: "Alice", Email: "alice@example.com", Password: "hash"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			state, code := f.authorize(t, "")
+			if _, _, err := f.uc.CompleteLogin("mock", state, code); err != ErrExternalEmailInUse {
+				t.Fatalf("CompleteLogin = %v, want %v", err, ErrExternalEmailInUse)
+			}
+			if identities, _ := f.identities.ListByUserID(aliceID); len(identities) != 0 {
+				t.Errorf("identity linked despite the error: %+v", identities)
+			}
+
+			// Signed in, Alice can link the provider herself
+			state, code = f.authorize(t, aliceID)
+			user, created, err := f.uc.CompleteLogin("mock", state, code)
+			if err != nil {
+				t.Fatalf("CompleteLogin after signing in: %v", err)
+			}
+			if created || user.ID != aliceID {
+				t.Errorf("CompleteLogin = %s (created %v), want %s", user.ID, created, aliceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"log"
+	"time"
+)
+
+var (
+	ErrInvalidFollowTarget = errors.New("invalid follow target")
+	ErrCannotFollowSelf    = errors.New("users cannot follow themselves")
+	ErrBlocked             = errors.New("following this user is not allowed")
+)
+
+type FollowUseCase struct {
+	users   repository.UserRepository
+	follows repository.FollowRepository
+}
+
+func NewFollowUseCase(users repository.UserRepository, follows repository.FollowRepository) *FollowUseCase {
+	return &FollowUseCase{users: users, follows: follows}
+}
+
+// Follow makes followerID follow the target. Following twice is a no-op.
+func (u *FollowUseCase) Follow(followerID, targetType, targetID string) error {
+	targetType, err := u.checkTarget(followerID, targetType, targetID)
+	if err != nil {
+		return err
+	}
+
+	if targetType == domain.FollowTargetUser {
+		// Blocks work in both directions: neither side may follow the other
+		if blocked, err := u.blockedEitherWay(followerID, targetID); err != nil {
+			return err
+		} else if blocked {
+			return ErrBlocked
+		}
+	}
+
+	created, err := u.follows.Create(&domain.Follow{
+		FollowerID: followerID,
+		TargetType: targetType,
+		TargetID:   targetID,
+	})
+	if err != nil {
+		return err
+	}
+	if created {
+		u.adjustCounters(followerID, targetType, targetID, 1)
+	}
+	return nil
+}
+
+// Unfollow removes the edge. Unfollowing a target that is not followed is a no-op.
+func (u *FollowUseCase) Unfollow(followerID, targetType, targetID string) error {
+	targetType, err := normalizeTargetType(targetType)
+	if err != nil {
+		return err
+	}
+	return u.removeEdge(followerID, targetType, targetID)
+}
+
+// IsFollowing reports whether followerID follows the target
+func (u *FollowUseCase) IsFollowing(followerID, targetType, targetID string) (bool, error) {
+	targetType, err := normalizeTargetType(targetType)
+	if err != nil {
+		return false, err
+	}
+	return u.follows.Exists(followerID, targetType, targetID)
+}
+
+// ListFollowers returns a page of edges pointing at the target and the total number of followers
+func (u *FollowUseCase) ListFollowers(targetType, targetID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	targetType, err := normalizeTargetType(targetType)
+	if err != nil {
+		return nil, 0, err
+	}
+	page, limit = normalizePage(page, limit)
+	return u.follows.ListFollowers(targetType, targetID, page, limit)
+}
+
+// ListFollowing returns a page of what the user follows; an empty targetType lists users and artists
+func (u *FollowUseCase) ListFollowing(userID, targetType string, page, limit int64) ([]*domain.Follow, int64, error) {
+	if targetType != "" {
+		var err error
+		if targetType, err = normalizeTargetType(targetType); err != nil {
+			return nil, 0, err
+		}
+	}
+	page, limit = normalizePage(page, limit)
+	return u.follows.ListFollowing(userID, targetType, page, limit)
+}
+
+// Block stops blockedID from following blockerID and removes existing edges between them
+func (u *FollowUseCase) Block(blockerID, blockedID string) error {
+	if blockerID == blockedID {
+		return ErrInvalidFollowTarget
+	}
+	if _, err := u.users.GetByID(blockerID); err != nil {
+		return ErrUserNotFound
+	}
+	if _, err := u.users.GetByID(blockedID); err != nil {
+		return ErrUserNotFound
+	}
+
+	if _, err := u.follows.Block(blockerID, blockedID); err != nil {
+		return err
+	}
+	if err := u.removeEdge(blockedID, domain.FollowTargetUser, blockerID); err != nil {
+		return err
+	}
+	return u.removeEdge(blockerID, domain.FollowTargetUser, blockedID)
+}
+
+// Unblock lifts a block; previously removed follows are not restored
+func (u *FollowUseCase) Unblock(blockerID, blockedID string) error {
+	return u.follows.Unblock(blockerID, blockedID)
+}
+
+// RecountFollowCounters recomputes the counters of a user from the edges,
+// repairing any drift left by a crash between an edge write and a counter update
+func (u *FollowUseCase) RecountFollowCounters(userID string) error {
+	followers, err := u.follows.CountFollowers(domain.FollowTargetUser, userID)
+	if err != nil {
+		return err
+	}
+	following, err := u.follows.CountFollowing(userID)
+	if err != nil {
+		return err
+	}
+	return u.users.SetFollowCounts(userID, followers, following)
+}
+
+// RecountAllFollowCounters recounts the counters of every user, batchSize
+// users at a time, and returns how many users were recounted. A user whose
+// recount fails is logged and skipped until the next run.
+func (u *FollowUseCase) RecountAllFollowCounters(ctx context.Context, batchSize int64) (int, error) {
+	recounted := 0
+	for page := int64(1); ; page++ {
+		if err := ctx.Err(); err != nil {
+			return recounted, err
+		}
+		users, err := u.users.List(page, batchSize)
+		if err != nil {
+			return recounted, err
+		}
+		for _, user := range users {
+			if err := u.RecountFollowCounters(user.ID); err != nil {
+				log.Printf("user %s: failed to recount follow counters: %v", user.ID, err)
+				continue
+			}
+			recounted++
+		}
+		if int64(len(users)) < batchSize {
+			return recounted, nil
+		}
+	}
+}
+
+// RunFollowCounterRepair calls RecountAllFollowCounters every interval until ctx is cancelled
+func (u *FollowUseCase) RunFollowCounterRepair(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.RecountAllFollowCounters(ctx, 500); err != nil && ctx.Err() == nil {
+				log.Printf("follow counter repair: %v", err)
+			}
+		}
+	}
+}
+
+func (u *FollowUseCase) checkTarget(followerID, targetType, targetID string) (string, error) {
+	targetType, err := normalizeTargetType(targetType)
+	if err != nil {
+		return "", err
+	}
+	if targetID == "" {
+		return "", ErrInvalidFollowTarget
+	}
+	if _, err := u.users.GetByID(followerID); err != nil {
+		return "", ErrUserNotFound
+	}
+
+	if targetType == domain.FollowTargetUser {
+		if followerID == targetID {
+			return "", ErrCannotFollowSelf
+		}
+		if _, err := u.users.GetByID(targetID); err != nil {
+			return "", ErrUserNotFound
+		}
+	}
+	return targetType, nil
+}
+
+func (u *FollowUseCase) blockedEitherWay(a, b string) (bool, error) {
+	blocked, err := u.follows.IsBlocked(a, b)
+	if err != nil || blocked {
+		return blocked, err
+	}
+	return u.follows.IsBlocked(b, a)
+}
+
+func (u *FollowUseCase) removeEdge(followerID, targetType, targetID string) error {
+	deleted, err := u.follows.Delete(followerID, targetType, targetID)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		u.adjustCounters(followerID, targetType, targetID, -1)
+	}
+	return nil
+}
+
+// adjustCounters applies delta to the counters touched by one edge. The edge
+// itself is the source of truth, so failures are logged and left for
+// RunFollowCounterRepair instead of failing the request.
+func (u *FollowUseCase) adjustCounters(followerID, targetType, targetID string, delta int64) {
+	if err := u.users.IncrementFollowCounts(followerID, 0, delta); err != nil {
+		log.Printf("failed to update following count of %s: %v", followerID, err)
+	}
+	if targetType == domain.FollowTargetUser {
+		if err := u.users.IncrementFollowCounts(targetID, delta, 0); err != nil {
+			log.Printf("failed to update followers count of %s: %v", targetID, err)
+		}
+	}
+}
+
+func normalizeTargetType(targetType string) (string, error) {
+	switch targetType {
+	case "", domain.FollowTargetUser:
+		return domain.FollowTargetUser, nil
+	case domain.FollowTargetArtist:
+		return domain.FollowTargetArtist, nil
+	default:
+		return "", ErrInvalidFollowTarget
+	}
+}
+
+func normalizePage(page, limit int64) (int64, int64) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10 // Default limit
+	}
+	return page, limit
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"testing"
+)
+
+// fakeFollows counts and removes edges, which is all the counter repair and
+// account deletion need
+type fakeFollows struct {
+	repository.FollowRepository
+	edges []domain.Follow
+}
+
+func (f *fakeFollows) DeleteUser(userID string) (followed, followers []string, err error) {
+	kept := f.edges[:0]
+	for _, e := range f.edges {
+		switch {
+		case e.FollowerID == userID:
+			if e.TargetType == domain.FollowTargetUser {
+				followed = append(followed, e.TargetID)
+			}
+		case e.TargetType == domain.FollowTargetUser && e.TargetID == userID:
+			followers = append(followers, e.FollowerID)
+		default:
+			kept = append(kept, e)
+		}
+	}
+	f.edges = kept
+	return followed, followers, nil
+}
+
+func (f *fakeFollows) CountFollowers(targetType, targetID string) (int64, error) {
+	var n int64
+	for _, e := range f.edges {
+		if e.TargetType == targetType && e.TargetID == targetID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *fakeFollows) CountFollowing(followerID string) (int64, error) {
+	var n int64
+	for _, e := range f.edges {
+		if e.FollowerID == followerID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestFollowUseCaseRecountAllFollowCounters(t *testing.T) {
+	f := newUserUseCaseFixture(t)
+	alice := f.register(t, "Alice", "alice@example.com")
+	bob := f.register(t, "Bob", "bob@example.com")
+	carol := f.register(t, "Carol", "carol@example.com")
+
+	follows := &fakeFollows{edges: []domain.Follow{
+		{FollowerID: alice, TargetType: domain.FollowTargetUser, TargetID: bob},
+		{FollowerID: carol, TargetType: domain.FollowTargetUser, TargetID: bob},
+		{FollowerID: bob, TargetType: domain.FollowTargetArtist, TargetID: "artist-1"},
+	}}
+	// Drift left by failed counter updates
+	if err := f.repo.IncrementFollowCounts(alice, 7, -2); err != nil {
+		t.Fatal(err)
+	}
+
+	uc := NewFollowUseCase(f.repo, follows)
+	// A batch smaller than the user count makes the repair walk several pages
+	n, err := uc.RecountAllFollowCounters(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("RecountAllFollowCounters: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("recounted %d users, want 3", n)
+	}
+
+	want := map[string][2]int64{alice: {0, 1}, bob: {2, 1}, carol: {0, 1}}
+	for id, counts := range want {
+		u := f.get(t, id)
+		if u.FollowersCount != counts[0] || u.FollowingCount != counts[1] {
+			t.Errorf("%s: followers %d following %d, want %d %d", u.Name, u.FollowersCount, u.FollowingCount, counts[0], counts[1])
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"strings"
+	"time"
+)
+
+var (
+	ErrHouseholdNotFound     = errors.New("household not found")
+	ErrNotHouseholdOwner     = errors.New("only the household owner can do this")
+	ErrFamilyPlanRequired    = errors.New("a family plan is required to manage a household")
+	ErrAlreadyInHousehold    = errors.New("user already belongs to a household")
+	ErrHouseholdFull         = errors.New("household member limit reached")
+	ErrInvalidInvitation     = errors.New("invitation is invalid or expired")
+	ErrCannotRemoveOwner     = errors.New("the household owner cannot be removed")
+	ErrChildProfileForbidden = errors.New("child profiles cannot do this")
+	ErrInvalidChildProfile   = errors.New("child profile name is required")
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+type HouseholdUseCase struct {
+	users      repository.UserRepository
+	households repository.HouseholdRepository
+	userUC     *UserUseCase
+}
+
+func NewHouseholdUseCase(users repository.UserRepository, households repository.HouseholdRepository, userUC *UserUseCase) *HouseholdUseCase {
+	return &HouseholdUseCase{users: users, households: households, userUC: userUC}
+}
+
+// Create makes ownerID the owner of a new household
+func (u *HouseholdUseCase) Create(ownerID, name string) (*domain.Household, error) {
+	owner, err := u.users.GetByID(ownerID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if owner.IsChild {
+		return nil, ErrChildProfileForbidden
+	}
+	if owner.HouseholdID != "" {
+		return nil, ErrAlreadyInHousehold
+	}
+	if u.memberCap(owner) == 0 {
+		return nil, ErrFamilyPlanRequired
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = owner.Name + "'s household"
+	}
+	household := &domain.Household{
+		OwnerID:   ownerID,
+		Name:      name,
+		MemberIDs: []string{ownerID},
+	}
+	id, err := u.households.Create(household)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	household.ID = id
+	household.CreatedAt = &now
+
+	owner.HouseholdID = id
+	if err := u.users.Update(owner); err != nil {
+		u.households.Delete(id)
+		return nil, err
+	}
+	return household, nil
+}
+
+// Invite creates an invitation for email and returns it together with the
+// plain token, which is shown only once and must be delivered to the invitee
+func (u *HouseholdUseCase) Invite(ownerID, householdID, email string) (*domain.HouseholdInvitation, string, error) {
+	household, owner, err := u.ownedHousehold(ownerID, householdID)
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(household.MemberIDs)) >= u.memberCap(owner) {
+		return nil, "", ErrHouseholdFull
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, "", ErrInvalidInvitation
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+	expiresAt := time.Now().Add(invitationTTL)
+	invitation := &domain.HouseholdInvitation{
+		HouseholdID: householdID,
+		Email:       email,
+		TokenHash:   hashSecretToken(token),
+		InvitedBy:   ownerID,
+		ExpiresAt:   &expiresAt,
+	}
+	id, err := u.households.CreateInvitation(invitation)
+	if err != nil {
+		return nil, "", err
+	}
+	invitation.ID = id
+
+	return invitation, token, nil
+}
+
+// Accept adds userID to the household of the invitation. The invitation must
+// have been issued to the user's email address.
+func (u *HouseholdUseCase) Accept(userID, token string) (*domain.Household, error) {
+	user, err := u.users.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.HouseholdID != "" {
+		return nil, ErrAlreadyInHousehold
+	}
+
+	invitation, err := u.households.GetInvitationByTokenHash(hashSecretToken(token))
+	if err != nil ||
+		invitation.AcceptedAt != nil ||
+		invitation.ExpiresAt == nil || time.Now().After(*invitation.ExpiresAt) ||
+		!strings.EqualFold(invitation.Email, user.Email) {
+		return nil, ErrInvalidInvitation
+	}
+
+	household, err := u.households.GetByID(invitation.HouseholdID)
+	if err != nil {
+		return nil, ErrHouseholdNotFound
+	}
+	owner, err := u.users.GetByID(household.OwnerID)
+	if err != nil {
+		return nil, ErrHouseholdNotFound
+	}
+
+	if err := u.addMember(household, owner, user); err != nil {
+		return nil, err
+	}
+	if err := u.households.MarkInvitationAccepted(invitation.ID, userID); err != nil {
+		return nil, err
+	}
+
+	return u.households.GetByID(household.ID)
+}
+
+// CreateChildProfile creates a managed profile without email or password.
+// Explicit content is always filtered for child profiles.
+func (u *HouseholdUseCase) CreateChildProfile(ownerID, householdID, name, displayName string) (*domain.User, error) {
+	household, owner, err := u.ownedHousehold(ownerID, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidChildProfile
+	}
+	child := &domain.User{
+		Name:        name,
+		DisplayName: strings.TrimSpace(displayName),
+		IsChild:     true,
+		ManagedBy:   ownerID,
+		HouseholdID: householdID,
+		Preferences: domain.Preferences{
+			ExplicitContentFilter: true,
+			AudioQuality:          domain.AudioQualityNormal,
+		},
+	}
+
+	// The repository enforces the cap atomically; a profile that could not
+	// join after all is removed again
+	if int64(len(household.MemberIDs)) >= u.memberCap(owner) {
+		return nil, ErrHouseholdFull
+	}
+	id, err := u.users.Create(child)
+	if err != nil {
+		return nil, err
+	}
+	child.ID = id
+	if err := u.households.AddMember(householdID, id, u.memberCap(owner)); err != nil {
+		u.users.Delete(id)
+		if errors.Is(err, repository.ErrHouseholdFull) {
+			return nil, ErrHouseholdFull
+		}
+		return nil, err
+	}
+
+	return u.users.GetByID(id)
+}
+
+// ListMembers returns the household with the profiles of its members; only members may see it
+func (u *HouseholdUseCase) ListMembers(userID, householdID string) (*domain.Household, []*domain.User, error) {
+	household, err := u.households.GetByID(householdID)
+	if err != nil {
+		return nil, nil, ErrHouseholdNotFound
+	}
+	if !containsID(household.MemberIDs, userID) {
+		return nil, nil, ErrHouseholdNotFound
+	}
+
+	members := make([]*domain.User, 0, len(household.MemberIDs))
+	for _, id := range household.MemberIDs {
+		member, err := u.users.GetByID(id)
+		if err != nil {
+			continue // Member deleted concurrently
+		}
+		members = append(members, member)
+	}
+	return household, members, nil
+}
+
+// RemoveMember removes memberID from the household. The owner can remove
+// anyone but themselves, other members can only leave. Child profiles
+// cannot exist outside their household and are deleted.
+func (u *HouseholdUseCase) RemoveMember(callerID, householdID, memberID string) error {
+	household, err := u.households.GetByID(householdID)
+	if err != nil {
+		return ErrHouseholdNotFound
+	}
+	if memberID == household.OwnerID {
+		return ErrCannotRemoveOwner
+	}
+	if callerID != household.OwnerID && callerID != memberID {
+		return ErrNotHouseholdOwner
+	}
+	if !containsID(household.MemberIDs, memberID) {
+		return ErrUserNotFound
+	}
+
+	member, err := u.users.GetByID(memberID)
+	if err != nil {
+		return u.households.RemoveMember(householdID, memberID)
+	}
+	if member.IsChild {
+		return u.userUC.Delete(memberID)
+	}
+
+	member.HouseholdID = ""
+	if err := u.users.Update(member); err != nil {
+		return err
+	}
+	return u.households.RemoveMember(householdID, memberID)
+}
+
+// ChildProfile returns a child profile managed by ownerID, used to switch to that profile
+func (u *HouseholdUseCase) ChildProfile(ownerID, childID string) (*domain.User, error) {
+	child, err := u.users.GetByID(childID)
+	if err != nil || !child.IsChild || child.ManagedBy != ownerID {
+		return nil, ErrUserNotFound
+	}
+	return child, nil
+}
+
+func (u *HouseholdUseCase) ownedHousehold(ownerID, householdID string) (*domain.Household, *domain.User, error) {
+	household, err := u.households.GetByID(householdID)
+	if err != nil {
+		return nil, nil, ErrHouseholdNotFound
+	}
+	if household.OwnerID != ownerID {
+		return nil, nil, ErrNotHouseholdOwner
+	}
+	owner, err := u.users.GetByID(ownerID)
+	if err != nil {
+		return nil, nil, ErrUserNotFound
+	}
+	return household, owner, nil
+}
+
+func (u *HouseholdUseCase) addMember(household *domain.Household, owner, member *domain.User) error {
+	if err := u.households.AddMember(household.ID, member.ID, u.memberCap(owner)); err != nil {
+		if errors.Is(err, repository.ErrHouseholdFull) {
+			return ErrHouseholdFull
+		}
+		return err
+	}
+
+	member.HouseholdID = household.ID
+	if err := u.users.Update(member); err != nil {
+		u.households.RemoveMember(household.ID, member.ID)
+		return err
+	}
+	return nil
+}
+
+// memberCap is the maximum household size allowed by the owner's current plan
+func (u *HouseholdUseCase) memberCap(owner *domain.User) int64 {
+	return domain.EntitlementsFor(owner.Subscription.EffectivePlan(time.Now())).MaxHouseholdMembers
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// householdOwner returns the owner of the user's household, or nil when the
+// user is not a member of one or owns it
+func (u *UserUseCase) householdOwner(user *domain.User) *domain.User {
+	if user.HouseholdID == "" {
+		return nil
+	}
+	household, err := u.households.GetByID(user.HouseholdID)
+	if err != nil || household.OwnerID == user.ID {
+		return nil
+	}
+	owner, err := u.repo.GetByID(household.OwnerID)
+	if err != nil {
+		return nil
+	}
+	return owner
+}
+
+// leaveHousehold detaches a user that is about to be deleted from their
+// household. When the owner leaves the household is dissolved: adult members
+// keep their accounts, child profiles are deleted along with it.
+func (u *UserUseCase) leaveHousehold(user *domain.User) error {
+	if user.HouseholdID == "" {
+		return nil
+	}
+	household, err := u.households.GetByID(user.HouseholdID)
+	if err != nil {
+		return nil // Household already gone
+	}
+	if household.OwnerID != user.ID {
+		return u.households.RemoveMember(household.ID, user.ID)
+	}
+
+	for _, id := range household.MemberIDs {
+		if id == user.ID {
+			continue
+		}
+		member, err := u.repo.GetByID(id)
+		if err != nil {
+			continue
+		}
+		if member.IsChild {
+			if err := u.Delete(id); err != nil {
+				return err
+			}
+			continue
+		}
+		member.HouseholdID = ""
+		if err := u.repo.Update(member); err != nil {
+			return err
+		}
+	}
+	return u.households.Delete(household.ID)
+}
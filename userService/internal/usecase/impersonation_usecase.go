@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"strings"
+)
+
+var (
+	ErrImpersonationReasonRequired = errors.New("a reason is required to impersonate a user")
+	ErrCannotImpersonate           = errors.New("admins cannot be impersonated")
+)
+
+// maxExportedAuditRecords bounds the audit section of a data export
+const maxExportedAuditRecords = 1000
+
+// ImpersonationUseCase lets admins act as a user, e.g. to reproduce a bug
+// report, and keeps an audit trail of everything done that way
+type ImpersonationUseCase struct {
+	users repository.UserRepository
+	audit repository.AuditRepository
+}
+
+func NewImpersonationUseCase(users repository.UserRepository, audit repository.AuditRepository) *ImpersonationUseCase {
+	return &ImpersonationUseCase{users: users, audit: audit}
+}
+
+// Start opens an impersonation session of admin as the user with targetID
+// and returns the user together with the new session ID
+func (u *ImpersonationUseCase) Start(admin *domain.User, targetID, reason string) (*domain.User, string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, "", ErrImpersonationReasonRequired
+	}
+
+	target, err := u.users.GetByID(targetID)
+	if err != nil {
+		return nil, "", ErrUserNotFound
+	}
+	// Acting as another admin (or oneself) would only hide who did what
+	if target.IsAdmin() || target.ID == admin.ID {
+		return nil, "", ErrCannotImpersonate
+	}
+
+	sessionID, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// No token is handed out unless the start of the session is on record
+	if err := u.audit.Create(&domain.AuditRecord{
+		Action:    domain.AuditActionImpersonationStarted,
+		ActorID:   admin.ID,
+		SubjectID: target.ID,
+		SessionID: sessionID,
+		Reason:    reason,
+	}); err != nil {
+		return nil, "", err
+	}
+	return target, sessionID, nil
+}
+
+// RecordCall notes a call made by actorID while impersonating subjectID
+func (u *ImpersonationUseCase) RecordCall(actorID, subjectID, sessionID, method string) error {
+	return u.audit.Create(&domain.AuditRecord{
+		Action:    domain.AuditActionImpersonatedCall,
+		ActorID:   actorID,
+		SubjectID: subjectID,
+		SessionID: sessionID,
+		Method:    method,
+	})
+}
+
+// Name implements ExportSource
+func (u *ImpersonationUseCase) Name() string { return "audit_log" }
+
+// Collect implements ExportSource, so users can see when support acted on their account
+func (u *ImpersonationUseCase) Collect(ctx context.Context, userID string) (interface{}, error) {
+	return u.audit.ListBySubject(userID, maxExportedAuditRecords)
+}
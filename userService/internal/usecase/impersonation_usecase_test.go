@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"testing"
+)
+
+type fakeAudit struct {
+	records []*domain.AuditRecord
+	err     error
+}
+
+func (f *fakeAudit) Create(record *domain.AuditRecord) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.records = append(f.records, record)
+	return nil
+}
+
+func (f *fakeAudit) ListBySubject(userID string, limit int64) ([]*domain.AuditRecord, error) {
+	var records []*domain.AuditRecord
+	for _, r := range f.records {
+		if r.SubjectID == userID {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func TestImpersonationUseCaseStart(t *testing.T) {
+	users := repository.NewMemoryUserRepository()
+	create := func(name, role string) *domain.User {
+		id, err := users.Create(&domain.User{Name: name, Email: name + "@example.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, _ := users.GetByID(id)
+		u.Role = role
+		if err := users.Update(u); err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+	admin := create("admin", domain.RoleAdmin)
+	otherAdmin := create("other", domain.RoleAdmin)
+	listener := create("listener", "")
+
+	cases := []struct {
+		name     string
+		targetID string
+		reason   string
+		auditErr error
+		wantErr  error
+	}{
+		{name: "valid", targetID: listener.ID, reason: "TICKET-1"},
+		{name: "reason required", targetID: listener.ID, reason: "  ", wantErr: ErrImpersonationReasonRequired},
+		{name: "unknown user", targetID: "000000000000000000000000", reason: "TICKET-1", wantErr: ErrUserNotFound},
+		{name: "another admin", targetID: otherAdmin.ID, reason: "TICKET-1", wantErr: ErrCannotImpersonate},
+		{name: "oneself", targetID: admin.ID, reason: "TICKET-1", wantErr: ErrCannotImpersonate},
+		{name: "audit unavailable", targetID: listener.ID, reason: "TICKET-1", auditErr: errors.New("mongo down")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			audit := &fakeAudit{err: tc.auditErr}
+			uc := NewImpersonationUseCase(users, audit)
+
+			target, sessionID, err := uc.Start(admin, tc.targetID, tc.reason)
+			switch {
+			case tc.auditErr != nil:
+				if err == nil {
+					t.Fatal("session started without an audit record")
+				}
+				return
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Start = %v, want %v", err, tc.wantErr)
+				}
+				if len(audit.records) != 0 {
+					t.Errorf("refused session was audited: %+v", audit.records)
+				}
+				return
+			case err != nil:
+				t.Fatalf("Start: %v", err)
+			}
+
+			if target.ID != tc.targetID || sessionID == "" {
+				t.Errorf("Start = %s, %q", target.ID, sessionID)
+			}
+			if len(audit.records) != 1 {
+				t.Fatalf("audit records = %+v, want one", audit.records)
+			}
+			r := audit.records[0]
+			if r.Action != domain.AuditActionImpersonationStarted || r.ActorID != admin.ID ||
+				r.SubjectID != target.ID || r.SessionID != sessionID || r.Reason != tc.reason {
+				t.Errorf("audit record = %+v", r)
+			}
+
+			if err := uc.RecordCall(admin.ID, target.ID, sessionID, "/user.UserService/GetUserProfile"); err != nil {
+				t.Fatalf("RecordCall: %v", err)
+			}
+			exported, err := uc.Collect(context.Background(), target.ID)
+			if err != nil {
+				t.Fatalf("Collect: %v", err)
+			}
+			if records := exported.([]*domain.AuditRecord); len(records) != 2 {
+				t.Errorf("export has %d audit records, want 2", len(records))
+			}
+		})
+	}
+}
@@ -0,0 +1,441 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// OAuth errors carry the error codes of RFC 6749 so they can be passed to clients as they are
+var (
+	ErrOAuthInvalidRequest       = errors.New("invalid_request")
+	ErrOAuthInvalidClient        = errors.New("invalid_client")
+	ErrOAuthInvalidGrant         = errors.New("invalid_grant")
+	ErrOAuthInvalidScope         = errors.New("invalid_scope")
+	ErrOAuthUnsupportedGrantType = errors.New("unsupported_grant_type")
+)
+
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+
+	authorizationCodeTTL = 10 * time.Minute
+	refreshTokenTTL      = 30 * 24 * time.Hour
+)
+
+// Scopes a client can be registered for
+var knownScopes = map[string]bool{
+	domain.ScopeProfile:        true,
+	domain.ScopeEmail:          true,
+	domain.ScopeCatalogRead:    true,
+	domain.ScopePlaylistsRead:  true,
+	domain.ScopePlaylistsWrite: true,
+}
+
+// A PKCE code verifier is 43-128 characters of the unreserved URL alphabet (RFC 7636)
+var codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+type OAuthUseCase struct {
+	users repository.UserRepository
+	oauth repository.OAuthRepository
+}
+
+func NewOAuthUseCase(users repository.UserRepository, oauth repository.OAuthRepository) *OAuthUseCase {
+	return &OAuthUseCase{users: users, oauth: oauth}
+}
+
+// OAuthClientRegistration describes a new third-party application
+type OAuthClientRegistration struct {
+	Name         string
+	RedirectURIs []string
+	Scopes       []string
+	// Public clients get no secret and have to use PKCE
+	Public bool
+}
+
+// AuthorizeRequest holds the parameters of an authorization request
+type AuthorizeRequest struct {
+	ClientID            string
+	RedirectURI         string
+	Scope               string // Space separated, empty means all scopes of the client
+	CodeChallenge       string
+	CodeChallengeMethod string
+	// Approve records the user's consent for the requested scopes
+	Approve bool
+}
+
+// AuthorizeResult is either a code for the client or a request to ask the user for consent
+type AuthorizeResult struct {
+	Client          *domain.OAuthClient
+	Scopes          []string
+	Code            string
+	ConsentRequired bool
+}
+
+// TokenRequest holds the parameters of a token request
+type TokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthGrant is what the token endpoint hands out; the access token itself is
+// signed by the delivery layer like every other token
+type OAuthGrant struct {
+	User         *domain.User
+	ClientID     string
+	Scopes       []string
+	RefreshToken string
+}
+
+// RegisterClient registers an application owned by ownerID and returns it
+// with its secret, which is shown only once (empty for public clients)
+func (u *OAuthUseCase) RegisterClient(ownerID string, reg OAuthClientRegistration) (*domain.OAuthClient, string, error) {
+	name := strings.TrimSpace(reg.Name)
+	if name == "" || len(reg.RedirectURIs) == 0 {
+		return nil, "", ErrOAuthInvalidRequest
+	}
+	for _, uri := range reg.RedirectURIs {
+		if !validRedirectURI(uri) {
+			return nil, "", ErrOAuthInvalidRequest
+		}
+	}
+
+	scopes := dedupe(reg.Scopes)
+	if len(scopes) == 0 {
+		return nil, "", ErrOAuthInvalidScope
+	}
+	for _, s := range scopes {
+		if !knownScopes[s] {
+			return nil, "", ErrOAuthInvalidScope
+		}
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, "", err
+	}
+	client := &domain.OAuthClient{
+		ID:           hex.EncodeToString(id),
+		Name:         name,
+		OwnerID:      ownerID,
+		RedirectURIs: reg.RedirectURIs,
+		Scopes:       scopes,
+	}
+
+	var secret string
+	if !reg.Public {
+		var err error
+		if secret, err = newSecretToken(); err != nil {
+			return nil, "", err
+		}
+		client.SecretHash = hashSecretToken(secret)
+	}
+
+	if err := u.oauth.CreateClient(client); err != nil {
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+// Authorize handles an authorization request of the signed-in user. Errors
+// about the client or the redirect URI must be shown to the user instead of
+// redirecting back to the client.
+func (u *OAuthUseCase) Authorize(userID string, req AuthorizeRequest) (*AuthorizeResult, error) {
+	client, err := u.oauth.GetClient(req.ClientID)
+	if err != nil {
+		return nil, ErrOAuthInvalidClient
+	}
+	if !containsID(client.RedirectURIs, req.RedirectURI) {
+		return nil, ErrOAuthInvalidRequest
+	}
+
+	scopes, err := resolveScopes(req.Scope, client.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only S256 is supported; "plain" would leak the verifier with the challenge
+	if req.CodeChallenge != "" || client.Public() {
+		if req.CodeChallengeMethod != "S256" || !codeVerifierPattern.MatchString(req.CodeChallenge) {
+			return nil, ErrOAuthInvalidRequest
+		}
+	}
+
+	result := &AuthorizeResult{Client: client, Scopes: scopes}
+
+	consent, err := u.oauth.GetConsent(userID, client.ID)
+	granted := err == nil && containsAll(consent.Scopes, scopes)
+	if !granted {
+		if !req.Approve {
+			result.ConsentRequired = true
+			return result, nil
+		}
+		all := scopes
+		if consent != nil {
+			all = dedupe(append(append([]string{}, consent.Scopes...), scopes...))
+		}
+		if err := u.oauth.SaveConsent(&domain.OAuthConsent{UserID: userID, ClientID: client.ID, Scopes: all}); err != nil {
+			return nil, err
+		}
+	}
+
+	code, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(authorizationCodeTTL)
+	err = u.oauth.CreateCode(&domain.OAuthAuthorizationCode{
+		CodeHash:            hashSecretToken(code),
+		ClientID:            client.ID,
+		UserID:              userID,
+		RedirectURI:         req.RedirectURI,
+		Scopes:              scopes,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		ExpiresAt:           &expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Code = code
+	return result, nil
+}
+
+// Token exchanges an authorization code or a refresh token for a new grant
+func (u *OAuthUseCase) Token(req TokenRequest) (*OAuthGrant, error) {
+	client, err := u.authenticateClient(req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GrantType {
+	case GrantTypeAuthorizationCode:
+		return u.exchangeCode(client, req)
+	case GrantTypeRefreshToken:
+		return u.refresh(client, req)
+	default:
+		return nil, ErrOAuthUnsupportedGrantType
+	}
+}
+
+// Revoke revokes a refresh token of the client. Unknown tokens are ignored (RFC 7009).
+func (u *OAuthUseCase) Revoke(clientID, clientSecret, token string) error {
+	client, err := u.authenticateClient(clientID, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	stored, err := u.oauth.GetRefreshToken(hashSecretToken(token))
+	if err != nil || stored.ClientID != client.ID {
+		return nil
+	}
+	_, err = u.oauth.RevokeRefreshToken(stored.TokenHash)
+	return err
+}
+
+// GetClient returns a registered client
+func (u *OAuthUseCase) GetClient(clientID string) (*domain.OAuthClient, error) {
+	client, err := u.oauth.GetClient(clientID)
+	if err != nil {
+		return nil, ErrOAuthInvalidClient
+	}
+	return client, nil
+}
+
+// ListConsents returns the applications the user has granted access to
+func (u *OAuthUseCase) ListConsents(userID string) ([]*domain.OAuthConsent, error) {
+	return u.oauth.ListConsents(userID)
+}
+
+// RevokeConsent withdraws the user's consent for a client and revokes its refresh tokens
+func (u *OAuthUseCase) RevokeConsent(userID, clientID string) error {
+	if err := u.oauth.DeleteConsent(userID, clientID); err != nil {
+		return err
+	}
+	return u.oauth.RevokeRefreshTokens(userID, clientID)
+}
+
+func (u *OAuthUseCase) exchangeCode(client *domain.OAuthClient, req TokenRequest) (*OAuthGrant, error) {
+	if req.Code == "" {
+		return nil, ErrOAuthInvalidRequest
+	}
+	code, err := u.oauth.ConsumeCode(hashSecretToken(req.Code))
+	if err != nil {
+		return nil, ErrOAuthInvalidGrant
+	}
+	if code.ClientID != client.ID || code.RedirectURI != req.RedirectURI {
+		return nil, ErrOAuthInvalidGrant
+	}
+	if code.CodeChallenge != "" {
+		if !codeVerifierPattern.MatchString(req.CodeVerifier) || pkceChallenge(req.CodeVerifier) != code.CodeChallenge {
+			return nil, ErrOAuthInvalidGrant
+		}
+	} else if req.CodeVerifier != "" {
+		return nil, ErrOAuthInvalidGrant
+	}
+
+	return u.issueGrant(client.ID, code.UserID, code.Scopes, code.Scopes)
+}
+
+func (u *OAuthUseCase) refresh(client *domain.OAuthClient, req TokenRequest) (*OAuthGrant, error) {
+	if req.RefreshToken == "" {
+		return nil, ErrOAuthInvalidRequest
+	}
+	token, err := u.oauth.GetRefreshToken(hashSecretToken(req.RefreshToken))
+	if err != nil || token.ClientID != client.ID {
+		return nil, ErrOAuthInvalidGrant
+	}
+	if token.ExpiresAt == nil || time.Now().After(*token.ExpiresAt) {
+		return nil, ErrOAuthInvalidGrant
+	}
+
+	revoked, err := u.oauth.RevokeRefreshToken(token.TokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		// A rotated token was used again: it has probably been stolen, so
+		// everything issued to this client for the user is revoked
+		if err := u.oauth.RevokeRefreshTokens(token.UserID, token.ClientID); err != nil {
+			return nil, err
+		}
+		return nil, ErrOAuthInvalidGrant
+	}
+
+	// The access token may be narrowed down, the refresh token keeps the original scopes
+	scopes := token.Scopes
+	if req.Scope != "" {
+		if scopes, err = resolveScopes(req.Scope, token.Scopes); err != nil {
+			return nil, err
+		}
+	}
+	return u.issueGrant(client.ID, token.UserID, scopes, token.Scopes)
+}
+
+func (u *OAuthUseCase) issueGrant(clientID, userID string, scopes, refreshScopes []string) (*OAuthGrant, error) {
+	user, err := u.users.GetByID(userID)
+	if err != nil {
+		return nil, ErrOAuthInvalidGrant
+	}
+
+	refreshToken, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(refreshTokenTTL)
+	err = u.oauth.CreateRefreshToken(&domain.OAuthRefreshToken{
+		TokenHash: hashSecretToken(refreshToken),
+		ClientID:  clientID,
+		UserID:    userID,
+		Scopes:    refreshScopes,
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OAuthGrant{
+		User:         user,
+		ClientID:     clientID,
+		Scopes:       scopes,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// authenticateClient checks the client secret; public clients must not send one
+func (u *OAuthUseCase) authenticateClient(clientID, secret string) (*domain.OAuthClient, error) {
+	client, err := u.oauth.GetClient(clientID)
+	if err != nil {
+		return nil, ErrOAuthInvalidClient
+	}
+	if client.Public() {
+		if secret != "" {
+			return nil, ErrOAuthInvalidClient
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecretToken(secret)), []byte(client.SecretHash)) != 1 {
+		return nil, ErrOAuthInvalidClient
+	}
+	return client, nil
+}
+
+// resolveScopes parses a space separated scope parameter, which must be a
+// subset of allowed. An empty parameter means all allowed scopes.
+func resolveScopes(scope string, allowed []string) ([]string, error) {
+	requested := dedupe(strings.Fields(scope))
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+	if !containsAll(allowed, requested) {
+		return nil, ErrOAuthInvalidScope
+	}
+	return requested, nil
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// validRedirectURI accepts absolute https URIs, http only for loopback
+// addresses and custom schemes of native apps; fragments are not allowed
+func validRedirectURI(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Fragment != "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return u.Host != ""
+	case "http":
+		host := u.Hostname()
+		if host == "localhost" {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	default:
+		return strings.Contains(u.Scheme, ".") // Reverse domain name, e.g. com.example.app
+	}
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+func containsAll(set, values []string) bool {
+	for _, v := range values {
+		if !containsID(set, v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/hasher"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// Upper bound keeps hashing time predictable. Passwords hashed with bcrypt
+// before the switch to argon2id were effectively cut at 72 bytes anyway.
+const maxPasswordBytes = 128
+
+// BreachedPasswordChecker reports whether a password is known from a data breach
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordPolicy describes the requirements for new passwords
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// HistorySize is the number of previous passwords that cannot be reused
+	HistorySize int
+	// Breached is optional; without it the breach check is skipped
+	Breached BreachedPasswordChecker
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    8,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+		HistorySize:  5,
+	}
+}
+
+// FieldViolation describes why the value of a request field was rejected
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// PasswordPolicyError is returned when a new password does not satisfy the policy
+type PasswordPolicyError struct {
+	Violations []FieldViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		descriptions = append(descriptions, v.Description)
+	}
+	return "password does not meet the policy: " + strings.Join(descriptions, "; ")
+}
+
+// check validates password for user and returns the violations under the given field name
+func (p PasswordPolicy) check(field, password string, user *domain.User, h hasher.Hasher) error {
+	var violations []string
+	add := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if n := len([]rune(password)); n < p.MinLength {
+		add("must be at least %d characters long", p.MinLength)
+	}
+	if len(password) > maxPasswordBytes {
+		add("must be at most %d bytes long", maxPasswordBytes)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		add("must contain an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		add("must contain a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		add("must contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		add("must contain a symbol")
+	}
+
+	if resemblesIdentity(password, user) {
+		add("must not be the same as your name or email")
+	}
+	if p.reused(password, user, h) {
+		add("must not match any of your last %d passwords", p.HistorySize)
+	}
+
+	// Only look the password up when it is otherwise acceptable
+	if len(violations) == 0 && p.Breached != nil {
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			log.Printf("breached password check failed: %v", err)
+		} else if breached {
+			add("has appeared in a data breach, choose a different one")
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	policyErr := &PasswordPolicyError{}
+	for _, v := range violations {
+		policyErr.Violations = append(policyErr.Violations, FieldViolation{Field: field, Description: v})
+	}
+	return policyErr
+}
+
+// reused reports whether password matches the current or a recent password of the user
+func (p PasswordPolicy) reused(password string, user *domain.User, h hasher.Hasher) bool {
+	if p.HistorySize <= 0 {
+		return false
+	}
+	hashes := append([]string{user.Password}, user.PasswordHistory...)
+	for _, hash := range hashes {
+		if hash == "" {
+			continue
+		}
+		if ok, _ := h.Verify(hash, password); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberPassword moves the current hash of the user into the password history
+func (p PasswordPolicy) rememberPassword(user *domain.User) {
+	if p.HistorySize <= 0 || user.Password == "" {
+		user.PasswordHistory = nil
+		return
+	}
+	history := append([]string{user.Password}, user.PasswordHistory...)
+	if len(history) > p.HistorySize-1 {
+		// The current password counts towards the history size
+		history = history[:p.HistorySize-1]
+	}
+	user.PasswordHistory = history
+}
+
+func resemblesIdentity(password string, user *domain.User) bool {
+	password = strings.ToLower(strings.TrimSpace(password))
+	if password == "" {
+		return false
+	}
+
+	email := strings.ToLower(strings.TrimSpace(user.Email))
+	local, _, _ := strings.Cut(email, "@")
+	for _, v := range []string{email, local, strings.ToLower(strings.TrimSpace(user.Name))} {
+		if v != "" && password == v {
+			return true
+		}
+	}
+	return false
+}
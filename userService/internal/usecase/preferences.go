@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidPreferences = errors.New("invalid preferences")
+
+const maxPreferenceItems = 20
+
+var languagePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+var audioQualities = map[string]bool{
+	domain.AudioQualityLow:      true,
+	domain.AudioQualityNormal:   true,
+	domain.AudioQualityHigh:     true,
+	domain.AudioQualityLossless: true,
+}
+
+// GetPreferences returns the preferences of the user, with defaults filled in
+func (u *UserUseCase) GetPreferences(userID string) (*domain.Preferences, error) {
+	user, err := u.repo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	prefs := user.Preferences
+	if prefs.AudioQuality == "" {
+		prefs.AudioQuality = domain.AudioQualityNormal
+	}
+	// Explicit content is always hidden from child profiles
+	if user.IsChild {
+		prefs.ExplicitContentFilter = true
+	}
+	return &prefs, nil
+}
+
+// UpdatePreferences validates and replaces the preferences of the user
+func (u *UserUseCase) UpdatePreferences(userID string, prefs domain.Preferences) (*domain.Preferences, error) {
+	user, err := u.repo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	genres, err := normalizeList(prefs.FavoriteGenres, nil)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := normalizeList(prefs.PreferredLanguages, languagePattern)
+	if err != nil {
+		return nil, err
+	}
+	prefs.FavoriteGenres = genres
+	prefs.PreferredLanguages = languages
+
+	prefs.AudioQuality = strings.ToLower(strings.TrimSpace(prefs.AudioQuality))
+	if prefs.AudioQuality == "" {
+		prefs.AudioQuality = domain.AudioQualityNormal
+	}
+	if !audioQualities[prefs.AudioQuality] {
+		return nil, ErrInvalidPreferences
+	}
+
+	// Explicit content is always hidden from child profiles
+	if user.IsChild {
+		prefs.ExplicitContentFilter = true
+	}
+
+	user.Preferences = prefs
+	if err := u.repo.Update(user); err != nil {
+		return nil, err
+	}
+	return &user.Preferences, nil
+}
+
+// normalizeList lowercases, trims and de-duplicates values, optionally
+// requiring every value to match pattern
+func normalizeList(values []string, pattern *regexp.Regexp) ([]string, error) {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" || seen[v] {
+			continue
+		}
+		if pattern != nil && !pattern.MatchString(v) {
+			return nil, ErrInvalidPreferences
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	if len(result) > maxPreferenceItems {
+		return nil, ErrInvalidPreferences
+	}
+	return result, nil
+}
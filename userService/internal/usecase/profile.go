@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidHandle  = errors.New("handle must be 3-30 characters: lowercase letters, digits, '_' or '.'")
+	ErrHandleTaken    = errors.New("handle is already taken")
+	ErrInvalidCountry = errors.New("country must be an ISO 3166-1 alpha-2 code")
+	ErrInvalidLocale  = errors.New("locale must be a BCP 47 language tag such as \"ru-KZ\"")
+	ErrBioTooLong     = errors.New("bio must not exceed 300 characters")
+)
+
+const (
+	maxBioLength         = 300
+	maxDisplayNameLength = 50
+)
+
+var (
+	handlePattern  = regexp.MustCompile(`^[a-z0-9_.]{3,30}$`)
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	localePattern  = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+
+	// Handles that would be confusing or could be used to impersonate staff
+	reservedHandles = map[string]bool{
+		"admin": true, "administrator": true, "support": true, "root": true,
+		"system": true, "moderator": true, "staff": true, "help": true,
+	}
+)
+
+// NormalizeHandle strips the leading @ and lowercases the handle
+func NormalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+}
+
+// GetByHandle retrieves a user by handle
+func (u *UserUseCase) GetByHandle(handle string) (*domain.User, error) {
+	user, err := u.repo.GetByHandle(NormalizeHandle(handle))
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// CheckHandle returns the normalized handle and nil if it can be claimed,
+// or the reason it cannot
+func (u *UserUseCase) CheckHandle(handle string) (string, error) {
+	handle = NormalizeHandle(handle)
+	if !handlePattern.MatchString(handle) || reservedHandles[handle] {
+		return handle, ErrInvalidHandle
+	}
+	if existing, err := u.repo.GetByHandle(handle); err == nil && existing != nil {
+		return handle, ErrHandleTaken
+	}
+	return handle, nil
+}
+
+// normalizeProfile validates the optional profile fields of user and brings
+// them into their canonical form. existing is the stored version of the user.
+func (u *UserUseCase) normalizeProfile(user, existing *domain.User) error {
+	user.DisplayName = strings.TrimSpace(user.DisplayName)
+	if utf8.RuneCountInString(user.DisplayName) > maxDisplayNameLength {
+		user.DisplayName = string([]rune(user.DisplayName)[:maxDisplayNameLength])
+	}
+
+	user.Bio = strings.TrimSpace(user.Bio)
+	if utf8.RuneCountInString(user.Bio) > maxBioLength {
+		return ErrBioTooLong
+	}
+
+	user.Country = strings.ToUpper(strings.TrimSpace(user.Country))
+	if user.Country != "" && !countryPattern.MatchString(user.Country) {
+		return ErrInvalidCountry
+	}
+
+	user.Locale = strings.TrimSpace(user.Locale)
+	if user.Locale != "" && !localePattern.MatchString(user.Locale) {
+		return ErrInvalidLocale
+	}
+
+	user.Handle = NormalizeHandle(user.Handle)
+	if user.Handle != "" && user.Handle != existing.Handle {
+		if _, err := u.CheckHandle(user.Handle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
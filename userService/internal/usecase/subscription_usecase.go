@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"time"
+)
+
+var ErrInvalidPlan = errors.New("invalid subscription plan")
+
+// GetEntitlements returns what the user's current plan allows together with the subscription itself
+func (u *UserUseCase) GetEntitlements(userID string) (domain.Entitlements, domain.Subscription, error) {
+	user, err := u.repo.GetByID(userID)
+	if err != nil {
+		return domain.Entitlements{}, domain.Subscription{}, ErrUserNotFound
+	}
+
+	plan := user.Subscription.EffectivePlan(time.Now())
+	if owner := u.householdOwner(user); owner != nil {
+		// Members of a family household share the owner's plan
+		if owner.Subscription.EffectivePlan(time.Now()) == domain.PlanFamily {
+			plan = domain.PlanFamily
+		}
+	}
+
+	return domain.EntitlementsFor(plan), user.Subscription, nil
+}
+
+// ChangePlan attaches a plan to the user. endsAt may be nil for a plan without an end date.
+func (u *UserUseCase) ChangePlan(userID, plan string, endsAt *time.Time, changedBy string) (*domain.User, error) {
+	if !domain.IsValidPlan(plan) {
+		return nil, ErrInvalidPlan
+	}
+	now := time.Now()
+	if endsAt != nil && !endsAt.After(now) {
+		return nil, ErrInvalidPlan
+	}
+
+	user, err := u.repo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	user.Subscription = domain.Subscription{
+		Plan:      plan,
+		StartedAt: &now,
+		EndsAt:    endsAt,
+		ChangedBy: changedBy,
+	}
+	if err := u.repo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
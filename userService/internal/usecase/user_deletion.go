@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"log"
+	"time"
+)
+
+// tombstoneGracePeriod is how long a tombstone whose user still exists is
+// kept: Delete writes the tombstone before it removes the user, so a young
+// one may belong to a deletion that is still in progress
+const tombstoneGracePeriod = 10 * time.Minute
+
+// PlaylistCleaner removes the playlists owned by a deleted user.
+// Implementations must be idempotent.
+type PlaylistCleaner interface {
+	DeleteUserPlaylists(userID string) (int64, error)
+}
+
+// cleanupUserData removes the user's follow edges, blocks and playlists. A
+// failure is only recorded on the tombstone: the user is already gone and the
+// reconciler will retry.
+func (u *UserUseCase) cleanupUserData(userID string) {
+	if err := u.removeFollowGraph(userID); err != nil {
+		log.Printf("user %s: follow graph cleanup failed, will retry: %v", userID, err)
+		u.recordCleanupFailure(userID, err)
+		return
+	}
+
+	deleted, err := u.playlists.DeleteUserPlaylists(userID)
+	if err != nil {
+		log.Printf("user %s: playlist cleanup failed, will retry: %v", userID, err)
+		u.recordCleanupFailure(userID, err)
+		return
+	}
+
+	if err := u.deletions.MarkPlaylistsCleaned(userID); err != nil {
+		log.Printf("user %s: failed to mark playlists as cleaned: %v", userID, err)
+		return
+	}
+	log.Printf("user %s: removed %d playlists", userID, deleted)
+}
+
+func (u *UserUseCase) recordCleanupFailure(userID string, cause error) {
+	if err := u.deletions.RecordFailure(userID, cause.Error()); err != nil {
+		log.Printf("user %s: failed to record cleanup failure: %v", userID, err)
+	}
+}
+
+// removeFollowGraph deletes the edges and blocks of a deleted user and takes
+// the edges off the counters of the users on the other side. A counter that
+// misses the update is fixed by the next follow counter repair.
+func (u *UserUseCase) removeFollowGraph(userID string) error {
+	followed, followers, err := u.follows.DeleteUser(userID)
+	if err != nil {
+		return err
+	}
+	for _, id := range followed {
+		if err := u.repo.IncrementFollowCounts(id, -1, 0); err != nil {
+			log.Printf("user %s: failed to update follower count: %v", id, err)
+		}
+	}
+	for _, id := range followers {
+		if err := u.repo.IncrementFollowCounts(id, 0, -1); err != nil {
+			log.Printf("user %s: failed to update following count: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// ReconcileDeletions retries the cleanup for deleted users whose playlists
+// have not been removed yet and returns how many tombstones were processed.
+// A tombstone whose user still exists is left over from a deletion that
+// failed (or crashed) after the tombstone was written; once it is older than
+// tombstoneGracePeriod it is dropped without touching the user's playlists.
+func (u *UserUseCase) ReconcileDeletions(batchSize int64) (int, error) {
+	pending, err := u.deletions.ListPending(batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range pending {
+		_, err := u.repo.GetByID(d.UserID)
+		switch {
+		case err == nil:
+			if d.DeletedAt != nil && time.Since(*d.DeletedAt) < tombstoneGracePeriod {
+				continue // The deletion may still be running
+			}
+			log.Printf("user %s: still exists, dropping the stale deletion tombstone", d.UserID)
+			if err := u.deletions.Delete(d.UserID); err != nil {
+				log.Printf("user %s: failed to drop the tombstone: %v", d.UserID, err)
+			}
+		case errors.Is(err, repository.ErrUserNotFound):
+			u.cleanupUserData(d.UserID)
+		default:
+			log.Printf("user %s: failed to check the user, will retry: %v", d.UserID, err)
+		}
+	}
+	return len(pending), nil
+}
+
+// RunDeletionReconciler calls ReconcileDeletions every interval until ctx is cancelled
+func (u *UserUseCase) RunDeletionReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.ReconcileDeletions(100); err != nil {
+				log.Printf("deletion reconciler: %v", err)
+			}
+		}
+	}
+}
@@ -3,8 +3,9 @@ package usecase
 import (
 	"errors"
 	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/hasher"
 	"github.com/facelessEmptiness/user_service/internal/repository"
-	"golang.org/x/crypto/bcrypt"
+	"log"
 )
 
 var (
@@ -14,11 +15,18 @@ var (
 )
 
 type UserUseCase struct {
-	repo repository.UserRepository
+	repo       repository.UserRepository
+	deletions  repository.UserDeletionRepository
+	households repository.HouseholdRepository
+	identities repository.ExternalIdentityRepository
+	follows    repository.FollowRepository
+	playlists  PlaylistCleaner
+	policy     PasswordPolicy
+	hasher     hasher.Hasher
 }
 
-func NewUserUseCase(r repository.UserRepository) *UserUseCase {
-	return &UserUseCase{repo: r}
+func NewUserUseCase(r repository.UserRepository, deletions repository.UserDeletionRepository, households repository.HouseholdRepository, identities repository.ExternalIdentityRepository, follows repository.FollowRepository, playlists PlaylistCleaner, policy PasswordPolicy, h hasher.Hasher) *UserUseCase {
+	return &UserUseCase{repo: r, deletions: deletions, households: households, identities: identities, follows: follows, playlists: playlists, policy: policy, hasher: h}
 }
 
 // Register creates a new user with hashed password
@@ -29,12 +37,11 @@ func (u *UserUseCase) Register(user *domain.User) (string, error) {
 		return "", ErrEmailAlreadyExists
 	}
 
-	// Hash the password before storing
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-	if err != nil {
+	password := user.Password
+	user.Password = ""
+	if err := u.setPassword(user, "password", password); err != nil {
 		return "", err
 	}
-	user.Password = string(hashedPassword)
 
 	return u.repo.Create(user)
 }
@@ -42,16 +49,29 @@ func (u *UserUseCase) Register(user *domain.User) (string, error) {
 // Login authenticates a user
 func (u *UserUseCase) Login(email, password string) (*domain.User, error) {
 	user, err := u.repo.GetByEmail(email)
-	if err != nil {
+	if err != nil || user.IsChild {
 		return nil, ErrInvalidCredentials
 	}
 
 	// Compare the provided password with the stored hash
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
-	if err != nil {
+	ok, err := u.hasher.Verify(user.Password, password)
+	if err != nil || !ok {
 		return nil, ErrInvalidCredentials
 	}
 
+	// Upgrade hashes made with an older algorithm or weaker parameters while
+	// the plain password is at hand; a failure only delays the upgrade
+	if u.hasher.NeedsRehash(user.Password) {
+		if hash, err := u.hasher.Hash(password); err != nil {
+			log.Printf("user %s: failed to rehash password: %v", user.ID, err)
+		} else {
+			user.Password = hash
+			if err := u.repo.Update(user); err != nil {
+				log.Printf("user %s: failed to store rehashed password: %v", user.ID, err)
+			}
+		}
+	}
+
 	return user, nil
 }
 
@@ -81,18 +101,29 @@ func (u *UserUseCase) Update(user *domain.User) error {
 		return ErrUserNotFound
 	}
 
-	// If password is being updated, hash it
+	// Child profiles never get credentials of their own
+	if existingUser.IsChild {
+		user.Email = existingUser.Email
+		user.Password = ""
+	}
+
+	// If password is being updated, validate and hash it
 	if user.Password != "" && user.Password != existingUser.Password {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-		if err != nil {
+		password := user.Password
+		user.Password = existingUser.Password
+		user.PasswordHistory = existingUser.PasswordHistory
+		if err := u.setPassword(user, "password", password); err != nil {
 			return err
 		}
-		user.Password = string(hashedPassword)
 	} else {
 		// Keep the original password if not changed
 		user.Password = existingUser.Password
 	}
 
+	if err := u.normalizeProfile(user, existingUser); err != nil {
+		return err
+	}
+
 	// Check if updating email and if new email already exists
 	if user.Email != existingUser.Email {
 		emailUser, err := u.repo.GetByEmail(user.Email)
@@ -101,18 +132,47 @@ func (u *UserUseCase) Update(user *domain.User) error {
 		}
 	}
 
-	return u.repo.Update(user)
+	if err := u.repo.Update(user); err != nil {
+		// Another user claimed the handle after normalizeProfile checked it
+		if errors.Is(err, repository.ErrDuplicateHandle) {
+			return ErrHandleTaken
+		}
+		return err
+	}
+	return nil
 }
 
-// Delete removes a user by ID
+// Delete removes a user by ID and cleans up the data the user owns in other services
 func (u *UserUseCase) Delete(id string) error {
 	// Check if user exists
-	_, err := u.repo.GetByID(id)
+	user, err := u.repo.GetByID(id)
 	if err != nil {
 		return ErrUserNotFound
 	}
 
-	return u.repo.Delete(id)
+	if err := u.leaveHousehold(user); err != nil {
+		return err
+	}
+
+	// Leave a tombstone first so the reconciler can finish the cleanup
+	// even if we crash right after removing the user document
+	if err := u.deletions.Create(id); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(id); err != nil {
+		// The user still exists, so the reconciler must not clean up after them
+		if err := u.deletions.Delete(id); err != nil {
+			log.Printf("user %s: failed to drop the tombstone of a failed deletion: %v", id, err)
+		}
+		return err
+	}
+	if err := u.identities.DeleteByUserID(id); err != nil {
+		log.Printf("user %s: failed to remove external identities: %v", id, err)
+	}
+
+	u.cleanupUserData(id)
+	return nil
 }
 
 // List retrieves a paginated list of users
@@ -135,18 +195,31 @@ func (u *UserUseCase) ChangePassword(id, currentPassword, newPassword string) er
 	}
 
 	// Verify current password
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword))
-	if err != nil {
+	ok, err := u.hasher.Verify(user.Password, currentPassword)
+	if err != nil || !ok {
 		return ErrInvalidCredentials
 	}
 
-	// Hash new password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err := u.setPassword(user, "new_password", newPassword); err != nil {
+		return err
+	}
+	return u.repo.Update(user)
+}
+
+// setPassword checks password against the policy and stores its hash on the
+// user, keeping the previous hash in the password history. field names the
+// request field the password came from, for error details.
+func (u *UserUseCase) setPassword(user *domain.User, field, password string) error {
+	if err := u.policy.check(field, password, user, u.hasher); err != nil {
+		return err
+	}
+
+	hashedPassword, err := u.hasher.Hash(password)
 	if err != nil {
 		return err
 	}
 
-	// Update user with new password
-	user.Password = string(hashedPassword)
-	return u.repo.Update(user)
+	u.policy.rememberPassword(user)
+	user.Password = hashedPassword
+	return nil
 }
@@ -0,0 +1,724 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/facelessEmptiness/user_service/internal/domain"
+	"github.com/facelessEmptiness/user_service/internal/hasher"
+	"github.com/facelessEmptiness/user_service/internal/repository"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testPassword = "Secret123"
+
+// testHasher keeps argon2id cheap so the suite stays fast
+var testHasher = hasher.New(hasher.Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+
+type fakeDeletions struct {
+	mu       sync.Mutex
+	created  []string
+	cleaned  []string
+	removed  []string
+	failures map[string]string
+	pending  []*domain.UserDeletion
+}
+
+func (f *fakeDeletions) Create(userID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = append(f.created, userID)
+	return nil
+}
+
+func (f *fakeDeletions) MarkPlaylistsCleaned(userID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cleaned = append(f.cleaned, userID)
+	return nil
+}
+
+func (f *fakeDeletions) RecordFailure(userID, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures == nil {
+		f.failures = make(map[string]string)
+	}
+	f.failures[userID] = reason
+	return nil
+}
+
+func (f *fakeDeletions) ListPending(limit int64) ([]*domain.UserDeletion, error) {
+	return f.pending, nil
+}
+
+func (f *fakeDeletions) Delete(userID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removed = append(f.removed, userID)
+	return nil
+}
+
+// failingDeleteRepo is a user repository whose Delete always fails
+type failingDeleteRepo struct {
+	repository.UserRepository
+}
+
+func (r failingDeleteRepo) Delete(id string) error {
+	return errors.New("delete failed")
+}
+
+// staleHandleRepo misses every handle lookup, as if another user claimed the
+// handle between the availability check and the write
+type staleHandleRepo struct {
+	repository.UserRepository
+}
+
+func (r staleHandleRepo) GetByHandle(handle string) (*domain.User, error) {
+	return nil, repository.ErrUserNotFound
+}
+
+// fakeHouseholds only supports what UserUseCase needs when a member leaves
+type fakeHouseholds struct {
+	repository.HouseholdRepository
+	households map[string]*domain.Household
+	removed    []string
+	deleted    []string
+}
+
+func (f *fakeHouseholds) GetByID(id string) (*domain.Household, error) {
+	if h, ok := f.households[id]; ok {
+		return h, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (f *fakeHouseholds) RemoveMember(householdID, userID string) error {
+	f.removed = append(f.removed, userID)
+	return nil
+}
+
+func (f *fakeHouseholds) Delete(id string) error {
+	f.deleted = append(f.deleted, id)
+	delete(f.households, id)
+	return nil
+}
+
+// fakeIdentities only records which users had their identities removed
+type fakeIdentities struct {
+	repository.ExternalIdentityRepository
+	deletedFor []string
+}
+
+func (f *fakeIdentities) DeleteByUserID(userID string) error {
+	f.deletedFor = append(f.deletedFor, userID)
+	return nil
+}
+
+type fakePlaylists struct {
+	err     error
+	deleted []string
+}
+
+func (f *fakePlaylists) DeleteUserPlaylists(userID string) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	f.deleted = append(f.deleted, userID)
+	return 1, nil
+}
+
+type userUseCaseFixture struct {
+	uc         *UserUseCase
+	repo       repository.UserRepository
+	deletions  *fakeDeletions
+	households *fakeHouseholds
+	identities *fakeIdentities
+	follows    *fakeFollows
+	playlists  *fakePlaylists
+}
+
+func newUserUseCaseFixture(t *testing.T) *userUseCaseFixture {
+	t.Helper()
+	f := &userUseCaseFixture{
+		repo:       repository.NewMemoryUserRepository(),
+		deletions:  &fakeDeletions{},
+		households: &fakeHouseholds{households: make(map[string]*domain.Household)},
+		identities: &fakeIdentities{},
+		follows:    &fakeFollows{},
+		playlists:  &fakePlaylists{},
+	}
+	f.uc = NewUserUseCase(f.repo, f.deletions, f.households, f.identities, f.follows, f.playlists, DefaultPasswordPolicy(), testHasher)
+	return f
+}
+
+// register creates a user through the use case and returns its id
+func (f *userUseCaseFixture) register(t *testing.T, name, email string) string {
+	t.Helper()
+	id, err := f.uc.Register(&domain.User{Name: name, Email: email, Password: testPassword})
+	if err != nil {
+		t.Fatalf("Register(%s): %v", email, err)
+	}
+	return id
+}
+
+func (f *userUseCaseFixture) get(t *testing.T, id string) *domain.User {
+	t.Helper()
+	u, err := f.repo.GetByID(id)
+	if err != nil {
+		t.Fatalf("GetByID(%s): %v", id, err)
+	}
+	return u
+}
+
+// assertPolicyViolation fails unless err is a PasswordPolicyError for field
+// with a violation containing want
+func assertPolicyViolation(t *testing.T, err error, field, want string) {
+	t.Helper()
+	var policyErr *PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("got %v, want a PasswordPolicyError", err)
+	}
+	for _, v := range policyErr.Violations {
+		if v.Field == field && strings.Contains(v.Description, want) {
+			return
+		}
+	}
+	t.Errorf("violations %+v do not mention %q for %s", policyErr.Violations, want, field)
+}
+
+func TestUserUseCaseRegister(t *testing.T) {
+	cases := []struct {
+		name      string
+		user      domain.User
+		wantErr   error
+		violation string
+	}{
+		{name: "valid", user: domain.User{Name: "Alice", Email: "alice@example.com", Password: testPassword}},
+		{name: "duplicate email", user: domain.User{Name: "Other", Email: "taken@example.com", Password: testPassword}, wantErr: ErrEmailAlreadyExists},
+		{name: "too short", user: domain.User{Name: "Bob", Email: "bob@example.com", Password: "Ab1"}, violation: "at least 8 characters"},
+		{name: "no uppercase", user: domain.User{Name: "Bob", Email: "bob@example.com", Password: "secret123"}, violation: "uppercase"},
+		{name: "no digit", user: domain.User{Name: "Bob", Email: "bob@example.com", Password: "SecretPass"}, violation: "digit"},
+		{name: "same as email", user: domain.User{Name: "Bob", Email: "Bob12345@example.com", Password: "Bob12345"}, violation: "name or email"},
+		{name: "too long", user: domain.User{Name: "Bob", Email: "bob@example.com", Password: "Aa1" + strings.Repeat("x", maxPasswordBytes)}, violation: "at most"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newUserUseCaseFixture(t)
+			f.register(t, "Taken", "taken@example.com")
+
+			user := tc.user
+			id, err := f.uc.Register(&user)
+			switch {
+			case tc.violation != "":
+				assertPolicyViolation(t, err, "password", tc.violation)
+				return
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Register = %v, want %v", err, tc.wantErr)
+				}
+				return
+			case err != nil:
+				t.Fatalf("Register: %v", err)
+			}
+
+			stored := f.get(t, id)
+			if stored.Password == tc.user.Password || !strings.HasPrefix(stored.Password, "$argon2id$") {
+				t.Errorf("password stored as %q, want an argon2id hash", stored.Password)
+			}
+			if len(stored.PasswordHistory) != 0 {
+				t.Errorf("new user has password history %v", stored.PasswordHistory)
+			}
+		})
+	}
+}
+
+func TestUserUseCaseLogin(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		setup      func(t *testing.T, f *userUseCaseFixture)
+		email      string
+		password   string
+		wantErr    error
+		wantRehash bool
+	}{
+		{
+			name:     "valid",
+			setup:    func(t *testing.T, f *userUseCaseFixture) { f.register(t, "Alice", "alice@example.com") },
+			email:    "alice@example.com",
+			password: testPassword,
+		},
+		{
+			name:     "wrong password",
+			setup:    func(t *testing.T, f *userUseCaseFixture) { f.register(t, "Alice", "alice@example.com") },
+			email:    "alice@example.com",
+			password: "Wrong1234",
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name:     "unknown email",
+			setup:    func(t *testing.T, f *userUseCaseFixture) {},
+			email:    "nobody@example.com",
+			password: testPassword,
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name: "child profile",
+			setup: func(t *testing.T, f *userUseCaseFixture) {
+				hash, _ := testHasher.Hash(testPassword)
+				if _, err := f.repo.Create(&domain.User{Name: "Kid", Email: "kid@example.com", Password: hash, IsChild: true}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			email:    "kid@example.com",
+			password: testPassword,
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name: "unknown hash format",
+			setup: func(t *testing.T, f *userUseCaseFixture) {
+				if _, err := f.repo.Create(&domain.User{Name: "Old", Email: "old@example.com", Password: testPassword}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			email:    "old@example.com",
+			password: testPassword,
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name: "bcrypt hash is upgraded",
+			setup: func(t *testing.T, f *userUseCaseFixture) {
+				if _, err := f.repo.Create(&domain.User{Name: "Legacy", Email: "legacy@example.com", Password: string(bcryptHash)}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			email:      "legacy@example.com",
+			password:   testPassword,
+			wantRehash: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newUserUseCaseFixture(t)
+			tc.setup(t, f)
+
+			user, err := f.uc.Login(tc.email, tc.password)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Login = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Login: %v", err)
+			}
+			if user.Email != tc.email {
+				t.Errorf("Login returned %s", user.Email)
+			}
+
+			stored := f.get(t, user.ID)
+			if tc.wantRehash && !strings.HasPrefix(stored.Password, "$argon2id$") {
+				t.Errorf("hash was not upgraded: %q", stored.Password)
+			}
+			if _, err := f.uc.Login(tc.email, tc.password); err != nil {
+				t.Errorf("second Login: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserUseCaseUpdate(t *testing.T) {
+	cases := []struct {
+		name    string
+		update  func(u *domain.User)
+		wantErr error
+		check   func(t *testing.T, before, after *domain.User)
+	}{
+		{
+			name:   "profile fields are normalized",
+			update: func(u *domain.User) { u.DisplayName = "  Alice  "; u.Country = "kz"; u.Handle = "@Alice" },
+			check: func(t *testing.T, before, after *domain.User) {
+				if after.DisplayName != "Alice" || after.Country != "KZ" || after.Handle != "alice" {
+					t.Errorf("stored %q %q %q", after.DisplayName, after.Country, after.Handle)
+				}
+			},
+		},
+		{
+			name:   "empty password keeps the current one",
+			update: func(u *domain.User) { u.Password = ""; u.Name = "Alice B" },
+			check: func(t *testing.T, before, after *domain.User) {
+				if after.Password != before.Password || after.Name != "Alice B" {
+					t.Errorf("password changed or name not stored: %+v", after)
+				}
+			},
+		},
+		{
+			name:   "new password is hashed and remembered",
+			update: func(u *domain.User) { u.Password = "Another123" },
+			check: func(t *testing.T, before, after *domain.User) {
+				if ok, _ := testHasher.Verify(after.Password, "Another123"); !ok {
+					t.Error("new password does not verify")
+				}
+				if len(after.PasswordHistory) != 1 || after.PasswordHistory[0] != before.Password {
+					t.Errorf("PasswordHistory = %v", after.PasswordHistory)
+				}
+			},
+		},
+		{name: "weak password", update: func(u *domain.User) { u.Password = "weak" }, wantErr: &PasswordPolicyError{}},
+		{name: "email taken", update: func(u *domain.User) { u.Email = "bob@example.com" }, wantErr: ErrEmailAlreadyExists},
+		{name: "handle taken", update: func(u *domain.User) { u.Handle = "bob" }, wantErr: ErrHandleTaken},
+		{name: "invalid handle", update: func(u *domain.User) { u.Handle = "a" }, wantErr: ErrInvalidHandle},
+		{name: "invalid country", update: func(u *domain.User) { u.Country = "Kazakhstan" }, wantErr: ErrInvalidCountry},
+		{name: "bio too long", update: func(u *domain.User) { u.Bio = strings.Repeat("x", maxBioLength+1) }, wantErr: ErrBioTooLong},
+		{name: "unknown user", update: func(u *domain.User) { u.ID = "000000000000000000000000" }, wantErr: ErrUserNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newUserUseCaseFixture(t)
+			id := f.register(t, "Alice", "alice@example.com")
+			bobID := f.register(t, "Bob", "bob@example.com")
+			bob := f.get(t, bobID)
+			bob.Handle = "bob"
+			if err := f.uc.Update(bob); err != nil {
+				t.Fatalf("Update(bob): %v", err)
+			}
+
+			before := f.get(t, id)
+			user := f.get(t, id)
+			tc.update(user)
+			err := f.uc.Update(user)
+
+			var policyErr *PasswordPolicyError
+			switch {
+			case errors.As(tc.wantErr, &policyErr):
+				if !errors.As(err, &policyErr) {
+					t.Fatalf("Update = %v, want a PasswordPolicyError", err)
+				}
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Update = %v, want %v", err, tc.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("Update: %v", err)
+			}
+
+			after := f.get(t, id)
+			if tc.wantErr != nil {
+				if after.Password != before.Password || after.Email != before.Email || after.Handle != before.Handle {
+					t.Errorf("failed Update changed the user: %+v", after)
+				}
+				return
+			}
+			tc.check(t, before, after)
+		})
+	}
+}
+
+func TestUserUseCaseUpdateHandleRace(t *testing.T) {
+	f := newUserUseCaseFixture(t)
+	id := f.register(t, "Alice", "alice@example.com")
+	bob := f.get(t, f.register(t, "Bob", "bob@example.com"))
+	bob.Handle = "bob"
+	if err := f.uc.Update(bob); err != nil {
+		t.Fatalf("Update(bob): %v", err)
+	}
+
+	f.uc = NewUserUseCase(staleHandleRepo{f.repo}, f.deletions, f.households, f.identities, f.follows, f.playlists, DefaultPasswordPolicy(), testHasher)
+	user := f.get(t, id)
+	user.Handle = "bob"
+	if err := f.uc.Update(user); !errors.Is(err, ErrHandleTaken) {
+		t.Fatalf("Update = %v, want %v", err, ErrHandleTaken)
+	}
+}
+
+func TestUserUseCaseUpdateChildProfile(t *testing.T) {
+	f := newUserUseCaseFixture(t)
+	id, err := f.repo.Create(&domain.User{Name: "Kid", IsChild: true, ManagedBy: "owner"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kid := f.get(t, id)
+	kid.Email = "kid@example.com"
+	kid.Password = testPassword
+	if err := f.uc.Update(kid); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if stored := f.get(t, id); stored.Email != "" || stored.Password != "" {
+		t.Errorf("child profile got credentials: email %q, password %q", stored.Email, stored.Password)
+	}
+}
+
+func TestUserUseCaseChangePassword(t *testing.T) {
+	cases := []struct {
+		name      string
+		current   string
+		new       string
+		wantErr   error
+		violation string
+	}{
+		{name: "valid", current: testPassword, new: "Another123"},
+		{name: "wrong current password", current: "Wrong1234", new: "Another123", wantErr: ErrInvalidCredentials},
+		{name: "same as current", current: testPassword, new: testPassword, violation: "last 5 passwords"},
+		{name: "recently used", current: testPassword, new: "Previous123", violation: "last 5 passwords"},
+		{name: "weak", current: testPassword, new: "short", violation: "at least 8 characters"},
+		{name: "same as name", current: testPassword, new: "Alice", violation: "name or email"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newUserUseCaseFixture(t)
+			id, err := f.uc.Register(&domain.User{Name: "Alice", Email: "alice@example.com", Password: "Previous123"})
+			if err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+			if err := f.uc.ChangePassword(id, "Previous123", testPassword); err != nil {
+				t.Fatalf("ChangePassword: %v", err)
+			}
+			before := f.get(t, id)
+
+			err = f.uc.ChangePassword(id, tc.current, tc.new)
+			switch {
+			case tc.violation != "":
+				assertPolicyViolation(t, err, "new_password", tc.violation)
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("ChangePassword = %v, want %v", err, tc.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("ChangePassword: %v", err)
+			}
+
+			after := f.get(t, id)
+			if tc.violation != "" || tc.wantErr != nil {
+				if after.Password != before.Password {
+					t.Error("rejected ChangePassword replaced the password")
+				}
+				return
+			}
+			if _, err := f.uc.Login("alice@example.com", tc.new); err != nil {
+				t.Errorf("Login with the new password: %v", err)
+			}
+			if _, err := f.uc.Login("alice@example.com", tc.current); err == nil {
+				t.Error("Login with the old password still succeeds")
+			}
+			if len(after.PasswordHistory) != 2 || after.PasswordHistory[0] != before.Password {
+				t.Errorf("PasswordHistory has %d entries, want the previous 2", len(after.PasswordHistory))
+			}
+		})
+	}
+
+	t.Run("unknown user", func(t *testing.T) {
+		f := newUserUseCaseFixture(t)
+		if err := f.uc.ChangePassword("000000000000000000000000", testPassword, "Another123"); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("ChangePassword = %v, want %v", err, ErrUserNotFound)
+		}
+	})
+
+	t.Run("history is bounded", func(t *testing.T) {
+		f := newUserUseCaseFixture(t)
+		id := f.register(t, "Alice", "alice@example.com")
+		current := testPassword
+		for i := 0; i < 7; i++ {
+			next := "Rotated" + strings.Repeat("9", i+1)
+			if err := f.uc.ChangePassword(id, current, next); err != nil {
+				t.Fatalf("ChangePassword #%d: %v", i, err)
+			}
+			current = next
+		}
+		if n := len(f.get(t, id).PasswordHistory); n != DefaultPasswordPolicy().HistorySize-1 {
+			t.Errorf("PasswordHistory has %d entries, want %d", n, DefaultPasswordPolicy().HistorySize-1)
+		}
+		// Old enough to have left the history
+		if err := f.uc.ChangePassword(id, current, testPassword); err != nil {
+			t.Errorf("reusing a password outside the history: %v", err)
+		}
+	})
+}
+
+func TestUserUseCaseDelete(t *testing.T) {
+	cases := []struct {
+		name        string
+		playlistErr error
+		household   bool // Whether the user is a member of someone else's household
+	}{
+		{name: "cleans up playlists"},
+		{name: "playlist cleanup failure is recorded", playlistErr: errors.New("playlist service unavailable")},
+		{name: "leaves the household", household: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newUserUseCaseFixture(t)
+			f.playlists.err = tc.playlistErr
+			id := f.register(t, "Alice", "alice@example.com")
+
+			if tc.household {
+				f.households.households["h1"] = &domain.Household{ID: "h1", OwnerID: "owner", MemberIDs: []string{"owner", id}}
+				user := f.get(t, id)
+				user.HouseholdID = "h1"
+				if err := f.repo.Update(user); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := f.uc.Delete(id); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+
+			if _, err := f.repo.GetByID(id); err == nil {
+				t.Error("user still exists")
+			}
+			if len(f.deletions.created) != 1 || f.deletions.created[0] != id {
+				t.Errorf("tombstones = %v, want [%s]", f.deletions.created, id)
+			}
+			if len(f.identities.deletedFor) != 1 || f.identities.deletedFor[0] != id {
+				t.Errorf("identities removed for %v, want [%s]", f.identities.deletedFor, id)
+			}
+
+			if tc.playlistErr != nil {
+				if f.deletions.failures[id] != tc.playlistErr.Error() {
+					t.Errorf("recorded failure %q, want %q", f.deletions.failures[id], tc.playlistErr)
+				}
+				if len(f.deletions.cleaned) != 0 {
+					t.Errorf("tombstone marked as cleaned despite the failure")
+				}
+			} else if len(f.deletions.cleaned) != 1 || len(f.playlists.deleted) != 1 {
+				t.Errorf("playlists deleted for %v, cleaned %v", f.playlists.deleted, f.deletions.cleaned)
+			}
+
+			if tc.household && (len(f.households.removed) != 1 || f.households.removed[0] != id) {
+				t.Errorf("removed household members %v, want [%s]", f.households.removed, id)
+			}
+		})
+	}
+
+	t.Run("unknown user", func(t *testing.T) {
+		f := newUserUseCaseFixture(t)
+		if err := f.uc.Delete("000000000000000000000000"); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("Delete = %v, want %v", err, ErrUserNotFound)
+		}
+		if len(f.deletions.created) != 0 {
+			t.Errorf("tombstone created for an unknown user")
+		}
+	})
+
+	t.Run("owner takes child profiles along", func(t *testing.T) {
+		f := newUserUseCaseFixture(t)
+		ownerID := f.register(t, "Owner", "owner@example.com")
+		kidID, err := f.repo.Create(&domain.User{Name: "Kid", IsChild: true, ManagedBy: ownerID, HouseholdID: "h1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.households.households["h1"] = &domain.Household{ID: "h1", OwnerID: ownerID, MemberIDs: []string{ownerID, kidID}}
+		owner := f.get(t, ownerID)
+		owner.HouseholdID = "h1"
+		if err := f.repo.Update(owner); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := f.uc.Delete(ownerID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := f.repo.GetByID(kidID); err == nil {
+			t.Error("child profile outlived the household owner")
+		}
+		if len(f.households.deleted) != 1 {
+			t.Errorf("deleted households %v, want [h1]", f.households.deleted)
+		}
+		if len(f.deletions.created) != 2 {
+			t.Errorf("tombstones = %v, want owner and child", f.deletions.created)
+		}
+	})
+}
+
+func TestUserUseCaseDeleteFailureDropsTombstone(t *testing.T) {
+	f := newUserUseCaseFixture(t)
+	id := f.register(t, "Alice", "alice@example.com")
+	f.uc = NewUserUseCase(failingDeleteRepo{f.repo}, f.deletions, f.households, f.identities, f.follows, f.playlists, DefaultPasswordPolicy(), testHasher)
+
+	if err := f.uc.Delete(id); err == nil {
+		t.Fatal("Delete succeeded despite the repository failure")
+	}
+	if len(f.deletions.removed) != 1 || f.deletions.removed[0] != id {
+		t.Errorf("dropped tombstones %v, want [%s]", f.deletions.removed, id)
+	}
+	if len(f.playlists.deleted) != 0 {
+		t.Errorf("playlists deleted for %v although the user still exists", f.playlists.deleted)
+	}
+}
+
+func TestUserUseCaseDeleteRemovesFollowGraph(t *testing.T) {
+	f := newUserUseCaseFixture(t)
+	alice := f.register(t, "Alice", "alice@example.com")
+	bob := f.register(t, "Bob", "bob@example.com")
+	carol := f.register(t, "Carol", "carol@example.com")
+
+	f.follows.edges = []domain.Follow{
+		{FollowerID: alice, TargetType: domain.FollowTargetUser, TargetID: bob},
+		{FollowerID: carol, TargetType: domain.FollowTargetUser, TargetID: alice},
+		{FollowerID: alice, TargetType: domain.FollowTargetArtist, TargetID: "artist-1"},
+		{FollowerID: carol, TargetType: domain.FollowTargetUser, TargetID: bob},
+	}
+	for id, counts := range map[string][2]int64{alice: {1, 2}, bob: {2, 0}, carol: {0, 2}} {
+		if err := f.repo.IncrementFollowCounts(id, counts[0], counts[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := f.uc.Delete(alice); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if len(f.follows.edges) != 1 || f.follows.edges[0].FollowerID != carol || f.follows.edges[0].TargetID != bob {
+		t.Errorf("edges left = %+v, want only carol -> bob", f.follows.edges)
+	}
+	want := map[string][2]int64{bob: {1, 0}, carol: {0, 1}}
+	for id, counts := range want {
+		u := f.get(t, id)
+		if u.FollowersCount != counts[0] || u.FollowingCount != counts[1] {
+			t.Errorf("%s: followers %d following %d, want %d %d", u.Name, u.FollowersCount, u.FollowingCount, counts[0], counts[1])
+		}
+	}
+}
+
+func TestUserUseCaseReconcileDeletions(t *testing.T) {
+	f := newUserUseCaseFixture(t)
+	aliveID := f.register(t, "Alice", "alice@example.com")
+	// Bob is being deleted right now: his tombstone exists, his user document
+	// is not removed yet
+	deletingID := f.register(t, "Bob", "bob@example.com")
+	goneID := "000000000000000000000000"
+	old, now := time.Now().Add(-time.Hour), time.Now()
+	f.deletions.pending = []*domain.UserDeletion{
+		{UserID: aliveID, DeletedAt: &old},
+		{UserID: deletingID, DeletedAt: &now},
+		{UserID: goneID, DeletedAt: &old},
+	}
+
+	n, err := f.uc.ReconcileDeletions(10)
+	if err != nil {
+		t.Fatalf("ReconcileDeletions: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("processed %d tombstones, want 3", n)
+	}
+	if len(f.playlists.deleted) != 1 || f.playlists.deleted[0] != goneID {
+		t.Errorf("playlists deleted for %v, want [%s]", f.playlists.deleted, goneID)
+	}
+	if len(f.deletions.removed) != 1 || f.deletions.removed[0] != aliveID {
+		t.Errorf("dropped tombstones %v, want [%s]", f.deletions.removed, aliveID)
+	}
+}
@@ -19,14 +19,54 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	UserService_RegisterUser_FullMethodName      = "/user.UserService/RegisterUser"
-	UserService_AuthenticateUser_FullMethodName  = "/user.UserService/AuthenticateUser"
-	UserService_GetUserProfile_FullMethodName    = "/user.UserService/GetUserProfile"
-	UserService_GetUserByEmail_FullMethodName    = "/user.UserService/GetUserByEmail"
-	UserService_UpdateUserProfile_FullMethodName = "/user.UserService/UpdateUserProfile"
-	UserService_ChangePassword_FullMethodName    = "/user.UserService/ChangePassword"
-	UserService_DeleteUser_FullMethodName        = "/user.UserService/DeleteUser"
-	UserService_ListUsers_FullMethodName         = "/user.UserService/ListUsers"
+	UserService_RegisterUser_FullMethodName              = "/user.UserService/RegisterUser"
+	UserService_AuthenticateUser_FullMethodName          = "/user.UserService/AuthenticateUser"
+	UserService_GetUserProfile_FullMethodName            = "/user.UserService/GetUserProfile"
+	UserService_GetUserByEmail_FullMethodName            = "/user.UserService/GetUserByEmail"
+	UserService_GetUserByHandle_FullMethodName           = "/user.UserService/GetUserByHandle"
+	UserService_CheckHandleAvailability_FullMethodName   = "/user.UserService/CheckHandleAvailability"
+	UserService_UpdateUserProfile_FullMethodName         = "/user.UserService/UpdateUserProfile"
+	UserService_ChangePassword_FullMethodName            = "/user.UserService/ChangePassword"
+	UserService_UploadAvatar_FullMethodName              = "/user.UserService/UploadAvatar"
+	UserService_GetAvatarImage_FullMethodName            = "/user.UserService/GetAvatarImage"
+	UserService_GetPreferences_FullMethodName            = "/user.UserService/GetPreferences"
+	UserService_GetCurrentPreferences_FullMethodName     = "/user.UserService/GetCurrentPreferences"
+	UserService_UpdatePreferences_FullMethodName         = "/user.UserService/UpdatePreferences"
+	UserService_Follow_FullMethodName                    = "/user.UserService/Follow"
+	UserService_Unfollow_FullMethodName                  = "/user.UserService/Unfollow"
+	UserService_IsFollowing_FullMethodName               = "/user.UserService/IsFollowing"
+	UserService_ListFollowers_FullMethodName             = "/user.UserService/ListFollowers"
+	UserService_ListFollowing_FullMethodName             = "/user.UserService/ListFollowing"
+	UserService_BlockUser_FullMethodName                 = "/user.UserService/BlockUser"
+	UserService_UnblockUser_FullMethodName               = "/user.UserService/UnblockUser"
+	UserService_GetEntitlements_FullMethodName           = "/user.UserService/GetEntitlements"
+	UserService_GetCurrentEntitlements_FullMethodName    = "/user.UserService/GetCurrentEntitlements"
+	UserService_ChangeUserPlan_FullMethodName            = "/user.UserService/ChangeUserPlan"
+	UserService_CreateHousehold_FullMethodName           = "/user.UserService/CreateHousehold"
+	UserService_InviteHouseholdMember_FullMethodName     = "/user.UserService/InviteHouseholdMember"
+	UserService_AcceptHouseholdInvitation_FullMethodName = "/user.UserService/AcceptHouseholdInvitation"
+	UserService_CreateChildProfile_FullMethodName        = "/user.UserService/CreateChildProfile"
+	UserService_ListHouseholdMembers_FullMethodName      = "/user.UserService/ListHouseholdMembers"
+	UserService_RemoveHouseholdMember_FullMethodName     = "/user.UserService/RemoveHouseholdMember"
+	UserService_GetChildProfileToken_FullMethodName      = "/user.UserService/GetChildProfileToken"
+	UserService_RegisterOAuthClient_FullMethodName       = "/user.UserService/RegisterOAuthClient"
+	UserService_AuthorizeOAuth_FullMethodName            = "/user.UserService/AuthorizeOAuth"
+	UserService_ExchangeOAuthToken_FullMethodName        = "/user.UserService/ExchangeOAuthToken"
+	UserService_RevokeOAuthToken_FullMethodName          = "/user.UserService/RevokeOAuthToken"
+	UserService_ListOAuthConsents_FullMethodName         = "/user.UserService/ListOAuthConsents"
+	UserService_RevokeOAuthConsent_FullMethodName        = "/user.UserService/RevokeOAuthConsent"
+	UserService_ListIdentityProviders_FullMethodName     = "/user.UserService/ListIdentityProviders"
+	UserService_BeginExternalLogin_FullMethodName        = "/user.UserService/BeginExternalLogin"
+	UserService_CompleteExternalLogin_FullMethodName     = "/user.UserService/CompleteExternalLogin"
+	UserService_ListExternalIdentities_FullMethodName    = "/user.UserService/ListExternalIdentities"
+	UserService_UnlinkExternalIdentity_FullMethodName    = "/user.UserService/UnlinkExternalIdentity"
+	UserService_DeleteUser_FullMethodName                = "/user.UserService/DeleteUser"
+	UserService_ListUsers_FullMethodName                 = "/user.UserService/ListUsers"
+	UserService_ImpersonateUser_FullMethodName           = "/user.UserService/ImpersonateUser"
+	UserService_AuthorizeCall_FullMethodName             = "/user.UserService/AuthorizeCall"
+	UserService_ExportMyData_FullMethodName              = "/user.UserService/ExportMyData"
+	UserService_GetDataExportStatus_FullMethodName       = "/user.UserService/GetDataExportStatus"
+	UserService_DownloadDataExport_FullMethodName        = "/user.UserService/DownloadDataExport"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -39,11 +79,76 @@ type UserServiceClient interface {
 	// User profile operations
 	GetUserProfile(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UserProfile, error)
 	GetUserByEmail(ctx context.Context, in *EmailRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	GetUserByHandle(ctx context.Context, in *HandleRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	CheckHandleAvailability(ctx context.Context, in *HandleRequest, opts ...grpc.CallOption) (*HandleAvailability, error)
 	UpdateUserProfile(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	// Requires the bearer token of the user whose password is changed
 	ChangePassword(ctx context.Context, in *PasswordChangeRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	UploadAvatar(ctx context.Context, in *AvatarUploadRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	// Public: serves an avatar image by the key in its URL
+	GetAvatarImage(ctx context.Context, in *AvatarImageRequest, opts ...grpc.CallOption) (*AvatarImage, error)
+	// Listener preferences
+	GetPreferences(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Preferences, error)
+	// Preferences of the bearer token's user, so other services can apply them
+	// without parsing the token; third-party tokens need the catalog:read scope
+	GetCurrentPreferences(ctx context.Context, in *CurrentUserRequest, opts ...grpc.CallOption) (*Preferences, error)
+	UpdatePreferences(ctx context.Context, in *UpdatePreferencesRequest, opts ...grpc.CallOption) (*Preferences, error)
+	// Social graph operations
+	Follow(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	Unfollow(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	IsFollowing(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowStatus, error)
+	ListFollowers(ctx context.Context, in *FollowListRequest, opts ...grpc.CallOption) (*FollowList, error)
+	ListFollowing(ctx context.Context, in *FollowListRequest, opts ...grpc.CallOption) (*FollowList, error)
+	BlockUser(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	UnblockUser(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Subscription operations
+	GetEntitlements(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Entitlements, error)
+	// Entitlements of the bearer token's user; third-party tokens need the catalog:read scope
+	GetCurrentEntitlements(ctx context.Context, in *CurrentUserRequest, opts ...grpc.CallOption) (*Entitlements, error)
+	// Admin only: requires a bearer token of a user with the admin role
+	ChangeUserPlan(ctx context.Context, in *ChangePlanRequest, opts ...grpc.CallOption) (*Entitlements, error)
+	// Family household operations, all require a bearer token
+	CreateHousehold(ctx context.Context, in *CreateHouseholdRequest, opts ...grpc.CallOption) (*Household, error)
+	InviteHouseholdMember(ctx context.Context, in *HouseholdInviteRequest, opts ...grpc.CallOption) (*HouseholdInvitation, error)
+	AcceptHouseholdInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*Household, error)
+	CreateChildProfile(ctx context.Context, in *ChildProfileRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	ListHouseholdMembers(ctx context.Context, in *HouseholdRequest, opts ...grpc.CallOption) (*HouseholdMembers, error)
+	RemoveHouseholdMember(ctx context.Context, in *HouseholdMemberRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Issues a token for a child profile managed by the caller
+	GetChildProfileToken(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*AuthResponse, error)
+	// OAuth2 authorization server for third-party apps. Client registration,
+	// authorization and consents require a bearer token of the user;
+	// the token endpoint authenticates the client instead.
+	RegisterOAuthClient(ctx context.Context, in *OAuthClientRequest, opts ...grpc.CallOption) (*OAuthClient, error)
+	AuthorizeOAuth(ctx context.Context, in *OAuthAuthorizeRequest, opts ...grpc.CallOption) (*OAuthAuthorizeResponse, error)
+	ExchangeOAuthToken(ctx context.Context, in *OAuthTokenRequest, opts ...grpc.CallOption) (*OAuthTokenResponse, error)
+	RevokeOAuthToken(ctx context.Context, in *OAuthRevokeRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListOAuthConsents(ctx context.Context, in *OAuthConsentRequest, opts ...grpc.CallOption) (*OAuthConsentList, error)
+	RevokeOAuthConsent(ctx context.Context, in *OAuthConsentRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Sign in with external OpenID Connect providers. Linking a provider to an
+	// existing account, listing and unlinking require a bearer token.
+	ListIdentityProviders(ctx context.Context, in *IdentityProviderRequest, opts ...grpc.CallOption) (*IdentityProviderList, error)
+	BeginExternalLogin(ctx context.Context, in *ExternalLoginRequest, opts ...grpc.CallOption) (*ExternalLoginRedirect, error)
+	CompleteExternalLogin(ctx context.Context, in *ExternalLoginCallback, opts ...grpc.CallOption) (*ExternalLoginResponse, error)
+	ListExternalIdentities(ctx context.Context, in *IdentityProviderRequest, opts ...grpc.CallOption) (*ExternalIdentityList, error)
+	UnlinkExternalIdentity(ctx context.Context, in *IdentityProviderRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	// User management operations
+	// Requires the bearer token of the user being deleted
 	DeleteUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*StatusResponse, error)
 	ListUsers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*UserList, error)
+	// Admin only: issues a short-lived token for acting as the user. Every call
+	// made with it is audited; changing the password, deleting the account,
+	// exporting its data and granting access to other apps are refused.
+	ImpersonateUser(ctx context.Context, in *ImpersonateRequest, opts ...grpc.CallOption) (*ImpersonationToken, error)
+	// For other services: verifies the bearer token forwarded to them and audits
+	// the call under the given method when the token is an impersonation token
+	AuthorizeCall(ctx context.Context, in *AuthorizeCallRequest, opts ...grpc.CallOption) (*CallAuthorization, error)
+	// Personal data export operations
+	ExportMyData(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*DataExportStatus, error)
+	GetDataExportStatus(ctx context.Context, in *DataExportRequest, opts ...grpc.CallOption) (*DataExportStatus, error)
+	// Streams the finished ZIP archive in chunks; the first message also
+	// carries the file name and content type
+	DownloadDataExport(ctx context.Context, in *DataExportRequest, opts ...grpc.CallOption) (UserService_DownloadDataExportClient, error)
 }
 
 type userServiceClient struct {
@@ -90,6 +195,24 @@ func (c *userServiceClient) GetUserByEmail(ctx context.Context, in *EmailRequest
 	return out, nil
 }
 
+func (c *userServiceClient) GetUserByHandle(ctx context.Context, in *HandleRequest, opts ...grpc.CallOption) (*UserProfile, error) {
+	out := new(UserProfile)
+	err := c.cc.Invoke(ctx, UserService_GetUserByHandle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CheckHandleAvailability(ctx context.Context, in *HandleRequest, opts ...grpc.CallOption) (*HandleAvailability, error) {
+	out := new(HandleAvailability)
+	err := c.cc.Invoke(ctx, UserService_CheckHandleAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) UpdateUserProfile(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UserResponse, error) {
 	out := new(UserResponse)
 	err := c.cc.Invoke(ctx, UserService_UpdateUserProfile_FullMethodName, in, out, opts...)
@@ -108,6 +231,303 @@ func (c *userServiceClient) ChangePassword(ctx context.Context, in *PasswordChan
 	return out, nil
 }
 
+func (c *userServiceClient) UploadAvatar(ctx context.Context, in *AvatarUploadRequest, opts ...grpc.CallOption) (*UserProfile, error) {
+	out := new(UserProfile)
+	err := c.cc.Invoke(ctx, UserService_UploadAvatar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAvatarImage(ctx context.Context, in *AvatarImageRequest, opts ...grpc.CallOption) (*AvatarImage, error) {
+	out := new(AvatarImage)
+	err := c.cc.Invoke(ctx, UserService_GetAvatarImage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPreferences(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Preferences, error) {
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, UserService_GetPreferences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetCurrentPreferences(ctx context.Context, in *CurrentUserRequest, opts ...grpc.CallOption) (*Preferences, error) {
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, UserService_GetCurrentPreferences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdatePreferences(ctx context.Context, in *UpdatePreferencesRequest, opts ...grpc.CallOption) (*Preferences, error) {
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, UserService_UpdatePreferences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Follow(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, UserService_Follow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Unfollow(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, UserService_Unfollow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) IsFollowing(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowStatus, error) {
+	out := new(FollowStatus)
+	err := c.cc.Invoke(ctx, UserService_IsFollowing_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListFollowers(ctx context.Context, in *FollowListRequest, opts ...grpc.CallOption) (*FollowList, error) {
+	out := new(FollowList)
+	err := c.cc.Invoke(ctx, UserService_ListFollowers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListFollowing(ctx context.Context, in *FollowListRequest, opts ...grpc.CallOption) (*FollowList, error) {
+	out := new(FollowList)
+	err := c.cc.Invoke(ctx, UserService_ListFollowing_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BlockUser(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, UserService_BlockUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnblockUser(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, UserService_UnblockUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetEntitlements(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Entitlements, error) {
+	out := new(Entitlements)
+	err := c.cc.Invoke(ctx, UserService_GetEntitlements_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetCurrentEntitlements(ctx context.Context, in *CurrentUserRequest, opts ...grpc.CallOption) (*Entitlements, error) {
+	out := new(Entitlements)
+	err := c.cc.Invoke(ctx, UserService_GetCurrentEntitlements_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ChangeUserPlan(ctx context.Context, in *ChangePlanRequest, opts ...grpc.CallOption) (*Entitlements, error) {
+	out := new(Entitlements)
+	err := c.cc.Invoke(ctx, UserService_ChangeUserPlan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateHousehold(ctx context.Context, in *CreateHouseholdRequest, opts ...grpc.CallOption) (*Household, error) {
+	out := new(Household)
+	err := c.cc.Invoke(ctx, UserService_CreateHousehold_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) InviteHouseholdMember(ctx context.Context, in *HouseholdInviteRequest, opts ...grpc.CallOption) (*HouseholdInvitation, error) {
+	out := new(HouseholdInvitation)
+	err := c.cc.Invoke(ctx, UserService_InviteHouseholdMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AcceptHouseholdInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*Household, error) {
+	out := new(Household)
+	err := c.cc.Invoke(ctx, UserService_AcceptHouseholdInvitation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateChildProfile(ctx context.Context, in *ChildProfileRequest, opts ...grpc.CallOption) (*UserProfile, error) {
+	out := new(UserProfile)
+	err := c.cc.Invoke(ctx, UserService_CreateChildProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListHouseholdMembers(ctx context.Context, in *HouseholdRequest, opts ...grpc.CallOption) (*HouseholdMembers, error) {
+	out := new(HouseholdMembers)
+	err := c.cc.Invoke(ctx, UserService_ListHouseholdMembers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemoveHouseholdMember(ctx context.Context, in *HouseholdMemberRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, UserService_RemoveHouseholdMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetChildProfileToken(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*AuthResponse, error) {
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, UserService_GetChildProfileToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RegisterOAuthClient(ctx context.Context, in *OAuthClientRequest, opts ...grpc.CallOption) (*OAuthClient, error) {
+	out := new(OAuthClient)
+	err := c.cc.Invoke(ctx, UserService_RegisterOAuthClient_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AuthorizeOAuth(ctx context.Context, in *OAuthAuthorizeRequest, opts ...grpc.CallOption) (*OAuthAuthorizeResponse, error) {
+	out := new(OAuthAuthorizeResponse)
+	err := c.cc.Invoke(ctx, UserService_AuthorizeOAuth_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExchangeOAuthToken(ctx context.Context, in *OAuthTokenRequest, opts ...grpc.CallOption) (*OAuthTokenResponse, error) {
+	out := new(OAuthTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_ExchangeOAuthToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeOAuthToken(ctx context.Context, in *OAuthRevokeRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, UserService_RevokeOAuthToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListOAuthConsents(ctx context.Context, in *OAuthConsentRequest, opts ...grpc.CallOption) (*OAuthConsentList, error) {
+	out := new(OAuthConsentList)
+	err := c.cc.Invoke(ctx, UserService_ListOAuthConsents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeOAuthConsent(ctx context.Context, in *OAuthConsentRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, UserService_RevokeOAuthConsent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListIdentityProviders(ctx context.Context, in *IdentityProviderRequest, opts ...grpc.CallOption) (*IdentityProviderList, error) {
+	out := new(IdentityProviderList)
+	err := c.cc.Invoke(ctx, UserService_ListIdentityProviders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BeginExternalLogin(ctx context.Context, in *ExternalLoginRequest, opts ...grpc.CallOption) (*ExternalLoginRedirect, error) {
+	out := new(ExternalLoginRedirect)
+	err := c.cc.Invoke(ctx, UserService_BeginExternalLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CompleteExternalLogin(ctx context.Context, in *ExternalLoginCallback, opts ...grpc.CallOption) (*ExternalLoginResponse, error) {
+	out := new(ExternalLoginResponse)
+	err := c.cc.Invoke(ctx, UserService_CompleteExternalLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListExternalIdentities(ctx context.Context, in *IdentityProviderRequest, opts ...grpc.CallOption) (*ExternalIdentityList, error) {
+	out := new(ExternalIdentityList)
+	err := c.cc.Invoke(ctx, UserService_ListExternalIdentities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnlinkExternalIdentity(ctx context.Context, in *IdentityProviderRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, UserService_UnlinkExternalIdentity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) DeleteUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*StatusResponse, error) {
 	out := new(StatusResponse)
 	err := c.cc.Invoke(ctx, UserService_DeleteUser_FullMethodName, in, out, opts...)
@@ -126,169 +546,1052 @@ func (c *userServiceClient) ListUsers(ctx context.Context, in *ListRequest, opts
 	return out, nil
 }
 
-// UserServiceServer is the server API for UserService service.
-// All implementations must embed UnimplementedUserServiceServer
-// for forward compatibility
-type UserServiceServer interface {
-	// Basic authentication operations
-	RegisterUser(context.Context, *UserRequest) (*UserResponse, error)
-	AuthenticateUser(context.Context, *AuthRequest) (*AuthResponse, error)
-	// User profile operations
-	GetUserProfile(context.Context, *UserID) (*UserProfile, error)
-	GetUserByEmail(context.Context, *EmailRequest) (*UserProfile, error)
-	UpdateUserProfile(context.Context, *UpdateRequest) (*UserResponse, error)
-	ChangePassword(context.Context, *PasswordChangeRequest) (*StatusResponse, error)
-	// User management operations
-	DeleteUser(context.Context, *UserID) (*StatusResponse, error)
-	ListUsers(context.Context, *ListRequest) (*UserList, error)
-	mustEmbedUnimplementedUserServiceServer()
+func (c *userServiceClient) ImpersonateUser(ctx context.Context, in *ImpersonateRequest, opts ...grpc.CallOption) (*ImpersonationToken, error) {
+	out := new(ImpersonationToken)
+	err := c.cc.Invoke(ctx, UserService_ImpersonateUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AuthorizeCall(ctx context.Context, in *AuthorizeCallRequest, opts ...grpc.CallOption) (*CallAuthorization, error) {
+	out := new(CallAuthorization)
+	err := c.cc.Invoke(ctx, UserService_AuthorizeCall_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportMyData(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*DataExportStatus, error) {
+	out := new(DataExportStatus)
+	err := c.cc.Invoke(ctx, UserService_ExportMyData_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetDataExportStatus(ctx context.Context, in *DataExportRequest, opts ...grpc.CallOption) (*DataExportStatus, error) {
+	out := new(DataExportStatus)
+	err := c.cc.Invoke(ctx, UserService_GetDataExportStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DownloadDataExport(ctx context.Context, in *DataExportRequest, opts ...grpc.CallOption) (UserService_DownloadDataExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_DownloadDataExport_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceDownloadDataExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_DownloadDataExportClient interface {
+	Recv() (*DataExportArchive, error)
+	grpc.ClientStream
+}
+
+type userServiceDownloadDataExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceDownloadDataExportClient) Recv() (*DataExportArchive, error) {
+	m := new(DataExportArchive)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility
+type UserServiceServer interface {
+	// Basic authentication operations
+	RegisterUser(context.Context, *UserRequest) (*UserResponse, error)
+	AuthenticateUser(context.Context, *AuthRequest) (*AuthResponse, error)
+	// User profile operations
+	GetUserProfile(context.Context, *UserID) (*UserProfile, error)
+	GetUserByEmail(context.Context, *EmailRequest) (*UserProfile, error)
+	GetUserByHandle(context.Context, *HandleRequest) (*UserProfile, error)
+	CheckHandleAvailability(context.Context, *HandleRequest) (*HandleAvailability, error)
+	UpdateUserProfile(context.Context, *UpdateRequest) (*UserResponse, error)
+	// Requires the bearer token of the user whose password is changed
+	ChangePassword(context.Context, *PasswordChangeRequest) (*StatusResponse, error)
+	UploadAvatar(context.Context, *AvatarUploadRequest) (*UserProfile, error)
+	// Public: serves an avatar image by the key in its URL
+	GetAvatarImage(context.Context, *AvatarImageRequest) (*AvatarImage, error)
+	// Listener preferences
+	GetPreferences(context.Context, *UserID) (*Preferences, error)
+	// Preferences of the bearer token's user, so other services can apply them
+	// without parsing the token; third-party tokens need the catalog:read scope
+	GetCurrentPreferences(context.Context, *CurrentUserRequest) (*Preferences, error)
+	UpdatePreferences(context.Context, *UpdatePreferencesRequest) (*Preferences, error)
+	// Social graph operations
+	Follow(context.Context, *FollowRequest) (*StatusResponse, error)
+	Unfollow(context.Context, *FollowRequest) (*StatusResponse, error)
+	IsFollowing(context.Context, *FollowRequest) (*FollowStatus, error)
+	ListFollowers(context.Context, *FollowListRequest) (*FollowList, error)
+	ListFollowing(context.Context, *FollowListRequest) (*FollowList, error)
+	BlockUser(context.Context, *BlockRequest) (*StatusResponse, error)
+	UnblockUser(context.Context, *BlockRequest) (*StatusResponse, error)
+	// Subscription operations
+	GetEntitlements(context.Context, *UserID) (*Entitlements, error)
+	// Entitlements of the bearer token's user; third-party tokens need the catalog:read scope
+	GetCurrentEntitlements(context.Context, *CurrentUserRequest) (*Entitlements, error)
+	// Admin only: requires a bearer token of a user with the admin role
+	ChangeUserPlan(context.Context, *ChangePlanRequest) (*Entitlements, error)
+	// Family household operations, all require a bearer token
+	CreateHousehold(context.Context, *CreateHouseholdRequest) (*Household, error)
+	InviteHouseholdMember(context.Context, *HouseholdInviteRequest) (*HouseholdInvitation, error)
+	AcceptHouseholdInvitation(context.Context, *AcceptInvitationRequest) (*Household, error)
+	CreateChildProfile(context.Context, *ChildProfileRequest) (*UserProfile, error)
+	ListHouseholdMembers(context.Context, *HouseholdRequest) (*HouseholdMembers, error)
+	RemoveHouseholdMember(context.Context, *HouseholdMemberRequest) (*StatusResponse, error)
+	// Issues a token for a child profile managed by the caller
+	GetChildProfileToken(context.Context, *UserID) (*AuthResponse, error)
+	// OAuth2 authorization server for third-party apps. Client registration,
+	// authorization and consents require a bearer token of the user;
+	// the token endpoint authenticates the client instead.
+	RegisterOAuthClient(context.Context, *OAuthClientRequest) (*OAuthClient, error)
+	AuthorizeOAuth(context.Context, *OAuthAuthorizeRequest) (*OAuthAuthorizeResponse, error)
+	ExchangeOAuthToken(context.Context, *OAuthTokenRequest) (*OAuthTokenResponse, error)
+	RevokeOAuthToken(context.Context, *OAuthRevokeRequest) (*StatusResponse, error)
+	ListOAuthConsents(context.Context, *OAuthConsentRequest) (*OAuthConsentList, error)
+	RevokeOAuthConsent(context.Context, *OAuthConsentRequest) (*StatusResponse, error)
+	// Sign in with external OpenID Connect providers. Linking a provider to an
+	// existing account, listing and unlinking require a bearer token.
+	ListIdentityProviders(context.Context, *IdentityProviderRequest) (*IdentityProviderList, error)
+	BeginExternalLogin(context.Context, *ExternalLoginRequest) (*ExternalLoginRedirect, error)
+	CompleteExternalLogin(context.Context, *ExternalLoginCallback) (*ExternalLoginResponse, error)
+	ListExternalIdentities(context.Context, *IdentityProviderRequest) (*ExternalIdentityList, error)
+	UnlinkExternalIdentity(context.Context, *IdentityProviderRequest) (*StatusResponse, error)
+	// User management operations
+	// Requires the bearer token of the user being deleted
+	DeleteUser(context.Context, *UserID) (*StatusResponse, error)
+	ListUsers(context.Context, *ListRequest) (*UserList, error)
+	// Admin only: issues a short-lived token for acting as the user. Every call
+	// made with it is audited; changing the password, deleting the account,
+	// exporting its data and granting access to other apps are refused.
+	ImpersonateUser(context.Context, *ImpersonateRequest) (*ImpersonationToken, error)
+	// For other services: verifies the bearer token forwarded to them and audits
+	// the call under the given method when the token is an impersonation token
+	AuthorizeCall(context.Context, *AuthorizeCallRequest) (*CallAuthorization, error)
+	// Personal data export operations
+	ExportMyData(context.Context, *UserID) (*DataExportStatus, error)
+	GetDataExportStatus(context.Context, *DataExportRequest) (*DataExportStatus, error)
+	// Streams the finished ZIP archive in chunks; the first message also
+	// carries the file name and content type
+	DownloadDataExport(*DataExportRequest, UserService_DownloadDataExportServer) error
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
+}
+
+func (UnimplementedUserServiceServer) RegisterUser(context.Context, *UserRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+}
+func (UnimplementedUserServiceServer) AuthenticateUser(context.Context, *AuthRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserProfile(context.Context, *UserID) (*UserProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserProfile not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserByEmail(context.Context, *EmailRequest) (*UserProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByEmail not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserByHandle(context.Context, *HandleRequest) (*UserProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByHandle not implemented")
+}
+func (UnimplementedUserServiceServer) CheckHandleAvailability(context.Context, *HandleRequest) (*HandleAvailability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckHandleAvailability not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateUserProfile(context.Context, *UpdateRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserProfile not implemented")
+}
+func (UnimplementedUserServiceServer) ChangePassword(context.Context, *PasswordChangeRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedUserServiceServer) UploadAvatar(context.Context, *AvatarUploadRequest) (*UserProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadAvatar not implemented")
+}
+func (UnimplementedUserServiceServer) GetAvatarImage(context.Context, *AvatarImageRequest) (*AvatarImage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvatarImage not implemented")
+}
+func (UnimplementedUserServiceServer) GetPreferences(context.Context, *UserID) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedUserServiceServer) GetCurrentPreferences(context.Context, *CurrentUserRequest) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentPreferences not implemented")
+}
+func (UnimplementedUserServiceServer) UpdatePreferences(context.Context, *UpdatePreferencesRequest) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePreferences not implemented")
+}
+func (UnimplementedUserServiceServer) Follow(context.Context, *FollowRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Follow not implemented")
+}
+func (UnimplementedUserServiceServer) Unfollow(context.Context, *FollowRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unfollow not implemented")
+}
+func (UnimplementedUserServiceServer) IsFollowing(context.Context, *FollowRequest) (*FollowStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsFollowing not implemented")
+}
+func (UnimplementedUserServiceServer) ListFollowers(context.Context, *FollowListRequest) (*FollowList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowers not implemented")
+}
+func (UnimplementedUserServiceServer) ListFollowing(context.Context, *FollowListRequest) (*FollowList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowing not implemented")
+}
+func (UnimplementedUserServiceServer) BlockUser(context.Context, *BlockRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedUserServiceServer) UnblockUser(context.Context, *BlockRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetEntitlements(context.Context, *UserID) (*Entitlements, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntitlements not implemented")
+}
+func (UnimplementedUserServiceServer) GetCurrentEntitlements(context.Context, *CurrentUserRequest) (*Entitlements, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentEntitlements not implemented")
+}
+func (UnimplementedUserServiceServer) ChangeUserPlan(context.Context, *ChangePlanRequest) (*Entitlements, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeUserPlan not implemented")
+}
+func (UnimplementedUserServiceServer) CreateHousehold(context.Context, *CreateHouseholdRequest) (*Household, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHousehold not implemented")
+}
+func (UnimplementedUserServiceServer) InviteHouseholdMember(context.Context, *HouseholdInviteRequest) (*HouseholdInvitation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteHouseholdMember not implemented")
+}
+func (UnimplementedUserServiceServer) AcceptHouseholdInvitation(context.Context, *AcceptInvitationRequest) (*Household, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptHouseholdInvitation not implemented")
+}
+func (UnimplementedUserServiceServer) CreateChildProfile(context.Context, *ChildProfileRequest) (*UserProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChildProfile not implemented")
+}
+func (UnimplementedUserServiceServer) ListHouseholdMembers(context.Context, *HouseholdRequest) (*HouseholdMembers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHouseholdMembers not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveHouseholdMember(context.Context, *HouseholdMemberRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveHouseholdMember not implemented")
+}
+func (UnimplementedUserServiceServer) GetChildProfileToken(context.Context, *UserID) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChildProfileToken not implemented")
+}
+func (UnimplementedUserServiceServer) RegisterOAuthClient(context.Context, *OAuthClientRequest) (*OAuthClient, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterOAuthClient not implemented")
+}
+func (UnimplementedUserServiceServer) AuthorizeOAuth(context.Context, *OAuthAuthorizeRequest) (*OAuthAuthorizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeOAuth not implemented")
+}
+func (UnimplementedUserServiceServer) ExchangeOAuthToken(context.Context, *OAuthTokenRequest) (*OAuthTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeOAuthToken not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeOAuthToken(context.Context, *OAuthRevokeRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeOAuthToken not implemented")
+}
+func (UnimplementedUserServiceServer) ListOAuthConsents(context.Context, *OAuthConsentRequest) (*OAuthConsentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOAuthConsents not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeOAuthConsent(context.Context, *OAuthConsentRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeOAuthConsent not implemented")
+}
+func (UnimplementedUserServiceServer) ListIdentityProviders(context.Context, *IdentityProviderRequest) (*IdentityProviderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIdentityProviders not implemented")
+}
+func (UnimplementedUserServiceServer) BeginExternalLogin(context.Context, *ExternalLoginRequest) (*ExternalLoginRedirect, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginExternalLogin not implemented")
+}
+func (UnimplementedUserServiceServer) CompleteExternalLogin(context.Context, *ExternalLoginCallback) (*ExternalLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteExternalLogin not implemented")
+}
+func (UnimplementedUserServiceServer) ListExternalIdentities(context.Context, *IdentityProviderRequest) (*ExternalIdentityList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExternalIdentities not implemented")
+}
+func (UnimplementedUserServiceServer) UnlinkExternalIdentity(context.Context, *IdentityProviderRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkExternalIdentity not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteUser(context.Context, *UserID) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListRequest) (*UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedUserServiceServer) ImpersonateUser(context.Context, *ImpersonateRequest) (*ImpersonationToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImpersonateUser not implemented")
+}
+func (UnimplementedUserServiceServer) AuthorizeCall(context.Context, *AuthorizeCallRequest) (*CallAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeCall not implemented")
+}
+func (UnimplementedUserServiceServer) ExportMyData(context.Context, *UserID) (*DataExportStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedUserServiceServer) GetDataExportStatus(context.Context, *DataExportRequest) (*DataExportStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataExportStatus not implemented")
+}
+func (UnimplementedUserServiceServer) DownloadDataExport(*DataExportRequest, UserService_DownloadDataExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadDataExport not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RegisterUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RegisterUser(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AuthenticateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AuthenticateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AuthenticateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AuthenticateUser(ctx, req.(*AuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserProfile(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserByEmail(ctx, req.(*EmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserByHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserByHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserByHandle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserByHandle(ctx, req.(*HandleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CheckHandleAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckHandleAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CheckHandleAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckHandleAvailability(ctx, req.(*HandleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUserProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUserProfile(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangePassword(ctx, req.(*PasswordChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UploadAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AvatarUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UploadAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UploadAvatar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UploadAvatar(ctx, req.(*AvatarUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAvatarImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AvatarImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetAvatarImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetAvatarImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetAvatarImage(ctx, req.(*AvatarImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPreferences(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetCurrentPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrentUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetCurrentPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetCurrentPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetCurrentPreferences(ctx, req.(*CurrentUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdatePreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdatePreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdatePreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdatePreferences(ctx, req.(*UpdatePreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Follow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Follow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Follow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Follow(ctx, req.(*FollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Unfollow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Unfollow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Unfollow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Unfollow(ctx, req.(*FollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_IsFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).IsFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_IsFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).IsFollowing(ctx, req.(*FollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListFollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListFollowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListFollowers(ctx, req.(*FollowListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListFollowing(ctx, req.(*FollowListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BlockUser(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnblockUser(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetEntitlements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetEntitlements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetEntitlements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetEntitlements(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetCurrentEntitlements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrentUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetCurrentEntitlements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetCurrentEntitlements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetCurrentEntitlements(ctx, req.(*CurrentUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ChangeUserPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangeUserPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ChangeUserPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangeUserPlan(ctx, req.(*ChangePlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateHousehold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHouseholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateHousehold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateHousehold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateHousehold(ctx, req.(*CreateHouseholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_InviteHouseholdMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HouseholdInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).InviteHouseholdMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_InviteHouseholdMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).InviteHouseholdMember(ctx, req.(*HouseholdInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AcceptHouseholdInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AcceptHouseholdInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AcceptHouseholdInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AcceptHouseholdInvitation(ctx, req.(*AcceptInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateChildProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChildProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateChildProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateChildProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateChildProfile(ctx, req.(*ChildProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListHouseholdMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HouseholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListHouseholdMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListHouseholdMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListHouseholdMembers(ctx, req.(*HouseholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveHouseholdMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HouseholdMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveHouseholdMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveHouseholdMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveHouseholdMember(ctx, req.(*HouseholdMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetChildProfileToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetChildProfileToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetChildProfileToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetChildProfileToken(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RegisterOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RegisterOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RegisterOAuthClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RegisterOAuthClient(ctx, req.(*OAuthClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedUserServiceServer struct {
+func _UserService_AuthorizeOAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthAuthorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AuthorizeOAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AuthorizeOAuth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AuthorizeOAuth(ctx, req.(*OAuthAuthorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedUserServiceServer) RegisterUser(context.Context, *UserRequest) (*UserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
-}
-func (UnimplementedUserServiceServer) AuthenticateUser(context.Context, *AuthRequest) (*AuthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateUser not implemented")
-}
-func (UnimplementedUserServiceServer) GetUserProfile(context.Context, *UserID) (*UserProfile, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserProfile not implemented")
-}
-func (UnimplementedUserServiceServer) GetUserByEmail(context.Context, *EmailRequest) (*UserProfile, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserByEmail not implemented")
-}
-func (UnimplementedUserServiceServer) UpdateUserProfile(context.Context, *UpdateRequest) (*UserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserProfile not implemented")
-}
-func (UnimplementedUserServiceServer) ChangePassword(context.Context, *PasswordChangeRequest) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
-}
-func (UnimplementedUserServiceServer) DeleteUser(context.Context, *UserID) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
-}
-func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListRequest) (*UserList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+func _UserService_ExchangeOAuthToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExchangeOAuthToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExchangeOAuthToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExchangeOAuthToken(ctx, req.(*OAuthTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
-// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to UserServiceServer will
-// result in compilation errors.
-type UnsafeUserServiceServer interface {
-	mustEmbedUnimplementedUserServiceServer()
+func _UserService_RevokeOAuthToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeOAuthToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeOAuthToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeOAuthToken(ctx, req.(*OAuthRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
-	s.RegisterService(&UserService_ServiceDesc, srv)
+func _UserService_ListOAuthConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListOAuthConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListOAuthConsents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListOAuthConsents(ctx, req.(*OAuthConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserRequest)
+func _UserService_RevokeOAuthConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthConsentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).RegisterUser(ctx, in)
+		return srv.(UserServiceServer).RevokeOAuthConsent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_RegisterUser_FullMethodName,
+		FullMethod: UserService_RevokeOAuthConsent_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).RegisterUser(ctx, req.(*UserRequest))
+		return srv.(UserServiceServer).RevokeOAuthConsent(ctx, req.(*OAuthConsentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_AuthenticateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthRequest)
+func _UserService_ListIdentityProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityProviderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).AuthenticateUser(ctx, in)
+		return srv.(UserServiceServer).ListIdentityProviders(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_AuthenticateUser_FullMethodName,
+		FullMethod: UserService_ListIdentityProviders_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).AuthenticateUser(ctx, req.(*AuthRequest))
+		return srv.(UserServiceServer).ListIdentityProviders(ctx, req.(*IdentityProviderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserID)
+func _UserService_BeginExternalLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalLoginRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetUserProfile(ctx, in)
+		return srv.(UserServiceServer).BeginExternalLogin(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetUserProfile_FullMethodName,
+		FullMethod: UserService_BeginExternalLogin_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetUserProfile(ctx, req.(*UserID))
+		return srv.(UserServiceServer).BeginExternalLogin(ctx, req.(*ExternalLoginRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetUserByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmailRequest)
+func _UserService_CompleteExternalLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalLoginCallback)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetUserByEmail(ctx, in)
+		return srv.(UserServiceServer).CompleteExternalLogin(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetUserByEmail_FullMethodName,
+		FullMethod: UserService_CompleteExternalLogin_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetUserByEmail(ctx, req.(*EmailRequest))
+		return srv.(UserServiceServer).CompleteExternalLogin(ctx, req.(*ExternalLoginCallback))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UpdateUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRequest)
+func _UserService_ListExternalIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityProviderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UpdateUserProfile(ctx, in)
+		return srv.(UserServiceServer).ListExternalIdentities(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UpdateUserProfile_FullMethodName,
+		FullMethod: UserService_ListExternalIdentities_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UpdateUserProfile(ctx, req.(*UpdateRequest))
+		return srv.(UserServiceServer).ListExternalIdentities(ctx, req.(*IdentityProviderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PasswordChangeRequest)
+func _UserService_UnlinkExternalIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityProviderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).ChangePassword(ctx, in)
+		return srv.(UserServiceServer).UnlinkExternalIdentity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_ChangePassword_FullMethodName,
+		FullMethod: UserService_UnlinkExternalIdentity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ChangePassword(ctx, req.(*PasswordChangeRequest))
+		return srv.(UserServiceServer).UnlinkExternalIdentity(ctx, req.(*IdentityProviderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -329,6 +1632,99 @@ func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ImpersonateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpersonateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ImpersonateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ImpersonateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ImpersonateUser(ctx, req.(*ImpersonateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AuthorizeCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AuthorizeCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AuthorizeCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AuthorizeCall(ctx, req.(*AuthorizeCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExportMyData(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetDataExportStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetDataExportStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetDataExportStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetDataExportStatus(ctx, req.(*DataExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DownloadDataExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DataExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).DownloadDataExport(m, &userServiceDownloadDataExportServer{stream})
+}
+
+type UserService_DownloadDataExportServer interface {
+	Send(*DataExportArchive) error
+	grpc.ServerStream
+}
+
+type userServiceDownloadDataExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceDownloadDataExportServer) Send(m *DataExportArchive) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -352,6 +1748,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserByEmail",
 			Handler:    _UserService_GetUserByEmail_Handler,
 		},
+		{
+			MethodName: "GetUserByHandle",
+			Handler:    _UserService_GetUserByHandle_Handler,
+		},
+		{
+			MethodName: "CheckHandleAvailability",
+			Handler:    _UserService_CheckHandleAvailability_Handler,
+		},
 		{
 			MethodName: "UpdateUserProfile",
 			Handler:    _UserService_UpdateUserProfile_Handler,
@@ -360,6 +1764,138 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangePassword",
 			Handler:    _UserService_ChangePassword_Handler,
 		},
+		{
+			MethodName: "UploadAvatar",
+			Handler:    _UserService_UploadAvatar_Handler,
+		},
+		{
+			MethodName: "GetAvatarImage",
+			Handler:    _UserService_GetAvatarImage_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _UserService_GetPreferences_Handler,
+		},
+		{
+			MethodName: "GetCurrentPreferences",
+			Handler:    _UserService_GetCurrentPreferences_Handler,
+		},
+		{
+			MethodName: "UpdatePreferences",
+			Handler:    _UserService_UpdatePreferences_Handler,
+		},
+		{
+			MethodName: "Follow",
+			Handler:    _UserService_Follow_Handler,
+		},
+		{
+			MethodName: "Unfollow",
+			Handler:    _UserService_Unfollow_Handler,
+		},
+		{
+			MethodName: "IsFollowing",
+			Handler:    _UserService_IsFollowing_Handler,
+		},
+		{
+			MethodName: "ListFollowers",
+			Handler:    _UserService_ListFollowers_Handler,
+		},
+		{
+			MethodName: "ListFollowing",
+			Handler:    _UserService_ListFollowing_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _UserService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _UserService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "GetEntitlements",
+			Handler:    _UserService_GetEntitlements_Handler,
+		},
+		{
+			MethodName: "GetCurrentEntitlements",
+			Handler:    _UserService_GetCurrentEntitlements_Handler,
+		},
+		{
+			MethodName: "ChangeUserPlan",
+			Handler:    _UserService_ChangeUserPlan_Handler,
+		},
+		{
+			MethodName: "CreateHousehold",
+			Handler:    _UserService_CreateHousehold_Handler,
+		},
+		{
+			MethodName: "InviteHouseholdMember",
+			Handler:    _UserService_InviteHouseholdMember_Handler,
+		},
+		{
+			MethodName: "AcceptHouseholdInvitation",
+			Handler:    _UserService_AcceptHouseholdInvitation_Handler,
+		},
+		{
+			MethodName: "CreateChildProfile",
+			Handler:    _UserService_CreateChildProfile_Handler,
+		},
+		{
+			MethodName: "ListHouseholdMembers",
+			Handler:    _UserService_ListHouseholdMembers_Handler,
+		},
+		{
+			MethodName: "RemoveHouseholdMember",
+			Handler:    _UserService_RemoveHouseholdMember_Handler,
+		},
+		{
+			MethodName: "GetChildProfileToken",
+			Handler:    _UserService_GetChildProfileToken_Handler,
+		},
+		{
+			MethodName: "RegisterOAuthClient",
+			Handler:    _UserService_RegisterOAuthClient_Handler,
+		},
+		{
+			MethodName: "AuthorizeOAuth",
+			Handler:    _UserService_AuthorizeOAuth_Handler,
+		},
+		{
+			MethodName: "ExchangeOAuthToken",
+			Handler:    _UserService_ExchangeOAuthToken_Handler,
+		},
+		{
+			MethodName: "RevokeOAuthToken",
+			Handler:    _UserService_RevokeOAuthToken_Handler,
+		},
+		{
+			MethodName: "ListOAuthConsents",
+			Handler:    _UserService_ListOAuthConsents_Handler,
+		},
+		{
+			MethodName: "RevokeOAuthConsent",
+			Handler:    _UserService_RevokeOAuthConsent_Handler,
+		},
+		{
+			MethodName: "ListIdentityProviders",
+			Handler:    _UserService_ListIdentityProviders_Handler,
+		},
+		{
+			MethodName: "BeginExternalLogin",
+			Handler:    _UserService_BeginExternalLogin_Handler,
+		},
+		{
+			MethodName: "CompleteExternalLogin",
+			Handler:    _UserService_CompleteExternalLogin_Handler,
+		},
+		{
+			MethodName: "ListExternalIdentities",
+			Handler:    _UserService_ListExternalIdentities_Handler,
+		},
+		{
+			MethodName: "UnlinkExternalIdentity",
+			Handler:    _UserService_UnlinkExternalIdentity_Handler,
+		},
 		{
 			MethodName: "DeleteUser",
 			Handler:    _UserService_DeleteUser_Handler,
@@ -368,7 +1904,29 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUsers",
 			Handler:    _UserService_ListUsers_Handler,
 		},
+		{
+			MethodName: "ImpersonateUser",
+			Handler:    _UserService_ImpersonateUser_Handler,
+		},
+		{
+			MethodName: "AuthorizeCall",
+			Handler:    _UserService_AuthorizeCall_Handler,
+		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _UserService_ExportMyData_Handler,
+		},
+		{
+			MethodName: "GetDataExportStatus",
+			Handler:    _UserService_GetDataExportStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadDataExport",
+			Handler:       _UserService_DownloadDataExport_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user.proto",
 }